@@ -19,7 +19,7 @@ FROM alpine:3.21 AS api
 
 RUN apk update \
     && apk upgrade \
-    && apk add --no-cache ca-certificates tzdata \
+    && apk add --no-cache ca-certificates tzdata git openssh-client \
     && update-ca-certificates 2>/dev/null || true \
     && rm -rf /var/cache/apk/*
 
@@ -0,0 +1,56 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+type GitSourceCreateReq struct {
+	KbID     string             `json:"kb_id" validate:"required"`
+	Name     string             `json:"name"`
+	RepoURL  string             `json:"repo_url" validate:"required"` // https、ssh 地址或本地裸仓库路径
+	Branch   string             `json:"branch"`                       // 默认为仓库默认分支
+	SubDir   string             `json:"sub_dir"`                      // 只同步该目录
+	ParentID string             `json:"parent_id"`                    // 同步到该文件夹，默认根目录
+	AuthType domain.GitAuthType `json:"auth_type" validate:"omitempty,oneof=none https ssh"`
+	Username string             `json:"username"`
+	Password string             `json:"password"`
+	SSHKey   string             `json:"ssh_key"`
+}
+
+type GitSourceCreateResp struct {
+	SourceID string `json:"source_id"`
+}
+
+// GitSourceUpdateReq keeps the stored password and ssh key when they are empty,
+// changing the repo, branch or sub dir makes the next sync a full one
+type GitSourceUpdateReq struct {
+	KbID     string              `json:"kb_id" validate:"required"`
+	SourceID string              `json:"source_id" validate:"required"`
+	Name     *string             `json:"name"`
+	RepoURL  *string             `json:"repo_url"`
+	Branch   *string             `json:"branch"`
+	SubDir   *string             `json:"sub_dir"`
+	AuthType *domain.GitAuthType `json:"auth_type" validate:"omitempty,oneof=none https ssh"`
+	Username *string             `json:"username"`
+	Password string              `json:"password"`
+	SSHKey   string              `json:"ssh_key"`
+}
+
+type GitSourceListReq struct {
+	KbID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type GitSourceListItem struct {
+	*domain.KBGitSource
+	HasPassword bool `json:"has_password"`
+	HasSSHKey   bool `json:"has_ssh_key"`
+}
+
+type GitSourceDeleteReq struct {
+	KbID     string `json:"kb_id" query:"kb_id" validate:"required"`
+	SourceID string `json:"source_id" query:"source_id" validate:"required"`
+}
+
+type GitSourceSyncReq struct {
+	KbID     string `json:"kb_id" validate:"required"`
+	SourceID string `json:"source_id" validate:"required"`
+	Full     bool   `json:"full"` // compare the whole tree instead of the diff since the last sync
+}
@@ -0,0 +1,30 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+// OpenAPIImportReq imports a spec from an uploaded file or the raw content,
+// with a spec id the new version updates the nodes of the earlier import
+type OpenAPIImportReq struct {
+	KbID     string `json:"kb_id" validate:"required"`
+	SpecID   string `json:"spec_id"`   // 再次导入已有的规范
+	Key      string `json:"key"`       // 上传文件返回的 key
+	Content  string `json:"content"`   // json 或 yaml 内容
+	ParentID string `json:"parent_id"` // 首次导入时的目标文件夹，默认根目录
+	Name     string `json:"name"`      // 默认为 info.title
+}
+
+type OpenAPIImportResp struct {
+	SpecID  string                     `json:"spec_id"`
+	Title   string                     `json:"title"`
+	Version string                     `json:"version"`
+	Result  domain.OpenAPIImportResult `json:"result"`
+}
+
+type OpenAPISpecListReq struct {
+	KbID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type OpenAPISpecDeleteReq struct {
+	KbID   string `json:"kb_id" query:"kb_id" validate:"required"`
+	SpecID string `json:"spec_id" query:"spec_id" validate:"required"`
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type CrawlerSyncCreateReq struct {
+	KbID          string               `json:"kb_id" validate:"required"`
+	Name          string               `json:"name"`
+	CrawlerSource consts.CrawlerSource `json:"crawler_source" validate:"required"`
+	Key           string               `json:"key"`
+	Filename      string               `json:"filename"`
+	FeishuSetting FeishuSetting        `json:"feishu_setting"`
+	ParentID      string               `json:"parent_id"`    // 同步到该文件夹，默认根目录
+	Schedule      string               `json:"schedule"`     // cron 表达式，如 0 3 * * *，为空时只手动同步
+	AutoPublish   bool                 `json:"auto_publish"` // 同步后自动发布变更的文档，否则保留为草稿
+}
+
+type CrawlerSyncCreateResp struct {
+	SyncID string `json:"sync_id"`
+}
+
+// CrawlerSyncUpdateReq keeps the stored feishu app secret and access token when they are empty
+type CrawlerSyncUpdateReq struct {
+	KbID          string         `json:"kb_id" validate:"required"`
+	SyncID        string         `json:"sync_id" validate:"required"`
+	Name          *string        `json:"name"`
+	Key           *string        `json:"key"`
+	Filename      *string        `json:"filename"`
+	FeishuSetting *FeishuSetting `json:"feishu_setting"`
+	Schedule      *string        `json:"schedule"`
+	AutoPublish   *bool          `json:"auto_publish"`
+}
+
+type CrawlerSyncListReq struct {
+	KbID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type CrawlerSyncListItem struct {
+	*domain.KBCrawlerSync
+	FeishuAppID     string `json:"feishu_app_id"`
+	FeishuSpaceID   string `json:"feishu_space_id"`
+	HasFeishuSecret bool   `json:"has_feishu_secret"`
+}
+
+type CrawlerSyncDeleteReq struct {
+	KbID   string `json:"kb_id" query:"kb_id" validate:"required"`
+	SyncID string `json:"sync_id" query:"sync_id" validate:"required"`
+}
+
+type CrawlerSyncRunReq struct {
+	KbID   string `json:"kb_id" validate:"required"`
+	SyncID string `json:"sync_id" validate:"required"`
+}
+
+type CrawlerSyncRunListReq struct {
+	KbID   string `json:"kb_id" query:"kb_id" validate:"required"`
+	SyncID string `json:"sync_id" query:"sync_id" validate:"required"`
+	domain.Pager
+}
+
+type CrawlerSyncRunListResp = domain.PaginatedResult[[]*domain.KBCrawlerSyncRun]
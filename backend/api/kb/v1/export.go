@@ -0,0 +1,55 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+// export_id and import_id are used instead of id, id in knowledge_base routes is read as kb id by auth middleware
+
+type KBExportCreateReq struct {
+	KBId   string                `json:"kb_id" validate:"required"`
+	Format domain.KBExportFormat `json:"format" validate:"omitempty,oneof=markdown epub pdf"` // 默认 markdown, epub and pdf are built from the published release
+	RootID string                `json:"root_id"`                                             // epub and pdf only, export this folder instead of the whole kb
+}
+
+type KBExportCreateResp struct {
+	ExportID string `json:"export_id"`
+}
+
+type KBExportListReq struct {
+	KBId string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type KBExportDetailReq struct {
+	KBId     string `json:"kb_id" query:"kb_id" validate:"required"`
+	ExportID string `json:"export_id" query:"export_id" validate:"required"`
+}
+
+type KBExportDownloadReq struct {
+	KBId     string `json:"kb_id" query:"kb_id" validate:"required"`
+	ExportID string `json:"export_id" query:"export_id" validate:"required"`
+}
+
+type KBExportDeleteReq struct {
+	KBId     string `json:"kb_id" query:"kb_id" validate:"required"`
+	ExportID string `json:"export_id" query:"export_id" validate:"required"`
+}
+
+type KBImportReq struct {
+	KBId        string              `json:"kb_id" validate:"required"`
+	Key         string              `json:"key" validate:"required"`                              // archive uploaded with /api/v1/file/upload
+	ParentID    string              `json:"parent_id"`                                            // import under this folder, default root
+	Mode        domain.KBImportMode `json:"mode" validate:"omitempty,oneof=skip overwrite merge"` // 默认 skip
+	PreserveIDs bool                `json:"preserve_ids"`                                         // keep node ids so /node/<id> links stay valid
+}
+
+type KBImportResp struct {
+	ImportID string `json:"import_id"`
+}
+
+type KBImportListReq struct {
+	KBId string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type KBImportDetailReq struct {
+	KBId     string `json:"kb_id" query:"kb_id" validate:"required"`
+	ImportID string `json:"import_id" query:"import_id" validate:"required"`
+}
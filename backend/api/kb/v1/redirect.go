@@ -0,0 +1,41 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+// redirect_id is used instead of id, id in knowledge_base routes is read as kb id by auth middleware
+
+type KBRedirectListReq struct {
+	KBId string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type KBRedirectItem struct {
+	domain.KBRedirect
+	TargetNodeName string `json:"target_node_name"`
+}
+
+// KBRedirectCreateReq 目标文档和目标地址二选一
+type KBRedirectCreateReq struct {
+	KBId         string `json:"kb_id" validate:"required"`
+	SourcePath   string `json:"source_path" validate:"required"` // e.g. /node/<id>, /docs/<slug> or any other path of the site
+	TargetNodeID string `json:"target_node_id"`
+	TargetURL    string `json:"target_url" validate:"omitempty,url"`
+	Permanent    *bool  `json:"permanent"` // 默认 true
+}
+
+type KBRedirectCreateResp struct {
+	RedirectID int64 `json:"redirect_id"`
+}
+
+type KBRedirectUpdateReq struct {
+	KBId         string `json:"kb_id" validate:"required"`
+	RedirectID   int64  `json:"redirect_id" validate:"required"`
+	SourcePath   string `json:"source_path" validate:"required"`
+	TargetNodeID string `json:"target_node_id"`
+	TargetURL    string `json:"target_url" validate:"omitempty,url"`
+	Permanent    bool   `json:"permanent"`
+}
+
+type KBRedirectDeleteReq struct {
+	KBId       string `json:"kb_id" query:"kb_id" validate:"required"`
+	RedirectID int64  `json:"redirect_id" query:"redirect_id" validate:"required"`
+}
@@ -0,0 +1,58 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+// webhook_id is used instead of id, id in knowledge_base routes is read as kb id by auth middleware
+
+type KBWebhookCreateReq struct {
+	KBId   string `json:"kb_id" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+	URL    string `json:"url" validate:"required,url"`
+	Secret string `json:"secret"` // generated when empty
+	// 为空时订阅全部事件
+	Events  []domain.WebhookEvent `json:"events" validate:"dive,oneof=node.created node.updated node.published node.deleted kb_release.created comment.created feedback.received node_feedback.received conversation.started"`
+	Enabled *bool                 `json:"enabled"` // 默认 true
+}
+
+type KBWebhookCreateResp struct {
+	WebhookID string `json:"webhook_id"`
+	Secret    string `json:"secret"` // only returned on creation, used to verify the X-PandaWiki-Signature header
+}
+
+type KBWebhookUpdateReq struct {
+	KBId      string  `json:"kb_id" validate:"required"`
+	WebhookID string  `json:"webhook_id" validate:"required"`
+	Name      *string `json:"name" validate:"omitempty,min=1"`
+	URL       *string `json:"url" validate:"omitempty,url"`
+	Secret    *string `json:"secret"` // empty keeps the stored secret
+	// 为空时订阅全部事件
+	Events  *[]domain.WebhookEvent `json:"events" validate:"omitempty,dive,oneof=node.created node.updated node.published node.deleted kb_release.created comment.created feedback.received node_feedback.received conversation.started"`
+	Enabled *bool                  `json:"enabled"`
+}
+
+type KBWebhookListReq struct {
+	KBId string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type KBWebhookDeleteReq struct {
+	KBId      string `json:"kb_id" query:"kb_id" validate:"required"`
+	WebhookID string `json:"webhook_id" query:"webhook_id" validate:"required"`
+}
+
+type KBWebhookTestReq struct {
+	KBId      string `json:"kb_id" validate:"required"`
+	WebhookID string `json:"webhook_id" validate:"required"`
+}
+
+type KBWebhookDeliveryListReq struct {
+	KBId      string `json:"kb_id" query:"kb_id" validate:"required"`
+	WebhookID string `json:"webhook_id" query:"webhook_id" validate:"required"`
+	domain.Pager
+}
+
+type KBWebhookDeliveryListResp = domain.PaginatedResult[[]*domain.KBWebhookDelivery]
+
+type KBWebhookRedeliverReq struct {
+	KBId       string `json:"kb_id" validate:"required"`
+	DeliveryID string `json:"delivery_id" validate:"required"`
+}
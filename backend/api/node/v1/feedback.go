@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type NodeFeedbackListReq struct {
+	KbID   string                        `query:"kb_id" json:"kb_id" validate:"required"`
+	NodeID string                        `query:"node_id" json:"node_id"`
+	Status domain.DocumentFeedbackStatus `query:"status" json:"status" validate:"omitempty,oneof=open resolved"`
+	Score  domain.ScoreType              `query:"score" json:"score" validate:"omitempty,oneof=1 -1"`
+	Reason domain.DocumentFeedbackReason `query:"reason" json:"reason"`
+	domain.Pager
+}
+
+type NodeFeedbackListItem struct {
+	ID                int64                         `json:"id"`
+	NodeID            string                        `json:"node_id"`
+	NodeName          string                        `json:"node_name"`
+	NodeReleaseID     string                        `json:"node_release_id"`
+	Score             domain.ScoreType              `json:"score"`
+	Reason            domain.DocumentFeedbackReason `json:"reason"`
+	Content           string                        `json:"content"`
+	Info              domain.DocumentFeedbackInfo   `json:"info" gorm:"type:jsonb"`
+	IPAddress         *domain.IPAddress             `json:"ip_address" gorm:"-"`
+	Status            domain.DocumentFeedbackStatus `json:"status"`
+	ResolvedBy        string                        `json:"resolved_by"`
+	ResolvedAt        *time.Time                    `json:"resolved_at"`
+	ResolvedReleaseID string                        `json:"resolved_release_id"`
+	Republished       bool                          `json:"republished" gorm:"-"` // the doc was published again after the feedback
+	CreatedAt         time.Time                     `json:"created_at"`
+}
+
+type NodeFeedbackListResp = domain.PaginatedResult[[]*NodeFeedbackListItem]
+
+// NodeFeedbackStatusReq 批量标记反馈为已解决或重新打开
+type NodeFeedbackStatusReq struct {
+	KbID   string                        `json:"kb_id" validate:"required"`
+	IDs    []int64                       `json:"ids" validate:"required,min=1"`
+	Status domain.DocumentFeedbackStatus `json:"status" validate:"required,oneof=open resolved"`
+}
+
+// NodeFeedbackLinkReq 将反馈关联到修正它的文档发布版本，并标记为已解决
+type NodeFeedbackLinkReq struct {
+	KbID      string `json:"kb_id" validate:"required"`
+	ID        int64  `json:"id" validate:"required"`
+	ReleaseID string `json:"release_id" validate:"required"`
+}
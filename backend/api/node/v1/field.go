@@ -0,0 +1,37 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+type NodeFieldListReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
+
+type NodeFieldCreateReq struct {
+	KbId     string               `json:"kb_id" validate:"required"`
+	Key      string               `json:"key" validate:"required,max=64"`
+	Name     string               `json:"name" validate:"required"`
+	Type     domain.NodeFieldType `json:"type" validate:"required,oneof=text select date user"`
+	Options  []string             `json:"options"` // select 类型的可选值
+	Position int                  `json:"position"`
+}
+
+type NodeFieldCreateResp struct {
+	ID string `json:"id"`
+}
+
+type NodeFieldUpdateReq struct {
+	KbId     string    `json:"kb_id" validate:"required"`
+	ID       string    `json:"id" validate:"required"`
+	Name     *string   `json:"name"`
+	Options  *[]string `json:"options"`
+	Position *int      `json:"position"`
+}
+
+type NodeFieldDeleteReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+type NodeTagListReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type NodeBacklinksReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+type NodeInboundLinksReq struct {
+	KbId string   `query:"kb_id" json:"kb_id" validate:"required"`
+	IDs  []string `query:"ids[]" json:"ids" validate:"required,min=1"`
+}
+
+type NodeBrokenLinksReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
+
+// NodeLinkItem 一条入链：NodeID 对应的文档链接到了 TargetNodeID
+type NodeLinkItem struct {
+	NodeID         string `json:"node_id"`
+	NodeName       string `json:"node_name"`
+	NodeEmoji      string `json:"node_emoji"`
+	TargetNodeID   string `json:"target_node_id"`
+	TargetNodeName string `json:"target_node_name"`
+	URL            string `json:"url"`
+	InDraft        bool   `json:"in_draft"`   // 当前编辑内容中存在该链接
+	InRelease      bool   `json:"in_release"` // 最新发布内容中存在该链接
+}
+
+type NodeBrokenLinkReason string
+
+const (
+	NodeBrokenLinkReasonNotFound     NodeBrokenLinkReason = "not_found"     // 目标文档不存在或已删除
+	NodeBrokenLinkReasonUnpublished  NodeBrokenLinkReason = "unpublished"   // 已发布内容链接到未发布的文档
+	NodeBrokenLinkReasonNotVisitable NodeBrokenLinkReason = "not_visitable" // 已发布内容链接到不可访问的文档
+	NodeBrokenLinkReasonHTTPError    NodeBrokenLinkReason = "http_error"    // 外部链接检查失败
+)
+
+type NodeBrokenLinkItem struct {
+	NodeID       string               `json:"node_id"`
+	NodeName     string               `json:"node_name"`
+	Scope        domain.NodeLinkScope `json:"scope"`
+	Type         domain.NodeLinkType  `json:"type"`
+	URL          string               `json:"url"`
+	TargetNodeID string               `json:"target_node_id"`
+	Reason       NodeBrokenLinkReason `json:"reason"`
+	StatusCode   int                  `json:"status_code"`
+	Error        string               `json:"error"`
+	CheckedAt    *time.Time           `json:"checked_at"`
+}
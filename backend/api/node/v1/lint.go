@@ -0,0 +1,29 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+type NodeLintReportReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
+
+// NodeLintReportResp 知识库内容质量报告
+type NodeLintReportResp struct {
+	IssueCount int                         `json:"issue_count"`
+	NodeCount  int                         `json:"node_count"` // 存在问题的文档数
+	RuleCounts map[domain.NodeLintRule]int `json:"rule_counts"`
+	Nodes      []*NodeLintReportItem       `json:"nodes"`
+}
+
+type NodeLintReportItem struct {
+	NodeID   string                  `json:"node_id"`
+	NodeName string                  `json:"node_name"`
+	Issues   []*domain.NodeLintIssue `json:"issues"`
+}
+
+type NodeLintRefreshReq struct {
+	KbId string `json:"kb_id" validate:"required"`
+}
+
+type NodeLintRefreshResp struct {
+	NodeCount int `json:"node_count"` // 检查的文档数
+}
@@ -31,6 +31,7 @@ type NodeDetailResp struct {
 	EditorAccount    string                 `json:"editor_account"`
 	PublisherAccount string                 `json:"publisher_account" gorm:"-"`
 	PV               int64                  `json:"pv" gorm:"-"`
+	Version          int64                  `json:"version"`
 }
 
 type NodePermissionReq struct {
@@ -56,6 +57,7 @@ type NodePermissionEditReq struct {
 }
 
 type NodePermissionEditResp struct {
+	InboundLinks []*NodeLinkItem `json:"inbound_links"` // 设为不可访问时，链接到这些文档的其他文档
 }
 
 type NodeRestudyReq struct {
@@ -65,3 +67,25 @@ type NodeRestudyReq struct {
 
 type NodeRestudyResp struct {
 }
+
+type NodeUpdateResp struct {
+	Version    int64                   `json:"version"`
+	LintIssues []*domain.NodeLintIssue `json:"lint_issues"` // 仅在更新内容时检查
+}
+
+// NodeUpdateConflictResp 保存时版本冲突，返回最新内容与三方合并结果
+type NodeUpdateConflictResp struct {
+	ID            string    `json:"id"`
+	Version       int64     `json:"version"` // 当前最新版本
+	Name          string    `json:"name"`
+	Content       string    `json:"content"` // 当前最新内容
+	EditorId      string    `json:"editor_id"`
+	EditorAccount string    `json:"editor_account"`
+	EditTime      time.Time `json:"edit_time"`
+	MergedContent string    `json:"merged_content"` // 基于提交版本、提交内容与最新内容的三方合并结果
+	HasConflict   bool      `json:"has_conflict"`   // 合并结果是否包含冲突标记
+}
+
+type NodeActionResp struct {
+	InboundLinks []*NodeLinkItem `json:"inbound_links"` // 删除后失效的入链
+}
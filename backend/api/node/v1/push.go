@@ -0,0 +1,34 @@
+package v1
+
+import "github.com/chaitin/panda-wiki/domain"
+
+type NodePushReq struct {
+	KbId     string `json:"kb_id" validate:"required"`
+	Source   string `json:"source" validate:"required,max=128"` // namespace of the external ids, e.g. the repository pushing the docs
+	ParentID string `json:"parent_id"`                          // paths are relative to this folder, root when empty
+	// publish the created and changed docs in a new release
+	Publish        bool           `json:"publish"`
+	ReleaseMessage string         `json:"release_message"` // 默认 "push from <source>"
+	ReleaseTag     string         `json:"release_tag"`     // 默认 push-<time>
+	Docs           []*NodePushDoc `json:"docs" validate:"required,min=1,max=200,dive,required"`
+}
+
+type NodePushDoc struct {
+	ExternalID  string `json:"external_id" validate:"required,max=255"` // stable id of the doc in the pipeline, pushing it again updates the same node
+	Path        string `json:"path" validate:"required"`                // e.g. sdk/go/client.md, folders are created for the directories
+	Title       string `json:"title"`                                   // 默认为路径中去掉扩展名的文件名
+	Content     string `json:"content"`
+	ContentType string `json:"content_type" validate:"omitempty,oneof=md html"` // 默认 md
+}
+
+type NodePushResp struct {
+	Results   []*NodePushResult `json:"results"`    // in the order of the docs
+	ReleaseID string            `json:"release_id"` // empty when nothing is published
+}
+
+type NodePushResult struct {
+	ExternalID string                `json:"external_id"`
+	NodeID     string                `json:"node_id"`
+	Status     domain.NodePushStatus `json:"status"`
+	Error      string                `json:"error,omitempty"`
+}
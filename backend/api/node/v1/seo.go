@@ -0,0 +1,18 @@
+package v1
+
+type NodeSEOReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+// NodeSEOUpdateReq 为空的字段使用自动生成的值
+type NodeSEOUpdateReq struct {
+	KbId         string `json:"kb_id" validate:"required"`
+	ID           string `json:"id" validate:"required"`
+	Title        string `json:"title" validate:"max=200"`
+	Description  string `json:"description" validate:"max=500"`
+	Keywords     string `json:"keywords" validate:"max=500"`
+	Image        string `json:"image" validate:"omitempty,max=2048"`
+	CanonicalURL string `json:"canonical_url" validate:"omitempty,url,max=2048"`
+	NoIndex      bool   `json:"no_index"`
+}
@@ -0,0 +1,18 @@
+package v1
+
+type NodeSlugReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+type NodeSlugResp struct {
+	Slug string `json:"slug"`
+	Path string `json:"path"` // /docs/<slug>, or /node/<id> without slug
+}
+
+// NodeSlugUpdateReq 设置文档别名，为空时删除别名。修改或删除的旧别名会重定向到该文档
+type NodeSlugUpdateReq struct {
+	KbId string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+	Slug string `json:"slug" validate:"max=200"`
+}
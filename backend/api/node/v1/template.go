@@ -0,0 +1,45 @@
+package v1
+
+type NodeTemplateListReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
+
+type NodeTemplateDetailReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+type NodeTemplateCreateReq struct {
+	KbId        string `json:"kb_id" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Emoji       string `json:"emoji"`
+	ContentType string `json:"content_type" validate:"omitempty,oneof=md html"`
+	Content     string `json:"content"`
+	Summary     string `json:"summary"`
+}
+
+type NodeTemplateUpdateReq struct {
+	KbId        string  `json:"kb_id" validate:"required"`
+	ID          string  `json:"id" validate:"required"`
+	Name        *string `json:"name"`
+	Emoji       *string `json:"emoji"`
+	ContentType *string `json:"content_type" validate:"omitempty,oneof=md html"`
+	Content     *string `json:"content"`
+	Summary     *string `json:"summary"`
+}
+
+type NodeTemplateDeleteReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"`
+}
+
+// NodeTemplateFromNodeReq 将已有文档保存为模板
+type NodeTemplateFromNodeReq struct {
+	KbId   string `json:"kb_id" validate:"required"`
+	NodeId string `json:"node_id" validate:"required"`
+	Name   string `json:"name"` // 为空时使用文档名称
+}
+
+type NodeTemplateCreateResp struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type NodeTranslationListReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+	ID   string `query:"id" json:"id" validate:"required"` // source node id
+}
+
+type NodeTranslationItem struct {
+	NodeID        string                       `json:"node_id"`
+	Locale        string                       `json:"locale"`
+	Name          string                       `json:"name"`
+	NodeStatus    domain.NodeStatus            `json:"node_status"` // draft when the translation has unpublished changes
+	Status        domain.NodeTranslationStatus `json:"status"`
+	Error         string                       `json:"error"`
+	SourceVersion int64                        `json:"source_version"`
+	Stale         bool                         `json:"stale" gorm:"-"` // the source changed after the translation
+	UpdatedAt     time.Time                    `json:"updated_at"`
+}
+
+// NodeTranslateReq 使用对话模型将文档翻译为目标语言，已有译文时覆盖
+type NodeTranslateReq struct {
+	KbId   string `json:"kb_id" validate:"required"`
+	ID     string `json:"id" validate:"required"` // source node id
+	Locale string `json:"locale" validate:"required"`
+}
+
+type NodeTranslateResp struct {
+	NodeID string `json:"node_id"`
+}
+
+// NodeTranslationLinkReq 将已有文档关联为源文档的译文
+type NodeTranslationLinkReq struct {
+	KbId   string `json:"kb_id" validate:"required"`
+	ID     string `json:"id" validate:"required"`      // source node id
+	NodeID string `json:"node_id" validate:"required"` // translated node id
+	Locale string `json:"locale" validate:"required"`
+}
+
+type NodeTranslationReq struct {
+	KbId   string `query:"kb_id" json:"kb_id" validate:"required"`
+	NodeID string `query:"node_id" json:"node_id" validate:"required"` // translated node id
+}
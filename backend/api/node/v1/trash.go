@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type NodeTrashListReq struct {
+	KbId string `query:"kb_id" json:"kb_id" validate:"required"`
+}
+
+type NodeTrashListItem struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name"`
+	Type             domain.NodeType `json:"type"`
+	Emoji            string          `json:"emoji"`
+	ParentID         string          `json:"parent_id"`   // 删除前的父文档
+	ParentName       string          `json:"parent_name"` // 父文档已不存在时为空，恢复到根目录
+	ChildCount       int64           `json:"child_count"` // 一同删除的子文档数量
+	DeletedBy        string          `json:"deleted_by"`
+	DeletedByAccount string          `json:"deleted_by_account"`
+	DeletedAt        time.Time       `json:"deleted_at"`
+	ExpireAt         time.Time       `json:"expire_at" gorm:"-"` // 到期后彻底删除
+}
+
+type NodeTrashRestoreReq struct {
+	KbId string   `json:"kb_id" validate:"required"`
+	IDs  []string `json:"ids" validate:"required,min=1"`
+}
+
+type NodeTrashDeleteReq struct {
+	KbId string   `query:"kb_id" json:"kb_id" validate:"required"`
+	IDs  []string `query:"ids[]" json:"ids"`
+	All  bool     `query:"all" json:"all"` // 清空回收站
+}
@@ -7,23 +7,39 @@ import (
 )
 
 type ShareNodeDetailResp struct {
-	ID               string                        `json:"id"`
-	KbID             string                        `json:"kb_id"`
-	Type             domain.NodeType               `json:"type"`
-	Status           domain.NodeStatus             `json:"status"`
-	Name             string                        `json:"name"`
-	Content          string                        `json:"content"`
-	Meta             domain.NodeMeta               `json:"meta"`
-	ParentID         string                        `json:"parent_id"`
-	CreatedAt        time.Time                     `json:"created_at"`
-	UpdatedAt        time.Time                     `json:"updated_at"`
-	Permissions      domain.NodePermissions        `json:"permissions"`
-	CreatorId        string                        `json:"creator_id"`
-	EditorId         string                        `json:"editor_id"`
-	PublisherId      string                        `json:"publisher_id"`
-	CreatorAccount   string                        `json:"creator_account"`
-	EditorAccount    string                        `json:"editor_account"`
-	PublisherAccount string                        `json:"publisher_account"`
-	List             []*domain.ShareNodeDetailItem `json:"list" gorm:"-"`
-	PV               int64                         `json:"pv" gorm:"-"`
+	ID               string                         `json:"id"`
+	KbID             string                         `json:"kb_id"`
+	Type             domain.NodeType                `json:"type"`
+	Status           domain.NodeStatus              `json:"status"`
+	Name             string                         `json:"name"`
+	Content          string                         `json:"content"`
+	Meta             domain.NodeMeta                `json:"meta"`
+	ParentID         string                         `json:"parent_id"`
+	CreatedAt        time.Time                      `json:"created_at"`
+	UpdatedAt        time.Time                      `json:"updated_at"`
+	Permissions      domain.NodePermissions         `json:"permissions"`
+	CreatorId        string                         `json:"creator_id"`
+	EditorId         string                         `json:"editor_id"`
+	PublisherId      string                         `json:"publisher_id"`
+	CreatorAccount   string                         `json:"creator_account"`
+	EditorAccount    string                         `json:"editor_account"`
+	PublisherAccount string                         `json:"publisher_account"`
+	List             []*domain.ShareNodeDetailItem  `json:"list" gorm:"-"`
+	PV               int64                          `json:"pv" gorm:"-"`
+	Locale           string                         `json:"locale,omitempty" gorm:"-"`       // language of a translated node, empty for a source node
+	Translations     []*domain.ShareNodeTranslation `json:"translations,omitempty" gorm:"-"` // language variants including this node
+	Slug             string                         `json:"slug,omitempty" gorm:"-"`
+}
+
+type ShareNodeResolveReq struct {
+	Path string `query:"path" json:"path" validate:"required"` // request path of the site, e.g. /docs/getting-started
+}
+
+// ShareNodeResolveResp 前端按 type 渲染文档、跳转或展示 404
+type ShareNodeResolveResp struct {
+	Type        domain.ShareResolveType `json:"type"`
+	NodeID      string                  `json:"node_id,omitempty"`      // type node
+	Path        string                  `json:"path,omitempty"`         // type node, canonical path of the node
+	RedirectURL string                  `json:"redirect_url,omitempty"` // type redirect, a site path or an external url
+	StatusCode  int                     `json:"status_code,omitempty"`  // type redirect, 301 or 302
 }
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type ShareNodeSearchReq struct {
+	Query    string   `query:"q" json:"q" validate:"required,max=200"` // 空格分隔多个关键词，"双引号" 内为短语
+	ParentID string   `query:"parent_id" json:"parent_id"`             // 仅搜索该目录下的文档
+	Tags     []string `query:"tags[]" json:"tags"`                     // 同时包含所有标签
+	domain.Pager
+}
+
+type ShareNodeSearchItem struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"` // 匹配部分以 <mark> 高亮，已转义
+	Emoji     string    `json:"emoji"`
+	Path      []string  `json:"path"` // 所在目录名称
+	Tags      []string  `json:"tags"`
+	Snippets  []string  `json:"snippets"` // 匹配的内容片段，以 <mark> 高亮，已转义
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ShareNodeSearchResp = domain.PaginatedResult[[]*ShareNodeSearchItem]
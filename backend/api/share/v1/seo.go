@@ -0,0 +1,35 @@
+package v1
+
+type ShareNodeSEOReq struct {
+	ID string `query:"id" json:"id"` // 为空时返回首页的 SEO 数据
+}
+
+// ShareNodeSEOResp 由前端注入到页面 head 中
+type ShareNodeSEOResp struct {
+	Title        string           `json:"title"`
+	Description  string           `json:"description"`
+	Keywords     string           `json:"keywords"`
+	CanonicalURL string           `json:"canonical_url"`
+	Robots       string           `json:"robots"`
+	OpenGraph    ShareOpenGraph   `json:"open_graph"`
+	Twitter      ShareTwitterCard `json:"twitter"`
+	JSONLD       []map[string]any `json:"json_ld"` // 每项输出为一个 application/ld+json 脚本
+}
+
+type ShareOpenGraph struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	URL           string `json:"url"`
+	Image         string `json:"image,omitempty"`
+	SiteName      string `json:"site_name"`
+	PublishedTime string `json:"published_time,omitempty"`
+	ModifiedTime  string `json:"modified_time,omitempty"`
+}
+
+type ShareTwitterCard struct {
+	Card        string `json:"card"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"`
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+type StatSearchQueriesReq struct {
+	KbID   string                   `json:"kb_id" query:"kb_id" validate:"required"`
+	Day    consts.StatDay           `json:"day" query:"day" validate:"omitempty,oneof=1 7 30 90"`
+	Source domain.SearchQuerySource `json:"source" query:"source" validate:"omitempty,oneof=search chat"` // 为空时包含全部来源
+}
+
+// StatSearchQueryItem 按规范化后的查询聚合
+type StatSearchQueryItem struct {
+	Query          string    `json:"query"` // 最近一次的原始查询
+	Count          int64     `json:"count"`
+	ZeroCount      int64     `json:"zero_count"` // 无结果次数
+	AvgResultCount float64   `json:"avg_result_count"`
+	AvgTopScore    float64   `json:"avg_top_score"`
+	LastAt         time.Time `json:"last_at"`
+}
+
+type StatNegativeFeedbackQueriesReq struct {
+	KbID string         `json:"kb_id" query:"kb_id" validate:"required"`
+	Day  consts.StatDay `json:"day" query:"day" validate:"omitempty,oneof=1 7 30 90"`
+}
+
+type StatNegativeFeedbackQueryItem struct {
+	Query              string    `json:"query"`
+	Count              int64     `json:"count"` // 被点踩的回答数
+	AvgTopScore        float64   `json:"avg_top_score"`
+	LastConversationID string    `json:"last_conversation_id"`
+	LastFeedback       string    `json:"last_feedback"` // 最近一条反馈内容
+	LastAt             time.Time `json:"last_at"`
+}
+
+type StatSearchGapDocReq struct {
+	KbID     string `json:"kb_id" validate:"required"`
+	Query    string `json:"query" validate:"required,max=500"`
+	ParentID string `json:"parent_id"`
+}
+
+type StatSearchGapDocResp struct {
+	NodeID string `json:"node_id"`
+}
@@ -54,3 +54,37 @@ type StatConversationDistributionResp struct {
 	AppType domain.AppType `json:"app_type"`
 	Count   int64          `json:"count"`
 }
+
+type StatNodeEngagementReq struct {
+	KbID string         `json:"kb_id" query:"kb_id" validate:"required"`
+	Day  consts.StatDay `json:"day" query:"day" validate:"omitempty,oneof=1 7 30 90"`
+}
+
+// StatNodeEngagementItem 文档阅读情况，比率均为0-1
+type StatNodeEngagementItem struct {
+	NodeID          string  `json:"node_id"`
+	NodeName        string  `json:"node_name"`
+	Views           int64   `json:"views"`
+	AvgDwellSeconds float64 `json:"avg_dwell_seconds"`
+	AvgScrollDepth  float64 `json:"avg_scroll_depth"`
+	ReadRate        float64 `json:"read_rate"`   // 停留够久或读到了末尾
+	BounceRate      float64 `json:"bounce_rate"` // 很快离开且没有滚动
+	ExitRate        float64 `json:"exit_rate"`   // 从该文档离开站点
+	Copies          int64   `json:"copies"`
+	OutboundClicks  int64   `json:"outbound_clicks"`
+}
+
+type StatNodeFeedbackReq struct {
+	KbID string         `json:"kb_id" query:"kb_id" validate:"required"`
+	Day  consts.StatDay `json:"day" query:"day" validate:"omitempty,oneof=1 7 30 90"`
+}
+
+type StatNodeFeedbackItem struct {
+	NodeID      string             `json:"node_id"`
+	NodeName    string             `json:"node_name" gorm:"-"`
+	Helpful     int64              `json:"helpful"`
+	NotHelpful  int64              `json:"not_helpful"`
+	HelpfulRate float64            `json:"helpful_rate" gorm:"-"`
+	OpenCount   int64              `json:"open_count"`       // 未解决的负面反馈
+	Reasons     domain.MapStrInt64 `json:"reasons" gorm:"-"` // 负面反馈的原因分布
+}
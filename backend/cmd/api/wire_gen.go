@@ -66,6 +66,7 @@ func createApp() (*App, error) {
 	}
 	knowledgeBaseRepository := pg2.NewKnowledgeBaseRepository(db, configConfig, logger, ragService)
 	nodeRepository := pg2.NewNodeRepository(db, logger)
+	nodeLinkRepository := pg2.NewNodeLinkRepository(db, logger)
 	mqProducer, err := mq.NewMQProducer(configConfig, logger)
 	if err != nil {
 		return nil, err
@@ -73,7 +74,13 @@ func createApp() (*App, error) {
 	ragRepository := mq2.NewRAGRepository(mqProducer)
 	userRepository := pg2.NewUserRepository(db, logger)
 	kbRepo := cache2.NewKBRepo(cacheCache)
-	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, ragRepository, userRepository, ragService, kbRepo, logger, configConfig)
+	webhookRepository := pg2.NewWebhookRepository(db, logger)
+	mqWebhookRepository := mq2.NewWebhookRepository(mqProducer)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository, mqWebhookRepository, logger)
+	nodeLintRepository := pg2.NewNodeLintRepository(db, logger)
+	blockWordRepo := pg2.NewBlockWordRepo(db, logger)
+	nodeLintUsecase := usecase.NewNodeLintUsecase(nodeLintRepository, nodeRepository, knowledgeBaseRepository, blockWordRepo, configConfig, logger)
+	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, nodeLinkRepository, ragRepository, userRepository, ragService, kbRepo, webhookUsecase, nodeLintUsecase, logger, configConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -88,33 +95,48 @@ func createApp() (*App, error) {
 	conversationRepository := pg2.NewConversationRepository(db, logger)
 	modelRepository := pg2.NewModelRepository(db, logger)
 	promptRepo := pg2.NewPromptRepo(db, logger)
-	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, logger)
-	knowledgeBaseHandler := v1.NewKnowledgeBaseHandler(baseHandler, echo, knowledgeBaseUsecase, llmUsecase, authMiddleware, logger)
+	nodeTranslationRepository := pg2.NewNodeTranslationRepository(db, logger)
+	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, nodeTranslationRepository, logger)
+	kbExportRepository := pg2.NewKBExportRepository(db, logger)
+	kbImportRepository := pg2.NewKBImportRepository(db, logger)
+	nodeFieldRepository := pg2.NewNodeFieldRepository(db, logger)
+	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
 	appRepository := pg2.NewAppRepository(db, logger)
+	nodeTemplateRepository := pg2.NewNodeTemplateRepository(db, logger)
+	kbRedirectRepository := pg2.NewKBRedirectRepository(db, logger)
 	minioClient, err := s3.NewMinioClient(configConfig)
 	if err != nil {
 		return nil, err
 	}
-	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
 	systemSettingRepo := pg2.NewSystemSettingRepo(db, logger)
 	modelUsecase := usecase.NewModelUsecase(modelRepository, nodeRepository, ragRepository, ragService, logger, configConfig, knowledgeBaseRepository, systemSettingRepo)
-	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase)
-	nodeHandler := v1.NewNodeHandler(baseHandler, echo, nodeUsecase, authMiddleware, logger)
-	geoRepo := cache2.NewGeoCache(cacheCache, db, logger)
+	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, nodeTemplateRepository, nodeLinkRepository, nodeFieldRepository, kbRedirectRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase, webhookUsecase, nodeLintUsecase, configConfig)
+	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig, systemSettingRepo)
+	kbExportUsecase := usecase.NewKBExportUsecase(kbExportRepository, kbImportRepository, nodeRepository, nodeFieldRepository, knowledgeBaseRepository, authRepo, appRepository, nodeUsecase, fileUsecase, minioClient, configConfig, logger)
+	kbRedirectUsecase := usecase.NewKBRedirectUsecase(kbRedirectRepository, nodeRepository, logger)
+	knowledgeBaseHandler := v1.NewKnowledgeBaseHandler(baseHandler, echo, knowledgeBaseUsecase, llmUsecase, kbExportUsecase, webhookUsecase, kbRedirectUsecase, authMiddleware, logger)
+	nodePushRepository := pg2.NewNodePushRepository(db, logger)
+	nodePushUsecase := usecase.NewNodePushUsecase(nodePushRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, logger)
+	nodeSEORepository := pg2.NewNodeSEORepository(db, logger)
+	nodeSEOUsecase := usecase.NewNodeSEOUsecase(nodeSEORepository, nodeRepository, knowledgeBaseRepository, appRepository, kbRedirectRepository, logger)
+	nodeTranslationUsecase := usecase.NewNodeTranslationUsecase(nodeTranslationRepository, nodeRepository, nodeUsecase, llmUsecase, modelUsecase, logger)
+	documentFeedbackRepository := pg2.NewDocumentFeedbackRepository(db, logger)
 	ipdbIPDB, err := ipdb.NewIPDB(configConfig, logger)
 	if err != nil {
 		return nil, err
 	}
 	ipAddressRepo := ipdb2.NewIPAddressRepo(ipdbIPDB, logger)
-	conversationUsecase := usecase.NewConversationUsecase(conversationRepository, nodeRepository, geoRepo, logger, ipAddressRepo, authRepo)
-	blockWordRepo := pg2.NewBlockWordRepo(db, logger)
-	chatUsecase, err := usecase.NewChatUsecase(llmUsecase, knowledgeBaseRepository, conversationUsecase, modelUsecase, appRepository, blockWordRepo, authRepo, logger)
+	documentFeedbackUsecase := usecase.NewDocumentFeedbackUsecase(documentFeedbackRepository, nodeRepository, ipAddressRepo, authRepo, webhookUsecase, logger)
+	nodeHandler := v1.NewNodeHandler(baseHandler, echo, nodeUsecase, nodePushUsecase, nodeLintUsecase, nodeSEOUsecase, nodeTranslationUsecase, documentFeedbackUsecase, kbRedirectUsecase, authMiddleware, logger)
+	geoRepo := cache2.NewGeoCache(cacheCache, db, logger)
+	conversationUsecase := usecase.NewConversationUsecase(conversationRepository, nodeRepository, geoRepo, logger, ipAddressRepo, authRepo, webhookUsecase)
+	searchQueryRepository := pg2.NewSearchQueryRepository(db, logger)
+	chatUsecase, err := usecase.NewChatUsecase(llmUsecase, knowledgeBaseRepository, conversationUsecase, modelUsecase, appRepository, blockWordRepo, authRepo, searchQueryRepository, logger)
 	if err != nil {
 		return nil, err
 	}
 	appUsecase := usecase.NewAppUsecase(appRepository, authRepo, nodeRepository, knowledgeBaseRepository, nodeUsecase, logger, configConfig, chatUsecase, cacheCache)
 	appHandler := v1.NewAppHandler(echo, baseHandler, logger, authMiddleware, appUsecase, modelUsecase, conversationUsecase, configConfig)
-	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig, systemSettingRepo)
 	fileHandler := v1.NewFileHandler(echo, baseHandler, logger, authMiddleware, minioClient, configConfig, fileUsecase)
 	modelHandler := v1.NewModelHandler(echo, baseHandler, logger, authMiddleware, modelUsecase, llmUsecase)
 	conversationHandler := v1.NewConversationHandler(echo, baseHandler, logger, authMiddleware, conversationUsecase)
@@ -122,18 +144,24 @@ func createApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	crawlerUsecase, err := usecase.NewCrawlerUsecase(logger, mqConsumer, cacheCache)
+	crawlerUsecase, err := usecase.NewCrawlerUsecase(logger, mqConsumer, cacheCache, fileUsecase)
 	if err != nil {
 		return nil, err
 	}
-	crawlerHandler := v1.NewCrawlerHandler(echo, baseHandler, authMiddleware, logger, configConfig, crawlerUsecase, fileUsecase)
+	gitSourceRepository := pg2.NewGitSourceRepository(db, logger)
+	gitSyncUsecase := usecase.NewGitSyncUsecase(gitSourceRepository, nodeRepository, nodeUsecase, fileUsecase, configConfig, logger)
+	crawlerSyncRepository := pg2.NewCrawlerSyncRepository(db, logger)
+	crawlerSyncUsecase := usecase.NewCrawlerSyncUsecase(crawlerSyncRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, crawlerUsecase, logger)
+	openAPISpecRepository := pg2.NewOpenAPISpecRepository(db, logger)
+	openAPIImportUsecase := usecase.NewOpenAPIImportUsecase(openAPISpecRepository, nodeRepository, nodeUsecase, minioClient, logger)
+	crawlerHandler := v1.NewCrawlerHandler(echo, baseHandler, authMiddleware, logger, configConfig, crawlerUsecase, fileUsecase, gitSyncUsecase, crawlerSyncUsecase, openAPIImportUsecase)
 	creationUsecase := usecase.NewCreationUsecase(logger, llmUsecase, modelUsecase)
 	creationHandler := v1.NewCreationHandler(echo, baseHandler, logger, creationUsecase)
 	statRepository := pg2.NewStatRepository(db, cacheCache)
-	statUseCase := usecase.NewStatUseCase(statRepository, nodeRepository, conversationRepository, appRepository, ipAddressRepo, geoRepo, authRepo, knowledgeBaseRepository, logger)
+	statUseCase := usecase.NewStatUseCase(statRepository, nodeRepository, conversationRepository, appRepository, ipAddressRepo, geoRepo, authRepo, knowledgeBaseRepository, searchQueryRepository, documentFeedbackRepository, nodeUsecase, logger)
 	statHandler := v1.NewStatHandler(baseHandler, echo, statUseCase, logger, authMiddleware)
 	commentRepository := pg2.NewCommentRepository(db, logger)
-	commentUsecase := usecase.NewCommentUsecase(commentRepository, logger, nodeRepository, ipAddressRepo, authRepo)
+	commentUsecase := usecase.NewCommentUsecase(commentRepository, logger, nodeRepository, ipAddressRepo, authRepo, webhookUsecase)
 	commentHandler := v1.NewCommentHandler(echo, baseHandler, logger, authMiddleware, commentUsecase)
 	authUsecase, err := usecase.NewAuthUsecase(authRepo, logger, knowledgeBaseRepository, cacheCache)
 	if err != nil {
@@ -154,13 +182,18 @@ func createApp() (*App, error) {
 		CommentHandler:       commentHandler,
 		AuthV1Handler:        authV1Handler,
 	}
-	shareNodeHandler := share.NewShareNodeHandler(baseHandler, echo, nodeUsecase, logger)
+	shareNodeHandler := share.NewShareNodeHandler(baseHandler, echo, nodeUsecase, kbExportUsecase, nodeSEOUsecase, nodeTranslationUsecase, kbRedirectUsecase, logger)
 	shareAppHandler := share.NewShareAppHandler(echo, baseHandler, logger, appUsecase)
 	shareChatHandler := share.NewShareChatHandler(echo, baseHandler, logger, appUsecase, chatUsecase, authUsecase, conversationUsecase, modelUsecase)
-	sitemapUsecase := usecase.NewSitemapUsecase(nodeRepository, knowledgeBaseRepository, logger)
+	sitemapUsecase := usecase.NewSitemapUsecase(nodeRepository, knowledgeBaseRepository, nodeTranslationRepository, kbRedirectRepository, logger)
 	shareSitemapHandler := share.NewShareSitemapHandler(echo, baseHandler, sitemapUsecase, appUsecase, logger)
+	feedUsecase := usecase.NewFeedUsecase(nodeRepository, knowledgeBaseRepository, appRepository, logger)
+	shareFeedHandler := share.NewShareFeedHandler(echo, baseHandler, feedUsecase, logger)
+	llMsUsecase := usecase.NewLLMsUsecase(nodeUsecase, nodeRepository, knowledgeBaseRepository, appRepository, logger)
+	shareLLMsHandler := share.NewShareLLMsHandler(echo, baseHandler, llMsUsecase, nodeUsecase, logger)
 	shareStatHandler := share.NewShareStatHandler(baseHandler, echo, statUseCase, logger)
 	shareCommentHandler := share.NewShareCommentHandler(echo, baseHandler, logger, commentUsecase, appUsecase)
+	shareDocumentFeedbackHandler := share.NewShareDocumentFeedbackHandler(echo, baseHandler, logger, documentFeedbackUsecase, appUsecase)
 	shareAuthHandler := share.NewShareAuthHandler(echo, baseHandler, logger, knowledgeBaseUsecase, authUsecase)
 	shareConversationHandler := share.NewShareConversationHandler(baseHandler, echo, conversationUsecase, logger)
 	wechatRepository := pg2.NewWechatRepository(db, logger)
@@ -172,18 +205,21 @@ func createApp() (*App, error) {
 	openapiV1Handler := share.NewOpenapiV1Handler(echo, baseHandler, logger, authUsecase, appUsecase)
 	shareCommonHandler := share.NewShareCommonHandler(echo, baseHandler, logger, fileUsecase)
 	shareHandler := &share.ShareHandler{
-		ShareNodeHandler:         shareNodeHandler,
-		ShareAppHandler:          shareAppHandler,
-		ShareChatHandler:         shareChatHandler,
-		ShareSitemapHandler:      shareSitemapHandler,
-		ShareStatHandler:         shareStatHandler,
-		ShareCommentHandler:      shareCommentHandler,
-		ShareAuthHandler:         shareAuthHandler,
-		ShareConversationHandler: shareConversationHandler,
-		ShareWechatHandler:       shareWechatHandler,
-		ShareCaptchaHandler:      shareCaptchaHandler,
-		OpenapiV1Handler:         openapiV1Handler,
-		ShareCommonHandler:       shareCommonHandler,
+		ShareNodeHandler:             shareNodeHandler,
+		ShareAppHandler:              shareAppHandler,
+		ShareChatHandler:             shareChatHandler,
+		ShareSitemapHandler:          shareSitemapHandler,
+		ShareFeedHandler:             shareFeedHandler,
+		ShareLLMsHandler:             shareLLMsHandler,
+		ShareStatHandler:             shareStatHandler,
+		ShareCommentHandler:          shareCommentHandler,
+		ShareDocumentFeedbackHandler: shareDocumentFeedbackHandler,
+		ShareAuthHandler:             shareAuthHandler,
+		ShareConversationHandler:     shareConversationHandler,
+		ShareWechatHandler:           shareWechatHandler,
+		ShareCaptchaHandler:          shareCaptchaHandler,
+		OpenapiV1Handler:             openapiV1Handler,
+		ShareCommonHandler:           shareCommonHandler,
 	}
 	mcpRepository := pg2.NewMCPRepository(db, logger)
 	client, err := telemetry.NewClient(logger, knowledgeBaseRepository, modelUsecase, userUsecase, nodeRepository, conversationRepository, mcpRepository, configConfig)
@@ -48,7 +48,8 @@ func createApp() (*App, error) {
 	conversationRepository := pg2.NewConversationRepository(db, logger)
 	modelRepository := pg2.NewModelRepository(db, logger)
 	promptRepo := pg2.NewPromptRepo(db, logger)
-	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, logger)
+	nodeTranslationRepository := pg2.NewNodeTranslationRepository(db, logger)
+	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, nodeTranslationRepository, logger)
 	mqProducer, err := mq.NewMQProducer(configConfig, logger)
 	if err != nil {
 		return nil, err
@@ -64,6 +65,13 @@ func createApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
+	webhookRepository := pg2.NewWebhookRepository(db, logger)
+	mqWebhookRepository := mq2.NewWebhookRepository(mqProducer)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository, mqWebhookRepository, logger)
+	webhookDeliveryHandler, err := mq3.NewWebhookDeliveryHandler(mqConsumer, logger, webhookUsecase)
+	if err != nil {
+		return nil, err
+	}
 	cacheCache, err := cache.NewCache(configConfig)
 	if err != nil {
 		return nil, err
@@ -77,20 +85,48 @@ func createApp() (*App, error) {
 	ipAddressRepo := ipdb2.NewIPAddressRepo(ipdbIPDB, logger)
 	geoRepo := cache2.NewGeoCache(cacheCache, db, logger)
 	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
-	statUseCase := usecase.NewStatUseCase(statRepository, nodeRepository, conversationRepository, appRepository, ipAddressRepo, geoRepo, authRepo, knowledgeBaseRepository, logger)
+	searchQueryRepository := pg2.NewSearchQueryRepository(db, logger)
+	documentFeedbackRepository := pg2.NewDocumentFeedbackRepository(db, logger)
+	nodeTemplateRepository := pg2.NewNodeTemplateRepository(db, logger)
+	nodeLinkRepository := pg2.NewNodeLinkRepository(db, logger)
+	nodeFieldRepository := pg2.NewNodeFieldRepository(db, logger)
+	kbRedirectRepository := pg2.NewKBRedirectRepository(db, logger)
 	userRepository := pg2.NewUserRepository(db, logger)
 	minioClient, err := s3.NewMinioClient(configConfig)
 	if err != nil {
 		return nil, err
 	}
-	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase)
-	cronHandler, err := mq3.NewStatCronHandler(logger, statRepository, statUseCase, nodeUsecase)
+	nodeLintRepository := pg2.NewNodeLintRepository(db, logger)
+	blockWordRepo := pg2.NewBlockWordRepo(db, logger)
+	nodeLintUsecase := usecase.NewNodeLintUsecase(nodeLintRepository, nodeRepository, knowledgeBaseRepository, blockWordRepo, configConfig, logger)
+	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, nodeTemplateRepository, nodeLinkRepository, nodeFieldRepository, kbRedirectRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase, webhookUsecase, nodeLintUsecase, configConfig)
+	statUseCase := usecase.NewStatUseCase(statRepository, nodeRepository, conversationRepository, appRepository, ipAddressRepo, geoRepo, authRepo, knowledgeBaseRepository, searchQueryRepository, documentFeedbackRepository, nodeUsecase, logger)
+	crawlerSyncRepository := pg2.NewCrawlerSyncRepository(db, logger)
+	kbRepo := cache2.NewKBRepo(cacheCache)
+	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, nodeLinkRepository, ragRepository, userRepository, ragService, kbRepo, webhookUsecase, nodeLintUsecase, logger, configConfig)
+	if err != nil {
+		return nil, err
+	}
+	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig, systemSettingRepo)
+	crawlerUsecase, err := usecase.NewCrawlerUsecase(logger, mqConsumer, cacheCache, fileUsecase)
+	if err != nil {
+		return nil, err
+	}
+	crawlerSyncUsecase := usecase.NewCrawlerSyncUsecase(crawlerSyncRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, crawlerUsecase, logger)
+	nodeTranslationUsecase := usecase.NewNodeTranslationUsecase(nodeTranslationRepository, nodeRepository, nodeUsecase, llmUsecase, modelUsecase, logger)
+	kbExportRepository := pg2.NewKBExportRepository(db, logger)
+	kbImportRepository := pg2.NewKBImportRepository(db, logger)
+	kbExportUsecase := usecase.NewKBExportUsecase(kbExportRepository, kbImportRepository, nodeRepository, nodeFieldRepository, knowledgeBaseRepository, authRepo, appRepository, nodeUsecase, fileUsecase, minioClient, configConfig, logger)
+	gitSourceRepository := pg2.NewGitSourceRepository(db, logger)
+	gitSyncUsecase := usecase.NewGitSyncUsecase(gitSourceRepository, nodeRepository, nodeUsecase, fileUsecase, configConfig, logger)
+	cronHandler, err := mq3.NewStatCronHandler(logger, statRepository, statUseCase, nodeUsecase, crawlerSyncUsecase, webhookUsecase, nodeTranslationUsecase, kbExportUsecase, gitSyncUsecase)
 	if err != nil {
 		return nil, err
 	}
 	mqHandlers := &mq3.MQHandlers{
 		RAGMQHandler:        ragmqHandler,
 		RagDocUpdateHandler: ragDocUpdateHandler,
+		WebhookHandler:      webhookDeliveryHandler,
 		StatCronHandler:     cronHandler,
 	}
 	app := &App{
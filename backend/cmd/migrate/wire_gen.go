@@ -35,6 +35,10 @@ func createApp() (*App, error) {
 	}
 	logger := log.NewLogger(configConfig)
 	nodeRepository := pg2.NewNodeRepository(db, logger)
+	nodeTemplateRepository := pg2.NewNodeTemplateRepository(db, logger)
+	nodeLinkRepository := pg2.NewNodeLinkRepository(db, logger)
+	nodeFieldRepository := pg2.NewNodeFieldRepository(db, logger)
+	kbRedirectRepository := pg2.NewKBRedirectRepository(db, logger)
 	appRepository := pg2.NewAppRepository(db, logger)
 	mqProducer, err := mq.NewMQProducer(configConfig, logger)
 	if err != nil {
@@ -50,7 +54,8 @@ func createApp() (*App, error) {
 	conversationRepository := pg2.NewConversationRepository(db, logger)
 	modelRepository := pg2.NewModelRepository(db, logger)
 	promptRepo := pg2.NewPromptRepo(db, logger)
-	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, logger)
+	nodeTranslationRepository := pg2.NewNodeTranslationRepository(db, logger)
+	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, nodeTranslationRepository, logger)
 	minioClient, err := s3.NewMinioClient(configConfig)
 	if err != nil {
 		return nil, err
@@ -62,9 +67,15 @@ func createApp() (*App, error) {
 	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
 	systemSettingRepo := pg2.NewSystemSettingRepo(db, logger)
 	modelUsecase := usecase.NewModelUsecase(modelRepository, nodeRepository, ragRepository, ragService, logger, configConfig, knowledgeBaseRepository, systemSettingRepo)
-	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase)
+	webhookRepository := pg2.NewWebhookRepository(db, logger)
+	mqWebhookRepository := mq2.NewWebhookRepository(mqProducer)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository, mqWebhookRepository, logger)
+	nodeLintRepository := pg2.NewNodeLintRepository(db, logger)
+	blockWordRepo := pg2.NewBlockWordRepo(db, logger)
+	nodeLintUsecase := usecase.NewNodeLintUsecase(nodeLintRepository, nodeRepository, knowledgeBaseRepository, blockWordRepo, configConfig, logger)
+	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, nodeTemplateRepository, nodeLinkRepository, nodeFieldRepository, kbRedirectRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase, webhookUsecase, nodeLintUsecase, configConfig)
 	kbRepo := cache2.NewKBRepo(cacheCache)
-	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, ragRepository, userRepository, ragService, kbRepo, logger, configConfig)
+	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, nodeLinkRepository, ragRepository, userRepository, ragService, kbRepo, webhookUsecase, nodeLintUsecase, logger, configConfig)
 	if err != nil {
 		return nil, err
 	}
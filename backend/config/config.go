@@ -9,18 +9,21 @@ import (
 )
 
 type Config struct {
-	Log           LogConfig    `mapstructure:"log"`
-	HTTP          HTTPConfig   `mapstructure:"http"`
-	AdminPassword string       `mapstructure:"admin_password"`
-	PG            PGConfig     `mapstructure:"pg"`
-	MQ            MQConfig     `mapstructure:"mq"`
-	RAG           RAGConfig    `mapstructure:"rag"`
-	Redis         RedisConfig  `mapstructure:"redis"`
-	Auth          AuthConfig   `mapstructure:"auth"`
-	S3            S3Config     `mapstructure:"s3"`
-	Sentry        SentryConfig `mapstructure:"sentry"`
-	CaddyAPI      string       `mapstructure:"caddy_api"`
-	SubnetPrefix  string       `mapstructure:"subnet_prefix"`
+	Log           LogConfig     `mapstructure:"log"`
+	HTTP          HTTPConfig    `mapstructure:"http"`
+	AdminPassword string        `mapstructure:"admin_password"`
+	PG            PGConfig      `mapstructure:"pg"`
+	MQ            MQConfig      `mapstructure:"mq"`
+	RAG           RAGConfig     `mapstructure:"rag"`
+	Redis         RedisConfig   `mapstructure:"redis"`
+	Auth          AuthConfig    `mapstructure:"auth"`
+	S3            S3Config      `mapstructure:"s3"`
+	Sentry        SentryConfig  `mapstructure:"sentry"`
+	Trash         TrashConfig   `mapstructure:"trash"`
+	Export        ExportConfig  `mapstructure:"export"`
+	GitSync       GitSyncConfig `mapstructure:"git_sync"`
+	CaddyAPI      string        `mapstructure:"caddy_api"`
+	SubnetPrefix  string        `mapstructure:"subnet_prefix"`
 }
 
 type LogConfig struct {
@@ -81,6 +84,22 @@ type SentryConfig struct {
 	DSN     string `mapstructure:"dsn"`
 }
 
+type TrashConfig struct {
+	RetentionDays int `mapstructure:"retention_days"` // 回收站保留天数，到期后彻底删除
+}
+
+type ExportConfig struct {
+	// PDFRenderURL is a gotenberg compatible html to pdf endpoint, e.g. http://gotenberg:3000/forms/chromium/convert/html
+	// pdf export is disabled when empty
+	PDFRenderURL string `mapstructure:"pdf_render_url"`
+}
+
+type GitSyncConfig struct {
+	WorkDir string `mapstructure:"work_dir"` // 仓库镜像缓存目录，丢失后重新克隆
+	// LocalRepoDir allows syncing local bare repos below it, local repos are rejected when empty
+	LocalRepoDir string `mapstructure:"local_repo_dir"`
+}
+
 func NewConfig() (*Config, error) {
 	// set default config
 	SUBNET_PREFIX := os.Getenv("SUBNET_PREFIX")
@@ -130,6 +149,12 @@ func NewConfig() (*Config, error) {
 			Enabled: true,
 			DSN:     "https://2a4cff1ae04b624ffc72663f523024ff@sentry.baizhi.cloud/4",
 		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		GitSync: GitSyncConfig{
+			WorkDir: "/tmp/panda-wiki/git-sync",
+		},
 		CaddyAPI:     "/app/run/caddy-admin.sock",
 		SubnetPrefix: "169.254.15",
 	}
@@ -208,6 +233,25 @@ func overrideWithEnv(c *Config) {
 	if env := os.Getenv("SENTRY_DSN"); env != "" {
 		c.Sentry.DSN = env
 	}
+	// trash
+	if env := os.Getenv("TRASH_RETENTION_DAYS"); env != "" {
+		if i, err := strconv.Atoi(env); err == nil && i > 0 {
+			c.Trash.RetentionDays = i
+		} else {
+			fmt.Fprintf(os.Stderr, "Invalid trash retention days: %s\n", env)
+		}
+	}
+	// export
+	if env := os.Getenv("EXPORT_PDF_RENDER_URL"); env != "" {
+		c.Export.PDFRenderURL = env
+	}
+	// git sync
+	if env := os.Getenv("GIT_SYNC_WORK_DIR"); env != "" {
+		c.GitSync.WorkDir = env
+	}
+	if env := os.Getenv("GIT_SYNC_LOCAL_REPO_DIR"); env != "" {
+		c.GitSync.LocalRepoDir = env
+	}
 	// log level
 	if env := os.Getenv("LOG_LEVEL"); env != "" {
 		if i, err := strconv.Atoi(env); err == nil {
@@ -18,6 +18,9 @@ const (
 	CrawlerSourceMindoc     CrawlerSource = "mindoc"
 	CrawlerSourceWikijs     CrawlerSource = "wikijs"
 	CrawlerSourceConfluence CrawlerSource = "confluence"
+
+	// CrawlerSourceGit 仓库形式 由服务端克隆并同步，见 /api/v1/crawler/git
+	CrawlerSourceGit CrawlerSource = "git"
 )
 
 type CrawlerSourceType string
@@ -26,6 +29,7 @@ const (
 	CrawlerSourceTypeFile CrawlerSourceType = "file"
 	CrawlerSourceTypeUrl  CrawlerSourceType = "url"
 	CrawlerSourceTypeKey  CrawlerSourceType = "key"
+	CrawlerSourceTypeRepo CrawlerSourceType = "repo"
 )
 
 func (c CrawlerSource) Type() CrawlerSourceType {
@@ -36,6 +40,8 @@ func (c CrawlerSource) Type() CrawlerSourceType {
 		return CrawlerSourceTypeUrl
 	case CrawlerSourceFile, CrawlerSourceEpub, CrawlerSourceYuque, CrawlerSourceSiyuan, CrawlerSourceMindoc, CrawlerSourceWikijs, CrawlerSourceConfluence:
 		return CrawlerSourceTypeFile
+	case CrawlerSourceGit:
+		return CrawlerSourceTypeRepo
 	default:
 		return ""
 	}
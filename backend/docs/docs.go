@@ -711,9 +711,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/crawler/parse": {
-            "post": {
-                "description": "解析文档树",
+        "/api/v1/crawler/git": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update a git source, empty password and ssh key keep the stored ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -723,15 +728,15 @@ const docTemplate = `{
                 "tags": [
                     "crawler"
                 ],
-                "summary": "解析文档树",
+                "summary": "GitSourceUpdate",
                 "parameters": [
                     {
-                        "description": "Scrape",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerParseReq"
+                            "$ref": "#/definitions/v1.GitSourceUpdateReq"
                         }
                     }
                 ],
@@ -739,27 +744,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerParseResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/crawler/result": {
-            "get": {
-                "description": "Retrieve the result of a previously started scraping task",
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Add a git repository as sync source of the knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -769,15 +765,15 @@ const docTemplate = `{
                 "tags": [
                     "crawler"
                 ],
-                "summary": "Get Crawler Result",
+                "summary": "GitSourceCreate",
                 "parameters": [
                     {
-                        "description": "Crawler Result Request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerResultReq"
+                            "$ref": "#/definitions/v1.GitSourceCreateReq"
                         }
                     }
                 ],
@@ -793,7 +789,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.CrawlerResultResp"
+                                            "$ref": "#/definitions/v1.GitSourceCreateResp"
                                         }
                                     }
                                 }
@@ -801,11 +797,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/crawler/results": {
-            "post": {
-                "description": "Retrieve the results of a previously started scraping task",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Delete a git source, synced documents are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -815,43 +814,39 @@ const docTemplate = `{
                 "tags": [
                     "crawler"
                 ],
-                "summary": "Get Crawler Results",
+                "summary": "GitSourceDelete",
                 "parameters": [
                     {
-                        "description": "Crawler Results Request",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.CrawlerResultsReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "source_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerResultsResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/creation/tab-complete": {
-            "post": {
-                "description": "Tab-based document completion similar to AI coding's FIM (Fill in Middle)",
+        "/api/v1/crawler/git/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "List git sources of the knowledge base with their sync status",
                 "consumes": [
                     "application/json"
                 ],
@@ -859,33 +854,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creation"
+                    "crawler"
                 ],
-                "summary": "Tab-based document completion",
+                "summary": "GitSourceList",
                 "parameters": [
                     {
-                        "description": "tab completion request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CompleteReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.GitSourceListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/creation/text": {
+        "/api/v1/crawler/git/sync": {
             "post": {
-                "description": "Text creation",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Start a sync of the git source, markdown files become documents and directories folders",
                 "consumes": [
                     "application/json"
                 ],
@@ -893,88 +905,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creation"
+                    "crawler"
                 ],
-                "summary": "Text creation",
+                "summary": "GitSourceSync",
                 "parameters": [
                     {
-                        "description": "text creation request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.TextReq"
+                            "$ref": "#/definitions/v1.GitSourceSyncReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/file/upload": {
-            "post": {
-                "description": "Upload File",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "tags": [
-                    "file"
-                ],
-                "summary": "Upload File",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
+        "/api/v1/crawler/openapi": {
+            "delete": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "kb_id",
-                        "in": "formData"
+                        "bearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ObjectUploadResp"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/file/upload/anydoc": {
-            "post": {
-                "description": "Upload Anydoc File",
+                "description": "Delete an imported OpenAPI spec, generated documents are kept",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "file"
+                    "crawler"
                 ],
-                "summary": "Upload Anydoc File",
+                "summary": "OpenAPISpecDelete",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "File Path",
-                        "name": "path",
-                        "in": "formData",
+                        "name": "spec_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -982,15 +965,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AnydocUploadResp"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base": {
+        "/api/v1/crawler/openapi/import": {
             "post": {
-                "description": "CreateKnowledgeBase",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Import an OpenAPI 3 or Swagger 2 spec, tags become folders and operations documents",
                 "consumes": [
                     "application/json"
                 ],
@@ -998,17 +986,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "CreateKnowledgeBase",
+                "summary": "OpenAPIImport",
                 "parameters": [
                     {
-                        "description": "CreateKnowledgeBase Request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateKnowledgeBaseReq"
+                            "$ref": "#/definitions/v1.OpenAPIImportReq"
                         }
                     }
                 ],
@@ -1016,20 +1004,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.OpenAPIImportResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/detail": {
+        "/api/v1/crawler/openapi/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "GetKnowledgeBaseDetail",
+                "description": "List the imported OpenAPI specs of the knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -1037,14 +1037,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "GetKnowledgeBaseDetail",
+                "summary": "OpenAPISpecList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "id",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
@@ -1061,7 +1060,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.KnowledgeBaseDetail"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.KBOpenAPISpec"
+                                            }
                                         }
                                     }
                                 }
@@ -1069,9 +1071,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UpdateKnowledgeBase",
+            }
+        },
+        "/api/v1/crawler/parse": {
+            "post": {
+                "description": "解析文档树",
                 "consumes": [
                     "application/json"
                 ],
@@ -1079,17 +1083,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "UpdateKnowledgeBase",
+                "summary": "解析文档树",
                 "parameters": [
                     {
-                        "description": "UpdateKnowledgeBase Request",
+                        "description": "Scrape",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateKnowledgeBaseReq"
+                            "$ref": "#/definitions/v1.CrawlerParseReq"
                         }
                     }
                 ],
@@ -1097,13 +1101,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CrawlerParseResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "DeleteKnowledgeBase",
+            }
+        },
+        "/api/v1/crawler/result": {
+            "get": {
+                "description": "Retrieve the result of a previously started scraping task",
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,31 +1129,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "DeleteKnowledgeBase",
+                "summary": "Get Crawler Result",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Crawler Result Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerResultReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CrawlerResultResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/list": {
-            "get": {
-                "description": "GetKnowledgeBaseList",
+        "/api/v1/crawler/results": {
+            "post": {
+                "description": "Retrieve the results of a previously started scraping task",
                 "consumes": [
                     "application/json"
                 ],
@@ -1143,9 +1175,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
+                ],
+                "summary": "Get Crawler Results",
+                "parameters": [
+                    {
+                        "description": "Crawler Results Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerResultsReq"
+                        }
+                    }
                 ],
-                "summary": "GetKnowledgeBaseList",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1158,10 +1201,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.KnowledgeBaseListItem"
-                                            }
+                                            "$ref": "#/definitions/v1.CrawlerResultsResp"
                                         }
                                     }
                                 }
@@ -1171,9 +1211,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/release": {
-            "post": {
-                "description": "CreateKBRelease",
+        "/api/v1/crawler/sync": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update a crawler sync, empty feishu app secret and access token keep the stored ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,17 +1226,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "CreateKBRelease",
+                "summary": "CrawlerSyncUpdate",
                 "parameters": [
                     {
-                        "description": "CreateKBRelease Request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateKBReleaseReq"
+                            "$ref": "#/definitions/v1.CrawlerSyncUpdateReq"
                         }
                     }
                 ],
@@ -1203,11 +1248,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/knowledge_base/release/list": {
-            "get": {
-                "description": "GetKBReleaseList",
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Save a crawler source of the knowledge base to be synced again manually or on a schedule",
                 "consumes": [
                     "application/json"
                 ],
@@ -1215,16 +1263,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "GetKBReleaseList",
+                "summary": "CrawlerSyncCreate",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerSyncCreateReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -1239,7 +1289,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.GetKBReleaseListResp"
+                                            "$ref": "#/definitions/v1.CrawlerSyncCreateResp"
                                         }
                                     }
                                 }
@@ -1247,16 +1297,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/knowledge_base/user/delete": {
+            },
             "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Remove user from knowledge base",
+                "description": "Delete a crawler sync and its history, synced documents are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1264,9 +1312,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "KBUserDelete",
+                "summary": "CrawlerSyncDelete",
                 "parameters": [
                     {
                         "type": "string",
@@ -1276,7 +1324,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "name": "user_id",
+                        "name": "sync_id",
                         "in": "query",
                         "required": true
                     }
@@ -1291,14 +1339,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/user/invite": {
+        "/api/v1/crawler/sync/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "List crawler syncs of the knowledge base with their schedule and last run",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "crawler"
+                ],
+                "summary": "CrawlerSyncList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.CrawlerSyncListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/crawler/sync/run": {
             "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Invite user to knowledge base",
+                "description": "Queue a run of the crawler sync, it starts within a minute",
                 "consumes": [
                     "application/json"
                 ],
@@ -1306,17 +1405,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "KBUserInvite",
+                "summary": "CrawlerSyncRun",
                 "parameters": [
                     {
-                        "description": "Invite User Request",
-                        "name": "param",
+                        "description": "para",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.KBUserInviteReq"
+                            "$ref": "#/definitions/v1.CrawlerSyncRunReq"
                         }
                     }
                 ],
@@ -1330,14 +1429,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/user/list": {
+        "/api/v1/crawler/sync/runs": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "KBUserList",
+                "description": "Sync history of a crawler sync with the per document errors, latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1345,16 +1444,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "crawler"
                 ],
-                "summary": "KBUserList",
+                "summary": "CrawlerSyncRunList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "sync_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1369,10 +1487,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/v1.KBUserListItemResp"
-                                            }
+                                            "$ref": "#/definitions/v1.CrawlerSyncRunListResp"
                                         }
                                     }
                                 }
@@ -1382,14 +1497,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/user/update": {
-            "patch": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Update user permission in knowledge base",
+        "/api/v1/creation/tab-complete": {
+            "post": {
+                "description": "Tab-based document completion similar to AI coding's FIM (Fill in Middle)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,33 +1507,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "creation"
                 ],
-                "summary": "KBUserUpdate",
+                "summary": "Tab-based document completion",
                 "parameters": [
                     {
-                        "description": "Update User Permission Request",
-                        "name": "param",
+                        "description": "tab completion request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.KBUserUpdateReq"
+                            "$ref": "#/definitions/domain.CompleteReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model": {
-            "put": {
-                "description": "update model",
+        "/api/v1/creation/text": {
+            "post": {
+                "description": "Text creation",
                 "consumes": [
                     "application/json"
                 ],
@@ -1431,110 +1541,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "creation"
                 ],
+                "summary": "Text creation",
                 "parameters": [
                     {
-                        "description": "update model request",
-                        "name": "model",
+                        "description": "text creation request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateModelReq"
+                            "$ref": "#/definitions/domain.TextReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/file/upload": {
             "post": {
-                "description": "create model",
+                "description": "Upload File",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "model"
+                    "file"
                 ],
-                "summary": "create model",
+                "summary": "Upload File",
                 "parameters": [
                     {
-                        "description": "create model request",
-                        "name": "model",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateModelReq"
-                        }
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.ObjectUploadResp"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model/check": {
+        "/api/v1/file/upload/anydoc": {
             "post": {
-                "description": "check model",
+                "description": "Upload Anydoc File",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "model"
+                    "file"
                 ],
-                "summary": "check model",
+                "summary": "Upload Anydoc File",
                 "parameters": [
                     {
-                        "description": "check model request",
-                        "name": "model",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelReq"
-                        }
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File Path",
+                        "name": "path",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.AnydocUploadResp"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model/list": {
-            "get": {
-                "description": "get model list",
+        "/api/v1/knowledge_base": {
+            "post": {
+                "description": "CreateKnowledgeBase",
                 "consumes": [
                     "application/json"
                 ],
@@ -1542,34 +1646,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "knowledge_base"
+                ],
+                "summary": "CreateKnowledgeBase",
+                "parameters": [
+                    {
+                        "description": "CreateKnowledgeBase Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateKnowledgeBaseReq"
+                        }
+                    }
                 ],
-                "summary": "get model list",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelListItem"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model/mode-setting": {
+        "/api/v1/knowledge_base/detail": {
             "get": {
-                "description": "get current model mode setting including mode, API key and chat model",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "GetKnowledgeBaseDetail",
                 "consumes": [
                     "application/json"
                 ],
@@ -1577,22 +1685,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "knowledge_base"
+                ],
+                "summary": "GetKnowledgeBaseDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "get model mode setting",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ModelModeSetting"
+                                            "$ref": "#/definitions/domain.KnowledgeBaseDetail"
                                         }
                                     }
                                 }
@@ -1600,11 +1717,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/model/provider/supported": {
-            "post": {
-                "description": "get provider supported model list",
+            },
+            "put": {
+                "description": "UpdateKnowledgeBase",
                 "consumes": [
                     "application/json"
                 ],
@@ -1612,17 +1727,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "knowledge_base"
                 ],
-                "summary": "get provider supported model list",
+                "summary": "UpdateKnowledgeBase",
                 "parameters": [
                     {
-                        "description": "get supported model list request",
-                        "name": "params",
+                        "description": "UpdateKnowledgeBase Request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.GetProviderModelListReq"
+                            "$ref": "#/definitions/domain.UpdateKnowledgeBaseReq"
                         }
                     }
                 ],
@@ -1630,27 +1745,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.GetProviderModelListResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/model/switch-mode": {
-            "post": {
-                "description": "switch model mode between manual and auto",
+            },
+            "delete": {
+                "description": "DeleteKnowledgeBase",
                 "consumes": [
                     "application/json"
                 ],
@@ -1658,50 +1759,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "knowledge_base"
                 ],
-                "summary": "switch mode",
+                "summary": "DeleteKnowledgeBase",
                 "parameters": [
                     {
-                        "description": "switch mode request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.SwitchModeReq"
-                        }
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.SwitchModeResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/node": {
+        "/api/v1/knowledge_base/export": {
             "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Create Node",
+                "description": "Create an export job of the whole knowledge base, markdown archive with manifest can be re-imported",
                 "consumes": [
                     "application/json"
                 ],
@@ -1709,17 +1796,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Create Node",
+                "summary": "KBExportCreate",
                 "parameters": [
                     {
-                        "description": "Node",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateNodeReq"
+                            "$ref": "#/definitions/v1.KBExportCreateReq"
                         }
                     }
                 ],
@@ -1735,10 +1822,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/v1.KBExportCreateResp"
                                         }
                                     }
                                 }
@@ -1746,16 +1830,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/node/action": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Node Action",
+                "description": "Delete an export job and its archive",
                 "consumes": [
                     "application/json"
                 ],
@@ -1763,72 +1845,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Node Action",
+                "summary": "KBExportDelete",
                 "parameters": [
                     {
-                        "description": "Action",
-                        "name": "action",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.NodeActionReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/node/batch_move": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Batch Move Node",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "node"
-                ],
-                "summary": "Batch Move Node",
-                "parameters": [
-                    {
-                        "description": "Batch Move Node",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.BatchMoveReq"
-                        }
+                        "type": "string",
+                        "name": "export_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1841,14 +1872,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/detail": {
+        "/api/v1/knowledge_base/export/detail": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Get Node Detail",
+                "description": "Get status of an export job",
                 "consumes": [
                     "application/json"
                 ],
@@ -1856,18 +1887,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Get Node Detail",
+                "summary": "KBExportDetail",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "format",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "id",
+                        "name": "export_id",
                         "in": "query",
                         "required": true
                     },
@@ -1890,7 +1916,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodeDetailResp"
+                                            "$ref": "#/definitions/domain.KBExport"
                                         }
                                     }
                                 }
@@ -1898,53 +1924,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/knowledge_base/export/download": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Update Node Detail",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download the file of a completed export job",
                 "produces": [
-                    "application/json"
+                    "application/zip",
+                    "application/epub+zip",
+                    "application/pdf"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Update Node Detail",
+                "summary": "KBExportDownload",
                 "parameters": [
                     {
-                        "description": "Node",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateNodeReq"
-                        }
+                        "type": "string",
+                        "name": "export_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/api/v1/node/list": {
+        "/api/v1/knowledge_base/export/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Get Node List",
+                "description": "KBExportList",
                 "consumes": [
                     "application/json"
                 ],
@@ -1952,20 +1982,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Get Node List",
+                "summary": "KBExportList",
                 "parameters": [
                     {
                         "type": "string",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1982,7 +2007,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/domain.NodeListItemResp"
+                                                "$ref": "#/definitions/domain.KBExport"
                                             }
                                         }
                                     }
@@ -1993,14 +2018,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/move": {
+        "/api/v1/knowledge_base/import": {
             "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Move Node",
+                "description": "Create an import job of an export archive uploaded with /api/v1/file/upload, existing nodes are skipped, overwritten or merged by mode",
                 "consumes": [
                     "application/json"
                 ],
@@ -2008,17 +2033,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Move Node",
+                "summary": "KBImport",
                 "parameters": [
                     {
-                        "description": "Move Node",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.MoveNodeReq"
+                            "$ref": "#/definitions/v1.KBImportReq"
                         }
                     }
                 ],
@@ -2026,20 +2051,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KBImportResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/node/permission": {
+        "/api/v1/knowledge_base/import/detail": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "文档授权信息获取",
+                "description": "Get status and result of an import job",
                 "consumes": [
                     "application/json"
                 ],
@@ -2047,14 +2084,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NodePermission"
+                    "knowledge_base"
                 ],
-                "summary": "文档授权信息获取",
-                "operationId": "v1-NodePermission",
+                "summary": "KBImportDetail",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "id",
+                        "name": "import_id",
                         "in": "query",
                         "required": true
                     },
@@ -2071,13 +2107,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodePermissionResp"
+                                            "$ref": "#/definitions/domain.KBImport"
                                         }
                                     }
                                 }
@@ -2087,14 +2123,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/permission/edit": {
-            "patch": {
+        "/api/v1/knowledge_base/import/list": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "文档授权信息更新",
+                "description": "KBImportList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2102,19 +2138,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NodePermission"
+                    "knowledge_base"
                 ],
-                "summary": "文档授权信息更新",
-                "operationId": "v1-NodePermissionEdit",
+                "summary": "KBImportList",
                 "parameters": [
                     {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.NodePermissionEditReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2123,13 +2155,16 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodePermissionEditResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.KBImport"
+                                            }
                                         }
                                     }
                                 }
@@ -2139,14 +2174,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/recommend_nodes": {
+        "/api/v1/knowledge_base/list": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Recommend Nodes",
+                "description": "GetKnowledgeBaseList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2154,27 +2184,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
-                ],
-                "summary": "Recommend Nodes",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "name": "node_ids",
-                        "in": "query",
-                        "required": true
-                    }
+                    "knowledge_base"
                 ],
+                "summary": "GetKnowledgeBaseList",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2189,7 +2201,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/domain.RecommendNodeListResp"
+                                                "$ref": "#/definitions/domain.KnowledgeBaseListItem"
                                             }
                                         }
                                     }
@@ -2200,14 +2212,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/restudy": {
-            "post": {
+        "/api/v1/knowledge_base/redirect": {
+            "put": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "文档重新学习",
+                "description": "KBRedirectUpdate",
                 "consumes": [
                     "application/json"
                 ],
@@ -2215,18 +2227,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Node"
+                    "knowledge_base"
                 ],
-                "summary": "文档重新学习",
-                "operationId": "v1-NodeRestudy",
+                "summary": "KBRedirectUpdate",
                 "parameters": [
                     {
                         "description": "para",
-                        "name": "param",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.NodeRestudyReq"
+                            "$ref": "#/definitions/v1.KBRedirectUpdateReq"
                         }
                     }
                 ],
@@ -2234,32 +2245,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.NodeRestudyResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/node/summary": {
+            },
             "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Summary Node",
+                "description": "Redirect an old path of the site to a node or an url, 301 by default",
                 "consumes": [
                     "application/json"
                 ],
@@ -2267,17 +2264,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "knowledge_base"
                 ],
-                "summary": "Summary Node",
+                "summary": "KBRedirectCreate",
                 "parameters": [
                     {
-                        "description": "Summary Node",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.NodeSummaryReq"
+                            "$ref": "#/definitions/v1.KBRedirectCreateReq"
                         }
                     }
                 ],
@@ -2285,20 +2282,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.KBRedirectCreateResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/stat/browsers": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "客户端统计",
+                "description": "KBRedirectDelete",
                 "consumes": [
                     "application/json"
                 ],
@@ -2306,64 +2313,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "客户端统计",
+                "summary": "KBRedirectDelete",
                 "parameters": [
-                    {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "name": "redirect_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.HotBrowser"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/stat/conversation_distribution": {
+        "/api/v1/knowledge_base/redirect/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "问答来源",
+                "description": "Redirects of the knowledge base with their hit counts, including the ones created for renamed and deleted nodes",
                 "consumes": [
                     "application/json"
                 ],
@@ -2371,27 +2355,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "问答来源",
+                "summary": "KBRedirectList",
                 "parameters": [
-                    {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "name": "kb_id",
@@ -2405,7 +2372,7 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
@@ -2413,7 +2380,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/v1.StatConversationDistributionResp"
+                                                "$ref": "#/definitions/v1.KBRedirectItem"
                                             }
                                         }
                                     }
@@ -2424,14 +2391,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/count": {
-            "get": {
-                "security": [
+        "/api/v1/knowledge_base/release": {
+            "post": {
+                "description": "CreateKBRelease",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "CreateKBRelease",
+                "parameters": [
                     {
-                        "bearerAuth": []
+                        "description": "CreateKBRelease Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateKBReleaseReq"
+                        }
                     }
                 ],
-                "description": "全局统计",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/release/list": {
+            "get": {
+                "description": "GetKBReleaseList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2439,29 +2435,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "全局统计",
+                "summary": "GetKBReleaseList",
                 "parameters": [
-                    {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
@@ -2479,7 +2459,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.StatCountResp"
+                                            "$ref": "#/definitions/domain.GetKBReleaseListResp"
                                         }
                                     }
                                 }
@@ -2489,14 +2469,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/geo_count": {
-            "get": {
+        "/api/v1/knowledge_base/user/delete": {
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "用户地理分布",
+                "description": "Remove user from knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -2504,30 +2484,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "用户地理分布",
+                "summary": "KBUserDelete",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "name": "kb_id",
+                        "name": "user_id",
                         "in": "query",
                         "required": true
                     }
@@ -2542,14 +2511,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/hot_pages": {
-            "get": {
+        "/api/v1/knowledge_base/user/invite": {
+            "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "热门文档",
+                "description": "Invite user to knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -2557,67 +2526,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "热门文档",
+                "summary": "KBUserInvite",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Invite User Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KBUserInviteReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.HotPage"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/stat/instant_count": {
+        "/api/v1/knowledge_base/user/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "GetInstantCount",
+                "description": "KBUserList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2625,12 +2565,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "GetInstantCount",
+                "summary": "KBUserList",
                 "parameters": [
                     {
                         "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
@@ -2642,7 +2583,7 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
@@ -2650,7 +2591,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/domain.InstantCountResp"
+                                                "$ref": "#/definitions/v1.KBUserListItemResp"
                                             }
                                         }
                                     }
@@ -2661,14 +2602,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/instant_pages": {
-            "get": {
+        "/api/v1/knowledge_base/user/update": {
+            "patch": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "GetInstantPages",
+                "description": "Update user permission in knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -2676,50 +2617,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "GetInstantPages",
+                "summary": "KBUserUpdate",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Update User Permission Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KBUserUpdateReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.InstantPageResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/stat/referer_hosts": {
-            "get": {
+        "/api/v1/knowledge_base/webhook": {
+            "put": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "来源域名",
+                "description": "Update a webhook, empty secret keeps the stored one",
                 "consumes": [
                     "application/json"
                 ],
@@ -2727,82 +2656,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "knowledge_base"
                 ],
-                "summary": "来源域名",
+                "summary": "KBWebhookUpdate",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.HotRefererHost"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/v1.KBWebhookUpdateReq"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/user": {
-            "get": {
-                "description": "GetUser",
-                "consumes": [
-                    "application/json"
                 ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "GetUser",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.UserInfoResp"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/user/create": {
+            },
             "post": {
-                "description": "CreateUser",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Create an outbound webhook of the knowledge base, requests are signed with the returned secret in the X-PandaWiki-Signature header",
                 "consumes": [
                     "application/json"
                 ],
@@ -2810,17 +2693,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "knowledge_base"
                 ],
-                "summary": "CreateUser",
+                "summary": "KBWebhookCreate",
                 "parameters": [
                     {
-                        "description": "CreateUser Request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CreateUserReq"
+                            "$ref": "#/definitions/v1.KBWebhookCreateReq"
                         }
                     }
                 ],
@@ -2830,13 +2713,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.CreateUserResp"
+                                            "$ref": "#/definitions/v1.KBWebhookCreateResp"
                                         }
                                     }
                                 }
@@ -2844,11 +2727,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/user/delete": {
+            },
             "delete": {
-                "description": "DeleteUser",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook and its delivery log",
                 "consumes": [
                     "application/json"
                 ],
@@ -2856,13 +2742,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "knowledge_base"
                 ],
-                "summary": "DeleteUser",
+                "summary": "KBWebhookDelete",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "user_id",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "webhook_id",
                         "in": "query",
                         "required": true
                     }
@@ -2877,9 +2769,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/list": {
+        "/api/v1/knowledge_base/webhook/deliveries": {
             "get": {
-                "description": "ListUsers",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Delivery log of a webhook, latest first, deliveries are kept for 30 days",
                 "consumes": [
                     "application/json"
                 ],
@@ -2887,9 +2784,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "knowledge_base"
+                ],
+                "summary": "KBWebhookDeliveryList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "webhook_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "ListUsers",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2902,7 +2827,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.UserListResp"
+                                            "$ref": "#/definitions/v1.KBWebhookDeliveryListResp"
                                         }
                                     }
                                 }
@@ -2912,9 +2837,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/login": {
-            "post": {
-                "description": "Login",
+        "/api/v1/knowledge_base/webhook/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "KBWebhookList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2922,33 +2852,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "knowledge_base"
                 ],
-                "summary": "Login",
+                "summary": "KBWebhookList",
                 "parameters": [
                     {
-                        "description": "Login Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.LoginReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.LoginResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.KBWebhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/reset_password": {
-            "put": {
-                "description": "ResetPassword",
+        "/api/v1/knowledge_base/webhook/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Queue a delivery again with the same payload",
                 "consumes": [
                     "application/json"
                 ],
@@ -2956,17 +2903,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "knowledge_base"
                 ],
-                "summary": "ResetPassword",
+                "summary": "KBWebhookRedeliver",
                 "parameters": [
                     {
-                        "description": "ResetPassword Request",
+                        "description": "para",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.ResetPasswordReq"
+                            "$ref": "#/definitions/v1.KBWebhookRedeliverReq"
                         }
                     }
                 ],
@@ -2980,9 +2927,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/app/web/info": {
-            "get": {
-                "description": "GetAppInfo",
+        "/api/v1/knowledge_base/webhook/test": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Send a ping event to the webhook right away and return the result of the attempt",
                 "consumes": [
                     "application/json"
                 ],
@@ -2990,16 +2942,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_app"
+                    "knowledge_base"
                 ],
-                "summary": "GetAppInfo",
+                "summary": "KBWebhookTest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KBWebhookTestReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -3008,13 +2962,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.AppInfoResp"
+                                            "$ref": "#/definitions/domain.KBWebhookDelivery"
                                         }
                                     }
                                 }
@@ -3024,9 +2978,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/app/wechat/info": {
-            "get": {
-                "description": "WechatAppInfo",
+        "/api/v1/model": {
+            "put": {
+                "description": "update model",
                 "consumes": [
                     "application/json"
                 ],
@@ -3034,43 +2988,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "model"
                 ],
-                "summary": "WechatAppInfo",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "update model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateModelReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.WechatAppInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/app/wechat/service/answer": {
-            "get": {
-                "description": "GetWechatAnswer",
+            },
+            "post": {
+                "description": "create model",
                 "consumes": [
                     "application/json"
                 ],
@@ -3078,16 +3019,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Wechat"
+                    "model"
                 ],
-                "summary": "GetWechatAnswer",
+                "summary": "create model",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "conversation id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "create model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateModelReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -3100,9 +3043,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/app/widget/info": {
-            "get": {
-                "description": "GetWidgetAppInfo",
+        "/api/v1/model/check": {
+            "post": {
+                "description": "check model",
                 "consumes": [
                     "application/json"
                 ],
@@ -3110,31 +3053,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_app"
+                    "model"
                 ],
-                "summary": "GetWidgetAppInfo",
+                "summary": "check model",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "check model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/auth/get": {
+        "/api/v1/model/list": {
             "get": {
-                "description": "AuthGet",
+                "description": "get model list",
                 "consumes": [
                     "application/json"
                 ],
@@ -3142,19 +3099,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_auth"
-                ],
-                "summary": "AuthGet",
-                "operationId": "v1-AuthGet",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "kb_id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    }
+                    "model"
                 ],
+                "summary": "get model list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3167,7 +3114,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp"
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelListItem"
                                         }
                                     }
                                 }
@@ -3177,9 +3124,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/auth/github": {
-            "post": {
-                "description": "GitHub登录",
+        "/api/v1/model/mode-setting": {
+            "get": {
+                "description": "get current model mode setting including mode, API key and chat model",
                 "consumes": [
                     "application/json"
                 ],
@@ -3187,41 +3134,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ShareAuth"
-                ],
-                "summary": "GitHub登录",
-                "operationId": "v1-AuthGitHub",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.AuthGitHubReq"
-                        }
-                    }
+                    "model"
                 ],
+                "summary": "get model mode setting",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.PWResponse"
+                                    "$ref": "#/definitions/domain.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.AuthGitHubResp"
+                                            "$ref": "#/definitions/domain.ModelModeSetting"
                                         }
                                     }
                                 }
@@ -3231,9 +3159,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/auth/login/simple": {
+        "/api/v1/model/provider/supported": {
             "post": {
-                "description": "AuthLoginSimple",
+                "description": "get provider supported model list",
                 "consumes": [
                     "application/json"
                 ],
@@ -3241,25 +3169,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_auth"
+                    "model"
                 ],
-                "summary": "AuthLoginSimple",
-                "operationId": "v1-AuthLoginSimple",
+                "summary": "get provider supported model list",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb_id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "para",
-                        "name": "param",
+                        "description": "get supported model list request",
+                        "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.AuthLoginSimpleReq"
+                            "$ref": "#/definitions/domain.GetProviderModelListReq"
                         }
                     }
                 ],
@@ -3267,15 +3187,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.GetProviderModelListResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/captcha/challenge": {
+        "/api/v1/model/switch-mode": {
             "post": {
-                "description": "CreateCaptcha",
+                "description": "switch model mode between manual and auto",
                 "consumes": [
                     "application/json"
                 ],
@@ -3283,31 +3215,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_captcha"
+                    "model"
                 ],
-                "summary": "CreateCaptcha",
+                "summary": "switch mode",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "switch mode request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SwitchModeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/gocap.ChallengeData"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SwitchModeResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/captcha/redeem": {
+        "/api/v1/node": {
             "post": {
-                "description": "RedeemCaptcha",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Create Node",
                 "consumes": [
                     "application/json"
                 ],
@@ -3315,24 +3266,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_captcha"
+                    "node"
                 ],
-                "summary": "RedeemCaptcha",
+                "summary": "Create Node",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "request",
+                        "description": "Node",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/consts.RedeemCaptchaReq"
+                            "$ref": "#/definitions/domain.CreateNodeReq"
                         }
                     }
                 ],
@@ -3340,15 +3284,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/gocap.VerificationResult"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/chat/completions": {
+        "/api/v1/node/action": {
             "post": {
-                "description": "OpenAI API compatible chat completions endpoint",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Node Action",
                 "consumes": [
                     "application/json"
                 ],
@@ -3356,24 +3320,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "node"
                 ],
-                "summary": "ChatCompletions",
+                "summary": "Node Action",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "OpenAI API request",
-                        "name": "request",
+                        "description": "Action",
+                        "name": "action",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.OpenAICompletionsRequest"
+                            "$ref": "#/definitions/domain.NodeActionReq"
                         }
                     }
                 ],
@@ -3381,21 +3338,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OpenAICompletionsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeActionResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/chat/feedback": {
+        "/api/v1/node/batch_move": {
             "post": {
-                "description": "Process user feedback for chat conversations",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Batch Move Node",
                 "consumes": [
                     "application/json"
                 ],
@@ -3403,17 +3371,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "node"
                 ],
-                "summary": "Handle chat feedback",
+                "summary": "Batch Move Node",
                 "parameters": [
                     {
-                        "description": "feedback request",
-                        "name": "request",
+                        "description": "Batch Move Node",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.FeedbackRequest"
+                            "$ref": "#/definitions/domain.BatchMoveReq"
                         }
                     }
                 ],
@@ -3427,9 +3395,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/chat/message": {
-            "post": {
-                "description": "ChatMessage",
+        "/api/v1/node/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get Node Detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -3437,40 +3410,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "node"
                 ],
-                "summary": "ChatMessage",
+                "summary": "Get Node Detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "app type",
-                        "name": "app_type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatRequest"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/chat/search": {
-            "post": {
-                "description": "ChatSearch",
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update Node Detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -3478,17 +3467,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat_search"
+                    "node"
                 ],
-                "summary": "ChatSearch",
+                "summary": "Update Node Detail",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
+                        "description": "Node",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ChatSearchReq"
+                            "$ref": "#/definitions/domain.UpdateNodeReq"
                         }
                     }
                 ],
@@ -3498,13 +3487,31 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                            "$ref": "#/definitions/v1.NodeUpdateResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeUpdateConflictResp"
                                         }
                                     }
                                 }
@@ -3514,9 +3521,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/chat/widget": {
-            "post": {
-                "description": "ChatWidget",
+        "/api/v1/node/feedback/link": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "将反馈关联到修正它的文档发布版本，并标记为已解决",
                 "consumes": [
                     "application/json"
                 ],
@@ -3524,24 +3536,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Widget"
+                    "NodeFeedback"
                 ],
-                "summary": "ChatWidget",
+                "summary": "关联修正反馈的文档版本",
+                "operationId": "v1-NodeFeedbackLink",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "app type",
-                        "name": "app_type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "request",
-                        "name": "request",
+                        "description": "para",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ChatRequest"
+                            "$ref": "#/definitions/v1.NodeFeedbackLinkReq"
                         }
                     }
                 ],
@@ -3555,9 +3561,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/chat/widget/search": {
-            "post": {
-                "description": "WidgetSearch",
+        "/api/v1/node/feedback/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "读者对文档“是否有帮助”的反馈，按状态、评分和原因筛选，用于分拣处理",
                 "consumes": [
                     "application/json"
                 ],
@@ -3565,18 +3576,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Widget"
+                    "NodeFeedback"
                 ],
-                "summary": "WidgetSearch",
+                "summary": "文档反馈列表",
+                "operationId": "v1-NodeFeedbackList",
                 "parameters": [
                     {
-                        "description": "Comment",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatSearchReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "node_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "inaccurate",
+                            "outdated",
+                            "unclear",
+                            "incomplete",
+                            "broken_link",
+                            "other"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "DocumentFeedbackReasonInaccurate",
+                            "DocumentFeedbackReasonOutdated",
+                            "DocumentFeedbackReasonUnclear",
+                            "DocumentFeedbackReasonIncomplete",
+                            "DocumentFeedbackReasonBrokenLink",
+                            "DocumentFeedbackReasonOther"
+                        ],
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            -1
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "Like",
+                            "DisLike"
+                        ],
+                        "name": "score",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "DocumentFeedbackStatusOpen",
+                            "DocumentFeedbackStatusResolved"
+                        ],
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3585,13 +3660,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                            "$ref": "#/definitions/v1.NodeFeedbackListResp"
                                         }
                                     }
                                 }
@@ -3601,9 +3676,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/comment": {
-            "post": {
-                "description": "CreateComment",
+        "/api/v1/node/feedback/status": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "批量将反馈标记为已解决或重新打开",
                 "consumes": [
                     "application/json"
                 ],
@@ -3611,67 +3691,102 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_comment"
+                    "NodeFeedback"
                 ],
-                "summary": "CreateComment",
+                "summary": "更新文档反馈状态",
+                "operationId": "v1-NodeFeedbackStatus",
                 "parameters": [
                     {
-                        "description": "Comment",
-                        "name": "comment",
+                        "description": "para",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CommentReq"
+                            "$ref": "#/definitions/v1.NodeFeedbackStatusReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CommentID",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/comment/list": {
-            "get": {
-                "description": "GetCommentList",
-                "consumes": [
-                    "application/json"
+        "/api/v1/node/field": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "更新自定义字段，key 与类型不可修改",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "share_comment"
+                    "NodeField"
                 ],
-                "summary": "GetCommentList",
+                "summary": "更新自定义字段",
+                "operationId": "v1-NodeFieldUpdate",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "nodeID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeFieldUpdateReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CommentList",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "创建自定义字段，如负责人、产品版本、受众、复审日期",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeField"
+                ],
+                "summary": "创建自定义字段",
+                "operationId": "v1-NodeFieldCreate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeFieldCreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -3681,7 +3796,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/share.ShareCommentLists"
+                                            "$ref": "#/definitions/v1.NodeFieldCreateResp"
                                         }
                                     }
                                 }
@@ -3689,42 +3804,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/common/file/upload": {
-            "post": {
-                "description": "前台用户上传文件,目前只支持图片文件上传",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "删除自定义字段，同时清除文档中该字段的值",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ShareFile"
+                    "NodeField"
                 ],
-                "summary": "文件上传",
-                "operationId": "share-FileUpload",
+                "summary": "删除自定义字段",
+                "operationId": "v1-NodeFieldDelete",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
+                        "name": "id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "captcha_token",
-                        "name": "captcha_token",
-                        "in": "formData",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -3732,27 +3841,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.FileUploadResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/conversation/detail": {
+        "/api/v1/node/field/list": {
             "get": {
-                "description": "GetConversationDetail",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "自定义字段列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -3760,21 +3862,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_conversation"
+                    "NodeField"
                 ],
-                "summary": "GetConversationDetail",
+                "summary": "自定义字段列表",
+                "operationId": "v1-NodeFieldList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "conversation id",
-                        "name": "id",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
@@ -3791,7 +3886,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ShareConversationDetailResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeField"
+                                            }
                                         }
                                     }
                                 }
@@ -3801,9 +3899,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/node/detail": {
+        "/api/v1/node/link/backlinks": {
             "get": {
-                "description": "GetNodeDetail",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "返回链接到该文档的其他文档",
                 "consumes": [
                     "application/json"
                 ],
@@ -3811,28 +3914,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_node"
+                    "NodeLink"
                 ],
-                "summary": "GetNodeDetail",
+                "summary": "文档反向链接",
+                "operationId": "v1-NodeBacklinks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "node id",
                         "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "format",
-                        "name": "format",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
@@ -3843,13 +3938,16 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.ShareNodeDetailResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NodeLinkItem"
+                                            }
                                         }
                                     }
                                 }
@@ -3859,9 +3957,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/node/list": {
+        "/api/v1/node/link/broken": {
             "get": {
-                "description": "GetNodeList",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "知识库内失效的内部链接与检查失败的外部链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -3869,15 +3972,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_node"
+                    "NodeLink"
                 ],
-                "summary": "GetNodeList",
+                "summary": "失效链接报告",
+                "operationId": "v1-NodeBrokenLinks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -3885,15 +3988,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NodeBrokenLinkItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/openapi/github/callback": {
+        "/api/v1/node/link/inbound": {
             "get": {
-                "description": "GitHub回调",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "删除或取消发布前调用，返回文档及其子文档被删除后会失效的链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -3901,20 +4024,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ShareOpenapi"
+                    "NodeLink"
                 ],
-                "summary": "GitHub回调",
-                "operationId": "v1-GitHubCallback",
+                "summary": "文档入链检查",
+                "operationId": "v1-NodeInboundLinks",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "code",
-                        "in": "query"
+                        "minItems": 1,
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "name": "state",
-                        "in": "query"
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3929,7 +4059,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NodeLinkItem"
+                                            }
                                         }
                                     }
                                 }
@@ -3939,42 +4072,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/openapi/lark/bot/{kb_id}": {
+        "/api/v1/node/lint/refresh": {
             "post": {
-                "description": "Lark机器人请求",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ShareOpenapi"
-                ],
-                "summary": "Lark机器人请求",
-                "operationId": "v1-LarkBot",
-                "parameters": [
+                "security": [
                     {
-                        "type": "string",
-                        "description": "知识库ID",
-                        "name": "kb_id",
-                        "in": "path",
-                        "required": true
+                        "bearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.PWResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/share/v1/stat/page": {
-            "post": {
-                "description": "RecordPage",
+                "description": "重新检查知识库内全部文档的当前内容，用于规则或屏蔽词变更后刷新报告",
                 "consumes": [
                     "application/json"
                 ],
@@ -3982,17 +4087,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_stat"
+                    "NodeLint"
                 ],
-                "summary": "RecordPage",
+                "summary": "重新检查全部文档",
+                "operationId": "v1-NodeLintRefresh",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
+                        "description": "para",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.StatPageReq"
+                            "$ref": "#/definitions/v1.NodeLintRefreshReq"
                         }
                     }
                 ],
@@ -4000,1243 +4106,7110 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeLintRefreshResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/lint/report": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档保存或发布时检查出的内容问题，按问题数量排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeLint"
+                ],
+                "summary": "内容质量报告",
+                "operationId": "v1-NodeLintReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeLintReportResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get Node List",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Get Node List",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "key:value，同时满足所有字段",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "同时包含所有标签",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeListItemResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/move": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Move Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Move Node",
+                "parameters": [
+                    {
+                        "description": "Move Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MoveNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/permission": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档授权信息获取",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodePermission"
+                ],
+                "summary": "文档授权信息获取",
+                "operationId": "v1-NodePermission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodePermissionResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/permission/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档授权信息更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodePermission"
+                ],
+                "summary": "文档授权信息更新",
+                "operationId": "v1-NodePermissionEdit",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodePermissionEditReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodePermissionEditResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/push": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Bulk create or update documents by external id, pushing the same batch again is a no-op. Works with api tokens which have doc_manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "NodePush",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodePushReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodePushResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/recommend_nodes": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Recommend Nodes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Recommend Nodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "node_ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.RecommendNodeListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/restudy": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档重新学习",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Node"
+                ],
+                "summary": "文档重新学习",
+                "operationId": "v1-NodeRestudy",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeRestudyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeRestudyResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/seo": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "管理员为文档设置的 SEO 覆盖项，为空的字段使用自动生成的值",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeSEO"
+                ],
+                "summary": "文档 SEO 设置",
+                "operationId": "v1-NodeSEO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeSEO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "覆盖文档自动生成的标题、描述、关键词、图片和规范链接，或禁止搜索引擎收录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeSEO"
+                ],
+                "summary": "更新文档 SEO 设置",
+                "operationId": "v1-NodeSEOUpdate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeSEOUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/slug": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档的可读地址别名，未设置时使用 /node/\u003cid\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeSlug"
+                ],
+                "summary": "文档别名",
+                "operationId": "v1-NodeSlug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeSlugResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "设置文档的地址别名 /docs/\u003cslug\u003e，为空时删除。旧别名会自动 301 重定向到该文档",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeSlug"
+                ],
+                "summary": "设置文档别名",
+                "operationId": "v1-NodeSlugUpdate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeSlugUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Summary Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Summary Node",
+                "parameters": [
+                    {
+                        "description": "Summary Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NodeSummaryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/tag/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "知识库内已使用的标签及文档数量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeField"
+                ],
+                "summary": "文档标签列表",
+                "operationId": "v1-NodeTagList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeTagCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/template": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "更新文档模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "更新文档模板",
+                "operationId": "v1-NodeTemplateUpdate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTemplateUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "创建文档模板，内容与摘要支持 {{date}} {{time}} {{datetime}} {{author}} {{title}} {{parent_name}} {{kb_name}} 占位符",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "创建文档模板",
+                "operationId": "v1-NodeTemplateCreate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTemplateCreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeTemplateCreateResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "删除文档模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "删除文档模板",
+                "operationId": "v1-NodeTemplateDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/template/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档模板详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "文档模板详情",
+                "operationId": "v1-NodeTemplateDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/template/from_node": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "将文档保存为模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "将文档保存为模板",
+                "operationId": "v1-NodeTemplateFromNode",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTemplateFromNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeTemplateCreateResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/template/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档模板列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTemplate"
+                ],
+                "summary": "文档模板列表",
+                "operationId": "v1-NodeTemplateList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeTemplate"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "取消文档与源文档的译文关联，文档本身保留为普通文档",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTranslation"
+                ],
+                "summary": "取消译文关联",
+                "operationId": "v1-NodeTranslationUnlink",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "translated node id",
+                        "name": "node_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation/link": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "将人工翻译的已有文档关联为源文档在某一语言的译文",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTranslation"
+                ],
+                "summary": "关联已有文档为译文",
+                "operationId": "v1-NodeTranslationLink",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTranslationLinkReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "源文档的各语言译文，源文档在翻译后有修改时译文标记为过期",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTranslation"
+                ],
+                "summary": "文档译文列表",
+                "operationId": "v1-NodeTranslationList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "source node id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NodeTranslationItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation/synced": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "人工校对或更新译文后，将译文标记为与当前源文档一致，取消过期标记",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTranslation"
+                ],
+                "summary": "标记译文为最新",
+                "operationId": "v1-NodeTranslationSynced",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTranslationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation/translate": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "使用对话模型在后台将文档翻译为目标语言，首次翻译时创建译文文档，之后覆盖译文。译文需要发布后才对外可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTranslation"
+                ],
+                "summary": "机器翻译文档",
+                "operationId": "v1-NodeTranslate",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTranslateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeTranslateResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/trash": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "从回收站彻底删除文档及其向量数据，all 为 true 时清空回收站",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTrash"
+                ],
+                "summary": "彻底删除",
+                "operationId": "v1-NodeTrashDelete",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "清空回收站",
+                        "name": "all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/trash/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "回收站中被删除的文档，子文档随父文档一同展示与恢复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTrash"
+                ],
+                "summary": "回收站列表",
+                "operationId": "v1-NodeTrashList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.NodeTrashListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/trash/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "恢复到原父文档与位置，父文档已不存在时恢复到根目录，文档 ID 已被其他文档使用时返回 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodeTrash"
+                ],
+                "summary": "从回收站恢复",
+                "operationId": "v1-NodeTrashRestore",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeTrashRestoreReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/browsers": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "客户端统计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "客户端统计",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.HotBrowser"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/conversation_distribution": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "问答来源",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "问答来源",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatConversationDistributionResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "全局统计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "全局统计",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.StatCountResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/geo_count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "用户地理分布",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "用户地理分布",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/hot_pages": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "热门文档",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "热门文档",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.HotPage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/instant_count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "GetInstantCount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "GetInstantCount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InstantCountResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/instant_pages": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "GetInstantPages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "GetInstantPages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InstantPageResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/node_engagement": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "访问最多的文档的停留时长、滚动深度、读完率和跳出率",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "文档阅读情况",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatNodeEngagementItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/node_feedback": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "按文档汇总“是否有帮助”的反馈，负面反馈最多的文档在前",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "文档反馈统计",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatNodeFeedbackItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/referer_hosts": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "来源域名",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "来源域名",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.HotRefererHost"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/search/gap_doc": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "为无结果或差评的查询创建草稿文档",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "为查询创建文档",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.StatSearchGapDocReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.StatSearchGapDocResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/search/negative_feedback_queries": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "回答被点踩的问题",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "差评问题",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatNegativeFeedbackQueryItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/search/top_queries": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "按规范化后的查询聚合的热门搜索和问答",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "热门查询",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "search",
+                            "chat"
+                        ],
+                        "type": "string",
+                        "x-enum-comments": {
+                            "SearchQuerySourceChat": "questions asked in chat",
+                            "SearchQuerySourceSearch": "/share/v1/chat/search and widget search"
+                        },
+                        "x-enum-descriptions": [
+                            "/share/v1/chat/search and widget search",
+                            "questions asked in chat"
+                        ],
+                        "x-enum-varnames": [
+                            "SearchQuerySourceSearch",
+                            "SearchQuerySourceChat"
+                        ],
+                        "description": "为空时包含全部来源",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatSearchQueryItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/search/zero_result_queries": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "没有召回任何文档的搜索和问答",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "无结果查询",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "search",
+                            "chat"
+                        ],
+                        "type": "string",
+                        "x-enum-comments": {
+                            "SearchQuerySourceChat": "questions asked in chat",
+                            "SearchQuerySourceSearch": "/share/v1/chat/search and widget search"
+                        },
+                        "x-enum-descriptions": [
+                            "/share/v1/chat/search and widget search",
+                            "questions asked in chat"
+                        ],
+                        "x-enum-varnames": [
+                            "SearchQuerySourceSearch",
+                            "SearchQuerySourceChat"
+                        ],
+                        "description": "为空时包含全部来源",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StatSearchQueryItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user": {
+            "get": {
+                "description": "GetUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUser",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserInfoResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/create": {
+            "post": {
+                "description": "CreateUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "CreateUser",
+                "parameters": [
+                    {
+                        "description": "CreateUser Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreateUserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CreateUserResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/delete": {
+            "delete": {
+                "description": "DeleteUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "DeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/list": {
+            "get": {
+                "description": "ListUsers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ListUsers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.UserListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/login": {
+            "post": {
+                "description": "Login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/reset_password": {
+            "put": {
+                "description": "ResetPassword",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ResetPassword",
+                "parameters": [
+                    {
+                        "description": "ResetPassword Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ResetPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/web/info": {
+            "get": {
+                "description": "GetAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_app"
+                ],
+                "summary": "GetAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.AppInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/wechat/info": {
+            "get": {
+                "description": "WechatAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "WechatAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WechatAppInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/wechat/service/answer": {
+            "get": {
+                "description": "GetWechatAnswer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wechat"
+                ],
+                "summary": "GetWechatAnswer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/widget/info": {
+            "get": {
+                "description": "GetWidgetAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_app"
+                ],
+                "summary": "GetWidgetAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/get": {
+            "get": {
+                "description": "AuthGet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_auth"
+                ],
+                "summary": "AuthGet",
+                "operationId": "v1-AuthGet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb_id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/github": {
+            "post": {
+                "description": "GitHub登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareAuth"
+                ],
+                "summary": "GitHub登录",
+                "operationId": "v1-AuthGitHub",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.AuthGitHubReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.AuthGitHubResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/login/simple": {
+            "post": {
+                "description": "AuthLoginSimple",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_auth"
+                ],
+                "summary": "AuthLoginSimple",
+                "operationId": "v1-AuthLoginSimple",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb_id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.AuthLoginSimpleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/captcha/challenge": {
+            "post": {
+                "description": "CreateCaptcha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_captcha"
+                ],
+                "summary": "CreateCaptcha",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gocap.ChallengeData"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/captcha/redeem": {
+            "post": {
+                "description": "RedeemCaptcha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_captcha"
+                ],
+                "summary": "RedeemCaptcha",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/consts.RedeemCaptchaReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gocap.VerificationResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/completions": {
+            "post": {
+                "description": "OpenAI API compatible chat completions endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatCompletions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "OpenAI API request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAICompletionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAICompletionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/feedback": {
+            "post": {
+                "description": "Process user feedback for chat conversations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "Handle chat feedback",
+                "parameters": [
+                    {
+                        "description": "feedback request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/message": {
+            "post": {
+                "description": "ChatMessage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatMessage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app type",
+                        "name": "app_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/search": {
+            "post": {
+                "description": "ChatSearch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat_search"
+                ],
+                "summary": "ChatSearch",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/widget": {
+            "post": {
+                "description": "ChatWidget",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Widget"
+                ],
+                "summary": "ChatWidget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app type",
+                        "name": "app_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/widget/search": {
+            "post": {
+                "description": "WidgetSearch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Widget"
+                ],
+                "summary": "WidgetSearch",
+                "parameters": [
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/comment": {
+            "post": {
+                "description": "CreateComment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_comment"
+                ],
+                "summary": "CreateComment",
+                "parameters": [
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CommentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CommentID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/comment/list": {
+            "get": {
+                "description": "GetCommentList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_comment"
+                ],
+                "summary": "GetCommentList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "nodeID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CommentList",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/share.ShareCommentLists"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/common/file/upload": {
+            "post": {
+                "description": "前台用户上传文件,目前只支持图片文件上传",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareFile"
+                ],
+                "summary": "文件上传",
+                "operationId": "share-FileUpload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "captcha_token",
+                        "name": "captcha_token",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FileUploadResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/conversation/detail": {
+            "get": {
+                "description": "GetConversationDetail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_conversation"
+                ],
+                "summary": "GetConversationDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ShareConversationDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/detail": {
+            "get": {
+                "description": "GetNodeDetail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "node id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "returns the published translation in this locale when there is one",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ShareNodeDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/export": {
+            "get": {
+                "description": "Download the public documents of the published kb or a folder as an ebook, the format must be enabled in the web app settings",
+                "produces": [
+                    "application/epub+zip",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "ExportBook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "epub or pdf",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "folder id, whole kb when empty",
+                        "name": "node_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/feedback": {
+            "post": {
+                "description": "文档是否有帮助的反馈，同一会话重复反馈时更新之前未处理的反馈",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_document_feedback"
+                ],
+                "summary": "CreateDocumentFeedback",
+                "parameters": [
+                    {
+                        "description": "Feedback",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.DocumentFeedbackReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FeedbackID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "integer",
+                                            "format": "int64"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/list": {
+            "get": {
+                "description": "GetNodeList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "GetNodeList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nodes with a published translation in this locale are replaced by the translation",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/resolve": {
+            "get": {
+                "description": "Resolve a request path of the site, /node/\u003cid\u003e or /docs/\u003cslug\u003e, to a node or a redirect",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "ResolveNode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "request path of the site, e.g. /docs/getting-started",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ShareNodeResolveResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/search": {
+            "get": {
+                "description": "Full-text search over the published documents the visitor can visit, with highlighted snippets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "SearchNodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "仅搜索该目录下的文档",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 200,
+                        "type": "string",
+                        "description": "空格分隔多个关键词，\"双引号\" 内为短语",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "同时包含所有标签",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ShareNodeSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/seo": {
+            "get": {
+                "description": "SEO metadata, Open Graph, Twitter card and JSON-LD of a published node, or of the landing page when id is empty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "GetNodeSEO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "为空时返回首页的 SEO 数据",
+                        "name": "id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ShareNodeSEOResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/openapi/github/callback": {
+            "get": {
+                "description": "GitHub回调",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareOpenapi"
+                ],
+                "summary": "GitHub回调",
+                "operationId": "v1-GitHubCallback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/openapi/lark/bot/{kb_id}": {
+            "post": {
+                "description": "Lark机器人请求",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareOpenapi"
+                ],
+                "summary": "Lark机器人请求",
+                "operationId": "v1-LarkBot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "kb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/stat/engagement": {
+            "post": {
+                "description": "页面隐藏或离开时上报的阅读行为，可以用 navigator.sendBeacon 发送，body 按 json 解析",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_stat"
+                ],
+                "summary": "RecordEngagement",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.StatPageEngagementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/stat/page": {
+            "post": {
+                "description": "RecordPage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_stat"
+                ],
+                "summary": "RecordPage",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.StatPageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "anydoc.Child": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/anydoc.Child"
+                    }
+                },
+                "value": {
+                    "$ref": "#/definitions/anydoc.Value"
+                }
+            }
+        },
+        "anydoc.Value": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "boolean"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "consts.AuthType": {
+            "type": "string",
+            "enum": [
+                "",
+                "simple",
+                "enterprise"
+            ],
+            "x-enum-comments": {
+                "AuthTypeEnterprise": "企业认证",
+                "AuthTypeNull": "无认证",
+                "AuthTypeSimple": "简单口令"
+            },
+            "x-enum-descriptions": [
+                "无认证",
+                "简单口令",
+                "企业认证"
+            ],
+            "x-enum-varnames": [
+                "AuthTypeNull",
+                "AuthTypeSimple",
+                "AuthTypeEnterprise"
+            ]
+        },
+        "consts.CopySetting": {
+            "type": "string",
+            "enum": [
+                "",
+                "append",
+                "disabled"
+            ],
+            "x-enum-comments": {
+                "CopySettingAppend": "增加内容尾巴",
+                "CopySettingDisabled": "禁止复制内容",
+                "CopySettingNone": "无限制"
+            },
+            "x-enum-descriptions": [
+                "无限制",
+                "增加内容尾巴",
+                "禁止复制内容"
+            ],
+            "x-enum-varnames": [
+                "CopySettingNone",
+                "CopySettingAppend",
+                "CopySettingDisabled"
+            ]
+        },
+        "consts.CrawlerSource": {
+            "type": "string",
+            "enum": [
+                "url",
+                "rss",
+                "sitemap",
+                "notion",
+                "feishu",
+                "file",
+                "epub",
+                "yuque",
+                "siyuan",
+                "mindoc",
+                "wikijs",
+                "confluence",
+                "git"
+            ],
+            "x-enum-varnames": [
+                "CrawlerSourceUrl",
+                "CrawlerSourceRSS",
+                "CrawlerSourceSitemap",
+                "CrawlerSourceNotion",
+                "CrawlerSourceFeishu",
+                "CrawlerSourceFile",
+                "CrawlerSourceEpub",
+                "CrawlerSourceYuque",
+                "CrawlerSourceSiyuan",
+                "CrawlerSourceMindoc",
+                "CrawlerSourceWikijs",
+                "CrawlerSourceConfluence",
+                "CrawlerSourceGit"
+            ]
+        },
+        "consts.CrawlerStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "in_process",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "CrawlerStatusPending",
+                "CrawlerStatusInProcess",
+                "CrawlerStatusCompleted",
+                "CrawlerStatusFailed"
+            ]
+        },
+        "consts.HomePageSetting": {
+            "type": "string",
+            "enum": [
+                "doc",
+                "custom"
+            ],
+            "x-enum-comments": {
+                "HomePageSettingCustom": "自定义首页",
+                "HomePageSettingDoc": "文档页面"
+            },
+            "x-enum-descriptions": [
+                "文档页面",
+                "自定义首页"
+            ],
+            "x-enum-varnames": [
+                "HomePageSettingDoc",
+                "HomePageSettingCustom"
+            ]
+        },
+        "consts.LicenseEdition": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-comments": {
+                "LicenseEditionBusiness": "商业版",
+                "LicenseEditionEnterprise": "企业版",
+                "LicenseEditionFree": "开源版",
+                "LicenseEditionProfession": "专业版"
+            },
+            "x-enum-descriptions": [
+                "开源版",
+                "专业版",
+                "企业版",
+                "商业版"
+            ],
+            "x-enum-varnames": [
+                "LicenseEditionFree",
+                "LicenseEditionProfession",
+                "LicenseEditionEnterprise",
+                "LicenseEditionBusiness"
+            ]
+        },
+        "consts.ModelSettingMode": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "auto"
+            ],
+            "x-enum-varnames": [
+                "ModelSettingModeManual",
+                "ModelSettingModeAuto"
+            ]
+        },
+        "consts.NodeAccessPerm": {
+            "type": "string",
+            "enum": [
+                "open",
+                "partial",
+                "closed"
+            ],
+            "x-enum-comments": {
+                "NodeAccessPermClosed": "完全禁止",
+                "NodeAccessPermOpen": "完全开放",
+                "NodeAccessPermPartial": "部分开放"
+            },
+            "x-enum-descriptions": [
+                "完全开放",
+                "部分开放",
+                "完全禁止"
+            ],
+            "x-enum-varnames": [
+                "NodeAccessPermOpen",
+                "NodeAccessPermPartial",
+                "NodeAccessPermClosed"
+            ]
+        },
+        "consts.NodePermName": {
+            "type": "string",
+            "enum": [
+                "visible",
+                "visitable",
+                "answerable"
+            ],
+            "x-enum-comments": {
+                "NodePermNameAnswerable": "可被问答",
+                "NodePermNameVisible": "导航内可见",
+                "NodePermNameVisitable": "可被访问"
+            },
+            "x-enum-descriptions": [
+                "导航内可见",
+                "可被访问",
+                "可被问答"
+            ],
+            "x-enum-varnames": [
+                "NodePermNameVisible",
+                "NodePermNameVisitable",
+                "NodePermNameAnswerable"
+            ]
+        },
+        "consts.NodeRagInfoStatus": {
+            "type": "string",
+            "enum": [
+                "BASIC_PENDING",
+                "BASIC_RUNNING",
+                "BASIC_FAILED",
+                "BASIC_SUCCEEDED",
+                "ENHANCE_PENDING",
+                "ENHANCE_RUNNING",
+                "ENHANCE_FAILED",
+                "ENHANCE_SUCCEEDED"
+            ],
+            "x-enum-comments": {
+                "NodeRagStatusBasicFailed": "基础处理失败",
+                "NodeRagStatusBasicPending": "等待基础处理",
+                "NodeRagStatusBasicRunning": "正在进行基础处理（文本分割、向量化等）",
+                "NodeRagStatusBasicSucceeded": "基础处理成功",
+                "NodeRagStatusEnhanceFailed": "增强处理失败",
+                "NodeRagStatusEnhancePending": "基础处理完成，等待增强处理",
+                "NodeRagStatusEnhanceRunning": "正在进行增强处理（关键词提取等）",
+                "NodeRagStatusEnhanceSucceeded": "增强处理成功"
+            },
+            "x-enum-descriptions": [
+                "等待基础处理",
+                "正在进行基础处理（文本分割、向量化等）",
+                "基础处理失败",
+                "基础处理成功",
+                "基础处理完成，等待增强处理",
+                "正在进行增强处理（关键词提取等）",
+                "增强处理失败",
+                "增强处理成功"
+            ],
+            "x-enum-varnames": [
+                "NodeRagStatusBasicPending",
+                "NodeRagStatusBasicRunning",
+                "NodeRagStatusBasicFailed",
+                "NodeRagStatusBasicSucceeded",
+                "NodeRagStatusEnhancePending",
+                "NodeRagStatusEnhanceRunning",
+                "NodeRagStatusEnhanceFailed",
+                "NodeRagStatusEnhanceSucceeded"
+            ]
+        },
+        "consts.RedeemCaptchaReq": {
+            "type": "object",
+            "properties": {
+                "solutions": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "consts.SourceType": {
+            "type": "string",
+            "enum": [
+                "dingtalk",
+                "feishu",
+                "wecom",
+                "oauth",
+                "github",
+                "cas",
+                "ldap",
+                "widget",
+                "dingtalk_bot",
+                "feishu_bot",
+                "lark_bot",
+                "wechat_bot",
+                "wecom_ai_bot",
+                "wechat_service_bot",
+                "discord_bot",
+                "wechat_official_account",
+                "openai_api",
+                "mcp_server"
+            ],
+            "x-enum-varnames": [
+                "SourceTypeDingTalk",
+                "SourceTypeFeishu",
+                "SourceTypeWeCom",
+                "SourceTypeOAuth",
+                "SourceTypeGitHub",
+                "SourceTypeCAS",
+                "SourceTypeLDAP",
+                "SourceTypeWidget",
+                "SourceTypeDingtalkBot",
+                "SourceTypeFeishuBot",
+                "SourceTypeLarkBot",
+                "SourceTypeWechatBot",
+                "SourceTypeWecomAIBot",
+                "SourceTypeWechatServiceBot",
+                "SourceTypeDiscordBot",
+                "SourceTypeWechatOfficialAccount",
+                "SourceTypeOpenAIAPI",
+                "SourceTypeMcpServer"
+            ]
+        },
+        "consts.StatDay": {
+            "type": "integer",
+            "enum": [
+                1,
+                7,
+                30,
+                90
+            ],
+            "x-enum-varnames": [
+                "StatDay1",
+                "StatDay7",
+                "StatDay30",
+                "StatDay90"
+            ]
+        },
+        "consts.UserKBPermission": {
+            "type": "string",
+            "enum": [
+                "",
+                "full_control",
+                "doc_manage",
+                "data_operate"
+            ],
+            "x-enum-comments": {
+                "UserKBPermissionDataOperate": "数据运营",
+                "UserKBPermissionDocManage": "文档管理",
+                "UserKBPermissionFullControl": "完全控制",
+                "UserKBPermissionNull": "无权限"
+            },
+            "x-enum-descriptions": [
+                "无权限",
+                "完全控制",
+                "文档管理",
+                "数据运营"
+            ],
+            "x-enum-varnames": [
+                "UserKBPermissionNull",
+                "UserKBPermissionFullControl",
+                "UserKBPermissionDocManage",
+                "UserKBPermissionDataOperate"
+            ]
+        },
+        "consts.UserRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "user"
+            ],
+            "x-enum-comments": {
+                "UserRoleAdmin": "管理员",
+                "UserRoleUser": "普通用户"
+            },
+            "x-enum-descriptions": [
+                "管理员",
+                "普通用户"
+            ],
+            "x-enum-varnames": [
+                "UserRoleAdmin",
+                "UserRoleUser"
+            ]
+        },
+        "consts.WatermarkSetting": {
+            "type": "string",
+            "enum": [
+                "",
+                "hidden",
+                "visible"
+            ],
+            "x-enum-comments": {
+                "WatermarkDisabled": "未开启水印",
+                "WatermarkHidden": "隐形水印",
+                "WatermarkVisible": "显性水印"
+            },
+            "x-enum-descriptions": [
+                "未开启水印",
+                "隐形水印",
+                "显性水印"
+            ],
+            "x-enum-varnames": [
+                "WatermarkDisabled",
+                "WatermarkHidden",
+                "WatermarkVisible"
+            ]
+        },
+        "domain.AIFeedbackSettings": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.AccessSettings": {
+            "type": "object",
+            "properties": {
+                "base_url": {
+                    "type": "string"
+                },
+                "enterprise_auth": {
+                    "$ref": "#/definitions/domain.EnterpriseAuth"
+                },
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_forbidden": {
+                    "description": "禁止访问",
+                    "type": "boolean"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "private_key": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "simple_auth": {
+                    "$ref": "#/definitions/domain.SimpleAuth"
+                },
+                "source_type": {
+                    "description": "企业认证来源",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.SourceType"
+                        }
+                    ]
+                },
+                "ssl_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "trusted_proxies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.AnydocUploadResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "err": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AppDetailResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettingsResp"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.AppType"
+                }
+            }
+        },
+        "domain.AppInfoResp": {
+            "type": "object",
+            "properties": {
+                "base_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettingsResp"
+                }
+            }
+        },
+        "domain.AppSettings": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_settings": {
+                    "description": "AI feedback",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AIFeedbackSettings"
+                        }
+                    ]
+                },
+                "body_code": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {}
+                },
+                "catalog_settings": {
+                    "description": "catalog settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CatalogSettings"
+                        }
+                    ]
+                },
+                "contribute_settings": {
+                    "$ref": "#/definitions/domain.ContributeSettings"
+                },
+                "conversation_setting": {
+                    "$ref": "#/definitions/domain.ConversationSetting"
+                },
+                "copy_setting": {
+                    "enum": [
+                        "",
+                        "append",
+                        "disabled"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.CopySetting"
+                        }
+                    ]
+                },
+                "desc": {
+                    "description": "seo",
+                    "type": "string"
+                },
+                "dingtalk_bot_client_id": {
+                    "type": "string"
+                },
+                "dingtalk_bot_client_secret": {
+                    "type": "string"
+                },
+                "dingtalk_bot_is_enabled": {
+                    "description": "DingTalkBot",
+                    "type": "boolean"
+                },
+                "dingtalk_bot_template_id": {
+                    "type": "string"
+                },
+                "disclaimer_settings": {
+                    "description": "Disclaimer Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DisclaimerSettings"
+                        }
+                    ]
+                },
+                "discord_bot_is_enabled": {
+                    "description": "DisCordBot",
+                    "type": "boolean"
+                },
+                "discord_bot_token": {
+                    "type": "string"
+                },
+                "document_feedback_is_enabled": {
+                    "description": "document feedback",
+                    "type": "boolean"
+                },
+                "export_settings": {
+                    "description": "ExportSettings offers the kb as ebook downloads on the share site",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ExportSettings"
+                        }
+                    ]
+                },
+                "feishu_bot_app_id": {
+                    "type": "string"
+                },
+                "feishu_bot_app_secret": {
+                    "type": "string"
+                },
+                "feishu_bot_is_enabled": {
+                    "description": "FeishuBot",
+                    "type": "boolean"
+                },
+                "footer_settings": {
+                    "description": "footer settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FooterSettings"
+                        }
+                    ]
+                },
+                "head_code": {
+                    "description": "inject code",
+                    "type": "string"
+                },
+                "home_page_setting": {
+                    "$ref": "#/definitions/consts.HomePageSetting"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "lark_bot_settings": {
+                    "description": "LarkBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LarkBotSettings"
+                        }
+                    ]
+                },
+                "llms_txt_settings": {
+                    "description": "LLMsTxtSettings serves /llms.txt, /llms-full.txt and /node/\u003cid\u003e.md for AI agents",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LLMsTxtSettings"
+                        }
+                    ]
+                },
+                "mcp_server_settings": {
+                    "description": "MCP Server Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MCPServerSettings"
+                        }
+                    ]
+                },
+                "openai_api_bot_settings": {
+                    "description": "OpenAI API Bot settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                        }
+                    ]
+                },
+                "recommend_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_placeholder": {
+                    "type": "string"
+                },
+                "stats_setting": {
+                    "$ref": "#/definitions/domain.StatsSetting"
+                },
+                "theme_and_style": {
+                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                },
+                "theme_mode": {
+                    "description": "theme",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "nav",
+                    "type": "string"
+                },
+                "watermark_content": {
+                    "type": "string"
+                },
+                "watermark_setting": {
+                    "enum": [
+                        "",
+                        "hidden",
+                        "visible"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.WatermarkSetting"
+                        }
+                    ]
+                },
+                "web_app_comment_settings": {
+                    "description": "webapp comment settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCommentSettings"
+                        }
+                    ]
+                },
+                "web_app_custom_style": {
+                    "description": "WebAppCustomStyle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCustomSettings"
+                        }
+                    ]
+                },
+                "web_app_landing_configs": {
+                    "description": "WebAppLandingConfigs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebAppLandingConfig"
+                    }
+                },
+                "web_app_landing_theme": {
+                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                },
+                "wechat_app_advanced_setting": {
+                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                },
+                "wechat_app_agent_id": {
+                    "type": "string"
+                },
+                "wechat_app_corpid": {
+                    "type": "string"
+                },
+                "wechat_app_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_app_is_enabled": {
+                    "description": "WechatAppBot 企业微信机器人",
+                    "type": "boolean"
+                },
+                "wechat_app_secret": {
+                    "type": "string"
+                },
+                "wechat_app_token": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_id": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_secret": {
+                    "type": "string"
+                },
+                "wechat_official_account_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_official_account_is_enabled": {
+                    "description": "WechatOfficialAccount",
+                    "type": "boolean"
+                },
+                "wechat_official_account_token": {
+                    "type": "string"
+                },
+                "wechat_service_contain_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_corpid": {
+                    "type": "string"
+                },
+                "wechat_service_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_service_equal_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_is_enabled": {
+                    "description": "WechatServiceBot",
+                    "type": "boolean"
+                },
+                "wechat_service_secret": {
+                    "type": "string"
+                },
+                "wechat_service_token": {
+                    "type": "string"
+                },
+                "wecom_ai_bot_settings": {
+                    "description": "WecomAIBotSettings 企业微信智能机器人",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WecomAIBotSettings"
+                        }
+                    ]
+                },
+                "welcome_str": {
+                    "description": "welcome",
+                    "type": "string"
+                },
+                "widget_bot_settings": {
+                    "description": "Widget bot settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WidgetBotSettings"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.AppSettingsResp": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_settings": {
+                    "description": "AI feedback",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AIFeedbackSettings"
+                        }
+                    ]
+                },
+                "body_code": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {}
+                },
+                "catalog_settings": {
+                    "description": "catalog settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CatalogSettings"
+                        }
+                    ]
+                },
+                "contribute_settings": {
+                    "$ref": "#/definitions/domain.ContributeSettings"
+                },
+                "conversation_setting": {
+                    "$ref": "#/definitions/domain.ConversationSetting"
+                },
+                "copy_setting": {
+                    "$ref": "#/definitions/consts.CopySetting"
+                },
+                "desc": {
+                    "description": "seo",
+                    "type": "string"
+                },
+                "dingtalk_bot_client_id": {
+                    "type": "string"
+                },
+                "dingtalk_bot_client_secret": {
+                    "type": "string"
+                },
+                "dingtalk_bot_is_enabled": {
+                    "description": "DingTalkBot",
+                    "type": "boolean"
+                },
+                "dingtalk_bot_template_id": {
+                    "type": "string"
+                },
+                "disclaimer_settings": {
+                    "description": "Disclaimer Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DisclaimerSettings"
+                        }
+                    ]
+                },
+                "discord_bot_is_enabled": {
+                    "description": "DisCordBot",
+                    "type": "boolean"
+                },
+                "discord_bot_token": {
+                    "type": "string"
+                },
+                "document_feedback_is_enabled": {
+                    "description": "document feedback",
+                    "type": "boolean"
+                },
+                "export_settings": {
+                    "description": "ExportSettings offers the kb as ebook downloads on the share site",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ExportSettings"
+                        }
+                    ]
+                },
+                "feishu_bot_app_id": {
+                    "type": "string"
+                },
+                "feishu_bot_app_secret": {
+                    "type": "string"
+                },
+                "feishu_bot_is_enabled": {
+                    "description": "FeishuBot",
+                    "type": "boolean"
+                },
+                "footer_settings": {
+                    "description": "footer settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FooterSettings"
+                        }
+                    ]
+                },
+                "head_code": {
+                    "description": "inject code",
+                    "type": "string"
+                },
+                "home_page_setting": {
+                    "$ref": "#/definitions/consts.HomePageSetting"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "lark_bot_settings": {
+                    "description": "LarkBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LarkBotSettings"
+                        }
+                    ]
+                },
+                "llms_txt_settings": {
+                    "description": "LLMsTxtSettings serves /llms.txt, /llms-full.txt and /node/\u003cid\u003e.md for AI agents",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LLMsTxtSettings"
+                        }
+                    ]
+                },
+                "mcp_server_settings": {
+                    "description": "MCP Server Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MCPServerSettings"
+                        }
+                    ]
+                },
+                "openai_api_bot_settings": {
+                    "description": "OpenAI API settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                        }
+                    ]
+                },
+                "recommend_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_placeholder": {
+                    "type": "string"
+                },
+                "stats_setting": {
+                    "$ref": "#/definitions/domain.StatsSetting"
+                },
+                "theme_and_style": {
+                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                },
+                "theme_mode": {
+                    "description": "theme",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "nav",
+                    "type": "string"
+                },
+                "watermark_content": {
+                    "type": "string"
+                },
+                "watermark_setting": {
+                    "$ref": "#/definitions/consts.WatermarkSetting"
+                },
+                "web_app_comment_settings": {
+                    "description": "webapp comment settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCommentSettings"
+                        }
+                    ]
+                },
+                "web_app_custom_style": {
+                    "description": "WebAppCustomStyle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCustomSettings"
+                        }
+                    ]
+                },
+                "web_app_landing_configs": {
+                    "description": "WebApp Landing Settings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebAppLandingConfigResp"
+                    }
+                },
+                "web_app_landing_theme": {
+                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                },
+                "wechat_app_advanced_setting": {
+                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                },
+                "wechat_app_agent_id": {
+                    "type": "string"
+                },
+                "wechat_app_corpid": {
+                    "type": "string"
+                },
+                "wechat_app_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_app_is_enabled": {
+                    "description": "WechatAppBot",
+                    "type": "boolean"
+                },
+                "wechat_app_secret": {
+                    "type": "string"
+                },
+                "wechat_app_token": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_id": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_secret": {
+                    "type": "string"
+                },
+                "wechat_official_account_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_official_account_is_enabled": {
+                    "description": "WechatOfficialAccount",
+                    "type": "boolean"
+                },
+                "wechat_official_account_token": {
+                    "type": "string"
+                },
+                "wechat_service_contain_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_corpid": {
+                    "type": "string"
+                },
+                "wechat_service_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_service_equal_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_is_enabled": {
+                    "description": "WechatServiceBot",
+                    "type": "boolean"
+                },
+                "wechat_service_secret": {
+                    "type": "string"
+                },
+                "wechat_service_token": {
+                    "type": "string"
+                },
+                "wecom_ai_bot_settings": {
+                    "$ref": "#/definitions/domain.WecomAIBotSettings"
+                },
+                "welcome_str": {
+                    "description": "welcome",
+                    "type": "string"
+                },
+                "widget_bot_settings": {
+                    "description": "WidgetBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WidgetBotSettings"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.AppType": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2,
+                3,
+                4,
+                5,
+                6,
+                7,
+                8,
+                9,
+                10,
+                11,
+                12
+            ],
+            "x-enum-varnames": [
+                "AppTypeWeb",
+                "AppTypeWidget",
+                "AppTypeDingTalkBot",
+                "AppTypeFeishuBot",
+                "AppTypeWechatBot",
+                "AppTypeWechatServiceBot",
+                "AppTypeDisCordBot",
+                "AppTypeWechatOfficialAccount",
+                "AppTypeOpenAIAPI",
+                "AppTypeWecomAIBot",
+                "AppTypeLarkBot",
+                "AppTypeMcpServer"
+            ]
+        },
+        "domain.AuthUserInfo": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BannerConfig": {
+            "type": "object",
+            "properties": {
+                "bg_url": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "href": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "text": {
+                                "type": "string"
+                            },
+                            "type": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "hot_search": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "placeholder": {
+                    "type": "string"
+                },
+                "subtitle": {
+                    "type": "string"
+                },
+                "subtitle_color": {
+                    "type": "string"
+                },
+                "subtitle_font_size": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                },
+                "title_font_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BasicDocConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BatchMoveReq": {
+            "type": "object",
+            "required": [
+                "ids",
+                "kb_id"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BlockGridConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "url": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BrandGroup": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Link"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BrowserCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CarouselConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "desc": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "title": {
+                                "type": "string"
+                            },
+                            "url": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CaseConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "link": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CatalogSettings": {
+            "type": "object",
+            "properties": {
+                "catalog_folder": {
+                    "description": "1: 展开, 2: 折叠, default: 1",
+                    "type": "integer"
+                },
+                "catalog_visible": {
+                    "description": "1: 显示, 2: 隐藏, default: 1",
+                    "type": "integer"
+                },
+                "catalog_width": {
+                    "description": "200 - 300, default: 260",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ChatRequest": {
+            "type": "object",
+            "required": [
+                "app_type",
+                "message"
+            ],
+            "properties": {
+                "app_type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AppType"
+                        }
+                    ]
+                },
+                "captcha_token": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "Filter restricts retrieval to documents with these tags and custom field values",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.NodeMetaFilter"
+                        }
+                    ]
+                },
+                "locale": {
+                    "description": "Locale of the asker, retrieval prefers the translations in this language",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatSearchReq": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "captcha_token": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/domain.NodeMetaFilter"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatSearchResp": {
+            "type": "object",
+            "properties": {
+                "node_result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeContentChunkSSE"
+                    }
+                }
+            }
+        },
+        "domain.CommentConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "avatar": {
+                                "type": "string"
+                            },
+                            "comment": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "profession": {
+                                "type": "string"
+                            },
+                            "user_name": {
+                                "type": "string"
+                            }
                         }
                     }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "anydoc.Child": {
+        },
+        "domain.CommentInfo": {
+            "type": "object",
+            "properties": {
+                "auth_user_id": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "avatar",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentListItem": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "$ref": "#/definitions/domain.CommentInfo"
+                },
+                "ip_address": {
+                    "description": "ip地址",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.IPAddress"
+                        }
+                    ]
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "description": "文档标题",
+                    "type": "string"
+                },
+                "node_type": {
+                    "type": "integer"
+                },
+                "root_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "status : -1 reject 0 pending 1 accept",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CommentStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.CommentReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "node_id",
+                "pic_urls"
+            ],
+            "properties": {
+                "captcha_token": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "pic_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "root_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentStatus": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                -1,
+                0,
+                1
+            ],
+            "x-enum-varnames": [
+                "CommentStatusReject",
+                "CommentStatusPending",
+                "CommentStatusAccepted"
+            ]
+        },
+        "domain.CompleteReq": {
+            "type": "object",
+            "properties": {
+                "prefix": {
+                    "description": "For FIM (Fill in Middle) style completion",
+                    "type": "string"
+                },
+                "suffix": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContributeSettings": {
+            "type": "object",
+            "properties": {
+                "is_enable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ConversationDetailResp": {
             "type": "object",
             "properties": {
-                "children": {
+                "app_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "messages": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/anydoc.Child"
+                        "$ref": "#/definitions/domain.ConversationMessage"
                     }
                 },
-                "value": {
-                    "$ref": "#/definitions/anydoc.Value"
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationReference"
+                    }
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
                 }
             }
         },
-        "anydoc.Value": {
+        "domain.ConversationInfo": {
             "type": "object",
             "properties": {
-                "file": {
-                    "type": "boolean"
+                "user_info": {
+                    "$ref": "#/definitions/domain.UserInfo"
+                }
+            }
+        },
+        "domain.ConversationListItem": {
+            "type": "object",
+            "properties": {
+                "app_name": {
+                    "type": "string"
                 },
-                "file_type": {
+                "app_type": {
+                    "$ref": "#/definitions/domain.AppType"
+                },
+                "created_at": {
                     "type": "string"
                 },
+                "feedback_info": {
+                    "description": "用户反馈信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
                 "id": {
                     "type": "string"
                 },
-                "summary": {
+                "info": {
+                    "description": "用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConversationInfo"
+                        }
+                    ]
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "remote_ip": {
                     "type": "string"
                 },
-                "title": {
+                "subject": {
                     "type": "string"
                 }
             }
         },
-        "consts.AuthType": {
-            "type": "string",
-            "enum": [
-                "",
-                "simple",
-                "enterprise"
-            ],
-            "x-enum-comments": {
-                "AuthTypeEnterprise": "企业认证",
-                "AuthTypeNull": "无认证",
-                "AuthTypeSimple": "简单口令"
-            },
-            "x-enum-descriptions": [
-                "无认证",
-                "简单口令",
-                "企业认证"
-            ],
-            "x-enum-varnames": [
-                "AuthTypeNull",
-                "AuthTypeSimple",
-                "AuthTypeEnterprise"
-            ]
+        "domain.ConversationMessage": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "description": "feedbackinfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "parent_id",
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "description": "model",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                        }
+                    ]
+                },
+                "remote_ip": {
+                    "description": "stats",
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/schema.RoleType"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
         },
-        "consts.CopySetting": {
-            "type": "string",
-            "enum": [
-                "",
-                "append",
-                "disabled"
-            ],
-            "x-enum-comments": {
-                "CopySettingAppend": "增加内容尾巴",
-                "CopySettingDisabled": "禁止复制内容",
-                "CopySettingNone": "无限制"
-            },
-            "x-enum-descriptions": [
-                "无限制",
-                "增加内容尾巴",
-                "禁止复制内容"
-            ],
-            "x-enum-varnames": [
-                "CopySettingNone",
-                "CopySettingAppend",
-                "CopySettingDisabled"
-            ]
+        "domain.ConversationMessageListItem": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "app_type": {
+                    "$ref": "#/definitions/domain.AppType"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "conversation_info": {
+                    "description": "userInfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConversationInfo"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "description": "feedbackInfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "description": "stats",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationReference": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationSetting": {
+            "type": "object",
+            "properties": {
+                "copyright_hide_enabled": {
+                    "type": "boolean"
+                },
+                "copyright_info": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.CrawlerSource": {
-            "type": "string",
-            "enum": [
-                "url",
-                "rss",
-                "sitemap",
-                "notion",
-                "feishu",
-                "file",
-                "epub",
-                "yuque",
-                "siyuan",
-                "mindoc",
-                "wikijs",
-                "confluence"
-            ],
-            "x-enum-varnames": [
-                "CrawlerSourceUrl",
-                "CrawlerSourceRSS",
-                "CrawlerSourceSitemap",
-                "CrawlerSourceNotion",
-                "CrawlerSourceFeishu",
-                "CrawlerSourceFile",
-                "CrawlerSourceEpub",
-                "CrawlerSourceYuque",
-                "CrawlerSourceSiyuan",
-                "CrawlerSourceMindoc",
-                "CrawlerSourceWikijs",
-                "CrawlerSourceConfluence"
-            ]
+        "domain.CrawlerSyncDocError": {
+            "type": "object",
+            "properties": {
+                "doc_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.CrawlerStatus": {
+        "domain.CrawlerSyncRunStatus": {
             "type": "string",
             "enum": [
-                "pending",
-                "in_process",
+                "running",
                 "completed",
                 "failed"
             ],
             "x-enum-varnames": [
-                "CrawlerStatusPending",
-                "CrawlerStatusInProcess",
-                "CrawlerStatusCompleted",
-                "CrawlerStatusFailed"
+                "CrawlerSyncRunStatusRunning",
+                "CrawlerSyncRunStatusCompleted",
+                "CrawlerSyncRunStatusFailed"
             ]
         },
-        "consts.HomePageSetting": {
+        "domain.CrawlerSyncStatus": {
             "type": "string",
             "enum": [
-                "doc",
-                "custom"
-            ],
-            "x-enum-comments": {
-                "HomePageSettingCustom": "自定义首页",
-                "HomePageSettingDoc": "文档页面"
-            },
-            "x-enum-descriptions": [
-                "文档页面",
-                "自定义首页"
-            ],
-            "x-enum-varnames": [
-                "HomePageSettingDoc",
-                "HomePageSettingCustom"
-            ]
-        },
-        "consts.LicenseEdition": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                0,
-                1,
-                2,
-                3
+                "idle",
+                "queued",
+                "running"
             ],
             "x-enum-comments": {
-                "LicenseEditionBusiness": "商业版",
-                "LicenseEditionEnterprise": "企业版",
-                "LicenseEditionFree": "开源版",
-                "LicenseEditionProfession": "专业版"
+                "CrawlerSyncStatusQueued": "run requested, picked up by the consumer within a minute"
             },
             "x-enum-descriptions": [
-                "开源版",
-                "专业版",
-                "企业版",
-                "商业版"
+                "run requested, picked up by the consumer within a minute"
             ],
             "x-enum-varnames": [
-                "LicenseEditionFree",
-                "LicenseEditionProfession",
-                "LicenseEditionEnterprise",
-                "LicenseEditionBusiness"
+                "CrawlerSyncStatusIdle",
+                "CrawlerSyncStatusQueued",
+                "CrawlerSyncStatusRunning"
             ]
         },
-        "consts.ModelSettingMode": {
+        "domain.CrawlerSyncTrigger": {
             "type": "string",
             "enum": [
                 "manual",
-                "auto"
+                "schedule"
             ],
             "x-enum-varnames": [
-                "ModelSettingModeManual",
-                "ModelSettingModeAuto"
+                "CrawlerSyncTriggerManual",
+                "CrawlerSyncTriggerSchedule"
             ]
         },
-        "consts.NodeAccessPerm": {
-            "type": "string",
-            "enum": [
-                "open",
-                "partial",
-                "closed"
-            ],
-            "x-enum-comments": {
-                "NodeAccessPermClosed": "完全禁止",
-                "NodeAccessPermOpen": "完全开放",
-                "NodeAccessPermPartial": "部分开放"
-            },
-            "x-enum-descriptions": [
-                "完全开放",
-                "部分开放",
-                "完全禁止"
+        "domain.CreateKBReleaseReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "message",
+                "tag"
             ],
-            "x-enum-varnames": [
-                "NodeAccessPermOpen",
-                "NodeAccessPermPartial",
-                "NodeAccessPermClosed"
-            ]
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "node_ids": {
+                    "description": "create release after these nodes published",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.NodePermName": {
-            "type": "string",
-            "enum": [
-                "visible",
-                "visitable",
-                "answerable"
-            ],
-            "x-enum-comments": {
-                "NodePermNameAnswerable": "可被问答",
-                "NodePermNameVisible": "导航内可见",
-                "NodePermNameVisitable": "可被访问"
-            },
-            "x-enum-descriptions": [
-                "导航内可见",
-                "可被访问",
-                "可被问答"
+        "domain.CreateKnowledgeBaseReq": {
+            "type": "object",
+            "required": [
+                "name"
             ],
-            "x-enum-varnames": [
-                "NodePermNameVisible",
-                "NodePermNameVisitable",
-                "NodePermNameAnswerable"
-            ]
+            "properties": {
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "private_key": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "ssl_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
         },
-        "consts.NodeRagInfoStatus": {
-            "type": "string",
-            "enum": [
-                "BASIC_PENDING",
-                "BASIC_RUNNING",
-                "BASIC_FAILED",
-                "BASIC_SUCCEEDED",
-                "ENHANCE_PENDING",
-                "ENHANCE_RUNNING",
-                "ENHANCE_FAILED",
-                "ENHANCE_SUCCEEDED"
-            ],
-            "x-enum-comments": {
-                "NodeRagStatusBasicFailed": "基础处理失败",
-                "NodeRagStatusBasicPending": "等待基础处理",
-                "NodeRagStatusBasicRunning": "正在进行基础处理（文本分割、向量化等）",
-                "NodeRagStatusBasicSucceeded": "基础处理成功",
-                "NodeRagStatusEnhanceFailed": "增强处理失败",
-                "NodeRagStatusEnhancePending": "基础处理完成，等待增强处理",
-                "NodeRagStatusEnhanceRunning": "正在进行增强处理（关键词提取等）",
-                "NodeRagStatusEnhanceSucceeded": "增强处理成功"
-            },
-            "x-enum-descriptions": [
-                "等待基础处理",
-                "正在进行基础处理（文本分割、向量化等）",
-                "基础处理失败",
-                "基础处理成功",
-                "基础处理完成，等待增强处理",
-                "正在进行增强处理（关键词提取等）",
-                "增强处理失败",
-                "增强处理成功"
+        "domain.CreateModelReq": {
+            "type": "object",
+            "required": [
+                "base_url",
+                "model",
+                "provider",
+                "type"
             ],
-            "x-enum-varnames": [
-                "NodeRagStatusBasicPending",
-                "NodeRagStatusBasicRunning",
-                "NodeRagStatusBasicFailed",
-                "NodeRagStatusBasicSucceeded",
-                "NodeRagStatusEnhancePending",
-                "NodeRagStatusEnhanceRunning",
-                "NodeRagStatusEnhanceFailed",
-                "NodeRagStatusEnhanceSucceeded"
-            ]
+            "properties": {
+                "api_header": {
+                    "type": "string"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "api_version": {
+                    "description": "for azure openai",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                },
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
         },
-        "consts.RedeemCaptchaReq": {
+        "domain.CreateNodeReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "name",
+                "type"
+            ],
             "properties": {
-                "solutions": {
+                "content": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "number"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "token": {
+                "template_id": {
+                    "description": "TemplateID fills content, emoji, summary and content type from a node template",
                     "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.NodeType"
+                        }
+                    ]
                 }
             }
         },
-        "consts.SourceType": {
-            "type": "string",
-            "enum": [
-                "dingtalk",
-                "feishu",
-                "wecom",
-                "oauth",
-                "github",
-                "cas",
-                "ldap",
-                "widget",
-                "dingtalk_bot",
-                "feishu_bot",
-                "lark_bot",
-                "wechat_bot",
-                "wecom_ai_bot",
-                "wechat_service_bot",
-                "discord_bot",
-                "wechat_official_account",
-                "openai_api",
-                "mcp_server"
-            ],
-            "x-enum-varnames": [
-                "SourceTypeDingTalk",
-                "SourceTypeFeishu",
-                "SourceTypeWeCom",
-                "SourceTypeOAuth",
-                "SourceTypeGitHub",
-                "SourceTypeCAS",
-                "SourceTypeLDAP",
-                "SourceTypeWidget",
-                "SourceTypeDingtalkBot",
-                "SourceTypeFeishuBot",
-                "SourceTypeLarkBot",
-                "SourceTypeWechatBot",
-                "SourceTypeWecomAIBot",
-                "SourceTypeWechatServiceBot",
-                "SourceTypeDiscordBot",
-                "SourceTypeWechatOfficialAccount",
-                "SourceTypeOpenAIAPI",
-                "SourceTypeMcpServer"
-            ]
+        "domain.DirDocConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.StatDay": {
-            "type": "integer",
-            "enum": [
-                1,
-                7,
-                30,
-                90
-            ],
-            "x-enum-varnames": [
-                "StatDay1",
-                "StatDay7",
-                "StatDay30",
-                "StatDay90"
-            ]
+        "domain.DisclaimerSettings": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.UserKBPermission": {
+        "domain.DocumentFeedbackInfo": {
+            "type": "object",
+            "properties": {
+                "auth_user_id": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DocumentFeedbackReason": {
             "type": "string",
             "enum": [
-                "",
-                "full_control",
-                "doc_manage",
-                "data_operate"
-            ],
-            "x-enum-comments": {
-                "UserKBPermissionDataOperate": "数据运营",
-                "UserKBPermissionDocManage": "文档管理",
-                "UserKBPermissionFullControl": "完全控制",
-                "UserKBPermissionNull": "无权限"
-            },
-            "x-enum-descriptions": [
-                "无权限",
-                "完全控制",
-                "文档管理",
-                "数据运营"
+                "inaccurate",
+                "outdated",
+                "unclear",
+                "incomplete",
+                "broken_link",
+                "other"
             ],
             "x-enum-varnames": [
-                "UserKBPermissionNull",
-                "UserKBPermissionFullControl",
-                "UserKBPermissionDocManage",
-                "UserKBPermissionDataOperate"
+                "DocumentFeedbackReasonInaccurate",
+                "DocumentFeedbackReasonOutdated",
+                "DocumentFeedbackReasonUnclear",
+                "DocumentFeedbackReasonIncomplete",
+                "DocumentFeedbackReasonBrokenLink",
+                "DocumentFeedbackReasonOther"
             ]
         },
-        "consts.UserRole": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "user"
-            ],
-            "x-enum-comments": {
-                "UserRoleAdmin": "管理员",
-                "UserRoleUser": "普通用户"
-            },
-            "x-enum-descriptions": [
-                "管理员",
-                "普通用户"
+        "domain.DocumentFeedbackReq": {
+            "type": "object",
+            "required": [
+                "node_id"
             ],
-            "x-enum-varnames": [
-                "UserRoleAdmin",
-                "UserRoleUser"
-            ]
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "enum": [
+                        "inaccurate",
+                        "outdated",
+                        "unclear",
+                        "incomplete",
+                        "broken_link",
+                        "other"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DocumentFeedbackReason"
+                        }
+                    ]
+                },
+                "score": {
+                    "enum": [
+                        1,
+                        -1
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreType"
+                        }
+                    ]
+                }
+            }
         },
-        "consts.WatermarkSetting": {
+        "domain.DocumentFeedbackStatus": {
             "type": "string",
             "enum": [
-                "",
-                "hidden",
-                "visible"
-            ],
-            "x-enum-comments": {
-                "WatermarkDisabled": "未开启水印",
-                "WatermarkHidden": "隐形水印",
-                "WatermarkVisible": "显性水印"
-            },
-            "x-enum-descriptions": [
-                "未开启水印",
-                "隐形水印",
-                "显性水印"
+                "open",
+                "resolved"
             ],
             "x-enum-varnames": [
-                "WatermarkDisabled",
-                "WatermarkHidden",
-                "WatermarkVisible"
+                "DocumentFeedbackStatusOpen",
+                "DocumentFeedbackStatusResolved"
             ]
         },
-        "domain.AIFeedbackSettings": {
+        "domain.EnterpriseAuth": {
             "type": "object",
             "properties": {
-                "ai_feedback_type": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ExportSettings": {
+            "type": "object",
+            "properties": {
+                "epub_enabled": {
+                    "type": "boolean"
                 },
-                "is_enabled": {
+                "pdf_enabled": {
                     "type": "boolean"
                 }
             }
         },
-        "domain.AccessSettings": {
+        "domain.FaqConfig": {
             "type": "object",
             "properties": {
-                "base_url": {
+                "bg_color": {
                     "type": "string"
                 },
-                "enterprise_auth": {
-                    "$ref": "#/definitions/domain.EnterpriseAuth"
-                },
-                "hosts": {
+                "list": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "link": {
+                                "type": "string"
+                            },
+                            "question": {
+                                "type": "string"
+                            }
+                        }
                     }
                 },
-                "is_forbidden": {
-                    "description": "禁止访问",
-                    "type": "boolean"
+                "title": {
+                    "type": "string"
                 },
-                "ports": {
+                "title_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FeatureConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "object",
+                        "properties": {
+                            "desc": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            }
+                        }
                     }
                 },
-                "private_key": {
+                "title": {
                     "type": "string"
                 },
-                "public_key": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FeedBackInfo": {
+            "type": "object",
+            "properties": {
+                "feedback_content": {
                     "type": "string"
                 },
-                "simple_auth": {
-                    "$ref": "#/definitions/domain.SimpleAuth"
+                "feedback_type": {
+                    "type": "string"
                 },
-                "source_type": {
-                    "description": "企业认证来源",
+                "score": {
+                    "$ref": "#/definitions/domain.ScoreType"
+                }
+            }
+        },
+        "domain.FeedbackRequest": {
+            "type": "object",
+            "required": [
+                "message_id"
+            ],
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "feedback_content": {
+                    "description": "限制内容长度",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "-1 踩 ,0 1 赞成",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/consts.SourceType"
+                            "$ref": "#/definitions/domain.ScoreType"
                         }
                     ]
                 },
-                "ssl_ports": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "type": {
+                    "description": "内容不准确，没有帮助，.......",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FooterSettings": {
+            "type": "object",
+            "properties": {
+                "brand_desc": {
+                    "type": "string"
                 },
-                "trusted_proxies": {
+                "brand_groups": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.BrandGroup"
                     }
+                },
+                "brand_logo": {
+                    "type": "string"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "corp_name": {
+                    "type": "string"
+                },
+                "footer_style": {
+                    "type": "string"
+                },
+                "icp": {
+                    "type": "string"
                 }
             }
         },
-        "domain.AnydocUploadResp": {
+        "domain.GetKBReleaseListResp": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
-                },
                 "data": {
-                    "type": "string"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.KBReleaseListItemResp"
+                    }
                 },
-                "err": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.AppDetailResp": {
+        "domain.GetProviderModelListReq": {
             "type": "object",
+            "required": [
+                "base_url",
+                "provider",
+                "type"
+            ],
             "properties": {
-                "id": {
+                "api_header": {
                     "type": "string"
                 },
-                "kb_id": {
+                "api_key": {
                     "type": "string"
                 },
-                "name": {
+                "base_url": {
                     "type": "string"
                 },
-                "recommend_nodes": {
+                "provider": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.GetProviderModelListResp": {
+            "type": "object",
+            "properties": {
+                "models": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "$ref": "#/definitions/domain.ProviderModelListItem"
                     }
+                }
+            }
+        },
+        "domain.GitAuthType": {
+            "type": "string",
+            "enum": [
+                "none",
+                "https",
+                "ssh"
+            ],
+            "x-enum-comments": {
+                "GitAuthTypeHTTPS": "username and password or token",
+                "GitAuthTypeSSH": "private key"
+            },
+            "x-enum-descriptions": [
+                "username and password or token",
+                "private key"
+            ],
+            "x-enum-varnames": [
+                "GitAuthTypeNone",
+                "GitAuthTypeHTTPS",
+                "GitAuthTypeSSH"
+            ]
+        },
+        "domain.GitSyncResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettingsResp"
+                "deleted": {
+                    "type": "integer"
                 },
-                "type": {
-                    "$ref": "#/definitions/domain.AppType"
+                "updated": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "domain.AppInfoResp": {
+        "domain.GitSyncStatus": {
+            "type": "string",
+            "enum": [
+                "idle",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "GitSyncStatusIdle",
+                "GitSyncStatusRunning",
+                "GitSyncStatusCompleted",
+                "GitSyncStatusFailed"
+            ]
+        },
+        "domain.HotBrowser": {
             "type": "object",
             "properties": {
-                "base_url": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "browser": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BrowserCount"
+                    }
                 },
-                "recommend_nodes": {
+                "os": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "$ref": "#/definitions/domain.BrowserCount"
                     }
+                }
+            }
+        },
+        "domain.HotPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettingsResp"
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "scene": {
+                    "$ref": "#/definitions/domain.StatPageScene"
                 }
             }
         },
-        "domain.AppSettings": {
+        "domain.HotRefererHost": {
             "type": "object",
             "properties": {
-                "ai_feedback_settings": {
-                    "description": "AI feedback",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.AIFeedbackSettings"
-                        }
-                    ]
+                "count": {
+                    "type": "integer"
                 },
-                "body_code": {
+                "referer_host": {
                     "type": "string"
-                },
-                "btns": {
-                    "type": "array",
-                    "items": {}
-                },
-                "catalog_settings": {
-                    "description": "catalog settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CatalogSettings"
-                        }
-                    ]
-                },
-                "contribute_settings": {
-                    "$ref": "#/definitions/domain.ContributeSettings"
-                },
-                "conversation_setting": {
-                    "$ref": "#/definitions/domain.ConversationSetting"
-                },
-                "copy_setting": {
-                    "enum": [
-                        "",
-                        "append",
-                        "disabled"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.CopySetting"
-                        }
-                    ]
-                },
-                "desc": {
-                    "description": "seo",
+                }
+            }
+        },
+        "domain.IPAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_id": {
+                "country": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_secret": {
+                "ip": {
                     "type": "string"
                 },
-                "dingtalk_bot_is_enabled": {
-                    "description": "DingTalkBot",
-                    "type": "boolean"
-                },
-                "dingtalk_bot_template_id": {
+                "province": {
                     "type": "string"
-                },
-                "disclaimer_settings": {
-                    "description": "Disclaimer Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.DisclaimerSettings"
+                }
+            }
+        },
+        "domain.ImgTextConfig": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "properties": {
+                        "desc": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "url": {
+                            "type": "string"
                         }
-                    ]
-                },
-                "discord_bot_is_enabled": {
-                    "description": "DisCordBot",
-                    "type": "boolean"
+                    }
                 },
-                "discord_bot_token": {
+                "title": {
                     "type": "string"
                 },
-                "document_feedback_is_enabled": {
-                    "description": "document feedback",
-                    "type": "boolean"
-                },
-                "feishu_bot_app_id": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.InstantCountResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "feishu_bot_app_secret": {
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InstantPageResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "feishu_bot_is_enabled": {
-                    "description": "FeishuBot",
-                    "type": "boolean"
-                },
-                "footer_settings": {
-                    "description": "footer settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FooterSettings"
-                        }
-                    ]
+                "info": {
+                    "$ref": "#/definitions/domain.AuthUserInfo"
                 },
-                "head_code": {
-                    "description": "inject code",
+                "ip": {
                     "type": "string"
                 },
-                "home_page_setting": {
-                    "$ref": "#/definitions/consts.HomePageSetting"
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
                 },
-                "icon": {
+                "node_id": {
                     "type": "string"
                 },
-                "keyword": {
+                "node_name": {
                     "type": "string"
                 },
-                "lark_bot_settings": {
-                    "description": "LarkBot",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.LarkBotSettings"
-                        }
-                    ]
-                },
-                "mcp_server_settings": {
-                    "description": "MCP Server Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.MCPServerSettings"
-                        }
-                    ]
+                "scene": {
+                    "$ref": "#/definitions/domain.StatPageScene"
                 },
-                "openai_api_bot_settings": {
-                    "description": "OpenAI API Bot settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
-                        }
-                    ]
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KBCrawlerSyncRun": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "deleted": {
+                    "type": "integer"
                 },
-                "recommend_questions": {
+                "doc_errors": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.CrawlerSyncDocError"
                     }
                 },
-                "search_placeholder": {
+                "error": {
                     "type": "string"
                 },
-                "stats_setting": {
-                    "$ref": "#/definitions/domain.StatsSetting"
-                },
-                "theme_and_style": {
-                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                "failed": {
+                    "type": "integer"
                 },
-                "theme_mode": {
-                    "description": "theme",
+                "finished_at": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "nav",
+                "id": {
                     "type": "string"
                 },
-                "watermark_content": {
+                "kb_id": {
                     "type": "string"
                 },
-                "watermark_setting": {
-                    "enum": [
-                        "",
-                        "hidden",
-                        "visible"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.WatermarkSetting"
-                        }
-                    ]
-                },
-                "web_app_comment_settings": {
-                    "description": "webapp comment settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCommentSettings"
-                        }
-                    ]
-                },
-                "web_app_custom_style": {
-                    "description": "WebAppCustomStyle",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCustomSettings"
-                        }
-                    ]
-                },
-                "web_app_landing_configs": {
-                    "description": "WebAppLandingConfigs",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.WebAppLandingConfig"
-                    }
+                "release_id": {
+                    "description": "kb release created by auto publish",
+                    "type": "string"
                 },
-                "web_app_landing_theme": {
-                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                "started_at": {
+                    "type": "string"
                 },
-                "wechat_app_advanced_setting": {
-                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                "status": {
+                    "$ref": "#/definitions/domain.CrawlerSyncRunStatus"
                 },
-                "wechat_app_agent_id": {
+                "sync_id": {
                     "type": "string"
                 },
-                "wechat_app_corpid": {
-                    "type": "string"
+                "trigger": {
+                    "$ref": "#/definitions/domain.CrawlerSyncTrigger"
                 },
-                "wechat_app_encodingaeskey": {
-                    "type": "string"
+                "unchanged": {
+                    "type": "integer"
                 },
-                "wechat_app_is_enabled": {
-                    "description": "WechatAppBot 企业微信机器人",
-                    "type": "boolean"
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KBExport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "wechat_app_secret": {
+                "creator_id": {
                     "type": "string"
                 },
-                "wechat_app_token": {
+                "error": {
                     "type": "string"
                 },
-                "wechat_official_account_app_id": {
+                "file_size": {
+                    "type": "integer"
+                },
+                "finished_at": {
                     "type": "string"
                 },
-                "wechat_official_account_app_secret": {
+                "format": {
+                    "$ref": "#/definitions/domain.KBExportFormat"
+                },
+                "id": {
                     "type": "string"
                 },
-                "wechat_official_account_encodingaeskey": {
+                "kb_id": {
                     "type": "string"
                 },
-                "wechat_official_account_is_enabled": {
-                    "description": "WechatOfficialAccount",
-                    "type": "boolean"
+                "node_count": {
+                    "type": "integer"
                 },
-                "wechat_official_account_token": {
+                "root_id": {
+                    "description": "folder to export, whole kb when empty",
                     "type": "string"
                 },
-                "wechat_service_contain_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "wechat_service_corpid": {
+                "status": {
+                    "$ref": "#/definitions/domain.KBExportStatus"
+                }
+            }
+        },
+        "domain.KBExportFormat": {
+            "type": "string",
+            "enum": [
+                "markdown",
+                "epub",
+                "pdf"
+            ],
+            "x-enum-comments": {
+                "KBExportFormatEPUB": "published content",
+                "KBExportFormatMarkdown": "zip of markdown files, re-importable",
+                "KBExportFormatPDF": "published content, rendered from html"
+            },
+            "x-enum-descriptions": [
+                "zip of markdown files, re-importable",
+                "published content",
+                "published content, rendered from html"
+            ],
+            "x-enum-varnames": [
+                "KBExportFormatMarkdown",
+                "KBExportFormatEPUB",
+                "KBExportFormatPDF"
+            ]
+        },
+        "domain.KBExportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "KBExportStatusPending",
+                "KBExportStatusRunning",
+                "KBExportStatusCompleted",
+                "KBExportStatusFailed"
+            ]
+        },
+        "domain.KBImport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "wechat_service_encodingaeskey": {
+                "creator_id": {
                     "type": "string"
                 },
-                "wechat_service_equal_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "error": {
+                    "type": "string"
                 },
-                "wechat_service_is_enabled": {
-                    "description": "WechatServiceBot",
-                    "type": "boolean"
+                "finished_at": {
+                    "type": "string"
                 },
-                "wechat_service_secret": {
+                "id": {
                     "type": "string"
                 },
-                "wechat_service_token": {
+                "kb_id": {
                     "type": "string"
                 },
-                "wecom_ai_bot_settings": {
-                    "description": "WecomAIBotSettings 企业微信智能机器人",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WecomAIBotSettings"
-                        }
-                    ]
+                "mode": {
+                    "$ref": "#/definitions/domain.KBImportMode"
                 },
-                "welcome_str": {
-                    "description": "welcome",
+                "parent_id": {
                     "type": "string"
                 },
-                "widget_bot_settings": {
-                    "description": "Widget bot settings",
+                "preserve_ids": {
+                    "type": "boolean"
+                },
+                "result": {
+                    "description": "set when completed",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.WidgetBotSettings"
+                            "$ref": "#/definitions/domain.KBImportResult"
                         }
                     ]
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.KBExportStatus"
                 }
             }
         },
-        "domain.AppSettingsResp": {
+        "domain.KBImportMode": {
+            "type": "string",
+            "enum": [
+                "skip",
+                "overwrite",
+                "merge"
+            ],
+            "x-enum-comments": {
+                "KBImportModeMerge": "replace existing nodes only when the archive is newer",
+                "KBImportModeOverwrite": "replace existing nodes with the archive",
+                "KBImportModeSkip": "keep existing nodes"
+            },
+            "x-enum-descriptions": [
+                "keep existing nodes",
+                "replace existing nodes with the archive",
+                "replace existing nodes only when the archive is newer"
+            ],
+            "x-enum-varnames": [
+                "KBImportModeSkip",
+                "KBImportModeOverwrite",
+                "KBImportModeMerge"
+            ]
+        },
+        "domain.KBImportResult": {
             "type": "object",
             "properties": {
-                "ai_feedback_settings": {
-                    "description": "AI feedback",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.AIFeedbackSettings"
-                        }
-                    ]
-                },
-                "body_code": {
-                    "type": "string"
-                },
-                "btns": {
-                    "type": "array",
-                    "items": {}
-                },
-                "catalog_settings": {
-                    "description": "catalog settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CatalogSettings"
-                        }
-                    ]
+                "created": {
+                    "type": "integer"
                 },
-                "contribute_settings": {
-                    "$ref": "#/definitions/domain.ContributeSettings"
+                "id_map": {
+                    "description": "archive node id -\u003e node id in kb",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "conversation_setting": {
-                    "$ref": "#/definitions/domain.ConversationSetting"
+                "skipped": {
+                    "type": "integer"
                 },
-                "copy_setting": {
-                    "$ref": "#/definitions/consts.CopySetting"
+                "updated": {
+                    "type": "integer"
                 },
-                "desc": {
-                    "description": "seo",
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.KBOpenAPISpec": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_id": {
+                "creator_id": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_secret": {
+                "id": {
                     "type": "string"
                 },
-                "dingtalk_bot_is_enabled": {
-                    "description": "DingTalkBot",
-                    "type": "boolean"
-                },
-                "dingtalk_bot_template_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "disclaimer_settings": {
-                    "description": "Disclaimer Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.DisclaimerSettings"
-                        }
-                    ]
-                },
-                "discord_bot_is_enabled": {
-                    "description": "DisCordBot",
-                    "type": "boolean"
-                },
-                "discord_bot_token": {
+                "last_imported_at": {
                     "type": "string"
                 },
-                "document_feedback_is_enabled": {
-                    "description": "document feedback",
-                    "type": "boolean"
+                "last_result": {
+                    "$ref": "#/definitions/domain.OpenAPIImportResult"
                 },
-                "feishu_bot_app_id": {
+                "name": {
                     "type": "string"
                 },
-                "feishu_bot_app_secret": {
+                "parent_id": {
+                    "description": "folder the tag folders are created in, root when empty",
                     "type": "string"
                 },
-                "feishu_bot_is_enabled": {
-                    "description": "FeishuBot",
-                    "type": "boolean"
-                },
-                "footer_settings": {
-                    "description": "footer settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FooterSettings"
-                        }
-                    ]
+                "title": {
+                    "description": "info.title of the last imported spec",
+                    "type": "string"
                 },
-                "head_code": {
-                    "description": "inject code",
+                "updated_at": {
                     "type": "string"
                 },
-                "home_page_setting": {
-                    "$ref": "#/definitions/consts.HomePageSetting"
+                "version": {
+                    "description": "info.version of the last imported spec",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KBReleaseListItemResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "icon": {
+                "id": {
                     "type": "string"
                 },
-                "keyword": {
+                "kb_id": {
                     "type": "string"
                 },
-                "lark_bot_settings": {
-                    "description": "LarkBot",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.LarkBotSettings"
-                        }
-                    ]
+                "message": {
+                    "type": "string"
                 },
-                "mcp_server_settings": {
-                    "description": "MCP Server Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.MCPServerSettings"
-                        }
-                    ]
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KBWebhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "openai_api_bot_settings": {
-                    "description": "OpenAI API settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
-                        }
-                    ]
+                "creator_id": {
+                    "type": "string"
                 },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "enabled": {
+                    "type": "boolean"
                 },
-                "recommend_questions": {
+                "events": {
+                    "description": "subscribed to all events when empty",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "search_placeholder": {
-                    "type": "string"
-                },
-                "stats_setting": {
-                    "$ref": "#/definitions/domain.StatsSetting"
+                "id": {
+                    "type": "string"
                 },
-                "theme_and_style": {
-                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                "kb_id": {
+                    "type": "string"
                 },
-                "theme_mode": {
-                    "description": "theme",
+                "name": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "nav",
+                "updated_at": {
                     "type": "string"
                 },
-                "watermark_content": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.KBWebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
                 },
-                "watermark_setting": {
-                    "$ref": "#/definitions/consts.WatermarkSetting"
+                "created_at": {
+                    "type": "string"
                 },
-                "web_app_comment_settings": {
-                    "description": "webapp comment settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCommentSettings"
-                        }
-                    ]
+                "duration": {
+                    "description": "ms",
+                    "type": "integer"
                 },
-                "web_app_custom_style": {
-                    "description": "WebAppCustomStyle",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCustomSettings"
-                        }
-                    ]
+                "error": {
+                    "type": "string"
                 },
-                "web_app_landing_configs": {
-                    "description": "WebApp Landing Settings",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.WebAppLandingConfigResp"
-                    }
+                "event": {
+                    "$ref": "#/definitions/domain.WebhookEvent"
                 },
-                "web_app_landing_theme": {
-                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                "id": {
+                    "type": "string"
                 },
-                "wechat_app_advanced_setting": {
-                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                "kb_id": {
+                    "type": "string"
                 },
-                "wechat_app_agent_id": {
+                "next_retry_at": {
                     "type": "string"
                 },
-                "wechat_app_corpid": {
+                "payload": {
+                    "description": "request body, the same body is sent again on retries",
                     "type": "string"
                 },
-                "wechat_app_encodingaeskey": {
+                "response_body": {
+                    "description": "truncated",
                     "type": "string"
                 },
-                "wechat_app_is_enabled": {
-                    "description": "WechatAppBot",
-                    "type": "boolean"
+                "response_code": {
+                    "description": "result of the last attempt",
+                    "type": "integer"
                 },
-                "wechat_app_secret": {
+                "status": {
+                    "$ref": "#/definitions/domain.WebhookDeliveryStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "wechat_app_token": {
+                "webhook_id": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.KnowledgeBaseDetail": {
+            "type": "object",
+            "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
                 },
-                "wechat_official_account_app_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "wechat_official_account_app_secret": {
+                "dataset_id": {
                     "type": "string"
                 },
-                "wechat_official_account_encodingaeskey": {
+                "id": {
                     "type": "string"
                 },
-                "wechat_official_account_is_enabled": {
-                    "description": "WechatOfficialAccount",
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "wechat_official_account_token": {
+                "perm": {
+                    "description": "用户对知识库的权限",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.UserKBPermission"
+                        }
+                    ]
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.KnowledgeBaseListItem": {
+            "type": "object",
+            "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
                 },
-                "wechat_service_contain_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "wechat_service_corpid": {
+                "dataset_id": {
                     "type": "string"
                 },
-                "wechat_service_encodingaeskey": {
+                "id": {
                     "type": "string"
                 },
-                "wechat_service_equal_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "wechat_service_is_enabled": {
-                    "description": "WechatServiceBot",
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LLMsTxtSettings": {
+            "type": "object",
+            "properties": {
+                "is_enabled": {
                     "type": "boolean"
+                }
+            }
+        },
+        "domain.LarkBotSettings": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
                 },
-                "wechat_service_secret": {
+                "app_secret": {
                     "type": "string"
                 },
-                "wechat_service_token": {
+                "encrypt_key": {
                     "type": "string"
                 },
-                "wecom_ai_bot_settings": {
-                    "$ref": "#/definitions/domain.WecomAIBotSettings"
+                "is_enabled": {
+                    "type": "boolean"
                 },
-                "welcome_str": {
-                    "description": "welcome",
+                "verify_token": {
                     "type": "string"
-                },
-                "widget_bot_settings": {
-                    "description": "WidgetBot",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WidgetBotSettings"
-                        }
-                    ]
                 }
             }
         },
-        "domain.AppType": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2,
-                3,
-                4,
-                5,
-                6,
-                7,
-                8,
-                9,
-                10,
-                11,
-                12
-            ],
-            "x-enum-varnames": [
-                "AppTypeWeb",
-                "AppTypeWidget",
-                "AppTypeDingTalkBot",
-                "AppTypeFeishuBot",
-                "AppTypeWechatBot",
-                "AppTypeWechatServiceBot",
-                "AppTypeDisCordBot",
-                "AppTypeWechatOfficialAccount",
-                "AppTypeOpenAIAPI",
-                "AppTypeWecomAIBot",
-                "AppTypeLarkBot",
-                "AppTypeMcpServer"
-            ]
-        },
-        "domain.AuthUserInfo": {
+        "domain.Link": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "name": {
                     "type": "string"
                 },
-                "email": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.MCPServerSettings": {
+            "type": "object",
+            "properties": {
+                "docs_tool_settings": {
+                    "$ref": "#/definitions/domain.MCPToolSettings"
                 },
-                "username": {
-                    "type": "string"
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "sample_auth": {
+                    "$ref": "#/definitions/domain.SimpleAuth"
                 }
             }
         },
-        "domain.BannerConfig": {
+        "domain.MCPToolSettings": {
             "type": "object",
             "properties": {
-                "bg_url": {
+                "desc": {
                     "type": "string"
                 },
-                "btns": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MapStrInt64": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "integer",
+                "format": "int64"
+            }
+        },
+        "domain.MessageContent": {
+            "type": "object"
+        },
+        "domain.MessageFrom": {
+            "type": "integer",
+            "enum": [
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "MessageFromGroup",
+                "MessageFromPrivate"
+            ]
+        },
+        "domain.MetricsConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
                     "type": "array",
                     "items": {
                         "type": "object",
                         "properties": {
-                            "href": {
-                                "type": "string"
-                            },
                             "id": {
                                 "type": "string"
                             },
-                            "text": {
+                            "name": {
                                 "type": "string"
                             },
-                            "type": {
+                            "number": {
                                 "type": "string"
                             }
-                        }
-                    }
-                },
-                "hot_search": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                        }
                     }
                 },
-                "placeholder": {
+                "title": {
                     "type": "string"
                 },
-                "subtitle": {
+                "type": {
                     "type": "string"
-                },
-                "subtitle_color": {
+                }
+            }
+        },
+        "domain.ModelModeSetting": {
+            "type": "object",
+            "properties": {
+                "auto_mode_api_key": {
+                    "description": "百智云 API Key",
                     "type": "string"
                 },
-                "subtitle_font_size": {
-                    "type": "integer"
-                },
-                "title": {
+                "chat_model": {
+                    "description": "自定义对话模型名称",
                     "type": "string"
                 },
-                "title_color": {
-                    "type": "string"
+                "is_manual_embedding_updated": {
+                    "description": "手动模式下嵌入模型是否更新",
+                    "type": "boolean"
                 },
-                "title_font_size": {
-                    "type": "integer"
+                "mode": {
+                    "description": "模式: manual 或 auto",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.ModelSettingMode"
+                        }
+                    ]
                 }
             }
         },
-        "domain.BasicDocConfig": {
+        "domain.ModelType": {
+            "type": "string",
+            "enum": [
+                "chat",
+                "embedding",
+                "rerank",
+                "analysis",
+                "analysis-vl"
+            ],
+            "x-enum-varnames": [
+                "ModelTypeChat",
+                "ModelTypeEmbedding",
+                "ModelTypeRerank",
+                "ModelTypeAnalysis",
+                "ModelTypeAnalysisVL"
+            ]
+        },
+        "domain.MoveNodeReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "bg_color": {
+                "id": {
                     "type": "string"
                 },
-                "title": {
+                "kb_id": {
                     "type": "string"
                 },
-                "title_color": {
+                "next_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "prev_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.BatchMoveReq": {
+        "domain.NodeActionReq": {
             "type": "object",
             "required": [
+                "action",
                 "ids",
                 "kb_id"
             ],
             "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "delete"
+                    ]
+                },
                 "ids": {
                     "type": "array",
                     "items": {
@@ -5246,1030 +11219,1331 @@ const docTemplate = `{
                 "kb_id": {
                     "type": "string"
                 },
-                "parent_id": {
+                "replacement_node_id": {
+                    "description": "delete only, urls of the deleted nodes are redirected to this node",
                     "type": "string"
                 }
             }
         },
-        "domain.BlockGridConfig": {
+        "domain.NodeContentChunkSSE": {
             "type": "object",
             "properties": {
-                "list": {
+                "emoji": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_path_names": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "url": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string"
                     }
                 },
-                "title": {
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeField": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "NodeMeta.Fields 中的 key，创建后不可修改",
                     "type": "string"
                 },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
                 "type": {
+                    "$ref": "#/definitions/domain.NodeFieldType"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.BrandGroup": {
+        "domain.NodeFieldType": {
+            "type": "string",
+            "enum": [
+                "text",
+                "select",
+                "date",
+                "user"
+            ],
+            "x-enum-comments": {
+                "NodeFieldTypeDate": "2006-01-02",
+                "NodeFieldTypeSelect": "值必须为 Options 之一",
+                "NodeFieldTypeUser": "用户 ID"
+            },
+            "x-enum-descriptions": [
+                "值必须为 Options 之一",
+                "2006-01-02",
+                "用户 ID"
+            ],
+            "x-enum-varnames": [
+                "NodeFieldTypeText",
+                "NodeFieldTypeSelect",
+                "NodeFieldTypeDate",
+                "NodeFieldTypeUser"
+            ]
+        },
+        "domain.NodeFields": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "domain.NodeGroupDetail": {
             "type": "object",
             "properties": {
-                "links": {
+                "auth_group_id": {
+                    "type": "integer"
+                },
+                "auth_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Link"
+                        "type": "integer"
                     }
                 },
+                "kb_id": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "perm": {
+                    "$ref": "#/definitions/consts.NodePermName"
                 }
             }
         },
-        "domain.BrowserCount": {
+        "domain.NodeLinkScope": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "release"
+            ],
+            "x-enum-comments": {
+                "NodeLinkScopeDraft": "解析自当前编辑内容",
+                "NodeLinkScopeRelease": "解析自最新发布内容"
+            },
+            "x-enum-descriptions": [
+                "解析自当前编辑内容",
+                "解析自最新发布内容"
+            ],
+            "x-enum-varnames": [
+                "NodeLinkScopeDraft",
+                "NodeLinkScopeRelease"
+            ]
+        },
+        "domain.NodeLinkType": {
+            "type": "string",
+            "enum": [
+                "internal",
+                "external"
+            ],
+            "x-enum-comments": {
+                "NodeLinkTypeExternal": "外部 http(s) 链接",
+                "NodeLinkTypeInternal": "指向本知识库文档的 /node/\u003cid\u003e 链接"
+            },
+            "x-enum-descriptions": [
+                "指向本知识库文档的 /node/\u003cid\u003e 链接",
+                "外部 http(s) 链接"
+            ],
+            "x-enum-varnames": [
+                "NodeLinkTypeInternal",
+                "NodeLinkTypeExternal"
+            ]
+        },
+        "domain.NodeLintIssue": {
             "type": "object",
             "properties": {
-                "count": {
+                "created_at": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "line": {
+                    "description": "1-based line of the content",
                     "type": "integer"
                 },
-                "name": {
+                "message": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "rule": {
+                    "$ref": "#/definitions/domain.NodeLintRule"
+                },
+                "target_node_id": {
+                    "description": "node links only",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "the heading, image, link, table or word the issue is about",
                     "type": "string"
                 }
             }
         },
-        "domain.CarouselConfig": {
+        "domain.NodeLintRule": {
+            "type": "string",
+            "enum": [
+                "empty_heading",
+                "skipped_heading_level",
+                "image_missing_alt",
+                "external_image",
+                "missing_node_link",
+                "unpublished_node_link",
+                "oversized_table",
+                "block_word"
+            ],
+            "x-enum-comments": {
+                "NodeLintRuleExternalImage": "not stored in the static file bucket",
+                "NodeLintRuleSkippedHeadingLevel": "e.g. h2 followed by h4"
+            },
+            "x-enum-descriptions": [
+                "e.g. h2 followed by h4",
+                "not stored in the static file bucket"
+            ],
+            "x-enum-varnames": [
+                "NodeLintRuleEmptyHeading",
+                "NodeLintRuleSkippedHeadingLevel",
+                "NodeLintRuleImageMissingAlt",
+                "NodeLintRuleExternalImage",
+                "NodeLintRuleMissingNodeLink",
+                "NodeLintRuleUnpublishedNodeLink",
+                "NodeLintRuleOversizedTable",
+                "NodeLintRuleBlockWord"
+            ]
+        },
+        "domain.NodeListItemResp": {
             "type": "object",
             "properties": {
-                "bg_color": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator": {
+                    "type": "string"
+                },
+                "creator_id": {
+                    "type": "string"
+                },
+                "editor": {
+                    "type": "string"
+                },
+                "editor_id": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "fields": {
+                    "$ref": "#/definitions/domain.NodeFields"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "position": {
+                    "type": "number"
+                },
+                "publisher_id": {
                     "type": "string"
                 },
-                "list": {
+                "rag_info": {
+                    "$ref": "#/definitions/domain.RagInfo"
+                },
+                "snippets": {
+                    "description": "matched content with \u003cmark\u003e highlights",
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "desc": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "title": {
-                                "type": "string"
-                            },
-                            "url": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string"
                     }
                 },
-                "title": {
+                "status": {
+                    "$ref": "#/definitions/domain.NodeStatus"
+                },
+                "summary": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.CaseConfig": {
-            "type": "object",
-            "properties": {
-                "list": {
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "link": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string"
                     }
                 },
-                "title": {
-                    "type": "string"
-                },
                 "type": {
+                    "$ref": "#/definitions/domain.NodeType"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.CatalogSettings": {
+        "domain.NodeMeta": {
             "type": "object",
             "properties": {
-                "catalog_folder": {
-                    "description": "1: 展开, 2: 折叠, default: 1",
-                    "type": "integer"
+                "content_type": {
+                    "type": "string"
                 },
-                "catalog_visible": {
-                    "description": "1: 显示, 2: 隐藏, default: 1",
-                    "type": "integer"
+                "emoji": {
+                    "type": "string"
                 },
-                "catalog_width": {
-                    "description": "200 - 300, default: 260",
-                    "type": "integer"
-                }
-            }
-        },
-        "domain.ChatRequest": {
-            "type": "object",
-            "required": [
-                "app_type",
-                "message"
-            ],
-            "properties": {
-                "app_type": {
-                    "enum": [
-                        1,
-                        2
-                    ],
+                "fields": {
+                    "description": "自定义字段值，key 为 NodeField.Key",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.AppType"
+                            "$ref": "#/definitions/domain.NodeFields"
                         }
                     ]
                 },
-                "captcha_token": {
-                    "type": "string"
-                },
-                "conversation_id": {
-                    "type": "string"
-                },
-                "message": {
+                "summary": {
                     "type": "string"
                 },
-                "nonce": {
-                    "type": "string"
+                "tags": {
+                    "description": "标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "domain.ChatSearchReq": {
+        "domain.NodeMetaFilter": {
             "type": "object",
-            "required": [
-                "message"
-            ],
             "properties": {
-                "captcha_token": {
-                    "type": "string"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.ChatSearchResp": {
-            "type": "object",
-            "properties": {
-                "node_result": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.NodeContentChunkSSE"
+                        "type": "string"
                     }
                 }
             }
         },
-        "domain.CommentConfig": {
+        "domain.NodePermissions": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "avatar": {
-                                "type": "string"
-                            },
-                            "comment": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "profession": {
-                                "type": "string"
-                            },
-                            "user_name": {
-                                "type": "string"
-                            }
+                "answerable": {
+                    "description": "可被问答",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
                         }
-                    }
+                    ]
                 },
-                "title": {
-                    "type": "string"
+                "visible": {
+                    "description": "导航内可见",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
+                        }
+                    ]
                 },
-                "type": {
-                    "type": "string"
+                "visitable": {
+                    "description": "可被访问",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
+                        }
+                    ]
                 }
             }
         },
-        "domain.CommentInfo": {
+        "domain.NodePushStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "unchanged",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "NodePushStatusCreated",
+                "NodePushStatusUpdated",
+                "NodePushStatusUnchanged",
+                "NodePushStatusFailed"
+            ]
+        },
+        "domain.NodeSEO": {
             "type": "object",
             "properties": {
-                "auth_user_id": {
-                    "type": "integer"
-                },
-                "avatar": {
-                    "description": "avatar",
+                "canonical_url": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
-                },
-                "remote_ip": {
+                "description": {
                     "type": "string"
                 },
-                "user_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.CommentListItem": {
-            "type": "object",
-            "properties": {
-                "content": {
+                "image": {
                     "type": "string"
                 },
-                "created_at": {
+                "kb_id": {
                     "type": "string"
                 },
-                "id": {
+                "keywords": {
                     "type": "string"
                 },
-                "info": {
-                    "$ref": "#/definitions/domain.CommentInfo"
-                },
-                "ip_address": {
-                    "description": "ip地址",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.IPAddress"
-                        }
-                    ]
+                "no_index": {
+                    "type": "boolean"
                 },
                 "node_id": {
                     "type": "string"
                 },
-                "node_name": {
-                    "description": "文档标题",
+                "title": {
                     "type": "string"
                 },
-                "node_type": {
-                    "type": "integer"
-                },
-                "root_id": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.NodeStatus": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "NodeStatusDraft",
+                "NodeStatusReleased"
+            ]
+        },
+        "domain.NodeSummaryReq": {
+            "type": "object",
+            "required": [
+                "ids",
+                "kb_id"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "status": {
-                    "description": "status : -1 reject 0 pending 1 accept",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CommentStatus"
-                        }
-                    ]
+                "kb_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeTagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
                 }
             }
         },
-        "domain.CommentReq": {
+        "domain.NodeTemplate": {
             "type": "object",
-            "required": [
-                "content",
-                "node_id",
-                "pic_urls"
-            ],
             "properties": {
-                "captcha_token": {
+                "content": {
+                    "description": "支持占位符，如 {{date}} {{author}} {{parent_name}}",
                     "type": "string"
                 },
-                "content": {
+                "content_type": {
                     "type": "string"
                 },
-                "node_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "parent_id": {
+                "creator_id": {
                     "type": "string"
                 },
-                "pic_urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "emoji": {
+                    "type": "string"
                 },
-                "root_id": {
+                "id": {
                     "type": "string"
                 },
-                "user_name": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "默认摘要，同样支持占位符",
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.CommentStatus": {
+        "domain.NodeTranslationStatus": {
+            "type": "string",
+            "enum": [
+                "ready",
+                "translating",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "NodeTranslationStatusTranslating": "machine translation is running"
+            },
+            "x-enum-descriptions": [
+                "machine translation is running"
+            ],
+            "x-enum-varnames": [
+                "NodeTranslationStatusReady",
+                "NodeTranslationStatusTranslating",
+                "NodeTranslationStatusFailed"
+            ]
+        },
+        "domain.NodeType": {
             "type": "integer",
             "format": "int32",
             "enum": [
-                -1,
-                0,
-                1
+                1,
+                2
             ],
             "x-enum-varnames": [
-                "CommentStatusReject",
-                "CommentStatusPending",
-                "CommentStatusAccepted"
+                "NodeTypeFolder",
+                "NodeTypeDocument"
             ]
         },
-        "domain.CompleteReq": {
+        "domain.ObjectUploadResp": {
             "type": "object",
             "properties": {
-                "prefix": {
-                    "description": "For FIM (Fill in Middle) style completion",
+                "filename": {
                     "type": "string"
                 },
-                "suffix": {
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "domain.ContributeSettings": {
+        "domain.OpenAIAPIBotSettings": {
             "type": "object",
             "properties": {
-                "is_enable": {
+                "is_enabled": {
                     "type": "boolean"
+                },
+                "secret_key": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ConversationDetailResp": {
+        "domain.OpenAIChoice": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
+                "delta": {
+                    "description": "for streaming",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIMessage"
+                        }
+                    ]
                 },
-                "created_at": {
+                "finish_reason": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "index": {
+                    "type": "integer"
                 },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
+                "message": {
+                    "$ref": "#/definitions/domain.OpenAIMessage"
+                }
+            }
+        },
+        "domain.OpenAICompletionsRequest": {
+            "type": "object",
+            "required": [
+                "messages",
+                "model"
+            ],
+            "properties": {
+                "frequency_penalty": {
+                    "type": "number"
                 },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ConversationMessage"
-                    }
+                "max_tokens": {
+                    "type": "integer"
                 },
-                "references": {
+                "messages": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.ConversationReference"
+                        "$ref": "#/definitions/domain.OpenAIMessage"
                     }
                 },
-                "remote_ip": {
+                "model": {
                     "type": "string"
                 },
-                "subject": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.ConversationInfo": {
-            "type": "object",
-            "properties": {
-                "user_info": {
-                    "$ref": "#/definitions/domain.UserInfo"
-                }
-            }
-        },
-        "domain.ConversationListItem": {
-            "type": "object",
-            "properties": {
-                "app_name": {
-                    "type": "string"
+                "presence_penalty": {
+                    "type": "number"
                 },
-                "app_type": {
-                    "$ref": "#/definitions/domain.AppType"
+                "response_format": {
+                    "$ref": "#/definitions/domain.OpenAIResponseFormat"
                 },
-                "created_at": {
-                    "type": "string"
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "feedback_info": {
-                    "description": "用户反馈信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
+                "stream": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "stream_options": {
+                    "$ref": "#/definitions/domain.OpenAIStreamOptions"
                 },
-                "info": {
-                    "description": "用户信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ConversationInfo"
-                        }
-                    ]
+                "temperature": {
+                    "type": "number"
                 },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
+                "tool_choice": {
+                    "$ref": "#/definitions/domain.OpenAIToolChoice"
                 },
-                "remote_ip": {
-                    "type": "string"
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAITool"
+                    }
                 },
-                "subject": {
+                "top_p": {
+                    "type": "number"
+                },
+                "user": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationMessage": {
+        "domain.OpenAICompletionsResponse": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAIChoice"
+                    }
                 },
-                "completion_tokens": {
+                "created": {
                     "type": "integer"
                 },
-                "content": {
-                    "type": "string"
-                },
-                "conversation_id": {
+                "id": {
                     "type": "string"
                 },
-                "created_at": {
+                "model": {
                     "type": "string"
                 },
-                "id": {
+                "object": {
                     "type": "string"
                 },
-                "info": {
-                    "description": "feedbackinfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
-                },
-                "kb_id": {
+                "usage": {
+                    "$ref": "#/definitions/domain.OpenAIUsage"
+                }
+            }
+        },
+        "domain.OpenAIError": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "model": {
+                "message": {
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "parent_id",
+                "param": {
                     "type": "string"
                 },
-                "prompt_tokens": {
-                    "type": "integer"
-                },
-                "provider": {
-                    "description": "model",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                        }
-                    ]
-                },
-                "remote_ip": {
-                    "description": "stats",
+                "type": {
                     "type": "string"
-                },
-                "role": {
-                    "$ref": "#/definitions/schema.RoleType"
-                },
-                "total_tokens": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.ConversationMessageListItem": {
+        "domain.OpenAIErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/domain.OpenAIError"
+                }
+            }
+        },
+        "domain.OpenAIFunction": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
-                "app_type": {
-                    "$ref": "#/definitions/domain.AppType"
-                },
-                "conversation_id": {
-                    "type": "string"
-                },
-                "conversation_info": {
-                    "description": "userInfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ConversationInfo"
-                        }
-                    ]
-                },
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "info": {
-                    "description": "feedbackInfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
-                },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
-                },
-                "question": {
+                "parameters": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "domain.OpenAIFunctionCall": {
+            "type": "object",
+            "required": [
+                "arguments",
+                "name"
+            ],
+            "properties": {
+                "arguments": {
                     "type": "string"
                 },
-                "remote_ip": {
-                    "description": "stats",
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationReference": {
+        "domain.OpenAIFunctionChoice": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "app_id": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.OpenAIMessage": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "content": {
+                    "$ref": "#/definitions/domain.MessageContent"
                 },
-                "conversation_id": {
+                "name": {
                     "type": "string"
                 },
-                "name": {
+                "role": {
                     "type": "string"
                 },
-                "node_id": {
+                "tool_call_id": {
                     "type": "string"
                 },
-                "url": {
+                "tool_calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAIToolCall"
+                    }
+                }
+            }
+        },
+        "domain.OpenAIResponseFormat": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationSetting": {
+        "domain.OpenAIStreamOptions": {
             "type": "object",
             "properties": {
-                "copyright_hide_enabled": {
+                "include_usage": {
                     "type": "boolean"
+                }
+            }
+        },
+        "domain.OpenAITool": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunction"
                 },
-                "copyright_info": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateKBReleaseReq": {
+        "domain.OpenAIToolCall": {
             "type": "object",
             "required": [
-                "kb_id",
-                "message",
-                "tag"
+                "function",
+                "id",
+                "type"
             ],
             "properties": {
-                "kb_id": {
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunctionCall"
+                },
+                "id": {
                     "type": "string"
                 },
-                "message": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.OpenAIToolChoice": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunctionChoice"
                 },
-                "node_ids": {
-                    "description": "create release after these nodes published",
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OpenAIUsage": {
+            "type": "object",
+            "properties": {
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OpenAPIImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "operations gone from the spec, their documents are marked as removed",
+                    "type": "integer"
+                },
+                "restored": {
+                    "description": "removed operations which are back in the spec",
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "warnings": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "domain.PWResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "tag": {
+                "data": {},
+                "message": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.CreateKnowledgeBaseReq": {
+        "domain.PaginatedResult-array_domain_ConversationMessageListItem": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "hosts": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.ConversationMessageListItem"
                     }
                 },
-                "name": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProviderModelListItem": {
+            "type": "object",
+            "properties": {
+                "model": {
                     "type": "string"
-                },
-                "ports": {
+                }
+            }
+        },
+        "domain.QuestionConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "question": {
+                                "type": "string"
+                            }
+                        }
                     }
                 },
-                "private_key": {
+                "title": {
                     "type": "string"
                 },
-                "public_key": {
+                "type": {
                     "type": "string"
-                },
-                "ssl_ports": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
                 }
             }
         },
-        "domain.CreateModelReq": {
+        "domain.RagInfo": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/consts.NodeRagInfoStatus"
+                }
+            }
+        },
+        "domain.RecommendNodeListResp": {
             "type": "object",
-            "required": [
-                "base_url",
-                "model",
-                "provider",
-                "type"
-            ],
             "properties": {
-                "api_header": {
+                "emoji": {
                     "type": "string"
                 },
-                "api_key": {
+                "id": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "name": {
                     "type": "string"
                 },
-                "base_url": {
+                "parent_id": {
                     "type": "string"
                 },
-                "model": {
-                    "type": "string"
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
                 },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                "position": {
+                    "type": "number"
                 },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
+                },
+                "summary": {
+                    "type": "string"
                 },
                 "type": {
-                    "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
-                    ]
+                    "$ref": "#/definitions/domain.NodeType"
                 }
             }
         },
-        "domain.CreateNodeReq": {
+        "domain.Response": {
             "type": "object",
-            "required": [
-                "kb_id",
-                "name",
-                "type"
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ScoreType": {
+            "type": "integer",
+            "enum": [
+                1,
+                -1
+            ],
+            "x-enum-varnames": [
+                "Like",
+                "DisLike"
+            ]
+        },
+        "domain.SearchQuerySource": {
+            "type": "string",
+            "enum": [
+                "search",
+                "chat"
+            ],
+            "x-enum-comments": {
+                "SearchQuerySourceChat": "questions asked in chat",
+                "SearchQuerySourceSearch": "/share/v1/chat/search and widget search"
+            },
+            "x-enum-descriptions": [
+                "/share/v1/chat/search and widget search",
+                "questions asked in chat"
             ],
+            "x-enum-varnames": [
+                "SearchQuerySourceSearch",
+                "SearchQuerySourceChat"
+            ]
+        },
+        "domain.ShareCommentListItem": {
+            "type": "object",
             "properties": {
                 "content": {
                     "type": "string"
                 },
-                "content_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "emoji": {
+                "id": {
                     "type": "string"
                 },
+                "info": {
+                    "$ref": "#/definitions/domain.CommentInfo"
+                },
+                "ip_address": {
+                    "description": "ip地址",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.IPAddress"
+                        }
+                    ]
+                },
                 "kb_id": {
                     "type": "string"
                 },
-                "name": {
+                "node_id": {
                     "type": "string"
                 },
                 "parent_id": {
                     "type": "string"
                 },
-                "position": {
-                    "type": "number"
+                "pic_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "summary": {
+                "root_id": {
                     "type": "string"
-                },
-                "type": {
-                    "enum": [
-                        1,
-                        2
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.NodeType"
-                        }
-                    ]
                 }
             }
         },
-        "domain.DirDocConfig": {
+        "domain.ShareConversationDetailResp": {
             "type": "object",
             "properties": {
-                "bg_color": {
+                "created_at": {
                     "type": "string"
                 },
-                "title": {
+                "id": {
                     "type": "string"
                 },
-                "title_color": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ShareConversationMessage"
+                    }
+                },
+                "subject": {
                     "type": "string"
                 }
             }
         },
-        "domain.DisclaimerSettings": {
+        "domain.ShareConversationMessage": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/schema.RoleType"
                 }
             }
         },
-        "domain.EnterpriseAuth": {
-            "type": "object",
-            "properties": {
-                "enabled": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "domain.FaqConfig": {
+        "domain.ShareNodeDetailItem": {
             "type": "object",
             "properties": {
-                "bg_color": {
-                    "type": "string"
-                },
-                "list": {
+                "children": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "link": {
-                                "type": "string"
-                            },
-                            "question": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/domain.ShareNodeDetailItem"
                     }
                 },
-                "title": {
+                "emoji": {
                     "type": "string"
                 },
-                "title_color": {
+                "id": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/domain.NodeMeta"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "position": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.NodeType"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.FeatureConfig": {
+        "domain.ShareNodeTranslation": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "desc": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "id": {
+                    "type": "string"
                 },
-                "title": {
+                "locale": {
+                    "description": "empty for the source node",
                     "type": "string"
                 },
-                "type": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.FeedBackInfo": {
+        "domain.ShareResolveType": {
+            "type": "string",
+            "enum": [
+                "node",
+                "redirect",
+                "not_found"
+            ],
+            "x-enum-varnames": [
+                "ShareResolveTypeNode",
+                "ShareResolveTypeRedirect",
+                "ShareResolveTypeNotFound"
+            ]
+        },
+        "domain.SimpleAuth": {
             "type": "object",
             "properties": {
-                "feedback_content": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "feedback_type": {
+                "password": {
                     "type": "string"
-                },
-                "score": {
-                    "$ref": "#/definitions/domain.ScoreType"
                 }
             }
         },
-        "domain.FeedbackRequest": {
+        "domain.SimpleDocConfig": {
             "type": "object",
-            "required": [
-                "message_id"
-            ],
             "properties": {
-                "conversation_id": {
+                "bg_color": {
                     "type": "string"
                 },
-                "feedback_content": {
-                    "description": "限制内容长度",
-                    "type": "string",
-                    "maxLength": 200
-                },
-                "message_id": {
+                "title": {
                     "type": "string"
                 },
-                "score": {
-                    "description": "-1 踩 ,0 1 赞成",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ScoreType"
-                        }
-                    ]
-                },
-                "type": {
-                    "description": "内容不准确，没有帮助，.......",
+                "title_color": {
                     "type": "string"
                 }
             }
         },
-        "domain.FooterSettings": {
+        "domain.SocialMediaAccount": {
             "type": "object",
             "properties": {
-                "brand_desc": {
-                    "type": "string"
-                },
-                "brand_groups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrandGroup"
-                    }
-                },
-                "brand_logo": {
+                "channel": {
                     "type": "string"
                 },
-                "brand_name": {
+                "icon": {
                     "type": "string"
                 },
-                "corp_name": {
+                "link": {
                     "type": "string"
                 },
-                "footer_style": {
+                "phone": {
                     "type": "string"
                 },
-                "icp": {
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "domain.GetKBReleaseListResp": {
+        "domain.StatPageEngagementReq": {
             "type": "object",
+            "required": [
+                "node_id"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.KBReleaseListItemResp"
-                    }
+                "copy_count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
                 },
-                "total": {
-                    "type": "integer"
+                "dwell_seconds": {
+                    "description": "页面可见的时长",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "exit": {
+                    "type": "boolean"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "outbound_click_count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "scroll_depth": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
                 }
             }
         },
-        "domain.GetProviderModelListReq": {
+        "domain.StatPageReq": {
             "type": "object",
             "required": [
-                "base_url",
-                "provider",
-                "type"
+                "scene"
             ],
             "properties": {
-                "api_header": {
-                    "type": "string"
-                },
-                "api_key": {
-                    "type": "string"
-                },
-                "base_url": {
-                    "type": "string"
-                },
-                "provider": {
+                "node_id": {
                     "type": "string"
                 },
-                "type": {
+                "scene": {
                     "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
+                        1,
+                        2,
+                        3,
+                        4
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.ModelType"
+                            "$ref": "#/definitions/domain.StatPageScene"
                         }
                     ]
                 }
             }
         },
-        "domain.GetProviderModelListResp": {
-            "type": "object",
-            "properties": {
-                "models": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ProviderModelListItem"
-                    }
-                }
-            }
+        "domain.StatPageScene": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "StatPageSceneWelcome",
+                "StatPageSceneNodeDetail",
+                "StatPageSceneChat",
+                "StatPageSceneLogin"
+            ]
         },
-        "domain.HotBrowser": {
+        "domain.StatsSetting": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrowserCount"
-                    }
-                },
-                "os": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrowserCount"
-                    }
+                "pv_enable": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.HotPage": {
+        "domain.SwitchModeReq": {
             "type": "object",
+            "required": [
+                "mode"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "node_id": {
+                "auto_mode_api_key": {
+                    "description": "百智云 API Key",
                     "type": "string"
                 },
-                "node_name": {
+                "chat_model": {
+                    "description": "自定义对话模型名称",
                     "type": "string"
                 },
-                "scene": {
-                    "$ref": "#/definitions/domain.StatPageScene"
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "manual",
+                        "auto"
+                    ]
                 }
             }
         },
-        "domain.HotRefererHost": {
+        "domain.SwitchModeResp": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "referer_host": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "domain.IPAddress": {
+        "domain.TextConfig": {
             "type": "object",
             "properties": {
-                "city": {
-                    "type": "string"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "ip": {
+                "title": {
                     "type": "string"
                 },
-                "province": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.ImgTextConfig": {
+        "domain.TextImgConfig": {
             "type": "object",
             "properties": {
                 "item": {
@@ -6294,2474 +12568,2927 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.InstantCountResp": {
+        "domain.TextReq": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "action": {
+                    "description": "action: improve, summary, extend, shorten, etc.",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ThemeAndStyle": {
+            "type": "object",
+            "properties": {
+                "bg_image": {
+                    "type": "string"
+                },
+                "doc_width": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UpdateAppReq": {
+            "type": "object",
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettings"
+                }
+            }
+        },
+        "domain.UpdateKnowledgeBaseReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UpdateModelReq": {
+            "type": "object",
+            "required": [
+                "base_url",
+                "id",
+                "model",
+                "provider",
+                "type"
+            ],
+            "properties": {
+                "api_header": {
+                    "type": "string"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "api_version": {
+                    "description": "for azure openai",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                },
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.UpdateNodeReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "content": {
+                    "type": "string"
                 },
-                "time": {
+                "content_type": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.InstantPageResp": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                },
+                "emoji": {
                     "type": "string"
                 },
-                "info": {
-                    "$ref": "#/definitions/domain.AuthUserInfo"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "ip": {
+                "id": {
                     "type": "string"
                 },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
+                "kb_id": {
+                    "type": "string"
                 },
-                "node_id": {
+                "name": {
                     "type": "string"
                 },
-                "node_name": {
+                "position": {
+                    "type": "number"
+                },
+                "summary": {
                     "type": "string"
                 },
-                "scene": {
-                    "$ref": "#/definitions/domain.StatPageScene"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "user_id": {
+                "version": {
+                    "description": "Version is the node version the editor started from, conflicts are detected when it is stale",
                     "type": "integer"
                 }
             }
         },
-        "domain.KBReleaseListItemResp": {
+        "domain.UserInfo": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "auth_user_id": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "kb_id": {
+                "from": {
+                    "$ref": "#/definitions/domain.MessageFrom"
+                },
+                "name": {
                     "type": "string"
                 },
-                "message": {
+                "real_name": {
                     "type": "string"
                 },
-                "tag": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.KnowledgeBaseDetail": {
+        "domain.WeChatAppAdvancedSetting": {
             "type": "object",
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
-                },
-                "created_at": {
+                "disclaimer_content": {
                     "type": "string"
                 },
-                "dataset_id": {
-                    "type": "string"
+                "feedback_enable": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "feedback_type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
+                "prompt": {
                     "type": "string"
                 },
-                "perm": {
-                    "description": "用户对知识库的权限",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.UserKBPermission"
-                        }
-                    ]
-                },
-                "updated_at": {
-                    "type": "string"
+                "text_response_enable": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.KnowledgeBaseListItem": {
+        "domain.WebAppCommentSettings": {
             "type": "object",
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
+                "is_enable": {
+                    "type": "boolean"
                 },
-                "created_at": {
-                    "type": "string"
+                "moderation_enable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.WebAppCustomSettings": {
+            "type": "object",
+            "properties": {
+                "allow_theme_switching": {
+                    "type": "boolean"
                 },
-                "dataset_id": {
-                    "type": "string"
+                "footer_show_intro": {
+                    "type": "boolean"
                 },
-                "id": {
+                "header_search_placeholder": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "show_brand_info": {
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "type": "string"
+                "social_media_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SocialMediaAccount"
+                    }
                 }
             }
         },
-        "domain.LarkBotSettings": {
+        "domain.WebAppLandingConfig": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
+                "banner_config": {
+                    "$ref": "#/definitions/domain.BannerConfig"
                 },
-                "app_secret": {
-                    "type": "string"
+                "basic_doc_config": {
+                    "$ref": "#/definitions/domain.BasicDocConfig"
                 },
-                "encrypt_key": {
-                    "type": "string"
+                "block_grid_config": {
+                    "$ref": "#/definitions/domain.BlockGridConfig"
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "carousel_config": {
+                    "$ref": "#/definitions/domain.CarouselConfig"
                 },
-                "verify_token": {
+                "case_config": {
+                    "$ref": "#/definitions/domain.CaseConfig"
+                },
+                "com_config_order": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "comment_config": {
+                    "$ref": "#/definitions/domain.CommentConfig"
+                },
+                "dir_doc_config": {
+                    "$ref": "#/definitions/domain.DirDocConfig"
+                },
+                "faq_config": {
+                    "$ref": "#/definitions/domain.FaqConfig"
+                },
+                "feature_config": {
+                    "$ref": "#/definitions/domain.FeatureConfig"
+                },
+                "img_text_config": {
+                    "$ref": "#/definitions/domain.ImgTextConfig"
+                },
+                "metrics_config": {
+                    "$ref": "#/definitions/domain.MetricsConfig"
+                },
+                "node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_config": {
+                    "$ref": "#/definitions/domain.QuestionConfig"
+                },
+                "simple_doc_config": {
+                    "$ref": "#/definitions/domain.SimpleDocConfig"
+                },
+                "text_config": {
+                    "$ref": "#/definitions/domain.TextConfig"
+                },
+                "text_img_config": {
+                    "$ref": "#/definitions/domain.TextImgConfig"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.Link": {
+        "domain.WebAppLandingConfigResp": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
+                "banner_config": {
+                    "$ref": "#/definitions/domain.BannerConfig"
+                },
+                "basic_doc_config": {
+                    "$ref": "#/definitions/domain.BasicDocConfig"
+                },
+                "block_grid_config": {
+                    "$ref": "#/definitions/domain.BlockGridConfig"
+                },
+                "carousel_config": {
+                    "$ref": "#/definitions/domain.CarouselConfig"
+                },
+                "case_config": {
+                    "$ref": "#/definitions/domain.CaseConfig"
+                },
+                "com_config_order": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "comment_config": {
+                    "$ref": "#/definitions/domain.CommentConfig"
+                },
+                "dir_doc_config": {
+                    "$ref": "#/definitions/domain.DirDocConfig"
+                },
+                "faq_config": {
+                    "$ref": "#/definitions/domain.FaqConfig"
+                },
+                "feature_config": {
+                    "$ref": "#/definitions/domain.FeatureConfig"
+                },
+                "img_text_config": {
+                    "$ref": "#/definitions/domain.ImgTextConfig"
+                },
+                "metrics_config": {
+                    "$ref": "#/definitions/domain.MetricsConfig"
+                },
+                "node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
                 },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.MCPServerSettings": {
-            "type": "object",
-            "properties": {
-                "docs_tool_settings": {
-                    "$ref": "#/definitions/domain.MCPToolSettings"
+                "question_config": {
+                    "$ref": "#/definitions/domain.QuestionConfig"
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "simple_doc_config": {
+                    "$ref": "#/definitions/domain.SimpleDocConfig"
                 },
-                "sample_auth": {
-                    "$ref": "#/definitions/domain.SimpleAuth"
+                "text_config": {
+                    "$ref": "#/definitions/domain.TextConfig"
+                },
+                "text_img_config": {
+                    "$ref": "#/definitions/domain.TextImgConfig"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "domain.MCPToolSettings": {
+        "domain.WebAppLandingTheme": {
             "type": "object",
             "properties": {
-                "desc": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.MessageContent": {
-            "type": "object"
+        "domain.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "retrying",
+                "success",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusRetrying",
+                "WebhookDeliveryStatusSuccess",
+                "WebhookDeliveryStatusFailed"
+            ]
         },
-        "domain.MessageFrom": {
-            "type": "integer",
+        "domain.WebhookEvent": {
+            "type": "string",
             "enum": [
-                1,
-                2
+                "node.created",
+                "node.updated",
+                "node.published",
+                "node.deleted",
+                "kb_release.created",
+                "comment.created",
+                "feedback.received",
+                "node_feedback.received",
+                "conversation.started",
+                "ping"
+            ],
+            "x-enum-comments": {
+                "WebhookEventFeedbackReceived": "feedback on an ai answer",
+                "WebhookEventNodeDeleted": "moved to trash",
+                "WebhookEventPing": "sent by the test endpoint only"
+            },
+            "x-enum-descriptions": [
+                "moved to trash",
+                "feedback on an ai answer",
+                "sent by the test endpoint only"
             ],
             "x-enum-varnames": [
-                "MessageFromGroup",
-                "MessageFromPrivate"
+                "WebhookEventNodeCreated",
+                "WebhookEventNodeUpdated",
+                "WebhookEventNodePublished",
+                "WebhookEventNodeDeleted",
+                "WebhookEventKBReleaseCreated",
+                "WebhookEventCommentCreated",
+                "WebhookEventFeedbackReceived",
+                "WebhookEventNodeFeedbackReceived",
+                "WebhookEventConversationStarted",
+                "WebhookEventPing"
             ]
         },
-        "domain.MetricsConfig": {
+        "domain.WecomAIBotSettings": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "number": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                },
-                "title": {
+                "encodingaeskey": {
                     "type": "string"
                 },
-                "type": {
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "domain.ModelModeSetting": {
+        "domain.WidgetBotSettings": {
             "type": "object",
             "properties": {
-                "auto_mode_api_key": {
-                    "description": "百智云 API Key",
+                "btn_id": {
                     "type": "string"
                 },
-                "chat_model": {
-                    "description": "自定义对话模型名称",
+                "btn_logo": {
                     "type": "string"
                 },
-                "is_manual_embedding_updated": {
-                    "description": "手动模式下嵌入模型是否更新",
-                    "type": "boolean"
+                "btn_position": {
+                    "type": "string"
                 },
-                "mode": {
-                    "description": "模式: manual 或 auto",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.ModelSettingMode"
-                        }
-                    ]
-                }
-            }
-        },
-        "domain.ModelType": {
-            "type": "string",
-            "enum": [
-                "chat",
-                "embedding",
-                "rerank",
-                "analysis",
-                "analysis-vl"
-            ],
-            "x-enum-varnames": [
-                "ModelTypeChat",
-                "ModelTypeEmbedding",
-                "ModelTypeRerank",
-                "ModelTypeAnalysis",
-                "ModelTypeAnalysisVL"
-            ]
-        },
-        "domain.MoveNodeReq": {
-            "type": "object",
-            "required": [
-                "id",
-                "kb_id"
-            ],
-            "properties": {
-                "id": {
+                "btn_style": {
                     "type": "string"
                 },
-                "kb_id": {
+                "btn_text": {
                     "type": "string"
                 },
-                "next_id": {
+                "copyright_hide_enabled": {
+                    "type": "boolean"
+                },
+                "copyright_info": {
                     "type": "string"
                 },
-                "parent_id": {
+                "disclaimer": {
                     "type": "string"
                 },
-                "prev_id": {
+                "is_open": {
+                    "type": "boolean"
+                },
+                "modal_position": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.NodeActionReq": {
-            "type": "object",
-            "required": [
-                "action",
-                "ids",
-                "kb_id"
-            ],
-            "properties": {
-                "action": {
-                    "type": "string",
-                    "enum": [
-                        "delete"
-                    ]
                 },
-                "ids": {
+                "placeholder": {
+                    "type": "string"
+                },
+                "recommend_node_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "kb_id": {
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_mode": {
+                    "type": "string"
+                },
+                "theme_mode": {
                     "type": "string"
                 }
             }
         },
-        "domain.NodeContentChunkSSE": {
+        "github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp": {
             "type": "object",
             "properties": {
-                "emoji": {
+                "auths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AuthItem"
+                    }
+                },
+                "client_id": {
                     "type": "string"
                 },
-                "name": {
+                "client_secret": {
                     "type": "string"
                 },
-                "node_id": {
+                "proxy": {
                     "type": "string"
                 },
-                "node_path_names": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "source_type": {
+                    "$ref": "#/definitions/consts.SourceType"
+                }
+            }
+        },
+        "github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp": {
+            "type": "object",
+            "properties": {
+                "auth_type": {
+                    "$ref": "#/definitions/consts.AuthType"
                 },
-                "summary": {
-                    "type": "string"
+                "license_edition": {
+                    "$ref": "#/definitions/consts.LicenseEdition"
+                },
+                "source_type": {
+                    "$ref": "#/definitions/consts.SourceType"
                 }
             }
         },
-        "domain.NodeGroupDetail": {
+        "github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp": {
+            "type": "object"
+        },
+        "github_com_chaitin_panda-wiki_domain.CheckModelReq": {
             "type": "object",
+            "required": [
+                "base_url",
+                "model",
+                "provider",
+                "type"
+            ],
             "properties": {
-                "auth_group_id": {
-                    "type": "integer"
+                "api_header": {
+                    "type": "string"
                 },
-                "auth_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "api_key": {
+                    "type": "string"
                 },
-                "kb_id": {
+                "api_version": {
+                    "description": "for azure openai",
                     "type": "string"
                 },
-                "name": {
+                "base_url": {
                     "type": "string"
                 },
-                "node_id": {
+                "model": {
                     "type": "string"
                 },
-                "perm": {
-                    "$ref": "#/definitions/consts.NodePermName"
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                },
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
                 }
             }
         },
-        "domain.NodeListItemResp": {
+        "github_com_chaitin_panda-wiki_domain.CheckModelResp": {
             "type": "object",
             "properties": {
-                "content_type": {
+                "content": {
                     "type": "string"
                 },
-                "created_at": {
+                "error": {
                     "type": "string"
-                },
-                "creator": {
+                }
+            }
+        },
+        "github_com_chaitin_panda-wiki_domain.ModelListItem": {
+            "type": "object",
+            "properties": {
+                "api_header": {
                     "type": "string"
                 },
-                "creator_id": {
+                "api_key": {
                     "type": "string"
                 },
-                "editor": {
+                "api_version": {
+                    "description": "for azure openai",
                     "type": "string"
                 },
-                "editor_id": {
+                "base_url": {
                     "type": "string"
                 },
-                "emoji": {
-                    "type": "string"
+                "completion_tokens": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "parent_id": {
+                "model": {
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
-                },
-                "position": {
-                    "type": "number"
-                },
-                "publisher_id": {
-                    "type": "string"
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
                 },
-                "rag_info": {
-                    "$ref": "#/definitions/domain.RagInfo"
+                "prompt_tokens": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.NodeStatus"
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
                 },
-                "summary": {
-                    "type": "string"
+                "total_tokens": {
+                    "type": "integer"
                 },
                 "type": {
-                    "$ref": "#/definitions/domain.NodeType"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "$ref": "#/definitions/domain.ModelType"
                 }
             }
         },
-        "domain.NodeMeta": {
+        "github_com_chaitin_panda-wiki_domain.ModelParam": {
             "type": "object",
             "properties": {
-                "content_type": {
-                    "type": "string"
+                "context_window": {
+                    "type": "integer"
                 },
-                "emoji": {
-                    "type": "string"
+                "max_tokens": {
+                    "type": "integer"
                 },
-                "summary": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.NodePermissions": {
-            "type": "object",
-            "properties": {
-                "answerable": {
-                    "description": "可被问答",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
+                "r1_enabled": {
+                    "type": "boolean"
                 },
-                "visible": {
-                    "description": "导航内可见",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
+                "support_computer_use": {
+                    "type": "boolean"
                 },
-                "visitable": {
-                    "description": "可被访问",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
-                }
-            }
-        },
-        "domain.NodeStatus": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "NodeStatusDraft",
-                "NodeStatusReleased"
-            ]
-        },
-        "domain.NodeSummaryReq": {
-            "type": "object",
-            "required": [
-                "ids",
-                "kb_id"
-            ],
-            "properties": {
-                "ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "support_images": {
+                    "type": "boolean"
                 },
-                "kb_id": {
-                    "type": "string"
+                "support_prompt_cache": {
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "type": "number"
                 }
             }
         },
-        "domain.NodeType": {
-            "type": "integer",
-            "format": "int32",
+        "github_com_chaitin_panda-wiki_domain.ModelProvider": {
+            "type": "string",
             "enum": [
-                1,
-                2
+                "BaiZhiCloud"
             ],
             "x-enum-varnames": [
-                "NodeTypeFolder",
-                "NodeTypeDocument"
+                "ModelProviderBrandBaiZhiCloud"
             ]
         },
-        "domain.ObjectUploadResp": {
+        "gocap.ChallengeData": {
             "type": "object",
             "properties": {
-                "filename": {
-                    "type": "string"
+                "challenge": {
+                    "$ref": "#/definitions/gocap.ChallengeItem"
                 },
-                "key": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.OpenAIAPIBotSettings": {
-            "type": "object",
-            "properties": {
-                "is_enabled": {
-                    "type": "boolean"
+                "expires": {
+                    "description": "过期时间,毫秒级时间戳",
+                    "type": "integer"
                 },
-                "secret_key": {
+                "token": {
+                    "description": "质询令牌",
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIChoice": {
+        "gocap.ChallengeItem": {
             "type": "object",
             "properties": {
-                "delta": {
-                    "description": "for streaming",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.OpenAIMessage"
-                        }
-                    ]
-                },
-                "finish_reason": {
-                    "type": "string"
+                "c": {
+                    "description": "质询数量",
+                    "type": "integer"
                 },
-                "index": {
+                "d": {
+                    "description": "质询难度",
                     "type": "integer"
                 },
-                "message": {
-                    "$ref": "#/definitions/domain.OpenAIMessage"
+                "s": {
+                    "description": "质询大小",
+                    "type": "integer"
                 }
             }
         },
-        "domain.OpenAICompletionsRequest": {
+        "gocap.VerificationResult": {
             "type": "object",
-            "required": [
-                "messages",
-                "model"
-            ],
             "properties": {
-                "frequency_penalty": {
-                    "type": "number"
-                },
-                "max_tokens": {
+                "expires": {
+                    "description": "过期时间,毫秒级时间戳",
                     "type": "integer"
                 },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.OpenAIMessage"
-                    }
-                },
-                "model": {
+                "message": {
                     "type": "string"
                 },
-                "presence_penalty": {
-                    "type": "number"
-                },
-                "response_format": {
-                    "$ref": "#/definitions/domain.OpenAIResponseFormat"
-                },
-                "stop": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "stream": {
+                "success": {
                     "type": "boolean"
                 },
-                "stream_options": {
-                    "$ref": "#/definitions/domain.OpenAIStreamOptions"
-                },
-                "temperature": {
-                    "type": "number"
-                },
-                "tool_choice": {
-                    "$ref": "#/definitions/domain.OpenAIToolChoice"
-                },
-                "tools": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.OpenAITool"
-                    }
-                },
-                "top_p": {
-                    "type": "number"
-                },
-                "user": {
+                "token": {
+                    "description": "验证令牌",
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAICompletionsResponse": {
+        "schema.RoleType": {
+            "type": "string",
+            "enum": [
+                "assistant",
+                "user",
+                "system",
+                "tool"
+            ],
+            "x-enum-varnames": [
+                "Assistant",
+                "User",
+                "System",
+                "Tool"
+            ]
+        },
+        "share.ShareCommentLists": {
             "type": "object",
             "properties": {
-                "choices": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OpenAIChoice"
+                        "$ref": "#/definitions/domain.ShareCommentListItem"
                     }
                 },
-                "created": {
+                "total": {
                     "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "model": {
-                    "type": "string"
-                },
-                "object": {
-                    "type": "string"
-                },
-                "usage": {
-                    "$ref": "#/definitions/domain.OpenAIUsage"
                 }
             }
         },
-        "domain.OpenAIError": {
+        "v1.AuthGitHubReq": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "param": {
+                "kb_id": {
                     "type": "string"
                 },
-                "type": {
+                "redirect_url": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIErrorResponse": {
+        "v1.AuthGitHubResp": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/domain.OpenAIError"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "domain.OpenAIFunction": {
+        "v1.AuthItem": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "avatar_url": {
                     "type": "string"
                 },
-                "name": {
+                "created_at": {
                     "type": "string"
                 },
-                "parameters": {
-                    "type": "object",
-                    "additionalProperties": true
-                }
-            }
-        },
-        "domain.OpenAIFunctionCall": {
-            "type": "object",
-            "required": [
-                "arguments",
-                "name"
-            ],
-            "properties": {
-                "arguments": {
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
                     "type": "string"
                 },
-                "name": {
+                "last_login_time": {
+                    "type": "string"
+                },
+                "source_type": {
+                    "$ref": "#/definitions/consts.SourceType"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIFunctionChoice": {
+        "v1.AuthLoginSimpleReq": {
             "type": "object",
             "required": [
-                "name"
+                "password"
             ],
             "properties": {
-                "name": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIMessage": {
+        "v1.AuthSetReq": {
             "type": "object",
             "required": [
-                "role"
+                "source_type"
             ],
             "properties": {
-                "content": {
-                    "$ref": "#/definitions/domain.MessageContent"
-                },
-                "name": {
+                "client_id": {
                     "type": "string"
                 },
-                "role": {
+                "client_secret": {
                     "type": "string"
                 },
-                "tool_call_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "tool_calls": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.OpenAIToolCall"
-                    }
-                }
-            }
-        },
-        "domain.OpenAIResponseFormat": {
-            "type": "object",
-            "required": [
-                "type"
-            ],
-            "properties": {
-                "type": {
+                "proxy": {
                     "type": "string"
+                },
+                "source_type": {
+                    "enum": [
+                        "github"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.SourceType"
+                        }
+                    ]
                 }
             }
         },
-        "domain.OpenAIStreamOptions": {
+        "v1.CommentLists": {
             "type": "object",
             "properties": {
-                "include_usage": {
-                    "type": "boolean"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CommentListItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.OpenAITool": {
+        "v1.ConversationListItems": {
             "type": "object",
-            "required": [
-                "type"
-            ],
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunction"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationListItem"
+                    }
                 },
-                "type": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.OpenAIToolCall": {
+        "v1.CrawlerExportReq": {
             "type": "object",
             "required": [
-                "function",
+                "doc_id",
                 "id",
-                "type"
+                "kb_id"
             ],
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunctionCall"
+                "doc_id": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "type": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "space_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIToolChoice": {
+        "v1.CrawlerExportResp": {
             "type": "object",
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunctionChoice"
-                },
-                "type": {
+                "task_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIUsage": {
+        "v1.CrawlerParseReq": {
             "type": "object",
+            "required": [
+                "crawler_source",
+                "kb_id"
+            ],
             "properties": {
-                "completion_tokens": {
-                    "type": "integer"
+                "crawler_source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
                 },
-                "prompt_tokens": {
-                    "type": "integer"
+                "feishu_setting": {
+                    "$ref": "#/definitions/v1.FeishuSetting"
                 },
-                "total_tokens": {
-                    "type": "integer"
-                }
-            }
-        },
-        "domain.PWResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
+                "filename": {
+                    "type": "string"
                 },
-                "data": {},
-                "message": {
+                "kb_id": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "key": {
+                    "type": "string"
                 }
             }
         },
-        "domain.PaginatedResult-array_domain_ConversationMessageListItem": {
+        "v1.CrawlerParseResp": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ConversationMessageListItem"
-                    }
+                "docs": {
+                    "$ref": "#/definitions/anydoc.Child"
                 },
-                "total": {
-                    "type": "integer"
-                }
-            }
-        },
-        "domain.ProviderModelListItem": {
-            "type": "object",
-            "properties": {
-                "model": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "domain.QuestionConfig": {
+        "v1.CrawlerResultItem": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "question": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "content": {
+                    "type": "string"
                 },
-                "title": {
+                "status": {
+                    "$ref": "#/definitions/consts.CrawlerStatus"
+                },
+                "task_id": {
                     "type": "string"
-                },
-                "type": {
+                }
+            }
+        },
+        "v1.CrawlerResultReq": {
+            "type": "object",
+            "required": [
+                "task_id"
+            ],
+            "properties": {
+                "task_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.RagInfo": {
+        "v1.CrawlerResultResp": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "message": {
+                "content": {
                     "type": "string"
                 },
                 "status": {
-                    "$ref": "#/definitions/consts.NodeRagInfoStatus"
+                    "$ref": "#/definitions/consts.CrawlerStatus"
                 }
             }
         },
-        "domain.RecommendNodeListResp": {
+        "v1.CrawlerResultsReq": {
             "type": "object",
+            "required": [
+                "task_ids"
+            ],
             "properties": {
-                "emoji": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "parent_id": {
-                    "type": "string"
-                },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
-                },
-                "position": {
-                    "type": "number"
-                },
-                "recommend_nodes": {
+                "task_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "type": "string"
                     }
-                },
-                "summary": {
-                    "type": "string"
-                },
-                "type": {
-                    "$ref": "#/definitions/domain.NodeType"
                 }
             }
         },
-        "domain.Response": {
+        "v1.CrawlerResultsResp": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
-                    "type": "string"
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CrawlerResultItem"
+                    }
                 },
-                "success": {
-                    "type": "boolean"
+                "status": {
+                    "$ref": "#/definitions/consts.CrawlerStatus"
                 }
             }
         },
-        "domain.ScoreType": {
-            "type": "integer",
-            "enum": [
-                1,
-                -1
-            ],
-            "x-enum-varnames": [
-                "Like",
-                "DisLike"
-            ]
-        },
-        "domain.ShareCommentListItem": {
+        "v1.CrawlerSyncCreateReq": {
             "type": "object",
+            "required": [
+                "crawler_source",
+                "kb_id"
+            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
+                "auto_publish": {
+                    "description": "同步后自动发布变更的文档，否则保留为草稿",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "crawler_source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
                 },
-                "info": {
-                    "$ref": "#/definitions/domain.CommentInfo"
+                "feishu_setting": {
+                    "$ref": "#/definitions/v1.FeishuSetting"
                 },
-                "ip_address": {
-                    "description": "ip地址",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.IPAddress"
-                        }
-                    ]
+                "filename": {
+                    "type": "string"
                 },
                 "kb_id": {
                     "type": "string"
                 },
-                "node_id": {
+                "key": {
                     "type": "string"
                 },
-                "parent_id": {
+                "name": {
                     "type": "string"
                 },
-                "pic_urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "parent_id": {
+                    "description": "同步到该文件夹，默认根目录",
+                    "type": "string"
                 },
-                "root_id": {
+                "schedule": {
+                    "description": "cron 表达式，如 0 3 * * *，为空时只手动同步",
                     "type": "string"
                 }
             }
         },
-        "domain.ShareConversationDetailResp": {
+        "v1.CrawlerSyncCreateResp": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ShareConversationMessage"
-                    }
-                },
-                "subject": {
+                "sync_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.ShareConversationMessage": {
+        "v1.CrawlerSyncListItem": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "auto_publish": {
+                    "type": "boolean"
+                },
+                "crawler_source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/schema.RoleType"
-                }
-            }
-        },
-        "domain.ShareNodeDetailItem": {
-            "type": "object",
-            "properties": {
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ShareNodeDetailItem"
-                    }
+                "creator_id": {
+                    "type": "string"
                 },
-                "emoji": {
+                "feishu_app_id": {
                     "type": "string"
                 },
-                "id": {
+                "feishu_space_id": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/domain.NodeMeta"
+                "filename": {
+                    "type": "string"
                 },
-                "name": {
+                "has_feishu_secret": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "string"
                 },
-                "parent_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
+                "key": {
+                    "type": "string"
                 },
-                "position": {
-                    "type": "number"
+                "last_run_at": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/domain.NodeType"
+                "last_run_status": {
+                    "$ref": "#/definitions/domain.CrawlerSyncRunStatus"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.SimpleAuth": {
-            "type": "object",
-            "properties": {
-                "enabled": {
-                    "type": "boolean"
                 },
-                "password": {
+                "next_run_at": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.SimpleDocConfig": {
-            "type": "object",
-            "properties": {
-                "bg_color": {
+                },
+                "parent_id": {
+                    "description": "folder the docs are synced into, root when empty",
                     "type": "string"
                 },
-                "title": {
+                "schedule": {
+                    "description": "standard cron spec, only synced manually when empty",
                     "type": "string"
                 },
-                "title_color": {
+                "status": {
+                    "$ref": "#/definitions/domain.CrawlerSyncStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.SocialMediaAccount": {
+        "v1.CrawlerSyncRunListResp": {
             "type": "object",
             "properties": {
-                "channel": {
-                    "type": "string"
-                },
-                "icon": {
-                    "type": "string"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.KBCrawlerSyncRun"
+                    }
                 },
-                "text": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.StatPageReq": {
+        "v1.CrawlerSyncRunReq": {
             "type": "object",
             "required": [
-                "scene"
+                "kb_id",
+                "sync_id"
             ],
             "properties": {
-                "node_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "scene": {
-                    "enum": [
-                        1,
-                        2,
-                        3,
-                        4
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.StatPageScene"
-                        }
-                    ]
+                "sync_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.StatPageScene": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "StatPageSceneWelcome",
-                "StatPageSceneNodeDetail",
-                "StatPageSceneChat",
-                "StatPageSceneLogin"
-            ]
-        },
-        "domain.StatsSetting": {
+        "v1.CrawlerSyncUpdateReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "sync_id"
+            ],
             "properties": {
-                "pv_enable": {
+                "auto_publish": {
                     "type": "boolean"
+                },
+                "feishu_setting": {
+                    "$ref": "#/definitions/v1.FeishuSetting"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "sync_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.SwitchModeReq": {
+        "v1.CreateUserReq": {
             "type": "object",
             "required": [
-                "mode"
+                "account",
+                "password",
+                "role"
             ],
             "properties": {
-                "auto_mode_api_key": {
-                    "description": "百智云 API Key",
-                    "type": "string"
-                },
-                "chat_model": {
-                    "description": "自定义对话模型名称",
+                "account": {
                     "type": "string"
                 },
-                "mode": {
+                "password": {
                     "type": "string",
+                    "minLength": 8
+                },
+                "role": {
                     "enum": [
-                        "manual",
-                        "auto"
+                        "admin",
+                        "user"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.UserRole"
+                        }
                     ]
                 }
             }
         },
-        "domain.SwitchModeResp": {
+        "v1.CreateUserResp": {
             "type": "object",
             "properties": {
-                "message": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "domain.TextConfig": {
+        "v1.FeishuSetting": {
             "type": "object",
             "properties": {
-                "title": {
+                "app_id": {
                     "type": "string"
                 },
-                "type": {
+                "app_secret": {
+                    "type": "string"
+                },
+                "space_id": {
+                    "type": "string"
+                },
+                "user_access_token": {
                     "type": "string"
                 }
             }
         },
-        "domain.TextImgConfig": {
+        "v1.FileUploadResp": {
             "type": "object",
             "properties": {
-                "item": {
-                    "type": "object",
-                    "properties": {
-                        "desc": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "url": {
-                            "type": "string"
-                        }
-                    }
-                },
-                "title": {
-                    "type": "string"
-                },
-                "type": {
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "domain.TextReq": {
+        "v1.GitSourceCreateReq": {
             "type": "object",
             "required": [
-                "text"
+                "kb_id",
+                "repo_url"
             ],
             "properties": {
-                "action": {
-                    "description": "action: improve, summary, extend, shorten, etc.",
+                "auth_type": {
+                    "enum": [
+                        "none",
+                        "https",
+                        "ssh"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.GitAuthType"
+                        }
+                    ]
+                },
+                "branch": {
+                    "description": "默认为仓库默认分支",
                     "type": "string"
                 },
-                "text": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "同步到该文件夹，默认根目录",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "repo_url": {
+                    "description": "https、ssh 地址或本地裸仓库路径",
+                    "type": "string"
+                },
+                "ssh_key": {
+                    "type": "string"
+                },
+                "sub_dir": {
+                    "description": "只同步该目录",
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "domain.ThemeAndStyle": {
+        "v1.GitSourceCreateResp": {
             "type": "object",
             "properties": {
-                "bg_image": {
-                    "type": "string"
-                },
-                "doc_width": {
+                "source_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.UpdateAppReq": {
+        "v1.GitSourceListItem": {
             "type": "object",
             "properties": {
+                "auth_type": {
+                    "$ref": "#/definitions/domain.GitAuthType"
+                },
+                "branch": {
+                    "description": "default branch of the repo when empty",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "has_ssh_key": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "kb_id": {
                     "type": "string"
                 },
+                "last_commit": {
+                    "description": "commit of the last completed sync, base of the next diff",
+                    "type": "string"
+                },
+                "last_result": {
+                    "$ref": "#/definitions/domain.GitSyncResult"
+                },
+                "last_synced_at": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettings"
+                "parent_id": {
+                    "description": "folder the repo is synced into, root when empty",
+                    "type": "string"
+                },
+                "repo_url": {
+                    "description": "RepoURL is a https or ssh url, or the path of a local bare repo under the configured local repo dir",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.GitSyncStatus"
+                },
+                "sub_dir": {
+                    "description": "only sync this directory of the repo",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "domain.UpdateKnowledgeBaseReq": {
+        "v1.GitSourceSyncReq": {
             "type": "object",
             "required": [
-                "id"
+                "kb_id",
+                "source_id"
             ],
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
+                "full": {
+                    "description": "compare the whole tree instead of the diff since the last sync",
+                    "type": "boolean"
                 },
-                "id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "name": {
+                "source_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.UpdateModelReq": {
+        "v1.GitSourceUpdateReq": {
             "type": "object",
             "required": [
-                "base_url",
-                "id",
-                "model",
-                "provider",
-                "type"
+                "kb_id",
+                "source_id"
             ],
             "properties": {
-                "api_header": {
+                "auth_type": {
+                    "enum": [
+                        "none",
+                        "https",
+                        "ssh"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.GitAuthType"
+                        }
+                    ]
+                },
+                "branch": {
                     "type": "string"
                 },
-                "api_key": {
+                "kb_id": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "name": {
                     "type": "string"
                 },
-                "base_url": {
+                "password": {
                     "type": "string"
                 },
-                "id": {
+                "repo_url": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "source_id": {
+                    "type": "string"
                 },
-                "model": {
+                "ssh_key": {
                     "type": "string"
                 },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                "sub_dir": {
+                    "type": "string"
                 },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KBExportCreateReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "format": {
+                    "description": "默认 markdown, epub and pdf are built from the published release",
+                    "enum": [
+                        "markdown",
+                        "epub",
+                        "pdf"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.KBExportFormat"
+                        }
+                    ]
                 },
-                "type": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "root_id": {
+                    "description": "epub and pdf only, export this folder instead of the whole kb",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KBExportCreateResp": {
+            "type": "object",
+            "properties": {
+                "export_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KBImportReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "key"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "archive uploaded with /api/v1/file/upload",
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "默认 skip",
                     "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
+                        "skip",
+                        "overwrite",
+                        "merge"
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.ModelType"
+                            "$ref": "#/definitions/domain.KBImportMode"
                         }
                     ]
+                },
+                "parent_id": {
+                    "description": "import under this folder, default root",
+                    "type": "string"
+                },
+                "preserve_ids": {
+                    "description": "keep node ids so /node/\u003cid\u003e links stay valid",
+                    "type": "boolean"
                 }
             }
         },
-        "domain.UpdateNodeReq": {
+        "v1.KBImportResp": {
+            "type": "object",
+            "properties": {
+                "import_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.KBRedirectCreateReq": {
             "type": "object",
             "required": [
-                "id",
-                "kb_id"
+                "kb_id",
+                "source_path"
             ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "content_type": {
-                    "type": "string"
-                },
-                "emoji": {
+                "kb_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "permanent": {
+                    "description": "默认 true",
+                    "type": "boolean"
                 },
-                "kb_id": {
+                "source_path": {
+                    "description": "e.g. /node/\u003cid\u003e, /docs/\u003cslug\u003e or any other path of the site",
                     "type": "string"
                 },
-                "name": {
+                "target_node_id": {
                     "type": "string"
                 },
-                "position": {
-                    "type": "number"
-                },
-                "summary": {
+                "target_url": {
                     "type": "string"
                 }
             }
         },
-        "domain.UserInfo": {
+        "v1.KBRedirectCreateResp": {
             "type": "object",
             "properties": {
-                "auth_user_id": {
+                "redirect_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "v1.KBRedirectItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "avatar": {
-                    "description": "avatar",
+                "created_by": {
                     "type": "string"
                 },
-                "email": {
+                "hits": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kb_id": {
                     "type": "string"
                 },
-                "from": {
-                    "$ref": "#/definitions/domain.MessageFrom"
+                "last_hit_at": {
+                    "type": "string"
                 },
-                "name": {
+                "permanent": {
+                    "description": "301 when true, otherwise 302",
+                    "type": "boolean"
+                },
+                "source_path": {
+                    "description": "normalized path, e.g. /node/\u003cid\u003e or /docs/\u003cslug\u003e",
                     "type": "string"
                 },
-                "real_name": {
+                "target_node_id": {
+                    "description": "either target node or target url is set",
                     "type": "string"
                 },
-                "user_id": {
+                "target_node_name": {
+                    "type": "string"
+                },
+                "target_url": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.WeChatAppAdvancedSetting": {
+        "v1.KBRedirectUpdateReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "redirect_id",
+                "source_path"
+            ],
             "properties": {
-                "disclaimer_content": {
+                "kb_id": {
                     "type": "string"
                 },
-                "feedback_enable": {
+                "permanent": {
                     "type": "boolean"
                 },
-                "feedback_type": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "redirect_id": {
+                    "type": "integer"
                 },
-                "prompt": {
+                "source_path": {
                     "type": "string"
                 },
-                "text_response_enable": {
-                    "type": "boolean"
+                "target_node_id": {
+                    "type": "string"
+                },
+                "target_url": {
+                    "type": "string"
                 }
             }
         },
-        "domain.WebAppCommentSettings": {
+        "v1.KBUserInviteReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "perm",
+                "user_id"
+            ],
             "properties": {
-                "is_enable": {
-                    "type": "boolean"
+                "kb_id": {
+                    "type": "string"
                 },
-                "moderation_enable": {
-                    "type": "boolean"
+                "perm": {
+                    "enum": [
+                        "full_control",
+                        "doc_manage",
+                        "data_operate"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.UserKBPermission"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.WebAppCustomSettings": {
+        "v1.KBUserListItemResp": {
             "type": "object",
             "properties": {
-                "allow_theme_switching": {
-                    "type": "boolean"
-                },
-                "footer_show_intro": {
-                    "type": "boolean"
+                "account": {
+                    "type": "string"
                 },
-                "header_search_placeholder": {
+                "id": {
                     "type": "string"
                 },
-                "show_brand_info": {
-                    "type": "boolean"
+                "perms": {
+                    "$ref": "#/definitions/consts.UserKBPermission"
                 },
-                "social_media_accounts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.SocialMediaAccount"
-                    }
+                "role": {
+                    "$ref": "#/definitions/consts.UserRole"
                 }
             }
         },
-        "domain.WebAppLandingConfig": {
+        "v1.KBUserUpdateReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "perm",
+                "user_id"
+            ],
             "properties": {
-                "banner_config": {
-                    "$ref": "#/definitions/domain.BannerConfig"
-                },
-                "basic_doc_config": {
-                    "$ref": "#/definitions/domain.BasicDocConfig"
-                },
-                "block_grid_config": {
-                    "$ref": "#/definitions/domain.BlockGridConfig"
-                },
-                "carousel_config": {
-                    "$ref": "#/definitions/domain.CarouselConfig"
-                },
-                "case_config": {
-                    "$ref": "#/definitions/domain.CaseConfig"
-                },
-                "com_config_order": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "comment_config": {
-                    "$ref": "#/definitions/domain.CommentConfig"
-                },
-                "dir_doc_config": {
-                    "$ref": "#/definitions/domain.DirDocConfig"
-                },
-                "faq_config": {
-                    "$ref": "#/definitions/domain.FaqConfig"
-                },
-                "feature_config": {
-                    "$ref": "#/definitions/domain.FeatureConfig"
-                },
-                "img_text_config": {
-                    "$ref": "#/definitions/domain.ImgTextConfig"
-                },
-                "metrics_config": {
-                    "$ref": "#/definitions/domain.MetricsConfig"
-                },
-                "node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "question_config": {
-                    "$ref": "#/definitions/domain.QuestionConfig"
-                },
-                "simple_doc_config": {
-                    "$ref": "#/definitions/domain.SimpleDocConfig"
-                },
-                "text_config": {
-                    "$ref": "#/definitions/domain.TextConfig"
+                "kb_id": {
+                    "type": "string"
                 },
-                "text_img_config": {
-                    "$ref": "#/definitions/domain.TextImgConfig"
+                "perm": {
+                    "enum": [
+                        "full_control",
+                        "doc_manage",
+                        "data_operate"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.UserKBPermission"
+                        }
+                    ]
                 },
-                "type": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.WebAppLandingConfigResp": {
-            "type": "object",
-            "properties": {
-                "banner_config": {
-                    "$ref": "#/definitions/domain.BannerConfig"
-                },
-                "basic_doc_config": {
-                    "$ref": "#/definitions/domain.BasicDocConfig"
-                },
-                "block_grid_config": {
-                    "$ref": "#/definitions/domain.BlockGridConfig"
-                },
-                "carousel_config": {
-                    "$ref": "#/definitions/domain.CarouselConfig"
-                },
-                "case_config": {
-                    "$ref": "#/definitions/domain.CaseConfig"
-                },
-                "com_config_order": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "comment_config": {
-                    "$ref": "#/definitions/domain.CommentConfig"
-                },
-                "dir_doc_config": {
-                    "$ref": "#/definitions/domain.DirDocConfig"
-                },
-                "faq_config": {
-                    "$ref": "#/definitions/domain.FaqConfig"
-                },
-                "feature_config": {
-                    "$ref": "#/definitions/domain.FeatureConfig"
-                },
-                "img_text_config": {
-                    "$ref": "#/definitions/domain.ImgTextConfig"
-                },
-                "metrics_config": {
-                    "$ref": "#/definitions/domain.MetricsConfig"
-                },
-                "node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+        "v1.KBWebhookCreateReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "默认 true",
+                    "type": "boolean"
                 },
-                "nodes": {
+                "events": {
+                    "description": "为空时订阅全部事件",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "$ref": "#/definitions/domain.WebhookEvent"
                     }
                 },
-                "question_config": {
-                    "$ref": "#/definitions/domain.QuestionConfig"
-                },
-                "simple_doc_config": {
-                    "$ref": "#/definitions/domain.SimpleDocConfig"
+                "kb_id": {
+                    "type": "string"
                 },
-                "text_config": {
-                    "$ref": "#/definitions/domain.TextConfig"
+                "name": {
+                    "type": "string"
                 },
-                "text_img_config": {
-                    "$ref": "#/definitions/domain.TextImgConfig"
+                "secret": {
+                    "description": "generated when empty",
+                    "type": "string"
                 },
-                "type": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "domain.WebAppLandingTheme": {
+        "v1.KBWebhookCreateResp": {
             "type": "object",
             "properties": {
-                "name": {
+                "secret": {
+                    "description": "only returned on creation, used to verify the X-PandaWiki-Signature header",
+                    "type": "string"
+                },
+                "webhook_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.WecomAIBotSettings": {
+        "v1.KBWebhookDeliveryListResp": {
             "type": "object",
             "properties": {
-                "encodingaeskey": {
-                    "type": "string"
-                },
-                "is_enabled": {
-                    "type": "boolean"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.KBWebhookDelivery"
+                    }
                 },
-                "token": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.WidgetBotSettings": {
+        "v1.KBWebhookRedeliverReq": {
             "type": "object",
+            "required": [
+                "delivery_id",
+                "kb_id"
+            ],
             "properties": {
-                "btn_id": {
-                    "type": "string"
-                },
-                "btn_logo": {
+                "delivery_id": {
                     "type": "string"
                 },
-                "btn_position": {
+                "kb_id": {
                     "type": "string"
-                },
-                "btn_style": {
+                }
+            }
+        },
+        "v1.KBWebhookTestReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "webhook_id"
+            ],
+            "properties": {
+                "kb_id": {
                     "type": "string"
                 },
-                "btn_text": {
+                "webhook_id": {
                     "type": "string"
-                },
-                "copyright_hide_enabled": {
+                }
+            }
+        },
+        "v1.KBWebhookUpdateReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "webhook_id"
+            ],
+            "properties": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "copyright_info": {
-                    "type": "string"
+                "events": {
+                    "description": "为空时订阅全部事件",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebhookEvent"
+                    }
                 },
-                "disclaimer": {
+                "kb_id": {
                     "type": "string"
                 },
-                "is_open": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string",
+                    "minLength": 1
                 },
-                "modal_position": {
+                "secret": {
+                    "description": "empty keeps the stored secret",
                     "type": "string"
                 },
-                "placeholder": {
+                "url": {
                     "type": "string"
                 },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "recommend_questions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "search_mode": {
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.LoginReq": {
+            "type": "object",
+            "required": [
+                "account",
+                "password"
+            ],
+            "properties": {
+                "account": {
                     "type": "string"
                 },
-                "theme_mode": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp": {
+        "v1.LoginResp": {
             "type": "object",
             "properties": {
-                "auths": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NodeActionResp": {
+            "type": "object",
+            "properties": {
+                "inbound_links": {
+                    "description": "删除后失效的入链",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.AuthItem"
+                        "$ref": "#/definitions/v1.NodeLinkItem"
                     }
+                }
+            }
+        },
+        "v1.NodeBrokenLinkItem": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
                 },
-                "client_id": {
+                "error": {
                     "type": "string"
                 },
-                "client_secret": {
+                "node_id": {
                     "type": "string"
                 },
-                "proxy": {
+                "node_name": {
                     "type": "string"
                 },
-                "source_type": {
-                    "$ref": "#/definitions/consts.SourceType"
-                }
-            }
-        },
-        "github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp": {
-            "type": "object",
-            "properties": {
-                "auth_type": {
-                    "$ref": "#/definitions/consts.AuthType"
+                "reason": {
+                    "$ref": "#/definitions/v1.NodeBrokenLinkReason"
                 },
-                "license_edition": {
-                    "$ref": "#/definitions/consts.LicenseEdition"
+                "scope": {
+                    "$ref": "#/definitions/domain.NodeLinkScope"
                 },
-                "source_type": {
-                    "$ref": "#/definitions/consts.SourceType"
+                "status_code": {
+                    "type": "integer"
+                },
+                "target_node_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.NodeLinkType"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp": {
-            "type": "object"
+        "v1.NodeBrokenLinkReason": {
+            "type": "string",
+            "enum": [
+                "not_found",
+                "unpublished",
+                "not_visitable",
+                "http_error"
+            ],
+            "x-enum-comments": {
+                "NodeBrokenLinkReasonHTTPError": "外部链接检查失败",
+                "NodeBrokenLinkReasonNotFound": "目标文档不存在或已删除",
+                "NodeBrokenLinkReasonNotVisitable": "已发布内容链接到不可访问的文档",
+                "NodeBrokenLinkReasonUnpublished": "已发布内容链接到未发布的文档"
+            },
+            "x-enum-descriptions": [
+                "目标文档不存在或已删除",
+                "已发布内容链接到未发布的文档",
+                "已发布内容链接到不可访问的文档",
+                "外部链接检查失败"
+            ],
+            "x-enum-varnames": [
+                "NodeBrokenLinkReasonNotFound",
+                "NodeBrokenLinkReasonUnpublished",
+                "NodeBrokenLinkReasonNotVisitable",
+                "NodeBrokenLinkReasonHTTPError"
+            ]
         },
-        "github_com_chaitin_panda-wiki_domain.CheckModelReq": {
+        "v1.NodeDetailResp": {
             "type": "object",
-            "required": [
-                "base_url",
-                "model",
-                "provider",
-                "type"
-            ],
             "properties": {
-                "api_header": {
+                "content": {
                     "type": "string"
                 },
-                "api_key": {
+                "created_at": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "creator_account": {
                     "type": "string"
                 },
-                "base_url": {
+                "creator_id": {
                     "type": "string"
                 },
-                "model": {
+                "editor_account": {
                     "type": "string"
                 },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                "editor_id": {
+                    "type": "string"
                 },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/domain.NodeMeta"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "publisher_account": {
+                    "type": "string"
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "pv": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.NodeStatus"
                 },
                 "type": {
-                    "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
-                    ]
+                    "$ref": "#/definitions/domain.NodeType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.CheckModelResp": {
+        "v1.NodeFeedbackLinkReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id",
+                "release_id"
+            ],
             "properties": {
-                "content": {
+                "id": {
+                    "type": "integer"
+                },
+                "kb_id": {
                     "type": "string"
                 },
-                "error": {
+                "release_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.ModelListItem": {
+        "v1.NodeFeedbackListItem": {
             "type": "object",
             "properties": {
-                "api_header": {
-                    "type": "string"
-                },
-                "api_key": {
-                    "type": "string"
-                },
-                "api_version": {
-                    "description": "for azure openai",
+                "content": {
                     "type": "string"
                 },
-                "base_url": {
+                "created_at": {
                     "type": "string"
                 },
-                "completion_tokens": {
+                "id": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "info": {
+                    "$ref": "#/definitions/domain.DocumentFeedbackInfo"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
                 },
-                "model": {
+                "node_id": {
                     "type": "string"
                 },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
-                },
-                "prompt_tokens": {
-                    "type": "integer"
-                },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                },
-                "total_tokens": {
-                    "type": "integer"
+                "node_name": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/domain.ModelType"
-                }
-            }
-        },
-        "github_com_chaitin_panda-wiki_domain.ModelParam": {
-            "type": "object",
-            "properties": {
-                "context_window": {
-                    "type": "integer"
+                "node_release_id": {
+                    "type": "string"
                 },
-                "max_tokens": {
-                    "type": "integer"
+                "reason": {
+                    "$ref": "#/definitions/domain.DocumentFeedbackReason"
                 },
-                "r1_enabled": {
+                "republished": {
+                    "description": "the doc was published again after the feedback",
                     "type": "boolean"
                 },
-                "support_computer_use": {
-                    "type": "boolean"
+                "resolved_at": {
+                    "type": "string"
                 },
-                "support_images": {
-                    "type": "boolean"
+                "resolved_by": {
+                    "type": "string"
                 },
-                "support_prompt_cache": {
-                    "type": "boolean"
+                "resolved_release_id": {
+                    "type": "string"
                 },
-                "temperature": {
-                    "type": "number"
+                "score": {
+                    "$ref": "#/definitions/domain.ScoreType"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.DocumentFeedbackStatus"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.ModelProvider": {
-            "type": "string",
-            "enum": [
-                "BaiZhiCloud"
-            ],
-            "x-enum-varnames": [
-                "ModelProviderBrandBaiZhiCloud"
-            ]
-        },
-        "gocap.ChallengeData": {
+        "v1.NodeFeedbackListResp": {
             "type": "object",
             "properties": {
-                "challenge": {
-                    "$ref": "#/definitions/gocap.ChallengeItem"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodeFeedbackListItem"
+                    }
                 },
-                "expires": {
-                    "description": "过期时间,毫秒级时间戳",
+                "total": {
                     "type": "integer"
-                },
-                "token": {
-                    "description": "质询令牌",
-                    "type": "string"
                 }
             }
         },
-        "gocap.ChallengeItem": {
+        "v1.NodeFeedbackStatusReq": {
             "type": "object",
+            "required": [
+                "ids",
+                "kb_id",
+                "status"
+            ],
             "properties": {
-                "c": {
-                    "description": "质询数量",
-                    "type": "integer"
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "d": {
-                    "description": "质询难度",
-                    "type": "integer"
+                "kb_id": {
+                    "type": "string"
                 },
-                "s": {
-                    "description": "质询大小",
-                    "type": "integer"
+                "status": {
+                    "enum": [
+                        "open",
+                        "resolved"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DocumentFeedbackStatus"
+                        }
+                    ]
                 }
             }
         },
-        "gocap.VerificationResult": {
+        "v1.NodeFieldCreateReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "key",
+                "name",
+                "type"
+            ],
             "properties": {
-                "expires": {
-                    "description": "过期时间,毫秒级时间戳",
-                    "type": "integer"
-                },
-                "message": {
+                "kb_id": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "key": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "token": {
-                    "description": "验证令牌",
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.RoleType": {
-            "type": "string",
-            "enum": [
-                "assistant",
-                "user",
-                "system",
-                "tool"
-            ],
-            "x-enum-varnames": [
-                "Assistant",
-                "User",
-                "System",
-                "Tool"
-            ]
-        },
-        "share.ShareCommentLists": {
-            "type": "object",
-            "properties": {
-                "data": {
+                },
+                "options": {
+                    "description": "select 类型的可选值",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.ShareCommentListItem"
+                        "type": "string"
                     }
                 },
-                "total": {
+                "position": {
                     "type": "integer"
-                }
-            }
-        },
-        "v1.AuthGitHubReq": {
-            "type": "object",
-            "properties": {
-                "kb_id": {
-                    "type": "string"
                 },
-                "redirect_url": {
-                    "type": "string"
+                "type": {
+                    "enum": [
+                        "text",
+                        "select",
+                        "date",
+                        "user"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.NodeFieldType"
+                        }
+                    ]
                 }
             }
         },
-        "v1.AuthGitHubResp": {
+        "v1.NodeFieldCreateResp": {
             "type": "object",
             "properties": {
-                "url": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "v1.AuthItem": {
+        "v1.NodeFieldUpdateReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "avatar_url": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
-                    "type": "integer"
+                    "type": "string"
                 },
-                "ip": {
+                "kb_id": {
                     "type": "string"
                 },
-                "last_login_time": {
+                "name": {
                     "type": "string"
                 },
-                "source_type": {
-                    "$ref": "#/definitions/consts.SourceType"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "position": {
+                    "type": "integer"
                 }
             }
         },
-        "v1.AuthLoginSimpleReq": {
+        "v1.NodeLinkItem": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
+                "in_draft": {
+                    "description": "当前编辑内容中存在该链接",
+                    "type": "boolean"
+                },
+                "in_release": {
+                    "description": "最新发布内容中存在该链接",
+                    "type": "boolean"
+                },
+                "node_emoji": {
                     "type": "string"
-                }
-            }
-        },
-        "v1.AuthSetReq": {
-            "type": "object",
-            "required": [
-                "source_type"
-            ],
-            "properties": {
-                "client_id": {
+                },
+                "node_id": {
                     "type": "string"
                 },
-                "client_secret": {
+                "node_name": {
                     "type": "string"
                 },
-                "kb_id": {
+                "target_node_id": {
                     "type": "string"
                 },
-                "proxy": {
+                "target_node_name": {
                     "type": "string"
                 },
-                "source_type": {
-                    "enum": [
-                        "github"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.SourceType"
-                        }
-                    ]
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NodeLintRefreshReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
                 }
             }
         },
-        "v1.CommentLists": {
+        "v1.NodeLintRefreshResp": {
             "type": "object",
             "properties": {
-                "data": {
+                "node_count": {
+                    "description": "检查的文档数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.NodeLintReportItem": {
+            "type": "object",
+            "properties": {
+                "issues": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.CommentListItem"
+                        "$ref": "#/definitions/domain.NodeLintIssue"
                     }
                 },
-                "total": {
-                    "type": "integer"
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
                 }
             }
         },
-        "v1.ConversationListItems": {
+        "v1.NodeLintReportResp": {
             "type": "object",
             "properties": {
-                "data": {
+                "issue_count": {
+                    "type": "integer"
+                },
+                "node_count": {
+                    "description": "存在问题的文档数",
+                    "type": "integer"
+                },
+                "nodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.ConversationListItem"
+                        "$ref": "#/definitions/v1.NodeLintReportItem"
                     }
                 },
-                "total": {
-                    "type": "integer"
+                "rule_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "v1.CrawlerExportReq": {
+        "v1.NodePermissionEditReq": {
             "type": "object",
             "required": [
-                "doc_id",
-                "id",
+                "ids",
                 "kb_id"
             ],
             "properties": {
-                "doc_id": {
-                    "type": "string"
-                },
-                "file_type": {
-                    "type": "string"
+                "answerable_groups": {
+                    "description": "可被问答",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "kb_id": {
                     "type": "string"
                 },
-                "space_id": {
-                    "type": "string"
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "visible_groups": {
+                    "description": "导航内可见",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "visitable_groups": {
+                    "description": "可被访问",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "v1.CrawlerExportResp": {
+        "v1.NodePermissionEditResp": {
             "type": "object",
             "properties": {
-                "task_id": {
+                "inbound_links": {
+                    "description": "设为不可访问时，链接到这些文档的其他文档",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodeLinkItem"
+                    }
+                }
+            }
+        },
+        "v1.NodePermissionResp": {
+            "type": "object",
+            "properties": {
+                "answerable_groups": {
+                    "description": "可被问答",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeGroupDetail"
+                    }
+                },
+                "id": {
                     "type": "string"
+                },
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "visible_groups": {
+                    "description": "导航内可见",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeGroupDetail"
+                    }
+                },
+                "visitable_groups": {
+                    "description": "可被访问",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeGroupDetail"
+                    }
                 }
             }
         },
-        "v1.CrawlerParseReq": {
+        "v1.NodePushDoc": {
             "type": "object",
             "required": [
-                "crawler_source",
-                "kb_id"
+                "external_id",
+                "path"
             ],
             "properties": {
-                "crawler_source": {
-                    "$ref": "#/definitions/consts.CrawlerSource"
+                "content": {
+                    "type": "string"
                 },
-                "feishu_setting": {
-                    "$ref": "#/definitions/v1.FeishuSetting"
+                "content_type": {
+                    "description": "默认 md",
+                    "type": "string",
+                    "enum": [
+                        "md",
+                        "html"
+                    ]
                 },
-                "filename": {
-                    "type": "string"
+                "external_id": {
+                    "description": "stable id of the doc in the pipeline, pushing it again updates the same node",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "kb_id": {
+                "path": {
+                    "description": "e.g. sdk/go/client.md, folders are created for the directories",
                     "type": "string"
                 },
-                "key": {
+                "title": {
+                    "description": "默认为路径中去掉扩展名的文件名",
                     "type": "string"
                 }
             }
         },
-        "v1.CrawlerParseResp": {
+        "v1.NodePushReq": {
             "type": "object",
+            "required": [
+                "docs",
+                "kb_id",
+                "source"
+            ],
             "properties": {
                 "docs": {
-                    "$ref": "#/definitions/anydoc.Child"
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.NodePushDoc"
+                    }
                 },
-                "id": {
+                "kb_id": {
                     "type": "string"
-                }
-            }
-        },
-        "v1.CrawlerResultItem": {
-            "type": "object",
-            "properties": {
-                "content": {
+                },
+                "parent_id": {
+                    "description": "paths are relative to this folder, root when empty",
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/consts.CrawlerStatus"
+                "publish": {
+                    "description": "publish the created and changed docs in a new release",
+                    "type": "boolean"
                 },
-                "task_id": {
+                "release_message": {
+                    "description": "默认 \"push from \u003csource\u003e\"",
+                    "type": "string"
+                },
+                "release_tag": {
+                    "description": "默认 push-\u003ctime\u003e",
                     "type": "string"
+                },
+                "source": {
+                    "description": "namespace of the external ids, e.g. the repository pushing the docs",
+                    "type": "string",
+                    "maxLength": 128
                 }
             }
         },
-        "v1.CrawlerResultReq": {
+        "v1.NodePushResp": {
             "type": "object",
-            "required": [
-                "task_id"
-            ],
             "properties": {
-                "task_id": {
+                "release_id": {
+                    "description": "empty when nothing is published",
                     "type": "string"
+                },
+                "results": {
+                    "description": "in the order of the docs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodePushResult"
+                    }
                 }
             }
         },
-        "v1.CrawlerResultResp": {
+        "v1.NodePushResult": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
-                "content": {
+                "error": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "node_id": {
                     "type": "string"
                 },
                 "status": {
-                    "$ref": "#/definitions/consts.CrawlerStatus"
+                    "$ref": "#/definitions/domain.NodePushStatus"
                 }
             }
         },
-        "v1.CrawlerResultsReq": {
+        "v1.NodeRestudyReq": {
             "type": "object",
             "required": [
-                "task_ids"
+                "kb_id",
+                "node_ids"
             ],
             "properties": {
-                "task_ids": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "node_ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
                 }
             }
         },
-        "v1.CrawlerResultsResp": {
+        "v1.NodeRestudyResp": {
+            "type": "object"
+        },
+        "v1.NodeSEOUpdateReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1.CrawlerResultItem"
-                    }
+                "canonical_url": {
+                    "type": "string",
+                    "maxLength": 2048
                 },
-                "status": {
-                    "$ref": "#/definitions/consts.CrawlerStatus"
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "no_index": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         },
-        "v1.CreateUserReq": {
+        "v1.NodeSlugResp": {
             "type": "object",
-            "required": [
-                "account",
-                "password",
-                "role"
-            ],
             "properties": {
-                "account": {
+                "path": {
+                    "description": "/docs/\u003cslug\u003e, or /node/\u003cid\u003e without slug",
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
-                },
-                "role": {
-                    "enum": [
-                        "admin",
-                        "user"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.UserRole"
-                        }
-                    ]
+                "slug": {
+                    "type": "string"
                 }
             }
         },
-        "v1.CreateUserResp": {
+        "v1.NodeSlugUpdateReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
                 "id": {
                     "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         },
-        "v1.FeishuSetting": {
+        "v1.NodeTemplateCreateReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "name"
+            ],
             "properties": {
-                "app_id": {
+                "content": {
                     "type": "string"
                 },
-                "app_secret": {
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "md",
+                        "html"
+                    ]
+                },
+                "emoji": {
                     "type": "string"
                 },
-                "space_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "user_access_token": {
+                "name": {
+                    "type": "string"
+                },
+                "summary": {
                     "type": "string"
                 }
             }
         },
-        "v1.FileUploadResp": {
+        "v1.NodeTemplateCreateResp": {
             "type": "object",
             "properties": {
-                "key": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "v1.KBUserInviteReq": {
+        "v1.NodeTemplateFromNodeReq": {
             "type": "object",
             "required": [
                 "kb_id",
-                "perm",
-                "user_id"
+                "node_id"
             ],
             "properties": {
                 "kb_id": {
                     "type": "string"
                 },
-                "perm": {
-                    "enum": [
-                        "full_control",
-                        "doc_manage",
-                        "data_operate"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.UserKBPermission"
-                        }
-                    ]
+                "name": {
+                    "description": "为空时使用文档名称",
+                    "type": "string"
                 },
-                "user_id": {
+                "node_id": {
                     "type": "string"
                 }
             }
         },
-        "v1.KBUserListItemResp": {
+        "v1.NodeTemplateUpdateReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "account": {
+                "content": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "md",
+                        "html"
+                    ]
+                },
+                "emoji": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "perms": {
-                    "$ref": "#/definitions/consts.UserKBPermission"
+                "kb_id": {
+                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/consts.UserRole"
+                "name": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
                 }
             }
         },
-        "v1.KBUserUpdateReq": {
+        "v1.NodeTranslateReq": {
             "type": "object",
             "required": [
+                "id",
                 "kb_id",
-                "perm",
-                "user_id"
+                "locale"
             ],
             "properties": {
+                "id": {
+                    "description": "source node id",
+                    "type": "string"
+                },
                 "kb_id": {
                     "type": "string"
                 },
-                "perm": {
-                    "enum": [
-                        "full_control",
-                        "doc_manage",
-                        "data_operate"
-                    ],
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NodeTranslateResp": {
+            "type": "object",
+            "properties": {
+                "node_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.NodeTranslationItem": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_status": {
+                    "description": "draft when the translation has unpublished changes",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/consts.UserKBPermission"
+                            "$ref": "#/definitions/domain.NodeStatus"
                         }
                     ]
                 },
-                "user_id": {
+                "source_version": {
+                    "type": "integer"
+                },
+                "stale": {
+                    "description": "the source changed after the translation",
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.NodeTranslationStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "v1.LoginReq": {
+        "v1.NodeTranslationLinkReq": {
             "type": "object",
             "required": [
-                "account",
-                "password"
+                "id",
+                "kb_id",
+                "locale",
+                "node_id"
             ],
             "properties": {
-                "account": {
+                "id": {
+                    "description": "source node id",
                     "type": "string"
                 },
-                "password": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "description": "translated node id",
                     "type": "string"
                 }
             }
         },
-        "v1.LoginResp": {
+        "v1.NodeTranslationReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "node_id"
+            ],
             "properties": {
-                "token": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "description": "translated node id",
                     "type": "string"
                 }
             }
         },
-        "v1.NodeDetailResp": {
+        "v1.NodeTrashListItem": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "child_count": {
+                    "description": "一同删除的子文档数量",
+                    "type": "integer"
                 },
-                "created_at": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "creator_account": {
+                "deleted_by": {
                     "type": "string"
                 },
-                "creator_id": {
+                "deleted_by_account": {
                     "type": "string"
                 },
-                "editor_account": {
+                "emoji": {
                     "type": "string"
                 },
-                "editor_id": {
+                "expire_at": {
+                    "description": "到期后彻底删除",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "kb_id": {
-                    "type": "string"
-                },
-                "meta": {
-                    "$ref": "#/definitions/domain.NodeMeta"
-                },
                 "name": {
                     "type": "string"
                 },
                 "parent_id": {
+                    "description": "删除前的父文档",
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
-                },
-                "publisher_account": {
-                    "type": "string"
-                },
-                "publisher_id": {
+                "parent_name": {
+                    "description": "父文档已不存在时为空，恢复到根目录",
                     "type": "string"
                 },
-                "pv": {
-                    "type": "integer"
-                },
-                "status": {
-                    "$ref": "#/definitions/domain.NodeStatus"
-                },
                 "type": {
                     "$ref": "#/definitions/domain.NodeType"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "v1.NodePermissionEditReq": {
+        "v1.NodeTrashRestoreReq": {
             "type": "object",
             "required": [
                 "ids",
                 "kb_id"
             ],
             "properties": {
-                "answerable_groups": {
-                    "description": "可被问答",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
                 "ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
                 },
                 "kb_id": {
                     "type": "string"
+                }
+            }
+        },
+        "v1.NodeUpdateConflictResp": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "当前最新内容",
+                    "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
+                "edit_time": {
+                    "type": "string"
                 },
-                "visible_groups": {
-                    "description": "导航内可见",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "editor_account": {
+                    "type": "string"
                 },
-                "visitable_groups": {
-                    "description": "可被访问",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "editor_id": {
+                    "type": "string"
+                },
+                "has_conflict": {
+                    "description": "合并结果是否包含冲突标记",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "merged_content": {
+                    "description": "基于提交版本、提交内容与最新内容的三方合并结果",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "当前最新版本",
+                    "type": "integer"
                 }
             }
         },
-        "v1.NodePermissionEditResp": {
-            "type": "object"
-        },
-        "v1.NodePermissionResp": {
+        "v1.NodeUpdateResp": {
             "type": "object",
             "properties": {
-                "answerable_groups": {
-                    "description": "可被问答",
+                "lint_issues": {
+                    "description": "仅在更新内容时检查",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.NodeGroupDetail"
+                        "$ref": "#/definitions/domain.NodeLintIssue"
                     }
                 },
-                "id": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.OpenAPIImportReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "content": {
+                    "description": "json 或 yaml 内容",
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
+                "kb_id": {
+                    "type": "string"
                 },
-                "visible_groups": {
-                    "description": "导航内可见",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.NodeGroupDetail"
-                    }
+                "key": {
+                    "description": "上传文件返回的 key",
+                    "type": "string"
                 },
-                "visitable_groups": {
-                    "description": "可被访问",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.NodeGroupDetail"
-                    }
+                "name": {
+                    "description": "默认为 info.title",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "首次导入时的目标文件夹，默认根目录",
+                    "type": "string"
+                },
+                "spec_id": {
+                    "description": "再次导入已有的规范",
+                    "type": "string"
                 }
             }
         },
-        "v1.NodeRestudyReq": {
+        "v1.OpenAPIImportResp": {
             "type": "object",
-            "required": [
-                "kb_id",
-                "node_ids"
-            ],
             "properties": {
-                "kb_id": {
+                "result": {
+                    "$ref": "#/definitions/domain.OpenAPIImportResult"
+                },
+                "spec_id": {
                     "type": "string"
                 },
-                "node_ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "v1.NodeRestudyResp": {
-            "type": "object"
-        },
         "v1.ResetPasswordReq": {
             "type": "object",
             "required": [
@@ -8811,6 +15538,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/domain.ShareNodeDetailItem"
                     }
                 },
+                "locale": {
+                    "description": "language of a translated node, empty for a source node",
+                    "type": "string"
+                },
                 "meta": {
                     "$ref": "#/definitions/domain.NodeMeta"
                 },
@@ -8832,9 +15563,19 @@ const docTemplate = `{
                 "pv": {
                     "type": "integer"
                 },
+                "slug": {
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/domain.NodeStatus"
                 },
+                "translations": {
+                    "description": "language variants including this node",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ShareNodeTranslation"
+                    }
+                },
                 "type": {
                     "$ref": "#/definitions/domain.NodeType"
                 },
@@ -8843,6 +15584,162 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.ShareNodeResolveResp": {
+            "type": "object",
+            "properties": {
+                "node_id": {
+                    "description": "type node",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "type node, canonical path of the node",
+                    "type": "string"
+                },
+                "redirect_url": {
+                    "description": "type redirect, a site path or an external url",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "type redirect, 301 or 302",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.ShareResolveType"
+                }
+            }
+        },
+        "v1.ShareNodeSEOResp": {
+            "type": "object",
+            "properties": {
+                "canonical_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "json_ld": {
+                    "description": "每项输出为一个 application/ld+json 脚本",
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "keywords": {
+                    "type": "string"
+                },
+                "open_graph": {
+                    "$ref": "#/definitions/v1.ShareOpenGraph"
+                },
+                "robots": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "twitter": {
+                    "$ref": "#/definitions/v1.ShareTwitterCard"
+                }
+            }
+        },
+        "v1.ShareNodeSearchItem": {
+            "type": "object",
+            "properties": {
+                "emoji": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "匹配部分以 \u003cmark\u003e 高亮，已转义",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "所在目录名称",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "snippets": {
+                    "description": "匹配的内容片段，以 \u003cmark\u003e 高亮，已转义",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ShareNodeSearchResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ShareNodeSearchItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ShareOpenGraph": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "modified_time": {
+                    "type": "string"
+                },
+                "published_time": {
+                    "type": "string"
+                },
+                "site_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ShareTwitterCard": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.StatConversationDistributionResp": {
             "type": "object",
             "properties": {
@@ -8871,6 +15768,153 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.StatNegativeFeedbackQueryItem": {
+            "type": "object",
+            "properties": {
+                "avg_top_score": {
+                    "type": "number"
+                },
+                "count": {
+                    "description": "被点踩的回答数",
+                    "type": "integer"
+                },
+                "last_at": {
+                    "type": "string"
+                },
+                "last_conversation_id": {
+                    "type": "string"
+                },
+                "last_feedback": {
+                    "description": "最近一条反馈内容",
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.StatNodeEngagementItem": {
+            "type": "object",
+            "properties": {
+                "avg_dwell_seconds": {
+                    "type": "number"
+                },
+                "avg_scroll_depth": {
+                    "type": "number"
+                },
+                "bounce_rate": {
+                    "description": "很快离开且没有滚动",
+                    "type": "number"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "exit_rate": {
+                    "description": "从该文档离开站点",
+                    "type": "number"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "outbound_clicks": {
+                    "type": "integer"
+                },
+                "read_rate": {
+                    "description": "停留够久或读到了末尾",
+                    "type": "number"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.StatNodeFeedbackItem": {
+            "type": "object",
+            "properties": {
+                "helpful": {
+                    "type": "integer"
+                },
+                "helpful_rate": {
+                    "type": "number"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "not_helpful": {
+                    "type": "integer"
+                },
+                "open_count": {
+                    "description": "未解决的负面反馈",
+                    "type": "integer"
+                },
+                "reasons": {
+                    "description": "负面反馈的原因分布",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MapStrInt64"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.StatSearchGapDocReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "query"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "v1.StatSearchGapDocResp": {
+            "type": "object",
+            "properties": {
+                "node_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.StatSearchQueryItem": {
+            "type": "object",
+            "properties": {
+                "avg_result_count": {
+                    "type": "number"
+                },
+                "avg_top_score": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "last_at": {
+                    "type": "string"
+                },
+                "query": {
+                    "description": "最近一次的原始查询",
+                    "type": "string"
+                },
+                "zero_count": {
+                    "description": "无结果次数",
+                    "type": "integer"
+                }
+            }
+        },
         "v1.UserInfoResp": {
             "type": "object",
             "properties": {
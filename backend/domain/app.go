@@ -172,6 +172,10 @@ type AppSettings struct {
 	// MCP Server Settings
 	MCPServerSettings MCPServerSettings `json:"mcp_server_settings,omitempty"`
 	StatsSetting      StatsSetting      `json:"stats_setting"`
+	// ExportSettings offers the kb as ebook downloads on the share site
+	ExportSettings ExportSettings `json:"export_settings"`
+	// LLMsTxtSettings serves /llms.txt, /llms-full.txt and /node/<id>.md for AI agents
+	LLMsTxtSettings LLMsTxtSettings `json:"llms_txt_settings"`
 }
 
 type WeChatAppAdvancedSetting struct {
@@ -182,6 +186,15 @@ type WeChatAppAdvancedSetting struct {
 	Prompt             string   `json:"prompt,omitempty"`
 }
 
+type ExportSettings struct {
+	EPUBEnabled bool `json:"epub_enabled"`
+	PDFEnabled  bool `json:"pdf_enabled"`
+}
+
+type LLMsTxtSettings struct {
+	IsEnabled bool `json:"is_enabled"`
+}
+
 type StatsSetting struct {
 	PVEnable bool `json:"pv_enable"`
 }
@@ -575,6 +588,10 @@ type AppSettingsResp struct {
 	// MCP Server Settings
 	MCPServerSettings MCPServerSettings `json:"mcp_server_settings,omitempty"`
 	StatsSetting      StatsSetting      `json:"stats_setting"`
+	// ExportSettings offers the kb as ebook downloads on the share site
+	ExportSettings ExportSettings `json:"export_settings"`
+	// LLMsTxtSettings serves /llms.txt, /llms-full.txt and /node/<id>.md for AI agents
+	LLMsTxtSettings LLMsTxtSettings `json:"llms_txt_settings"`
 }
 
 type WebAppLandingConfigResp struct {
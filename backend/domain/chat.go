@@ -22,6 +22,11 @@ type ChatRequest struct {
 	RemoteIP string           `json:"-"`
 	Info     ConversationInfo `json:"-"`
 	Prompt   string           `json:"-"`
+
+	// Filter restricts retrieval to documents with these tags and custom field values
+	Filter *NodeMetaFilter `json:"filter,omitempty"`
+	// Locale of the asker, retrieval prefers the translations in this language
+	Locale string `json:"locale,omitempty"`
 }
 
 type ChatRagOnlyRequest struct {
@@ -31,6 +36,9 @@ type ChatRagOnlyRequest struct {
 
 	UserInfo UserInfo `json:"user_info"`
 	AppType  AppType  `json:"app_type" validate:"required,oneof=1 2"`
+
+	Filter *NodeMetaFilter `json:"filter,omitempty"`
+	Locale string          `json:"locale,omitempty"`
 }
 
 type ConversationInfo struct {
@@ -85,6 +93,9 @@ type ChatSearchReq struct {
 
 	RemoteIP   string `json:"-"`
 	AuthUserID uint   `json:"-"`
+
+	Filter *NodeMetaFilter `json:"filter,omitempty"`
+	Locale string          `json:"locale,omitempty"`
 }
 
 type ChatSearchResp struct {
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chaitin/panda-wiki/consts"
+)
+
+type CrawlerSyncStatus string
+
+const (
+	CrawlerSyncStatusIdle    CrawlerSyncStatus = "idle"
+	CrawlerSyncStatusQueued  CrawlerSyncStatus = "queued" // run requested, picked up by the consumer within a minute
+	CrawlerSyncStatusRunning CrawlerSyncStatus = "running"
+)
+
+type CrawlerSyncRunStatus string
+
+const (
+	CrawlerSyncRunStatusRunning   CrawlerSyncRunStatus = "running"
+	CrawlerSyncRunStatusCompleted CrawlerSyncRunStatus = "completed"
+	CrawlerSyncRunStatusFailed    CrawlerSyncRunStatus = "failed"
+)
+
+type CrawlerSyncTrigger string
+
+const (
+	CrawlerSyncTriggerManual   CrawlerSyncTrigger = "manual"
+	CrawlerSyncTriggerSchedule CrawlerSyncTrigger = "schedule"
+)
+
+// table: kb_crawler_syncs, saved crawler parse parameters which are imported again on a schedule
+type KBCrawlerSync struct {
+	ID            string                   `json:"id" gorm:"primaryKey"`
+	KBID          string                   `json:"kb_id"`
+	Name          string                   `json:"name"`
+	CrawlerSource consts.CrawlerSource     `json:"crawler_source"`
+	Key           string                   `json:"key"`
+	Filename      string                   `json:"filename"`
+	FeishuSetting CrawlerSyncFeishuSetting `json:"-" gorm:"type:jsonb"`
+	ParentID      string                   `json:"parent_id"` // folder the docs are synced into, root when empty
+	Schedule      string                   `json:"schedule"`  // standard cron spec, only synced manually when empty
+	AutoPublish   bool                     `json:"auto_publish"`
+	MaxNode       int                      `json:"-"` // node limit of the edition, refreshed on every change by an admin
+
+	Status        CrawlerSyncStatus    `json:"status"`
+	NextRunAt     *time.Time           `json:"next_run_at"`
+	LastRunAt     *time.Time           `json:"last_run_at"`
+	LastRunStatus CrawlerSyncRunStatus `json:"last_run_status"`
+
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (KBCrawlerSync) TableName() string {
+	return "kb_crawler_syncs"
+}
+
+type CrawlerSyncFeishuSetting struct {
+	UserAccessToken string `json:"user_access_token"`
+	AppID           string `json:"app_id"`
+	AppSecret       string `json:"app_secret"`
+	SpaceId         string `json:"space_id"`
+}
+
+func (s CrawlerSyncFeishuSetting) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *CrawlerSyncFeishuSetting) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid crawler sync feishu setting type:", value))
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+// table: kb_crawler_sync_docs, source doc ids mapped to the nodes created for them
+type KBCrawlerSyncDoc struct {
+	SyncID      string    `json:"sync_id" gorm:"primaryKey"`
+	DocKey      string    `json:"doc_key" gorm:"primaryKey"` // source doc id, prefixed with folder: for the folder of a doc with children
+	KBID        string    `json:"kb_id"`
+	NodeID      string    `json:"node_id"`
+	Type        NodeType  `json:"type"`
+	Title       string    `json:"title"`
+	ContentHash string    `json:"content_hash"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (KBCrawlerSyncDoc) TableName() string {
+	return "kb_crawler_sync_docs"
+}
+
+// table: kb_crawler_sync_runs
+type KBCrawlerSyncRun struct {
+	ID         string               `json:"id" gorm:"primaryKey"`
+	SyncID     string               `json:"sync_id"`
+	KBID       string               `json:"kb_id"`
+	Trigger    CrawlerSyncTrigger   `json:"trigger"`
+	Status     CrawlerSyncRunStatus `json:"status"`
+	Created    int                  `json:"created"`
+	Updated    int                  `json:"updated"`
+	Deleted    int                  `json:"deleted"`
+	Unchanged  int                  `json:"unchanged"`
+	Failed     int                  `json:"failed"`
+	DocErrors  CrawlerSyncDocErrors `json:"doc_errors" gorm:"type:jsonb"`
+	Error      string               `json:"error"`
+	ReleaseID  string               `json:"release_id"` // kb release created by auto publish
+	StartedAt  time.Time            `json:"started_at"`
+	FinishedAt *time.Time           `json:"finished_at"`
+}
+
+func (KBCrawlerSyncRun) TableName() string {
+	return "kb_crawler_sync_runs"
+}
+
+type CrawlerSyncDocError struct {
+	DocID string `json:"doc_id"`
+	Title string `json:"title"`
+	Error string `json:"error"`
+}
+
+type CrawlerSyncDocErrors []CrawlerSyncDocError
+
+func (e CrawlerSyncDocErrors) Value() (driver.Value, error) {
+	if e == nil {
+		e = CrawlerSyncDocErrors{}
+	}
+	return json.Marshal(e)
+}
+
+func (e *CrawlerSyncDocErrors) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid crawler sync doc errors type:", value))
+	}
+	return json.Unmarshal(bytes, e)
+}
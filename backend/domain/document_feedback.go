@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DocumentFeedback 文档页面的“是否有帮助”反馈，记录反馈时的文档发布版本
+type DocumentFeedback struct {
+	ID                int64                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	KbID              string                 `json:"kb_id"`
+	UserID            string                 `json:"user_id"`
+	NodeID            string                 `json:"node_id"`
+	NodeReleaseID     string                 `json:"node_release_id"`
+	SessionID         string                 `json:"-"`
+	Score             ScoreType              `json:"score"` // 1 有帮助, -1 没有帮助
+	Reason            DocumentFeedbackReason `json:"reason"`
+	Content           string                 `json:"content"`
+	Info              DocumentFeedbackInfo   `json:"info" gorm:"type:jsonb"`
+	Status            DocumentFeedbackStatus `json:"status"`
+	ResolvedBy        string                 `json:"resolved_by"`
+	ResolvedAt        *time.Time             `json:"resolved_at"`
+	ResolvedReleaseID string                 `json:"resolved_release_id"` // 解决该反馈的文档发布版本
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+func (DocumentFeedback) TableName() string {
+	return "document_feedbacks"
+}
+
+type DocumentFeedbackReason string
+
+const (
+	DocumentFeedbackReasonInaccurate DocumentFeedbackReason = "inaccurate"
+	DocumentFeedbackReasonOutdated   DocumentFeedbackReason = "outdated"
+	DocumentFeedbackReasonUnclear    DocumentFeedbackReason = "unclear"
+	DocumentFeedbackReasonIncomplete DocumentFeedbackReason = "incomplete"
+	DocumentFeedbackReasonBrokenLink DocumentFeedbackReason = "broken_link"
+	DocumentFeedbackReasonOther      DocumentFeedbackReason = "other"
+)
+
+type DocumentFeedbackStatus string
+
+const (
+	DocumentFeedbackStatusOpen     DocumentFeedbackStatus = "open"
+	DocumentFeedbackStatusResolved DocumentFeedbackStatus = "resolved"
+)
+
+type DocumentFeedbackInfo struct {
+	AuthUserID uint   `json:"auth_user_id"`
+	UserName   string `json:"user_name"`
+	Email      string `json:"email"`
+	Avatar     string `json:"avatar"`
+	RemoteIP   string `json:"remote_ip"`
+}
+
+func (d *DocumentFeedbackInfo) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *DocumentFeedbackInfo) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid document feedback info type:", value))
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+type DocumentFeedbackReq struct {
+	NodeID  string                 `json:"node_id" validate:"required"`
+	Score   ScoreType              `json:"score" validate:"oneof=1 -1"`
+	Reason  DocumentFeedbackReason `json:"reason" validate:"omitempty,oneof=inaccurate outdated unclear incomplete broken_link other"`
+	Content string                 `json:"content" validate:"max=500"`
+}
@@ -15,3 +15,65 @@ var ErrPermissionDenied = errors.New("permission denied")
 var ErrInternalServerError = errors.New("internal server error")
 
 var ErrMaxNodeLimitReached = errors.New("max node limit reached")
+
+var ErrNodeVersionConflict = errors.New("node version conflict")
+
+var ErrNodeTrashIDInUse = errors.New("id of the trashed node is used by another node")
+
+var ErrInvalidNodeField = errors.New("invalid node field")
+
+var ErrKBExportRunning = errors.New("an export of this knowledge base is already running")
+
+var ErrKBExportNotReady = errors.New("export is not completed")
+
+var ErrInvalidImportArchive = errors.New("invalid import archive")
+
+var ErrKBImportRunning = errors.New("an import into this knowledge base is already running")
+
+var ErrKBNotPublished = errors.New("knowledge base has no published content")
+
+var ErrKBExportRootNotFound = errors.New("export folder is not published")
+
+var ErrKBExportDisabled = errors.New("export format is not enabled")
+
+var ErrPDFRenderNotConfigured = errors.New("pdf render service is not configured")
+
+var ErrInvalidGitSource = errors.New("invalid git source")
+
+var ErrGitSyncRunning = errors.New("a sync of this git source is already running")
+
+var ErrInvalidCrawlerSync = errors.New("invalid crawler sync")
+
+var ErrCrawlerSyncRunning = errors.New("a run of this crawler sync is already queued or running")
+
+var ErrInvalidOpenAPISpec = errors.New("invalid openapi spec")
+
+var ErrInvalidWebhookURL = errors.New("webhook url must be an http or https url")
+
+var ErrWebhookURLNotPublic = errors.New("webhook url must resolve to a public address")
+
+var ErrInvalidNodePush = errors.New("invalid node push")
+
+var ErrSitemapNotAvailable = errors.New("sitemap is not available")
+
+var ErrLLMsTxtDisabled = errors.New("llms.txt is not enabled")
+
+var ErrInvalidNodeTranslation = errors.New("invalid node translation")
+
+var ErrNodeTranslationRunning = errors.New("a translation of this node is already running")
+
+var ErrDocumentFeedbackDisabled = errors.New("document feedback is disabled")
+
+var ErrDocumentFeedbackNodeNotPublished = errors.New("document is not published")
+
+var ErrStatEngagementNodeNotPublished = errors.New("document is not published in this knowledge base")
+
+var ErrInvalidDocumentFeedbackRelease = errors.New("release does not belong to the feedback node")
+
+var ErrInvalidNodeSlug = errors.New("slug may only contain letters, digits, '-', '_' and '/' between words")
+
+var ErrNodeSlugExists = errors.New("slug is used by another document")
+
+var ErrInvalidRedirect = errors.New("invalid redirect")
+
+var ErrRedirectExists = errors.New("a redirect of this path already exists")
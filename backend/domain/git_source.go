@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type GitAuthType string
+
+const (
+	GitAuthTypeNone  GitAuthType = "none"
+	GitAuthTypeHTTPS GitAuthType = "https" // username and password or token
+	GitAuthTypeSSH   GitAuthType = "ssh"   // private key
+)
+
+type GitSyncStatus string
+
+const (
+	GitSyncStatusIdle      GitSyncStatus = "idle"
+	GitSyncStatusRunning   GitSyncStatus = "running"
+	GitSyncStatusCompleted GitSyncStatus = "completed"
+	GitSyncStatusFailed    GitSyncStatus = "failed"
+)
+
+// table: kb_git_sources
+type KBGitSource struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	KBID string `json:"kb_id"`
+	Name string `json:"name"`
+	// RepoURL is a https or ssh url, or the path of a local bare repo under the configured local repo dir
+	RepoURL  string `json:"repo_url"`
+	Branch   string `json:"branch"`    // default branch of the repo when empty
+	SubDir   string `json:"sub_dir"`   // only sync this directory of the repo
+	ParentID string `json:"parent_id"` // folder the repo is synced into, root when empty
+
+	AuthType GitAuthType `json:"auth_type"`
+	Username string      `json:"username"`
+	Password string      `json:"-"` // https password or token
+	SSHKey   string      `json:"-"` // ssh private key
+
+	Status       GitSyncStatus `json:"status"`
+	Error        string        `json:"error"`
+	LastCommit   string        `json:"last_commit"` // commit of the last completed sync, base of the next diff
+	LastResult   GitSyncResult `json:"last_result" gorm:"type:jsonb"`
+	LastSyncedAt *time.Time    `json:"last_synced_at"`
+
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (KBGitSource) TableName() string {
+	return "kb_git_sources"
+}
+
+type GitSyncResult struct {
+	Created  int      `json:"created"`
+	Updated  int      `json:"updated"`
+	Deleted  int      `json:"deleted"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (r *GitSyncResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *GitSyncResult) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid git sync result type:", value))
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// table: kb_git_source_nodes, repo paths relative to the sub dir mapped to the nodes created for them
+type KBGitSourceNode struct {
+	SourceID string   `json:"source_id" gorm:"primaryKey"`
+	Path     string   `json:"path" gorm:"primaryKey"`
+	KBID     string   `json:"kb_id"`
+	NodeID   string   `json:"node_id"`
+	Type     NodeType `json:"type"`
+}
+
+func (KBGitSourceNode) TableName() string {
+	return "kb_git_source_nodes"
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chaitin/panda-wiki/consts"
+)
+
+// ExportBucket keeps export archives out of the public static-file bucket
+const ExportBucket = "kb-export"
+
+type KBExportStatus string
+
+const (
+	KBExportStatusPending   KBExportStatus = "pending"
+	KBExportStatusRunning   KBExportStatus = "running"
+	KBExportStatusCompleted KBExportStatus = "completed"
+	KBExportStatusFailed    KBExportStatus = "failed"
+)
+
+type KBExportFormat string
+
+const (
+	KBExportFormatMarkdown KBExportFormat = "markdown" // zip of markdown files, re-importable
+	KBExportFormatEPUB     KBExportFormat = "epub"     // published content
+	KBExportFormatPDF      KBExportFormat = "pdf"      // published content, rendered from html
+)
+
+// IsBook reports whether the format is built from published content with a cover and table of contents
+func (f KBExportFormat) IsBook() bool {
+	return f == KBExportFormatEPUB || f == KBExportFormatPDF
+}
+
+func (f KBExportFormat) FileExt() string {
+	switch f {
+	case KBExportFormatEPUB:
+		return ".epub"
+	case KBExportFormatPDF:
+		return ".pdf"
+	default:
+		return ".zip"
+	}
+}
+
+func (f KBExportFormat) ContentType() string {
+	switch f {
+	case KBExportFormatEPUB:
+		return "application/epub+zip"
+	case KBExportFormatPDF:
+		return "application/pdf"
+	default:
+		return "application/zip"
+	}
+}
+
+// KBImportMode decides what happens to nodes of an archive which already exist in the kb
+type KBImportMode string
+
+const (
+	KBImportModeSkip      KBImportMode = "skip"      // keep existing nodes
+	KBImportModeOverwrite KBImportMode = "overwrite" // replace existing nodes with the archive
+	KBImportModeMerge     KBImportMode = "merge"     // replace existing nodes only when the archive is newer
+)
+
+// table: kb_exports
+type KBExport struct {
+	ID         string         `json:"id" gorm:"primaryKey"`
+	KBID       string         `json:"kb_id"`
+	Format     KBExportFormat `json:"format"`
+	RootID     string         `json:"root_id"` // folder to export, whole kb when empty
+	Status     KBExportStatus `json:"status"`
+	FileKey    string         `json:"-"` // object key in ExportBucket
+	FileSize   int64          `json:"file_size"`
+	NodeCount  int            `json:"node_count"`
+	Error      string         `json:"error"`
+	CreatorID  string         `json:"creator_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	FinishedAt *time.Time     `json:"finished_at"`
+}
+
+func (KBExport) TableName() string {
+	return "kb_exports"
+}
+
+// table: kb_imports, an import goes through the same states as an export
+type KBImport struct {
+	ID          string          `json:"id" gorm:"primaryKey"`
+	KBID        string          `json:"kb_id"`
+	FileKey     string          `json:"-"` // archive in Bucket, removed once imported
+	ParentID    string          `json:"parent_id"`
+	Mode        KBImportMode    `json:"mode"`
+	PreserveIDs bool            `json:"preserve_ids"`
+	Status      KBExportStatus  `json:"status"`
+	Result      *KBImportResult `json:"result" gorm:"type:jsonb"` // set when completed
+	Error       string          `json:"error"`
+	CreatorID   string          `json:"creator_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	FinishedAt  *time.Time      `json:"finished_at"`
+}
+
+func (KBImport) TableName() string {
+	return "kb_imports"
+}
+
+type KBImportResult struct {
+	Created  int               `json:"created"`
+	Updated  int               `json:"updated"`
+	Skipped  int               `json:"skipped"`
+	IDMap    map[string]string `json:"id_map"` // archive node id -> node id in kb
+	Warnings []string          `json:"warnings"`
+}
+
+func (r *KBImportResult) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid kb import result type: %T", value)
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+func (r *KBImportResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+const (
+	KBExportManifestFile    = "manifest.json"
+	KBExportManifestVersion = 1
+	KBExportAssetsDir       = "assets"
+	KBExportFolderIndexFile = "_index.md" // folder metadata, folders have no content
+)
+
+// KBExportManifest is written to the root of the archive and drives re-import
+type KBExportManifest struct {
+	Version    int                      `json:"version"`
+	ExportedAt time.Time                `json:"exported_at"`
+	KB         KBExportManifestKB       `json:"kb"`
+	Fields     []*NodeField             `json:"fields"`
+	Nodes      []*KBExportManifestNode  `json:"nodes"` // parents always come before children
+	Assets     []*KBExportManifestAsset `json:"assets"`
+}
+
+type KBExportManifestKB struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type KBExportManifestNode struct {
+	ID       string     `json:"id"`
+	ParentID string     `json:"parent_id"`
+	Type     NodeType   `json:"type"`
+	Name     string     `json:"name"`
+	Path     string     `json:"path"` // markdown file in the archive
+	Status   NodeStatus `json:"status"`
+	Position float64    `json:"position"`
+}
+
+type KBExportManifestAsset struct {
+	Key     string `json:"key"`  // original object key in static-file bucket
+	Path    string `json:"path"` // file in the archive, empty when fetching failed
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// NodeExportFrontMatter is the yaml header of every exported markdown file
+type NodeExportFrontMatter struct {
+	ID          string                `yaml:"id"`
+	ParentID    string                `yaml:"parent_id,omitempty"`
+	Name        string                `yaml:"name"`
+	Type        NodeType              `yaml:"type"`
+	Status      NodeStatus            `yaml:"status"`
+	Position    float64               `yaml:"position"`
+	Emoji       string                `yaml:"emoji,omitempty"`
+	Summary     string                `yaml:"summary,omitempty"`
+	ContentType string                `yaml:"content_type,omitempty"` // content type before conversion to markdown
+	Tags        []string              `yaml:"tags,omitempty"`
+	Fields      map[string]string     `yaml:"fields,omitempty"`
+	Permissions NodeExportPermissions `yaml:"permissions"`
+	CreatedAt   time.Time             `yaml:"created_at"`
+	UpdatedAt   time.Time             `yaml:"updated_at"`
+}
+
+type NodeExportPermissions struct {
+	Answerable       consts.NodeAccessPerm `yaml:"answerable"`
+	Visitable        consts.NodeAccessPerm `yaml:"visitable"`
+	Visible          consts.NodeAccessPerm `yaml:"visible"`
+	AnswerableGroups []int                 `yaml:"answerable_groups,omitempty"` // auth group ids when partial
+	VisitableGroups  []int                 `yaml:"visitable_groups,omitempty"`
+	VisibleGroups    []int                 `yaml:"visible_groups,omitempty"`
+}
+
+// KBExportNode is the published state of a node used to build books
+type KBExportNode struct {
+	ID          string          `json:"id"`
+	ParentID    string          `json:"parent_id"`
+	Type        NodeType        `json:"type"`
+	Name        string          `json:"name"`
+	Content     string          `json:"content"`
+	Meta        NodeMeta        `json:"meta" gorm:"type:jsonb"`
+	Position    float64         `json:"position"`
+	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const NodeSlugPathPrefix = "/docs/"
+
+// table: kb_redirects, old node urls and arbitrary paths of a kb redirected to a node or an external url
+type KBRedirect struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	KBID         string     `json:"kb_id"`
+	SourcePath   string     `json:"source_path"`    // normalized path, e.g. /node/<id> or /docs/<slug>
+	TargetNodeID string     `json:"target_node_id"` // either target node or target url is set
+	TargetURL    string     `json:"target_url"`
+	Permanent    bool       `json:"permanent"` // 301 when true, otherwise 302
+	Hits         int64      `json:"hits"`
+	LastHitAt    *time.Time `json:"last_hit_at"`
+	CreatedBy    string     `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (KBRedirect) TableName() string {
+	return "kb_redirects"
+}
+
+// table: node_slugs, human readable alias of a node served at /docs/<slug>
+type NodeSlug struct {
+	NodeID    string    `json:"node_id" gorm:"primaryKey"`
+	KBID      string    `json:"kb_id"`
+	Slug      string    `json:"slug"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (NodeSlug) TableName() string {
+	return "node_slugs"
+}
+
+// NodeSlugPath returns the path a slug is served at
+func NodeSlugPath(slug string) string {
+	return NodeSlugPathPrefix + slug
+}
+
+// NodePath returns the path of a node, the slug path when the node has one
+func NodePath(nodeID, slug string) string {
+	if slug != "" {
+		return NodeSlugPath(slug)
+	}
+	return "/node/" + nodeID
+}
+
+type ShareResolveType string
+
+const (
+	ShareResolveTypeNode     ShareResolveType = "node"
+	ShareResolveTypeRedirect ShareResolveType = "redirect"
+	ShareResolveTypeNotFound ShareResolveType = "not_found"
+)
@@ -171,3 +171,25 @@ var NodeFIMFormatter = `
 {{.Suffix}}
 </FIM_SUFFIX>
 `
+
+var NodeTranslateSystemPrompt = `
+你是一位专业的技术文档翻译。你的任务是将用户提供的文档片段翻译为目标语言 {{.Locale}}（BCP 47 语言标签）。
+
+规则：
+1. 忠实准确地翻译全部内容，不要增删信息，不要总结或解释
+2. 完整保留原文的格式：Markdown 语法、HTML 标签及其属性、表格结构、换行和缩进
+3. 不要翻译代码块、行内代码、链接地址、图片地址、变量名和命令
+4. 专有名词和产品名称保持一致，没有通用译名时保留原文
+5. 输入可能是长文档中的一个片段，首尾可能不完整，按原样翻译即可
+
+输出要求：
+1. 只返回译文
+2. 不要添加任何说明、前后缀或额外的代码块标记
+3. 不要输出 <DOCUMENT> 标签
+`
+
+var NodeTranslateFormatter = `
+<DOCUMENT>
+{{.Content}}
+</DOCUMENT>
+`
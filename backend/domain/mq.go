@@ -4,12 +4,14 @@ const (
 	VectorTaskTopic       = "apps.panda-wiki.vector.task"
 	AnydocTaskExportTopic = "anydoc.persistence.doc.task.export"
 	RagDocUpdateTopic     = "rag.doc.update"
+	WebhookDeliveryTopic  = "apps.panda-wiki.webhook.delivery"
 )
 
 var TopicConsumerName = map[string]string{
 	VectorTaskTopic:       "panda-wiki-vector-consumer",
 	AnydocTaskExportTopic: "anydoc-task-export-consumer",
 	RagDocUpdateTopic:     "rag-doc-update-consumer",
+	WebhookDeliveryTopic:  "panda-wiki-webhook-consumer",
 }
 
 type NodeReleaseVectorRequest struct {
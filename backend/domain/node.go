@@ -53,6 +53,7 @@ type Node struct {
 	EditorId    string          `json:"editor_id"`
 	EditTime    time.Time       `json:"edit_time"`
 	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
+	Version     int64           `json:"version"` // 乐观锁版本号，名称或内容变更时递增
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -61,6 +62,21 @@ func (Node) TableName() string {
 	return "nodes"
 }
 
+// table: node_versions
+type NodeVersion struct {
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	KBID      string    `json:"kb_id"`
+	NodeID    string    `json:"node_id"`
+	Version   int64     `json:"version"`
+	Content   string    `json:"content"`
+	EditorId  string    `json:"editor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (NodeVersion) TableName() string {
+	return "node_versions"
+}
+
 type RagInfo struct {
 	Status  consts.NodeRagInfoStatus `json:"status"`
 	Message string                   `json:"message"`
@@ -118,9 +134,11 @@ type NodeGroupDetail struct {
 }
 
 type NodeMeta struct {
-	Summary     string `json:"summary"`
-	Emoji       string `json:"emoji"`
-	ContentType string `json:"content_type"`
+	Summary     string     `json:"summary"`
+	Emoji       string     `json:"emoji"`
+	ContentType string     `json:"content_type"`
+	Tags        NodeTags   `json:"tags,omitempty"`   // 标签
+	Fields      NodeFields `json:"fields,omitempty"` // 自定义字段值，key 为 NodeField.Key
 }
 
 func (d *NodeMeta) Value() (driver.Value, error) {
@@ -150,11 +168,22 @@ type CreateNodeReq struct {
 	MaxNode int `json:"-"`
 
 	Position *float64 `json:"position"`
+
+	// TemplateID fills content, emoji, summary and content type from a node template
+	TemplateID string `json:"template_id"`
+
+	Tags   []string          `json:"tags"`
+	Fields map[string]string `json:"fields"`
+
+	// ID keeps the node id of an imported archive, a new id is generated when empty
+	ID string `json:"-"`
 }
 
 type GetNodeListReq struct {
-	KBID   string `json:"kb_id" query:"kb_id" validate:"required"`
-	Search string `json:"search" query:"search"`
+	KBID   string   `json:"kb_id" query:"kb_id" validate:"required"`
+	Search string   `json:"search" query:"search"`
+	Tags   []string `json:"tags" query:"tags[]"`     // 同时包含所有标签
+	Fields []string `json:"fields" query:"fields[]"` // key:value，同时满足所有字段
 }
 
 type NodeListItemResp struct {
@@ -176,6 +205,10 @@ type NodeListItemResp struct {
 	Editor      string          `json:"editor"`
 	PublisherId string          `json:"publisher_id" gorm:"-"`
 	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
+	Tags        NodeTags        `json:"tags" gorm:"type:jsonb"`
+	Fields      NodeFields      `json:"fields" gorm:"type:jsonb"`
+	Content     string          `json:"-"`                           // selected only when searching
+	Snippets    []string        `json:"snippets,omitempty" gorm:"-"` // matched content with <mark> highlights
 }
 
 type NodeContentChunk struct {
@@ -183,9 +216,10 @@ type NodeContentChunk struct {
 	KBID  string `json:"kb_id"`
 	DocID string `json:"doc_id"`
 
-	Seq     uint   `json:"seq"`
-	Name    string `json:"name"`
-	Content string `json:"content"`
+	Seq     uint    `json:"seq"`
+	Name    string  `json:"name"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"` // retrieval similarity
 }
 
 type RankedNodeChunks struct {
@@ -197,6 +231,15 @@ type RankedNodeChunks struct {
 	Chunks        []*NodeContentChunk
 }
 
+// TopScore returns the highest similarity of the chunks
+func (n *RankedNodeChunks) TopScore() float64 {
+	score := 0.0
+	for _, chunk := range n.Chunks {
+		score = max(score, chunk.Score)
+	}
+	return score
+}
+
 func (n *RankedNodeChunks) GetURL(baseURL string) string {
 	return fmt.Sprintf("%s/node/%s", baseURL, n.NodeID)
 }
@@ -232,17 +275,23 @@ type NodeActionReq struct {
 	IDs    []string `json:"ids" validate:"required"`
 	KBID   string   `json:"kb_id" validate:"required"`
 	Action string   `json:"action" validate:"required,oneof=delete"`
+	// delete only, urls of the deleted nodes are redirected to this node
+	ReplacementNodeID string `json:"replacement_node_id"`
 }
 
 type UpdateNodeReq struct {
-	ID          string   `json:"id" validate:"required"`
-	KBID        string   `json:"kb_id" validate:"required"`
-	Name        *string  `json:"name"`
-	Content     *string  `json:"content"`
-	Emoji       *string  `json:"emoji"`
-	Summary     *string  `json:"summary"`
-	Position    *float64 `json:"position"`
-	ContentType *string  `json:"content_type"`
+	ID          string             `json:"id" validate:"required"`
+	KBID        string             `json:"kb_id" validate:"required"`
+	Name        *string            `json:"name"`
+	Content     *string            `json:"content"`
+	Emoji       *string            `json:"emoji"`
+	Summary     *string            `json:"summary"`
+	Position    *float64           `json:"position"`
+	ContentType *string            `json:"content_type"`
+	Tags        *[]string          `json:"tags"`
+	Fields      *map[string]string `json:"fields"`
+	// Version is the node version the editor started from, conflicts are detected when it is stale
+	Version *int64 `json:"version"`
 }
 
 type ShareNodeListItemResp struct {
@@ -255,6 +304,7 @@ type ShareNodeListItemResp struct {
 	Meta        NodeMeta        `json:"meta"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
+	Slug        string          `json:"slug,omitempty" gorm:"-"`
 }
 
 type ShareNodeDetailItem struct {
@@ -267,6 +317,7 @@ type ShareNodeDetailItem struct {
 	Meta        NodeMeta               `json:"meta"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 	Permissions NodePermissions        `json:"permissions" gorm:"type:jsonb"`
+	Slug        string                 `json:"slug,omitempty" gorm:"-"`
 	Children    []*ShareNodeDetailItem `json:"children,omitempty"`
 }
 
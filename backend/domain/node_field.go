@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type NodeFieldType string
+
+const (
+	NodeFieldTypeText   NodeFieldType = "text"
+	NodeFieldTypeSelect NodeFieldType = "select" // 值必须为 Options 之一
+	NodeFieldTypeDate   NodeFieldType = "date"   // 2006-01-02
+	NodeFieldTypeUser   NodeFieldType = "user"   // 用户 ID
+)
+
+const NodeFieldDateLayout = "2006-01-02"
+
+// table: node_fields, admin defined custom fields of nodes per kb
+type NodeField struct {
+	ID        string         `json:"id" gorm:"primaryKey"`
+	KBID      string         `json:"kb_id"`
+	Key       string         `json:"key"` // NodeMeta.Fields 中的 key，创建后不可修改
+	Name      string         `json:"name"`
+	Type      NodeFieldType  `json:"type"`
+	Options   pq.StringArray `json:"options" gorm:"type:text[]" swaggertype:"array,string"`
+	Position  int            `json:"position"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (NodeField) TableName() string {
+	return "node_fields"
+}
+
+type NodeTags []string
+
+func (t NodeTags) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+func (t *NodeTags) Scan(value any) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid node tags type:", value))
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+type NodeFields map[string]string
+
+func (f NodeFields) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+func (f *NodeFields) Scan(value any) error {
+	if value == nil {
+		*f = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid node fields type:", value))
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// NodeMetaFilter matches nodes containing all tags and all field values
+type NodeMetaFilter struct {
+	Tags   []string          `json:"tags"`
+	Fields map[string]string `json:"fields"`
+}
+
+func (f *NodeMetaFilter) IsEmpty() bool {
+	return f == nil || (len(f.Tags) == 0 && len(f.Fields) == 0)
+}
+
+type NodeTagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
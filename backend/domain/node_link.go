@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+type NodeLinkType string
+
+const (
+	NodeLinkTypeInternal NodeLinkType = "internal" // 指向本知识库文档的 /node/<id> 链接
+	NodeLinkTypeExternal NodeLinkType = "external" // 外部 http(s) 链接
+)
+
+type NodeLinkScope string
+
+const (
+	NodeLinkScopeDraft   NodeLinkScope = "draft"   // 解析自当前编辑内容
+	NodeLinkScopeRelease NodeLinkScope = "release" // 解析自最新发布内容
+)
+
+type NodeLinkStatus uint8
+
+const (
+	NodeLinkStatusUnchecked NodeLinkStatus = 0
+	NodeLinkStatusOK        NodeLinkStatus = 1
+	NodeLinkStatusBroken    NodeLinkStatus = 2
+)
+
+// table: node_links
+type NodeLink struct {
+	ID           int64          `json:"id" gorm:"primaryKey"`
+	KBID         string         `json:"kb_id"`
+	NodeID       string         `json:"node_id"` // 链接所在文档
+	Scope        NodeLinkScope  `json:"scope"`
+	Type         NodeLinkType   `json:"type"`
+	URL          string         `json:"url"`
+	TargetNodeID string         `json:"target_node_id"` // 仅内部链接
+	Status       NodeLinkStatus `json:"status"`         // 仅外部链接，由定时任务检查
+	StatusCode   int            `json:"status_code"`
+	Error        string         `json:"error"`
+	CheckedAt    *time.Time     `json:"checked_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func (NodeLink) TableName() string {
+	return "node_links"
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type NodeLintRule string
+
+const (
+	NodeLintRuleEmptyHeading        NodeLintRule = "empty_heading"
+	NodeLintRuleSkippedHeadingLevel NodeLintRule = "skipped_heading_level" // e.g. h2 followed by h4
+	NodeLintRuleImageMissingAlt     NodeLintRule = "image_missing_alt"
+	NodeLintRuleExternalImage       NodeLintRule = "external_image" // not stored in the static file bucket
+	NodeLintRuleMissingNodeLink     NodeLintRule = "missing_node_link"
+	NodeLintRuleUnpublishedNodeLink NodeLintRule = "unpublished_node_link"
+	NodeLintRuleOversizedTable      NodeLintRule = "oversized_table"
+	NodeLintRuleBlockWord           NodeLintRule = "block_word"
+)
+
+// table: node_lint_issues, issues found in the latest saved or published content of a node
+type NodeLintIssue struct {
+	ID           int64        `json:"-" gorm:"primaryKey"`
+	KBID         string       `json:"kb_id"`
+	NodeID       string       `json:"node_id"`
+	Rule         NodeLintRule `json:"rule"`
+	Line         int          `json:"line"` // 1-based line of the content
+	Text         string       `json:"text"` // the heading, image, link, table or word the issue is about
+	Message      string       `json:"message"`
+	TargetNodeID string       `json:"target_node_id,omitempty"` // node links only
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+func (NodeLintIssue) TableName() string {
+	return "node_lint_issues"
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type NodePushStatus string
+
+const (
+	NodePushStatusCreated   NodePushStatus = "created"
+	NodePushStatusUpdated   NodePushStatus = "updated"
+	NodePushStatusUnchanged NodePushStatus = "unchanged"
+	NodePushStatusFailed    NodePushStatus = "failed"
+)
+
+// table: kb_node_pushes, nodes of the documents pushed with the content api, keyed by source and external id
+type KBNodePush struct {
+	KBID       string    `json:"kb_id" gorm:"primaryKey"`
+	Source     string    `json:"source" gorm:"primaryKey"`
+	ExternalID string    `json:"external_id" gorm:"primaryKey"` // folders are keyed by "folder:<path>"
+	NodeID     string    `json:"node_id"`
+	Type       NodeType  `json:"type"`
+	Path       string    `json:"path"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (KBNodePush) TableName() string {
+	return "kb_node_pushes"
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// table: node_seo, admin overrides of the generated seo data of a node
+type NodeSEO struct {
+	NodeID       string    `json:"node_id" gorm:"primaryKey"`
+	KBID         string    `json:"kb_id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Keywords     string    `json:"keywords"`
+	Image        string    `json:"image"`
+	CanonicalURL string    `json:"canonical_url"`
+	NoIndex      bool      `json:"no_index"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (NodeSEO) TableName() string {
+	return "node_seo"
+}
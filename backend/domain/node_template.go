@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// table: node_templates
+type NodeTemplate struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	KBID        string    `json:"kb_id" gorm:"index"`
+	Name        string    `json:"name"`
+	Emoji       string    `json:"emoji"`
+	ContentType string    `json:"content_type"`
+	Content     string    `json:"content"` // 支持占位符，如 {{date}} {{author}} {{parent_name}}
+	Summary     string    `json:"summary"` // 默认摘要，同样支持占位符
+	CreatorId   string    `json:"creator_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (NodeTemplate) TableName() string {
+	return "node_templates"
+}
+
+// 模板占位符
+const (
+	NodeTemplateVarDate       = "date"        // 2006-01-02
+	NodeTemplateVarTime       = "time"        // 15:04
+	NodeTemplateVarDatetime   = "datetime"    // 2006-01-02 15:04
+	NodeTemplateVarAuthor     = "author"      // 创建者账号
+	NodeTemplateVarTitle      = "title"       // 文档名称
+	NodeTemplateVarParentName = "parent_name" // 父目录名称
+	NodeTemplateVarKBName     = "kb_name"     // 知识库名称
+)
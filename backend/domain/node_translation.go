@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type NodeTranslationStatus string
+
+const (
+	NodeTranslationStatusReady       NodeTranslationStatus = "ready"
+	NodeTranslationStatusTranslating NodeTranslationStatus = "translating" // machine translation is running
+	NodeTranslationStatusFailed      NodeTranslationStatus = "failed"
+)
+
+// table: node_translations, a translated node is a node of its own linked to the source node
+type NodeTranslation struct {
+	NodeID       string `json:"node_id" gorm:"primaryKey"`
+	KBID         string `json:"kb_id"`
+	SourceNodeID string `json:"source_node_id"`
+	Locale       string `json:"locale"` // BCP 47 tag, unique per source node
+	// SourceVersion is the version of the source node the translation is up to date with,
+	// the translation is stale when the source has a newer version
+	SourceVersion int64                 `json:"source_version"`
+	Status        NodeTranslationStatus `json:"status"`
+	Error         string                `json:"error"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+func (NodeTranslation) TableName() string {
+	return "node_translations"
+}
+
+// ShareNodeTranslation is a language variant of a published node
+type ShareNodeTranslation struct {
+	ID     string `json:"id"`
+	Locale string `json:"locale"` // empty for the source node
+	Name   string `json:"name"`
+}
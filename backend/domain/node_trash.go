@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// table: node_trash, soft deleted nodes waiting to be restored or purged
+type NodeTrash struct {
+	NodeID    string            `json:"node_id" gorm:"primaryKey"`
+	KBID      string            `json:"kb_id"`
+	RootID    string            `json:"root_id"` // 同一次删除中最上层被删除的文档，恢复时整体恢复
+	Name      string            `json:"name"`
+	Type      NodeType          `json:"type"`
+	ParentID  string            `json:"parent_id"` // 删除前的父文档
+	Snapshot  NodeTrashSnapshot `json:"-" gorm:"type:jsonb"`
+	DeletedBy string            `json:"deleted_by"`
+	DeletedAt time.Time         `json:"deleted_at"`
+}
+
+func (NodeTrash) TableName() string {
+	return "node_trash"
+}
+
+// NodeTrashSnapshot keeps the node and its releases, release doc ids stay in rag until purged
+type NodeTrashSnapshot struct {
+	Node     *Node          `json:"node"`
+	Releases []*NodeRelease `json:"releases"`
+}
+
+func (s NodeTrashSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *NodeTrashSnapshot) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid node trash snapshot type:", value))
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+// DocIDs returns the rag doc ids of the node and its releases
+func (s *NodeTrashSnapshot) DocIDs() []string {
+	docIDs := make([]string, 0)
+	if s.Node != nil && s.Node.DocID != "" {
+		docIDs = append(docIDs, s.Node.DocID)
+	}
+	for _, release := range s.Releases {
+		if release.DocID != "" {
+			docIDs = append(docIDs, release.DocID)
+		}
+	}
+	return docIDs
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// table: kb_openapi_specs, an imported api spec which later versions are imported into again
+type KBOpenAPISpec struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	KBID     string `json:"kb_id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"` // folder the tag folders are created in, root when empty
+
+	Title          string              `json:"title"`   // info.title of the last imported spec
+	Version        string              `json:"version"` // info.version of the last imported spec
+	LastResult     OpenAPIImportResult `json:"last_result" gorm:"type:jsonb"`
+	LastImportedAt *time.Time          `json:"last_imported_at"`
+
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (KBOpenAPISpec) TableName() string {
+	return "kb_openapi_specs"
+}
+
+type OpenAPIImportResult struct {
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Removed   int      `json:"removed"`  // operations gone from the spec, their documents are marked as removed
+	Restored  int      `json:"restored"` // removed operations which are back in the spec
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+func (r *OpenAPIImportResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *OpenAPIImportResult) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid openapi import result type:", value))
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// table: kb_openapi_spec_nodes, operations by operation id and tags by "tag:<name>" mapped to their nodes
+type KBOpenAPISpecNode struct {
+	SpecID  string   `json:"spec_id" gorm:"primaryKey"`
+	Key     string   `json:"key" gorm:"primaryKey"`
+	KBID    string   `json:"kb_id"`
+	NodeID  string   `json:"node_id"`
+	Type    NodeType `json:"type"`
+	Removed bool     `json:"removed"`
+}
+
+func (KBOpenAPISpecNode) TableName() string {
+	return "kb_openapi_spec_nodes"
+}
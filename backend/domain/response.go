@@ -13,5 +13,6 @@ var (
 	ErrCodeNil              = PWResponseErrCode{"success", true, nil, 0}
 	ErrCodePermissionDenied = PWResponseErrCode{"Permission Denied", false, nil, 40003}
 	ErrCodeNotFound         = PWResponseErrCode{"Not Found", false, nil, 40004}
+	ErrCodeConflict         = PWResponseErrCode{"Conflict", false, nil, 40009}
 	ErrCodeInternalError    = PWResponseErrCode{"Internal Error", false, nil, 50001}
 )
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type SearchQuerySource string
+
+const (
+	SearchQuerySourceSearch SearchQuerySource = "search" // /share/v1/chat/search and widget search
+	SearchQuerySourceChat   SearchQuerySource = "chat"   // questions asked in chat
+)
+
+// table: kb_search_queries, one row per search or chat question
+type KBSearchQuery struct {
+	ID              int64             `json:"id" gorm:"primaryKey"`
+	KBID            string            `json:"kb_id"`
+	Source          SearchQuerySource `json:"source"`
+	AppType         AppType           `json:"app_type"` // chat only
+	Query           string            `json:"query"`
+	NormalizedQuery string            `json:"normalized_query"` // lower case with collapsed spaces, used for grouping
+	ResultCount     int               `json:"result_count"`     // retrieved documents
+	TopScore        float64           `json:"top_score"`        // highest similarity of the retrieved chunks
+	ConversationID  string            `json:"conversation_id"`
+	MessageID       string            `json:"message_id"` // assistant answer, carries the feedback of the question
+	RemoteIP        string            `json:"remote_ip"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func (KBSearchQuery) TableName() string {
+	return "kb_search_queries"
+}
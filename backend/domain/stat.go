@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -38,6 +41,98 @@ type StatPageReq struct {
 	NodeID string        `json:"node_id"`
 }
 
+const (
+	// StatEngagementMaxDwellSeconds caps the dwell time of one visit, longer visits are usually a forgotten tab
+	StatEngagementMaxDwellSeconds = 30 * 60
+	// a visit is read when the visitor stayed long enough or scrolled through most of the doc
+	StatEngagementReadDwellSeconds = 30
+	StatEngagementReadScrollDepth  = 75
+	// a visit is bounced when the visitor left quickly without scrolling
+	StatEngagementBounceDwellSeconds = 10
+	StatEngagementBounceScrollDepth  = 25
+)
+
+// StatPageEngagement 文档页面的阅读行为，在离开页面时上报，与stat_pages一样保留24小时
+type StatPageEngagement struct {
+	ID                 int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	KBID               string    `json:"kb_id"`
+	NodeID             string    `json:"node_id"`
+	SessionID          string    `json:"session_id"`
+	DwellSeconds       int       `json:"dwell_seconds"`
+	ScrollDepth        int       `json:"scroll_depth"` // 最大滚动深度百分比
+	CopyCount          int       `json:"copy_count"`
+	OutboundClickCount int       `json:"outbound_click_count"`
+	Exit               bool      `json:"exit"` // 访客从该页面离开了站点
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func (StatPageEngagement) TableName() string {
+	return "stat_page_engagements"
+}
+
+type StatPageEngagementReq struct {
+	NodeID             string `json:"node_id" validate:"required"`
+	DwellSeconds       int    `json:"dwell_seconds" validate:"min=0,max=86400"` // 页面可见的时长
+	ScrollDepth        int    `json:"scroll_depth" validate:"min=0,max=100"`
+	CopyCount          int    `json:"copy_count" validate:"min=0,max=1000"`
+	OutboundClickCount int    `json:"outbound_click_count" validate:"min=0,max=1000"`
+	Exit               bool   `json:"exit"`
+}
+
+// NodeEngagement 单个文档的阅读行为汇总
+type NodeEngagement struct {
+	Views          int64 `json:"views"`
+	DwellSeconds   int64 `json:"dwell_seconds"`
+	ScrollDepth    int64 `json:"scroll_depth"` // 滚动深度之和
+	Reads          int64 `json:"reads"`
+	Bounces        int64 `json:"bounces"`
+	Exits          int64 `json:"exits"`
+	Copies         int64 `json:"copies"`
+	OutboundClicks int64 `json:"outbound_clicks"`
+}
+
+func (e *NodeEngagement) Add(o *NodeEngagement) {
+	e.Views += o.Views
+	e.DwellSeconds += o.DwellSeconds
+	e.ScrollDepth += o.ScrollDepth
+	e.Reads += o.Reads
+	e.Bounces += o.Bounces
+	e.Exits += o.Exits
+	e.Copies += o.Copies
+	e.OutboundClicks += o.OutboundClicks
+}
+
+// NodeEngagements node_id -> 阅读行为汇总
+type NodeEngagements map[string]*NodeEngagement
+
+func (m NodeEngagements) Merge(o NodeEngagements) {
+	for nodeID, engagement := range o {
+		if m[nodeID] == nil {
+			m[nodeID] = &NodeEngagement{}
+		}
+		m[nodeID].Add(engagement)
+	}
+}
+
+func (m *NodeEngagements) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *NodeEngagements) Scan(value interface{}) error {
+	if value == nil {
+		*m = NodeEngagements{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("NodeEngagements: Scan source is not []byte")
+	}
+	return json.Unmarshal(bytes, m)
+}
+
 type HotPage struct {
 	Scene    StatPageScene `json:"scene"`
 	NodeID   string        `json:"node_id"`
@@ -85,19 +180,20 @@ type ConversationDistribution struct {
 
 // StatPageHour 按小时聚合的统计数据
 type StatPageHour struct {
-	ID                       int64       `json:"id" gorm:"primaryKey;autoIncrement"`
-	KbID                     string      `json:"kb_id" gorm:"index"`
-	Hour                     time.Time   `json:"hour" gorm:"index"` // 按小时截断的时间
-	IPCount                  int64       `json:"ip_count"`
-	SessionCount             int64       `json:"session_count"`
-	PageVisitCount           int64       `json:"page_visit_count"`
-	ConversationCount        int64       `json:"conversation_count"`
-	GeoCount                 MapStrInt64 `json:"geo_count" gorm:"type:jsonb"`
-	ConversationDistribution MapStrInt64 `json:"conversation_distribution" gorm:"type:jsonb"`
-	HotRefererHost           MapStrInt64 `json:"hot_referer_host" gorm:"type:jsonb"`
-	HotPage                  MapStrInt64 `json:"hot_page" gorm:"type:jsonb"`
-	HotBrowser               MapStrInt64 `json:"hot_browser" gorm:"type:jsonb"`
-	HotOS                    MapStrInt64 `json:"hot_os" gorm:"type:jsonb"`
+	ID                       int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	KbID                     string          `json:"kb_id" gorm:"index"`
+	Hour                     time.Time       `json:"hour" gorm:"index"` // 按小时截断的时间
+	IPCount                  int64           `json:"ip_count"`
+	SessionCount             int64           `json:"session_count"`
+	PageVisitCount           int64           `json:"page_visit_count"`
+	ConversationCount        int64           `json:"conversation_count"`
+	GeoCount                 MapStrInt64     `json:"geo_count" gorm:"type:jsonb"`
+	ConversationDistribution MapStrInt64     `json:"conversation_distribution" gorm:"type:jsonb"`
+	HotRefererHost           MapStrInt64     `json:"hot_referer_host" gorm:"type:jsonb"`
+	HotPage                  MapStrInt64     `json:"hot_page" gorm:"type:jsonb"`
+	HotBrowser               MapStrInt64     `json:"hot_browser" gorm:"type:jsonb"`
+	HotOS                    MapStrInt64     `json:"hot_os" gorm:"type:jsonb"`
+	NodeEngagement           NodeEngagements `json:"node_engagement" gorm:"type:jsonb"`
 
 	CreatedAt time.Time `json:"created_at"`
 }
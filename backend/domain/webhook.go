@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventNodeCreated          WebhookEvent = "node.created"
+	WebhookEventNodeUpdated          WebhookEvent = "node.updated"
+	WebhookEventNodePublished        WebhookEvent = "node.published"
+	WebhookEventNodeDeleted          WebhookEvent = "node.deleted" // moved to trash
+	WebhookEventKBReleaseCreated     WebhookEvent = "kb_release.created"
+	WebhookEventCommentCreated       WebhookEvent = "comment.created"
+	WebhookEventFeedbackReceived     WebhookEvent = "feedback.received" // feedback on an ai answer
+	WebhookEventNodeFeedbackReceived WebhookEvent = "node_feedback.received"
+	WebhookEventConversationStarted  WebhookEvent = "conversation.started"
+	WebhookEventPing                 WebhookEvent = "ping" // sent by the test endpoint only
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending  WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusRetrying WebhookDeliveryStatus = "retrying"
+	WebhookDeliveryStatusSuccess  WebhookDeliveryStatus = "success"
+	WebhookDeliveryStatusFailed   WebhookDeliveryStatus = "failed"
+)
+
+// request headers of a delivery, the signature is hex encoded hmac-sha256 of "<timestamp>.<body>" with the webhook secret
+const (
+	WebhookHeaderEvent     = "X-PandaWiki-Event"
+	WebhookHeaderDelivery  = "X-PandaWiki-Delivery"
+	WebhookHeaderTimestamp = "X-PandaWiki-Timestamp"
+	WebhookHeaderSignature = "X-PandaWiki-Signature"
+)
+
+// table: kb_webhooks, outbound webhooks of a knowledge base
+type KBWebhook struct {
+	ID      string         `json:"id" gorm:"primaryKey"`
+	KBID    string         `json:"kb_id"`
+	Name    string         `json:"name"`
+	URL     string         `json:"url"`
+	Secret  string         `json:"-"`
+	Events  pq.StringArray `json:"events" gorm:"type:text[]" swaggertype:"array,string"` // subscribed to all events when empty
+	Enabled bool           `json:"enabled"`
+
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (KBWebhook) TableName() string {
+	return "kb_webhooks"
+}
+
+func (w *KBWebhook) Subscribed(event WebhookEvent) bool {
+	return w.Enabled && (len(w.Events) == 0 || slices.Contains(w.Events, string(event)))
+}
+
+// table: kb_webhook_deliveries, one row per event and webhook, kept for the delivery log
+type KBWebhookDelivery struct {
+	ID        string                `json:"id" gorm:"primaryKey"`
+	WebhookID string                `json:"webhook_id"`
+	KBID      string                `json:"kb_id"`
+	Event     WebhookEvent          `json:"event"`
+	Payload   string                `json:"payload"` // request body, the same body is sent again on retries
+	Status    WebhookDeliveryStatus `json:"status"`
+	Attempts  int                   `json:"attempts"`
+
+	// result of the last attempt
+	ResponseCode int    `json:"response_code"`
+	ResponseBody string `json:"response_body"` // truncated
+	Error        string `json:"error"`
+	Duration     int64  `json:"duration"` // ms
+
+	NextRetryAt *time.Time `json:"next_retry_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (KBWebhookDelivery) TableName() string {
+	return "kb_webhook_deliveries"
+}
+
+// WebhookPayload is the request body of a delivery
+type WebhookPayload struct {
+	ID        string       `json:"id"` // event id, the same for all webhooks notified of the event
+	Event     WebhookEvent `json:"event"`
+	KBID      string       `json:"kb_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      any          `json:"data"`
+}
+
+type WebhookNodeData struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      NodeType `json:"type"`
+	ParentID  string   `json:"parent_id"`
+	ReleaseID string   `json:"release_id,omitempty"` // node.published only
+}
+
+type WebhookKBReleaseData struct {
+	ID      string   `json:"id"`
+	Tag     string   `json:"tag"`
+	Message string   `json:"message"`
+	NodeIDs []string `json:"node_ids"`
+}
+
+type WebhookCommentData struct {
+	ID       string        `json:"id"`
+	NodeID   string        `json:"node_id"`
+	ParentID string        `json:"parent_id"`
+	UserName string        `json:"user_name"`
+	Content  string        `json:"content"`
+	Status   CommentStatus `json:"status"` // comments wait for review when moderation is enabled
+}
+
+type WebhookFeedbackData struct {
+	ConversationID string       `json:"conversation_id"`
+	MessageID      string       `json:"message_id"`
+	Score          ScoreType    `json:"score"`
+	Type           FeedbackType `json:"type"`
+	Content        string       `json:"content"`
+}
+
+type WebhookNodeFeedbackData struct {
+	ID            int64                  `json:"id"`
+	NodeID        string                 `json:"node_id"`
+	NodeReleaseID string                 `json:"node_release_id"`
+	Score         ScoreType              `json:"score"`
+	Reason        DocumentFeedbackReason `json:"reason"`
+	Content       string                 `json:"content"`
+}
+
+type WebhookConversationData struct {
+	ID      string `json:"id"`
+	AppID   string `json:"app_id"`
+	Subject string `json:"subject"`
+}
+
+type WebhookPingData struct {
+	WebhookID string `json:"webhook_id"`
+	Message   string `json:"message"`
+}
+
+// WebhookDeliveryRequest is queued for every delivery attempt
+type WebhookDeliveryRequest struct {
+	DeliveryID string `json:"delivery_id"`
+}
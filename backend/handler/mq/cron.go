@@ -12,18 +12,28 @@ import (
 )
 
 type CronHandler struct {
-	logger      *log.Logger
-	statRepo    *pg.StatRepository
-	statUseCase *usecase.StatUseCase
-	nodeUseCase *usecase.NodeUsecase
+	logger           *log.Logger
+	statRepo         *pg.StatRepository
+	statUseCase      *usecase.StatUseCase
+	nodeUseCase      *usecase.NodeUsecase
+	syncUseCase      *usecase.CrawlerSyncUsecase
+	webhookUseCase   *usecase.WebhookUsecase
+	translateUseCase *usecase.NodeTranslationUsecase
+	exportUseCase    *usecase.KBExportUsecase
+	gitSyncUseCase   *usecase.GitSyncUsecase
 }
 
-func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUseCase *usecase.StatUseCase, nodeUseCase *usecase.NodeUsecase) (*CronHandler, error) {
+func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUseCase *usecase.StatUseCase, nodeUseCase *usecase.NodeUsecase, syncUseCase *usecase.CrawlerSyncUsecase, webhookUseCase *usecase.WebhookUsecase, translateUseCase *usecase.NodeTranslationUsecase, exportUseCase *usecase.KBExportUsecase, gitSyncUseCase *usecase.GitSyncUsecase) (*CronHandler, error) {
 	h := &CronHandler{
-		statRepo:    statRepo,
-		statUseCase: statUseCase,
-		nodeUseCase: nodeUseCase,
-		logger:      logger.WithModule("handler.mq.cron"),
+		statRepo:         statRepo,
+		statUseCase:      statUseCase,
+		nodeUseCase:      nodeUseCase,
+		syncUseCase:      syncUseCase,
+		webhookUseCase:   webhookUseCase,
+		translateUseCase: translateUseCase,
+		exportUseCase:    exportUseCase,
+		gitSyncUseCase:   gitSyncUseCase,
+		logger:           logger.WithModule("handler.mq.cron"),
 	}
 	cron := cron.New()
 
@@ -60,6 +70,55 @@ func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUse
 	}
 	h.logger.Info("add cron job", log.String("cron_id", "sync_rag_node_status"))
 
+	// 每小时检查一批超过一天未检查的外部链接
+	if _, err := cron.AddFunc("41 * * * *", h.CheckExternalLinks); err != nil {
+		h.logger.Error("failed to add cron job for checking external links", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "check_external_links"))
+
+	// 每天3点彻底删除超过保留期的回收站文档
+	if _, err := cron.AddFunc("15 3 * * *", h.PurgeExpiredNodeTrash); err != nil {
+		h.logger.Error("failed to add cron job for purging expired node trash", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "purge_expired_node_trash"))
+
+	// 每分钟启动到期和手动触发的爬虫同步
+	if _, err := cron.AddFunc("* * * * *", h.RunDueCrawlerSyncs); err != nil {
+		h.logger.Error("failed to add cron job for running crawler syncs", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "run_due_crawler_syncs"))
+
+	// 每10分钟把超时未完成的翻译、导出、导入、Git 同步和爬虫同步标记为失败，服务重启中断的任务也会在超时后被回收
+	if _, err := cron.AddFunc("*/10 * * * *", h.FailStaleJobs); err != nil {
+		h.logger.Error("failed to add cron job for failing stale jobs", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "fail_stale_jobs"))
+
+	// 每分钟重新投递到期重试和丢失的 webhook 消息
+	if _, err := cron.AddFunc("* * * * *", h.RetryWebhookDeliveries); err != nil {
+		h.logger.Error("failed to add cron job for retrying webhook deliveries", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "retry_webhook_deliveries"))
+
+	// 每天4点清理30天前的 webhook 投递记录
+	if _, err := cron.AddFunc("20 4 * * *", h.CleanupWebhookDeliveries); err != nil {
+		h.logger.Error("failed to add cron job for cleaning up webhook deliveries", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "cleanup_webhook_deliveries"))
+
+	// 每天4点清理180天前的搜索查询记录
+	if _, err := cron.AddFunc("30 4 * * *", h.CleanupOldSearchQueries); err != nil {
+		h.logger.Error("failed to add cron job for cleaning up old search queries", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "cleanup_old_search_queries"))
+
 	cron.Start()
 	h.logger.Info("start cron jobs")
 	return h, nil
@@ -104,6 +163,16 @@ func (h *CronHandler) CleanupOldHourlyStats() {
 	h.logger.Info("cleanup old hourly stats successful")
 }
 
+func (h *CronHandler) CleanupOldSearchQueries() {
+	h.logger.Info("cleanup old search queries start")
+	err := h.statUseCase.CleanupOldSearchQueries(context.Background())
+	if err != nil {
+		h.logger.Error("cleanup old search queries failed", log.Error(err))
+		return
+	}
+	h.logger.Info("cleanup old search queries successful")
+}
+
 func (h *CronHandler) SyncRagNodeStatus() {
 	h.logger.Info("sync rag node status")
 	err := h.nodeUseCase.SyncRagNodeStatus(context.Background())
@@ -113,3 +182,60 @@ func (h *CronHandler) SyncRagNodeStatus() {
 	}
 	h.logger.Info("sync rag node status successful")
 }
+
+func (h *CronHandler) CheckExternalLinks() {
+	h.logger.Info("check external links start")
+	err := h.nodeUseCase.CheckExternalLinks(context.Background())
+	if err != nil {
+		h.logger.Error("check external links failed", log.Error(err))
+		return
+	}
+	h.logger.Info("check external links successful")
+}
+
+func (h *CronHandler) PurgeExpiredNodeTrash() {
+	h.logger.Info("purge expired node trash start")
+	err := h.nodeUseCase.PurgeExpiredNodeTrash(context.Background())
+	if err != nil {
+		h.logger.Error("purge expired node trash failed", log.Error(err))
+		return
+	}
+	h.logger.Info("purge expired node trash successful")
+}
+
+func (h *CronHandler) RunDueCrawlerSyncs() {
+	if err := h.syncUseCase.RunDueSyncs(context.Background()); err != nil {
+		h.logger.Error("run due crawler syncs failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) FailStaleJobs() {
+	ctx := context.Background()
+	if err := h.translateUseCase.FailStale(ctx); err != nil {
+		h.logger.Error("fail stale node translations failed", log.Error(err))
+	}
+	if err := h.exportUseCase.FailStale(ctx); err != nil {
+		h.logger.Error("fail stale kb exports and imports failed", log.Error(err))
+	}
+	if err := h.gitSyncUseCase.FailStale(ctx); err != nil {
+		h.logger.Error("fail stale git syncs failed", log.Error(err))
+	}
+	if err := h.syncUseCase.FailStale(ctx); err != nil {
+		h.logger.Error("fail stale crawler syncs failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) RetryWebhookDeliveries() {
+	if err := h.webhookUseCase.RetryDueDeliveries(context.Background()); err != nil {
+		h.logger.Error("retry webhook deliveries failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) CleanupWebhookDeliveries() {
+	h.logger.Info("cleanup webhook deliveries start")
+	if err := h.webhookUseCase.CleanupOldDeliveries(context.Background()); err != nil {
+		h.logger.Error("cleanup webhook deliveries failed", log.Error(err))
+		return
+	}
+	h.logger.Info("cleanup webhook deliveries successful")
+}
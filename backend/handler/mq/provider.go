@@ -14,6 +14,7 @@ import (
 type MQHandlers struct {
 	RAGMQHandler        *RAGMQHandler
 	RagDocUpdateHandler *RagDocUpdateHandler
+	WebhookHandler      *WebhookDeliveryHandler
 	StatCronHandler     *CronHandler
 }
 
@@ -28,9 +29,19 @@ var ProviderSet = wire.NewSet(
 	usecase.NewStatUseCase,
 	usecase.NewNodeUsecase,
 	usecase.NewModelUsecase,
+	usecase.NewKnowledgeBaseUsecase,
+	usecase.NewCrawlerUsecase,
+	usecase.NewFileUsecase,
+	usecase.NewCrawlerSyncUsecase,
+	usecase.NewWebhookUsecase,
+	usecase.NewNodeLintUsecase,
+	usecase.NewNodeTranslationUsecase,
+	usecase.NewKBExportUsecase,
+	usecase.NewGitSyncUsecase,
 
 	NewRAGMQHandler,
 	NewRagDocUpdateHandler,
+	NewWebhookDeliveryHandler,
 	NewStatCronHandler,
 
 	wire.Struct(new(MQHandlers), "*"),
@@ -0,0 +1,44 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type WebhookDeliveryHandler struct {
+	consumer       mq.MQConsumer
+	logger         *log.Logger
+	webhookUsecase *usecase.WebhookUsecase
+}
+
+func NewWebhookDeliveryHandler(consumer mq.MQConsumer, logger *log.Logger, webhookUsecase *usecase.WebhookUsecase) (*WebhookDeliveryHandler, error) {
+	h := &WebhookDeliveryHandler{
+		consumer:       consumer,
+		logger:         logger.WithModule("mq.webhook"),
+		webhookUsecase: webhookUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.WebhookDeliveryTopic, h.HandleWebhookDelivery); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// HandleWebhookDelivery makes one attempt, failed attempts are queued again by the retry cron job
+func (h *WebhookDeliveryHandler) HandleWebhookDelivery(ctx context.Context, msg types.Message) error {
+	var req domain.WebhookDeliveryRequest
+	if err := json.Unmarshal(msg.GetData(), &req); err != nil {
+		h.logger.Error("unmarshal webhook delivery request failed", log.Error(err))
+		return nil
+	}
+	if err := h.webhookUsecase.Deliver(ctx, req.DeliveryID); err != nil {
+		h.logger.Error("deliver webhook failed", log.String("delivery_id", req.DeliveryID), log.Error(err))
+		return err
+	}
+	return nil
+}
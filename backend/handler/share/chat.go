@@ -13,6 +13,7 @@ import (
 	"github.com/chaitin/panda-wiki/handler"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/usecase"
+	"github.com/chaitin/panda-wiki/utils"
 )
 
 type ShareChatHandler struct {
@@ -98,6 +99,7 @@ func (h *ShareChatHandler) ChatMessage(c echo.Context) error {
 	}
 
 	req.RemoteIP = c.RealIP()
+	req.Locale = requestLocale(c, req.Locale)
 
 	c.Response().Header().Set("Content-Type", "text/event-stream")
 	c.Response().Header().Set("Cache-Control", "no-cache")
@@ -164,6 +166,7 @@ func (h *ShareChatHandler) ChatWidget(c echo.Context) error {
 	}
 
 	req.RemoteIP = c.RealIP()
+	req.Locale = requestLocale(c, req.Locale)
 
 	c.Response().Header().Set("Content-Type", "text/event-stream")
 	c.Response().Header().Set("Cache-Control", "no-cache")
@@ -305,6 +308,7 @@ func (h *ShareChatHandler) ChatCompletions(c echo.Context) error {
 		KBID:     kbID,
 		AppType:  domain.AppTypeOpenAIAPI,
 		RemoteIP: c.RealIP(),
+		Locale:   requestLocale(c, ""),
 	}
 
 	// set stream response header
@@ -437,6 +441,14 @@ func (h *ShareChatHandler) writeOpenAIStreamEvent(c echo.Context, data domain.Op
 	return nil
 }
 
+// requestLocale returns the locale of the request, the preferred language of the browser when it is not set
+func requestLocale(c echo.Context, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	return utils.PreferredLocale(c.Request().Header.Get("Accept-Language"))
+}
+
 func generateID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
@@ -471,6 +483,7 @@ func (h *ShareChatHandler) ChatSearch(c echo.Context) error {
 	}
 
 	req.RemoteIP = c.RealIP()
+	req.Locale = requestLocale(c, req.Locale)
 
 	// get user info --> no enterprise is nil
 	userID := c.Get("user_id")
@@ -521,6 +534,7 @@ func (h *ShareChatHandler) WidgetSearch(c echo.Context) error {
 	}
 
 	req.RemoteIP = c.RealIP()
+	req.Locale = requestLocale(c, req.Locale)
 
 	resp, err := h.chatUsecase.Search(ctx, &req)
 	if err != nil {
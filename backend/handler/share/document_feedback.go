@@ -0,0 +1,89 @@
+package share
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type ShareDocumentFeedbackHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	usecase *usecase.DocumentFeedbackUsecase
+	app     *usecase.AppUsecase
+}
+
+func NewShareDocumentFeedbackHandler(
+	e *echo.Echo,
+	baseHandler *handler.BaseHandler,
+	logger *log.Logger,
+	usecase *usecase.DocumentFeedbackUsecase,
+	app *usecase.AppUsecase,
+) *ShareDocumentFeedbackHandler {
+	h := &ShareDocumentFeedbackHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.share.document_feedback"),
+		usecase:     usecase,
+		app:         app,
+	}
+
+	share := e.Group("share/v1/node/feedback", h.ShareAuthMiddleware.Authorize)
+	share.POST("", h.CreateDocumentFeedback)
+	return h
+}
+
+// CreateDocumentFeedback
+//
+//	@Summary		CreateDocumentFeedback
+//	@Description	文档是否有帮助的反馈，同一会话重复反馈时更新之前未处理的反馈
+//	@Tags			share_document_feedback
+//	@Accept			json
+//	@Produce		json
+//	@Param			feedback	body		domain.DocumentFeedbackReq		true	"Feedback"
+//	@Success		200			{object}	domain.PWResponse{data=int64}	"FeedbackID"
+//	@Router			/share/v1/node/feedback [post]
+func (h *ShareDocumentFeedbackHandler) CreateDocumentFeedback(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+
+	var req domain.DocumentFeedbackReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind feedback request failed", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate req failed", err)
+	}
+	// 校验是否开启了文档反馈
+	appInfo, err := h.app.GetAppDetailByKBIDAndAppType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return h.NewResponseWithError(c, "app info is not found", err)
+	}
+	if appInfo.Settings.DocumentFeedBackIsEnabled == nil || !*appInfo.Settings.DocumentFeedBackIsEnabled {
+		return h.NewResponseWithError(c, domain.ErrDocumentFeedbackDisabled.Error(), nil)
+	}
+
+	// get user info --> no enterprise is nil
+	var userIDValue uint
+	userID := c.Get("user_id")
+	if userID != nil { // can find userinfo from auth
+		userIDValue = userID.(uint)
+	}
+
+	feedbackID, err := h.usecase.CreateFeedback(ctx, kbID, &req, shareSessionID(c), c.RealIP(), userIDValue)
+	if err != nil {
+		if errors.Is(err, domain.ErrDocumentFeedbackNodeNotPublished) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create document feedback failed", err)
+	}
+	return h.NewResponseWithData(c, feedbackID)
+}
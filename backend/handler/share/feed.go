@@ -0,0 +1,58 @@
+package share
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type ShareFeedHandler struct {
+	*handler.BaseHandler
+	feedUsecase *usecase.FeedUsecase
+	logger      *log.Logger
+}
+
+func NewShareFeedHandler(echo *echo.Echo, baseHandler *handler.BaseHandler, feedUsecase *usecase.FeedUsecase, logger *log.Logger) *ShareFeedHandler {
+	h := &ShareFeedHandler{
+		BaseHandler: baseHandler,
+		feedUsecase: feedUsecase,
+		logger:      logger.WithModule("handler.share.feed"),
+	}
+
+	echo.GET("/feed.xml", h.GetAtomFeed)
+	echo.GET("/feed.json", h.GetJSONFeed)
+
+	return h
+}
+
+func (h *ShareFeedHandler) GetAtomFeed(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+
+	feed, err := h.feedUsecase.GetAtomFeed(c.Request().Context(), kbID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to generate feed", err)
+	}
+
+	return c.Blob(http.StatusOK, "application/atom+xml; charset=UTF-8", feed)
+}
+
+func (h *ShareFeedHandler) GetJSONFeed(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+
+	feed, err := h.feedUsecase.GetJSONFeed(c.Request().Context(), kbID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to generate feed", err)
+	}
+
+	return c.Blob(http.StatusOK, "application/feed+json; charset=UTF-8", feed)
+}
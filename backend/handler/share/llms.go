@@ -0,0 +1,96 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+const (
+	mimeTextPlainCharsetUTF8    = "text/plain; charset=UTF-8"
+	mimeTextMarkdownCharsetUTF8 = "text/markdown; charset=UTF-8"
+)
+
+type ShareLLMsHandler struct {
+	*handler.BaseHandler
+	llmsUsecase *usecase.LLMsUsecase
+	nodeUsecase *usecase.NodeUsecase
+	logger      *log.Logger
+}
+
+func NewShareLLMsHandler(echo *echo.Echo, baseHandler *handler.BaseHandler, llmsUsecase *usecase.LLMsUsecase, nodeUsecase *usecase.NodeUsecase, logger *log.Logger) *ShareLLMsHandler {
+	h := &ShareLLMsHandler{
+		BaseHandler: baseHandler,
+		llmsUsecase: llmsUsecase,
+		nodeUsecase: nodeUsecase,
+		logger:      logger.WithModule("handler.share.llms"),
+	}
+
+	echo.GET("/llms.txt", h.GetLLMsTxt, h.ShareAuthMiddleware.Authorize)
+	echo.GET("/llms-full.txt", h.GetLLMsFullTxt, h.ShareAuthMiddleware.Authorize)
+	echo.GET("/node/:file", h.GetNodeMarkdown, h.ShareAuthMiddleware.Authorize) // /node/<id>.md
+
+	return h
+}
+
+func (h *ShareLLMsHandler) GetLLMsTxt(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+
+	text, err := h.llmsUsecase.GetLLMsTxt(c.Request().Context(), kbID, domain.GetAuthID(c))
+	if err != nil {
+		return h.llmsError(c, err, "failed to generate llms.txt")
+	}
+	return c.Blob(http.StatusOK, mimeTextPlainCharsetUTF8, []byte(text))
+}
+
+func (h *ShareLLMsHandler) GetLLMsFullTxt(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+
+	text, err := h.llmsUsecase.GetLLMsFullTxt(c.Request().Context(), kbID, domain.GetAuthID(c))
+	if err != nil {
+		return h.llmsError(c, err, "failed to generate llms-full.txt")
+	}
+	return c.Blob(http.StatusOK, mimeTextPlainCharsetUTF8, []byte(text))
+}
+
+func (h *ShareLLMsHandler) GetNodeMarkdown(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+	id, ok := strings.CutSuffix(c.Param("file"), ".md")
+	if !ok || id == "" {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	errCode := h.nodeUsecase.ValidateNodePerm(c.Request().Context(), kbID, id, domain.GetAuthID(c))
+	if errCode != nil {
+		return h.NewResponseWithErrCode(c, *errCode)
+	}
+
+	text, err := h.llmsUsecase.GetNodeMarkdown(c.Request().Context(), kbID, id, domain.GetAuthID(c))
+	if err != nil {
+		return h.llmsError(c, err, "failed to get node markdown")
+	}
+	return c.Blob(http.StatusOK, mimeTextMarkdownCharsetUTF8, []byte(text))
+}
+
+func (h *ShareLLMsHandler) llmsError(c echo.Context, err error, msg string) error {
+	if errors.Is(err, domain.ErrLLMsTxtDisabled) {
+		return c.NoContent(http.StatusNotFound)
+	}
+	return h.NewResponseWithError(c, msg, err)
+}
@@ -1,8 +1,13 @@
 package share
 
 import (
+	"errors"
+	"mime"
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 
+	v1 "github.com/chaitin/panda-wiki/api/share/v1"
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/handler"
 	"github.com/chaitin/panda-wiki/log"
@@ -11,20 +16,32 @@ import (
 
 type ShareNodeHandler struct {
 	*handler.BaseHandler
-	logger  *log.Logger
-	usecase *usecase.NodeUsecase
+	logger             *log.Logger
+	usecase            *usecase.NodeUsecase
+	exportUsecase      *usecase.KBExportUsecase
+	seoUsecase         *usecase.NodeSEOUsecase
+	translationUsecase *usecase.NodeTranslationUsecase
+	redirectUsecase    *usecase.KBRedirectUsecase
 }
 
 func NewShareNodeHandler(
 	baseHandler *handler.BaseHandler,
 	echo *echo.Echo,
 	usecase *usecase.NodeUsecase,
+	exportUsecase *usecase.KBExportUsecase,
+	seoUsecase *usecase.NodeSEOUsecase,
+	translationUsecase *usecase.NodeTranslationUsecase,
+	redirectUsecase *usecase.KBRedirectUsecase,
 	logger *log.Logger,
 ) *ShareNodeHandler {
 	h := &ShareNodeHandler{
-		BaseHandler: baseHandler,
-		logger:      logger.WithModule("handler.share.node"),
-		usecase:     usecase,
+		BaseHandler:        baseHandler,
+		logger:             logger.WithModule("handler.share.node"),
+		usecase:            usecase,
+		exportUsecase:      exportUsecase,
+		seoUsecase:         seoUsecase,
+		translationUsecase: translationUsecase,
+		redirectUsecase:    redirectUsecase,
 	}
 
 	group := echo.Group("share/v1/node",
@@ -32,6 +49,10 @@ func NewShareNodeHandler(
 	)
 	group.GET("/list", h.GetNodeList)
 	group.GET("/detail", h.GetNodeDetail)
+	group.GET("/search", h.SearchNodes)
+	group.GET("/seo", h.GetNodeSEO)
+	group.GET("/resolve", h.ResolveNode)
+	group.GET("/export", h.ExportBook)
 
 	return h
 }
@@ -44,6 +65,7 @@ func NewShareNodeHandler(
 //	@Accept			json
 //	@Produce		json
 //	@Param			X-KB-ID	header		string	true	"kb id"
+//	@Param			locale	query		string	false	"nodes with a published translation in this locale are replaced by the translation"
 //	@Success		200		{object}	domain.Response
 //	@Router			/share/v1/node/list [get]
 func (h *ShareNodeHandler) GetNodeList(c echo.Context) error {
@@ -56,6 +78,13 @@ func (h *ShareNodeHandler) GetNodeList(c echo.Context) error {
 	if err != nil {
 		return h.NewResponseWithError(c, "failed to get node list", err)
 	}
+	nodes, err = h.translationUsecase.LocalizeNodeList(c.Request().Context(), kbID, c.QueryParam("locale"), nodes)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to localize node list", err)
+	}
+	if err := h.redirectUsecase.SetShareNodeListSlugs(c.Request().Context(), kbID, nodes); err != nil {
+		return h.NewResponseWithError(c, "failed to get node slugs", err)
+	}
 
 	return h.NewResponseWithData(c, nodes)
 }
@@ -70,6 +99,7 @@ func (h *ShareNodeHandler) GetNodeList(c echo.Context) error {
 //	@Param			X-KB-ID	header		string	true	"kb id"
 //	@Param			id		query		string	true	"node id"
 //	@Param			format	query		string	true	"format"
+//	@Param			locale	query		string	false	"returns the published translation in this locale when there is one"
 //	@Success		200		{object}	domain.Response{data=v1.ShareNodeDetailResp}
 //	@Router			/share/v1/node/detail [get]
 func (h *ShareNodeHandler) GetNodeDetail(c echo.Context) error {
@@ -81,6 +111,10 @@ func (h *ShareNodeHandler) GetNodeDetail(c echo.Context) error {
 	if id == "" {
 		return h.NewResponseWithError(c, "id is required", nil)
 	}
+	id, err := h.translationUsecase.ResolveNodeID(c.Request().Context(), kbID, id, c.QueryParam("locale"))
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to resolve node locale", err)
+	}
 
 	errCode := h.usecase.ValidateNodePerm(c.Request().Context(), kbID, id, domain.GetAuthID(c))
 	if errCode != nil {
@@ -94,12 +128,155 @@ func (h *ShareNodeHandler) GetNodeDetail(c echo.Context) error {
 
 	// If the node is a folder, return the list of child nodes
 	if node.Type == domain.NodeTypeFolder {
-		childNodes, err := h.usecase.GetNodeReleaseListByParentID(c.Request().Context(), kbID, id, domain.GetAuthID(c))
+		// the children of a translated folder are under its source
+		parentID, err := h.translationUsecase.GetSourceNodeID(c.Request().Context(), kbID, id)
+		if err != nil {
+			return h.NewResponseWithError(c, "failed to get source node", err)
+		}
+		childNodes, err := h.usecase.GetNodeReleaseListByParentID(c.Request().Context(), kbID, parentID, domain.GetAuthID(c))
 		if err != nil {
 			return h.NewResponseWithError(c, "failed to get child nodes", err)
 		}
 		node.List = childNodes
 	}
+	if err := h.translationUsecase.SetShareNodeTranslations(c.Request().Context(), kbID, node); err != nil {
+		return h.NewResponseWithError(c, "failed to get node translations", err)
+	}
+	if err := h.redirectUsecase.SetShareNodeSlugs(c.Request().Context(), kbID, node); err != nil {
+		return h.NewResponseWithError(c, "failed to get node slugs", err)
+	}
 
 	return h.NewResponseWithData(c, node)
 }
+
+// GetNodeSEO
+//
+//	@Summary		GetNodeSEO
+//	@Description	SEO metadata, Open Graph, Twitter card and JSON-LD of a published node, or of the landing page when id is empty
+//	@Tags			share_node
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-KB-ID	header		string				true	"kb id"
+//	@Param			param	query		v1.ShareNodeSEOReq	true	"para"
+//	@Success		200		{object}	domain.Response{data=v1.ShareNodeSEOResp}
+//	@Router			/share/v1/node/seo [get]
+func (h *ShareNodeHandler) GetNodeSEO(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+	var req v1.ShareNodeSEOReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+
+	if req.ID != "" {
+		errCode := h.usecase.ValidateNodePerm(c.Request().Context(), kbID, req.ID, domain.GetAuthID(c))
+		if errCode != nil {
+			return h.NewResponseWithErrCode(c, *errCode)
+		}
+	}
+
+	seo, err := h.seoUsecase.GetShareSEO(c.Request().Context(), kbID, req.ID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node seo", err)
+	}
+	return h.NewResponseWithData(c, seo)
+}
+
+// ResolveNode
+//
+//	@Summary		ResolveNode
+//	@Description	Resolve a request path of the site, /node/<id> or /docs/<slug>, to a node or a redirect
+//	@Tags			share_node
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-KB-ID	header		string					true	"kb id"
+//	@Param			param	query		v1.ShareNodeResolveReq	true	"para"
+//	@Success		200		{object}	domain.Response{data=v1.ShareNodeResolveResp}
+//	@Router			/share/v1/node/resolve [get]
+func (h *ShareNodeHandler) ResolveNode(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+	var req v1.ShareNodeResolveReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	resp, err := h.redirectUsecase.Resolve(c.Request().Context(), kbID, req.Path)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to resolve path", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// SearchNodes
+//
+//	@Summary		SearchNodes
+//	@Description	Full-text search over the published documents the visitor can visit, with highlighted snippets
+//	@Tags			share_node
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-KB-ID	header		string					true	"kb id"
+//	@Param			param	query		v1.ShareNodeSearchReq	true	"para"
+//	@Success		200		{object}	domain.Response{data=v1.ShareNodeSearchResp}
+//	@Router			/share/v1/node/search [get]
+func (h *ShareNodeHandler) SearchNodes(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+	var req v1.ShareNodeSearchReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	resp, err := h.usecase.SearchNodeReleases(c.Request().Context(), kbID, &req, domain.GetAuthID(c))
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to search nodes", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// ExportBook
+//
+//	@Summary		ExportBook
+//	@Description	Download the public documents of the published kb or a folder as an ebook, the format must be enabled in the web app settings
+//	@Tags			share_node
+//	@Produce		application/epub+zip,application/pdf
+//	@Param			X-KB-ID	header	string	true	"kb id"
+//	@Param			format	query	string	true	"epub or pdf"
+//	@Param			node_id	query	string	false	"folder id, whole kb when empty"
+//	@Success		200		{file}	file
+//	@Router			/share/v1/node/export [get]
+func (h *ShareNodeHandler) ExportBook(c echo.Context) error {
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return h.NewResponseWithError(c, "kb_id is required", nil)
+	}
+	format := domain.KBExportFormat(c.QueryParam("format"))
+	if !format.IsBook() {
+		return h.NewResponseWithError(c, "format is invalid", nil)
+	}
+
+	file, filename, err := h.exportUsecase.GetShareBook(c.Request().Context(), kbID, format, c.QueryParam("node_id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrKBExportDisabled) || errors.Is(err, domain.ErrPDFRenderNotConfigured) ||
+			errors.Is(err, domain.ErrKBNotPublished) || errors.Is(err, domain.ErrKBExportRootNotFound) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "export book failed", err)
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	return c.Stream(http.StatusOK, format.ContentType(), file)
+}
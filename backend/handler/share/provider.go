@@ -7,18 +7,21 @@ import (
 )
 
 type ShareHandler struct {
-	ShareNodeHandler         *ShareNodeHandler
-	ShareAppHandler          *ShareAppHandler
-	ShareChatHandler         *ShareChatHandler
-	ShareSitemapHandler      *ShareSitemapHandler
-	ShareStatHandler         *ShareStatHandler
-	ShareCommentHandler      *ShareCommentHandler
-	ShareAuthHandler         *ShareAuthHandler
-	ShareConversationHandler *ShareConversationHandler
-	ShareWechatHandler       *ShareWechatHandler
-	ShareCaptchaHandler      *ShareCaptchaHandler
-	OpenapiV1Handler         *OpenapiV1Handler
-	ShareCommonHandler       *ShareCommonHandler
+	ShareNodeHandler             *ShareNodeHandler
+	ShareAppHandler              *ShareAppHandler
+	ShareChatHandler             *ShareChatHandler
+	ShareSitemapHandler          *ShareSitemapHandler
+	ShareFeedHandler             *ShareFeedHandler
+	ShareLLMsHandler             *ShareLLMsHandler
+	ShareStatHandler             *ShareStatHandler
+	ShareCommentHandler          *ShareCommentHandler
+	ShareDocumentFeedbackHandler *ShareDocumentFeedbackHandler
+	ShareAuthHandler             *ShareAuthHandler
+	ShareConversationHandler     *ShareConversationHandler
+	ShareWechatHandler           *ShareWechatHandler
+	ShareCaptchaHandler          *ShareCaptchaHandler
+	OpenapiV1Handler             *OpenapiV1Handler
+	ShareCommonHandler           *ShareCommonHandler
 }
 
 var ProviderSet = wire.NewSet(
@@ -28,8 +31,11 @@ var ProviderSet = wire.NewSet(
 	NewShareAppHandler,
 	NewShareChatHandler,
 	NewShareSitemapHandler,
+	NewShareFeedHandler,
+	NewShareLLMsHandler,
 	NewShareStatHandler,
 	NewShareCommentHandler,
+	NewShareDocumentFeedbackHandler,
 	NewShareAuthHandler,
 	NewShareConversationHandler,
 	NewShareWechatHandler,
@@ -1,10 +1,13 @@
 package share
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/handler"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/usecase"
@@ -37,10 +40,16 @@ func (h *ShareSitemapHandler) GetSitemap(c echo.Context) error {
 		return h.NewResponseWithError(c, "kb_id is required", nil)
 	}
 
-	xml, err := h.sitemapUsecase.GetSitemap(c.Request().Context(), kbID)
+	// page is set by the sitemap index of large knowledge bases
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+
+	xml, err := h.sitemapUsecase.GetSitemap(c.Request().Context(), kbID, max(page, 0))
 	if err != nil {
+		if errors.Is(err, domain.ErrSitemapNotAvailable) {
+			return c.NoContent(http.StatusNotFound)
+		}
 		return h.NewResponseWithError(c, "failed to generate sitemap", err)
 	}
 
-	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, []byte(xml))
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, xml)
 }
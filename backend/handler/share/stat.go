@@ -1,6 +1,8 @@
 package share
 
 import (
+	"encoding/json"
+	"errors"
 	"net/url"
 	"time"
 
@@ -28,6 +30,7 @@ func NewShareStatHandler(baseHandler *handler.BaseHandler, echo *echo.Echo, useC
 
 	group := echo.Group("/share/v1/stat")
 	group.POST("/page", h.RecordPage, h.ShareAuthMiddleware.Authorize)
+	group.POST("/engagement", h.RecordEngagement, h.ShareAuthMiddleware.Authorize)
 	return h
 }
 
@@ -70,15 +73,9 @@ func (h *ShareStatHandler) RecordPage(c echo.Context) error {
 			refererHost = refererURL.Host
 		}
 	}
-	sessionID := ""
-	sessionIDCookie, err := c.Request().Cookie("x-pw-session-id")
-	if err != nil {
-		sessionID = c.Request().Header.Get("x-pw-session-id")
-	} else {
-		sessionID = sessionIDCookie.Value
-	}
+	sessionID := shareSessionID(c)
 	if sessionID == "" {
-		return h.NewResponseWithError(c, "session id not found", err)
+		return h.NewResponseWithError(c, "session id not found", nil)
 	}
 	ip := c.RealIP()
 	stat := &domain.StatPage{
@@ -100,3 +97,54 @@ func (h *ShareStatHandler) RecordPage(c echo.Context) error {
 	}
 	return h.NewResponseWithData(c, nil)
 }
+
+// RecordEngagement record how a doc page was read
+//
+//	@Summary		RecordEngagement
+//	@Description	页面隐藏或离开时上报的阅读行为，可以用 navigator.sendBeacon 发送，body 按 json 解析
+//	@Tags			share_stat
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.StatPageEngagementReq	true	"request"
+//	@Success		200		{object}	domain.Response
+//	@Router			/share/v1/stat/engagement [post]
+func (h *ShareStatHandler) RecordEngagement(c echo.Context) error {
+	req := &domain.StatPageEngagementReq{}
+	// sendBeacon posts strings as text/plain, so the body is decoded without checking the content type
+	if err := json.NewDecoder(c.Request().Body).Decode(req); err != nil {
+		return h.NewResponseWithError(c, "bind request body failed", err)
+	}
+	if err := c.Validate(req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	sessionID := shareSessionID(c)
+	if sessionID == "" {
+		return h.NewResponseWithError(c, "session id not found", nil)
+	}
+	engagement := &domain.StatPageEngagement{
+		KBID:               c.Request().Header.Get("X-KB-ID"),
+		NodeID:             req.NodeID,
+		SessionID:          sessionID,
+		DwellSeconds:       req.DwellSeconds,
+		ScrollDepth:        req.ScrollDepth,
+		CopyCount:          req.CopyCount,
+		OutboundClickCount: req.OutboundClickCount,
+		Exit:               req.Exit,
+		CreatedAt:          time.Now(),
+	}
+	if err := h.useCase.RecordPageEngagement(c.Request().Context(), engagement); err != nil {
+		if errors.Is(err, domain.ErrStatEngagementNodeNotPublished) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "record engagement failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+func shareSessionID(c echo.Context) string {
+	if cookie, err := c.Request().Cookie("x-pw-session-id"); err == nil {
+		return cookie.Value
+	}
+	return c.Request().Header.Get("x-pw-session-id")
+}
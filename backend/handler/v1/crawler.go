@@ -14,10 +14,13 @@ import (
 
 type CrawlerHandler struct {
 	*handler.BaseHandler
-	logger      *log.Logger
-	usecase     *usecase.CrawlerUsecase
-	config      *config.Config
-	fileUsecase *usecase.FileUsecase
+	logger         *log.Logger
+	usecase        *usecase.CrawlerUsecase
+	config         *config.Config
+	fileUsecase    *usecase.FileUsecase
+	gitUsecase     *usecase.GitSyncUsecase
+	syncUsecase    *usecase.CrawlerSyncUsecase
+	openapiUsecase *usecase.OpenAPIImportUsecase
 }
 
 func NewCrawlerHandler(echo *echo.Echo,
@@ -27,13 +30,19 @@ func NewCrawlerHandler(echo *echo.Echo,
 	config *config.Config,
 	usecase *usecase.CrawlerUsecase,
 	fileUsecase *usecase.FileUsecase,
+	gitUsecase *usecase.GitSyncUsecase,
+	syncUsecase *usecase.CrawlerSyncUsecase,
+	openapiUsecase *usecase.OpenAPIImportUsecase,
 ) *CrawlerHandler {
 	h := &CrawlerHandler{
-		BaseHandler: baseHandler,
-		logger:      logger.WithModule("handler.v1.crawler"),
-		config:      config,
-		usecase:     usecase,
-		fileUsecase: fileUsecase,
+		BaseHandler:    baseHandler,
+		logger:         logger.WithModule("handler.v1.crawler"),
+		config:         config,
+		usecase:        usecase,
+		fileUsecase:    fileUsecase,
+		gitUsecase:     gitUsecase,
+		syncUsecase:    syncUsecase,
+		openapiUsecase: openapiUsecase,
 	}
 	group := echo.Group("/api/v1/crawler", auth.Authorize)
 	group.POST("/parse", h.CrawlerParse)
@@ -41,6 +50,26 @@ func NewCrawlerHandler(echo *echo.Echo,
 	group.GET("/result", h.CrawlerResult)
 	group.POST("/results", h.CrawlerResults)
 
+	gitGroup := group.Group("/git", auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	gitGroup.POST("", h.GitSourceCreate)
+	gitGroup.PUT("", h.GitSourceUpdate)
+	gitGroup.GET("/list", h.GitSourceList)
+	gitGroup.DELETE("", h.GitSourceDelete)
+	gitGroup.POST("/sync", h.GitSourceSync)
+
+	syncGroup := group.Group("/sync", auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	syncGroup.POST("", h.CrawlerSyncCreate)
+	syncGroup.PUT("", h.CrawlerSyncUpdate)
+	syncGroup.GET("/list", h.CrawlerSyncList)
+	syncGroup.DELETE("", h.CrawlerSyncDelete)
+	syncGroup.POST("/run", h.CrawlerSyncRun)
+	syncGroup.GET("/runs", h.CrawlerSyncRunList)
+
+	openapiGroup := group.Group("/openapi", auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	openapiGroup.POST("/import", h.OpenAPIImport)
+	openapiGroup.GET("/list", h.OpenAPISpecList)
+	openapiGroup.DELETE("", h.OpenAPISpecDelete)
+
 	return h
 }
 
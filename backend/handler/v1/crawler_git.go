@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// GitSourceCreate
+//
+//	@Summary		GitSourceCreate
+//	@Description	Add a git repository as sync source of the knowledge base
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.GitSourceCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.GitSourceCreateResp}
+//	@Router			/api/v1/crawler/git [post]
+func (h *CrawlerHandler) GitSourceCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.GitSourceCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.gitUsecase.CreateSource(ctx, &req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGitSource) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create git source failed", err)
+	}
+	return h.NewResponseWithData(c, v1.GitSourceCreateResp{SourceID: id})
+}
+
+// GitSourceUpdate
+//
+//	@Summary		GitSourceUpdate
+//	@Description	Update a git source, empty password and ssh key keep the stored ones
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.GitSourceUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/git [put]
+func (h *CrawlerHandler) GitSourceUpdate(c echo.Context) error {
+	var req v1.GitSourceUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.gitUsecase.UpdateSource(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrInvalidGitSource) || errors.Is(err, domain.ErrGitSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update git source failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// GitSourceList
+//
+//	@Summary		GitSourceList
+//	@Description	List git sources of the knowledge base with their sync status
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.GitSourceListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.GitSourceListItem}
+//	@Router			/api/v1/crawler/git/list [get]
+func (h *CrawlerHandler) GitSourceList(c echo.Context) error {
+	var req v1.GitSourceListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	sources, err := h.gitUsecase.GetSourceList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get git source list failed", err)
+	}
+	return h.NewResponseWithData(c, sources)
+}
+
+// GitSourceDelete
+//
+//	@Summary		GitSourceDelete
+//	@Description	Delete a git source, synced documents are kept
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.GitSourceDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/git [delete]
+func (h *CrawlerHandler) GitSourceDelete(c echo.Context) error {
+	var req v1.GitSourceDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.gitUsecase.DeleteSource(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrGitSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "delete git source failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// GitSourceSync
+//
+//	@Summary		GitSourceSync
+//	@Description	Start a sync of the git source, markdown files become documents and directories folders
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.GitSourceSyncReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/git/sync [post]
+func (h *CrawlerHandler) GitSourceSync(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.GitSourceSyncReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.gitUsecase.Sync(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode); err != nil {
+		if errors.Is(err, domain.ErrGitSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "start git sync failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
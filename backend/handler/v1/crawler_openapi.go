@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// OpenAPIImport
+//
+//	@Summary		OpenAPIImport
+//	@Description	Import an OpenAPI 3 or Swagger 2 spec, tags become folders and operations documents
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.OpenAPIImportReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.OpenAPIImportResp}
+//	@Router			/api/v1/crawler/openapi/import [post]
+func (h *CrawlerHandler) OpenAPIImport(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.OpenAPIImportReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	resp, err := h.openapiUsecase.Import(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOpenAPISpec) || errors.Is(err, domain.ErrMaxNodeLimitReached) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "import openapi spec failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// OpenAPISpecList
+//
+//	@Summary		OpenAPISpecList
+//	@Description	List the imported OpenAPI specs of the knowledge base
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.OpenAPISpecListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.KBOpenAPISpec}
+//	@Router			/api/v1/crawler/openapi/list [get]
+func (h *CrawlerHandler) OpenAPISpecList(c echo.Context) error {
+	var req v1.OpenAPISpecListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	specs, err := h.openapiUsecase.GetSpecList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get openapi spec list failed", err)
+	}
+	return h.NewResponseWithData(c, specs)
+}
+
+// OpenAPISpecDelete
+//
+//	@Summary		OpenAPISpecDelete
+//	@Description	Delete an imported OpenAPI spec, generated documents are kept
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.OpenAPISpecDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/openapi [delete]
+func (h *CrawlerHandler) OpenAPISpecDelete(c echo.Context) error {
+	var req v1.OpenAPISpecDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.openapiUsecase.DeleteSpec(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "delete openapi spec failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// CrawlerSyncCreate
+//
+//	@Summary		CrawlerSyncCreate
+//	@Description	Save a crawler source of the knowledge base to be synced again manually or on a schedule
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.CrawlerSyncCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.CrawlerSyncCreateResp}
+//	@Router			/api/v1/crawler/sync [post]
+func (h *CrawlerHandler) CrawlerSyncCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.CrawlerSyncCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.syncUsecase.CreateSync(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCrawlerSync) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create crawler sync failed", err)
+	}
+	return h.NewResponseWithData(c, v1.CrawlerSyncCreateResp{SyncID: id})
+}
+
+// CrawlerSyncUpdate
+//
+//	@Summary		CrawlerSyncUpdate
+//	@Description	Update a crawler sync, empty feishu app secret and access token keep the stored ones
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.CrawlerSyncUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/sync [put]
+func (h *CrawlerHandler) CrawlerSyncUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req v1.CrawlerSyncUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.syncUsecase.UpdateSync(ctx, &req, domain.GetBaseEditionLimitation(ctx).MaxNode); err != nil {
+		if errors.Is(err, domain.ErrInvalidCrawlerSync) || errors.Is(err, domain.ErrCrawlerSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update crawler sync failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// CrawlerSyncList
+//
+//	@Summary		CrawlerSyncList
+//	@Description	List crawler syncs of the knowledge base with their schedule and last run
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.CrawlerSyncListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.CrawlerSyncListItem}
+//	@Router			/api/v1/crawler/sync/list [get]
+func (h *CrawlerHandler) CrawlerSyncList(c echo.Context) error {
+	var req v1.CrawlerSyncListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	syncs, err := h.syncUsecase.GetSyncList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get crawler sync list failed", err)
+	}
+	return h.NewResponseWithData(c, syncs)
+}
+
+// CrawlerSyncDelete
+//
+//	@Summary		CrawlerSyncDelete
+//	@Description	Delete a crawler sync and its history, synced documents are kept
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.CrawlerSyncDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/sync [delete]
+func (h *CrawlerHandler) CrawlerSyncDelete(c echo.Context) error {
+	var req v1.CrawlerSyncDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.syncUsecase.DeleteSync(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrCrawlerSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "delete crawler sync failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// CrawlerSyncRun
+//
+//	@Summary		CrawlerSyncRun
+//	@Description	Queue a run of the crawler sync, it starts within a minute
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.CrawlerSyncRunReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/sync/run [post]
+func (h *CrawlerHandler) CrawlerSyncRun(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req v1.CrawlerSyncRunReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.syncUsecase.RunSync(ctx, &req, domain.GetBaseEditionLimitation(ctx).MaxNode); err != nil {
+		if errors.Is(err, domain.ErrCrawlerSyncRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "queue crawler sync run failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// CrawlerSyncRunList
+//
+//	@Summary		CrawlerSyncRunList
+//	@Description	Sync history of a crawler sync with the per document errors, latest first
+//	@Tags			crawler
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.CrawlerSyncRunListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.CrawlerSyncRunListResp}
+//	@Router			/api/v1/crawler/sync/runs [get]
+func (h *CrawlerHandler) CrawlerSyncRunList(c echo.Context) error {
+	var req v1.CrawlerSyncRunListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	runs, err := h.syncUsecase.GetRunList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get crawler sync runs failed", err)
+	}
+	return h.NewResponseWithData(c, runs)
+}
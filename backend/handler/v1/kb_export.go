@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// KBExportCreate
+//
+//	@Summary		KBExportCreate
+//	@Description	Create an export job of the whole knowledge base, markdown archive with manifest can be re-imported
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBExportCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.KBExportCreateResp}
+//	@Router			/api/v1/knowledge_base/export [post]
+func (h *KnowledgeBaseHandler) KBExportCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.KBExportCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.exportUsecase.CreateExport(ctx, &req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrKBExportRunning) || errors.Is(err, domain.ErrPDFRenderNotConfigured) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create kb export failed", err)
+	}
+	return h.NewResponseWithData(c, v1.KBExportCreateResp{ExportID: id})
+}
+
+// KBExportList
+//
+//	@Summary		KBExportList
+//	@Description	KBExportList
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBExportListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.KBExport}
+//	@Router			/api/v1/knowledge_base/export/list [get]
+func (h *KnowledgeBaseHandler) KBExportList(c echo.Context) error {
+	var req v1.KBExportListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	exports, err := h.exportUsecase.GetExportList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb export list failed", err)
+	}
+	return h.NewResponseWithData(c, exports)
+}
+
+// KBExportDetail
+//
+//	@Summary		KBExportDetail
+//	@Description	Get status of an export job
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBExportDetailReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=domain.KBExport}
+//	@Router			/api/v1/knowledge_base/export/detail [get]
+func (h *KnowledgeBaseHandler) KBExportDetail(c echo.Context) error {
+	var req v1.KBExportDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	export, err := h.exportUsecase.GetExport(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb export failed", err)
+	}
+	return h.NewResponseWithData(c, export)
+}
+
+// KBExportDownload
+//
+//	@Summary		KBExportDownload
+//	@Description	Download the file of a completed export job
+//	@Tags			knowledge_base
+//	@Produce		application/zip,application/epub+zip,application/pdf
+//	@Security		bearerAuth
+//	@Param			param	query	v1.KBExportDownloadReq	true	"para"
+//	@Success		200		{file}	file
+//	@Router			/api/v1/knowledge_base/export/download [get]
+func (h *KnowledgeBaseHandler) KBExportDownload(c echo.Context) error {
+	var req v1.KBExportDownloadReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	file, filename, contentType, err := h.exportUsecase.GetExportFile(c.Request().Context(), &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrKBExportNotReady) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "get kb export file failed", err)
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	return c.Stream(http.StatusOK, contentType, file)
+}
+
+// KBExportDelete
+//
+//	@Summary		KBExportDelete
+//	@Description	Delete an export job and its archive
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBExportDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/export [delete]
+func (h *KnowledgeBaseHandler) KBExportDelete(c echo.Context) error {
+	var req v1.KBExportDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.exportUsecase.DeleteExport(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrKBExportRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "delete kb export failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// KBImport
+//
+//	@Summary		KBImport
+//	@Description	Create an import job of an export archive uploaded with /api/v1/file/upload, existing nodes are skipped, overwritten or merged by mode
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBImportReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.KBImportResp}
+//	@Router			/api/v1/knowledge_base/import [post]
+func (h *KnowledgeBaseHandler) KBImport(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.KBImportReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.exportUsecase.CreateImport(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImportArchive) || errors.Is(err, domain.ErrKBImportRunning) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create kb import failed", err)
+	}
+	return h.NewResponseWithData(c, v1.KBImportResp{ImportID: id})
+}
+
+// KBImportList
+//
+//	@Summary		KBImportList
+//	@Description	KBImportList
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBImportListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.KBImport}
+//	@Router			/api/v1/knowledge_base/import/list [get]
+func (h *KnowledgeBaseHandler) KBImportList(c echo.Context) error {
+	var req v1.KBImportListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	imports, err := h.exportUsecase.GetImportList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb import list failed", err)
+	}
+	return h.NewResponseWithData(c, imports)
+}
+
+// KBImportDetail
+//
+//	@Summary		KBImportDetail
+//	@Description	Get status and result of an import job
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBImportDetailReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=domain.KBImport}
+//	@Router			/api/v1/knowledge_base/import/detail [get]
+func (h *KnowledgeBaseHandler) KBImportDetail(c echo.Context) error {
+	var req v1.KBImportDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	kbImport, err := h.exportUsecase.GetImport(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb import failed", err)
+	}
+	return h.NewResponseWithData(c, kbImport)
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// KBRedirectCreate
+//
+//	@Summary		KBRedirectCreate
+//	@Description	Redirect an old path of the site to a node or an url, 301 by default
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBRedirectCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.KBRedirectCreateResp}
+//	@Router			/api/v1/knowledge_base/redirect [post]
+func (h *KnowledgeBaseHandler) KBRedirectCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.KBRedirectCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	resp, err := h.redirectUsecase.Create(ctx, &req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRedirect) || errors.Is(err, domain.ErrRedirectExists) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create kb redirect failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// KBRedirectUpdate
+//
+//	@Summary		KBRedirectUpdate
+//	@Description	KBRedirectUpdate
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBRedirectUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/redirect [put]
+func (h *KnowledgeBaseHandler) KBRedirectUpdate(c echo.Context) error {
+	var req v1.KBRedirectUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.redirectUsecase.Update(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrInvalidRedirect) || errors.Is(err, domain.ErrRedirectExists) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update kb redirect failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// KBRedirectList
+//
+//	@Summary		KBRedirectList
+//	@Description	Redirects of the knowledge base with their hit counts, including the ones created for renamed and deleted nodes
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBRedirectListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.KBRedirectItem}
+//	@Router			/api/v1/knowledge_base/redirect/list [get]
+func (h *KnowledgeBaseHandler) KBRedirectList(c echo.Context) error {
+	var req v1.KBRedirectListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	redirects, err := h.redirectUsecase.GetList(c.Request().Context(), req.KBId)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb redirect list failed", err)
+	}
+	return h.NewResponseWithData(c, redirects)
+}
+
+// KBRedirectDelete
+//
+//	@Summary		KBRedirectDelete
+//	@Description	KBRedirectDelete
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBRedirectDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/redirect [delete]
+func (h *KnowledgeBaseHandler) KBRedirectDelete(c echo.Context) error {
+	var req v1.KBRedirectDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.redirectUsecase.Delete(c.Request().Context(), req.KBId, req.RedirectID); err != nil {
+		return h.NewResponseWithError(c, "delete kb redirect failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
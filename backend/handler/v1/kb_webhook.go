@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// KBWebhookCreate
+//
+//	@Summary		KBWebhookCreate
+//	@Description	Create an outbound webhook of the knowledge base, requests are signed with the returned secret in the X-PandaWiki-Signature header
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBWebhookCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.KBWebhookCreateResp}
+//	@Router			/api/v1/knowledge_base/webhook [post]
+func (h *KnowledgeBaseHandler) KBWebhookCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.KBWebhookCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	resp, err := h.webhookUsecase.CreateWebhook(ctx, &req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhookURL) || errors.Is(err, domain.ErrWebhookURLNotPublic) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create kb webhook failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// KBWebhookUpdate
+//
+//	@Summary		KBWebhookUpdate
+//	@Description	Update a webhook, empty secret keeps the stored one
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBWebhookUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/webhook [put]
+func (h *KnowledgeBaseHandler) KBWebhookUpdate(c echo.Context) error {
+	var req v1.KBWebhookUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.webhookUsecase.UpdateWebhook(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhookURL) || errors.Is(err, domain.ErrWebhookURLNotPublic) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update kb webhook failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// KBWebhookList
+//
+//	@Summary		KBWebhookList
+//	@Description	KBWebhookList
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBWebhookListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.KBWebhook}
+//	@Router			/api/v1/knowledge_base/webhook/list [get]
+func (h *KnowledgeBaseHandler) KBWebhookList(c echo.Context) error {
+	var req v1.KBWebhookListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	webhooks, err := h.webhookUsecase.GetWebhookList(c.Request().Context(), req.KBId)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb webhook list failed", err)
+	}
+	return h.NewResponseWithData(c, webhooks)
+}
+
+// KBWebhookDelete
+//
+//	@Summary		KBWebhookDelete
+//	@Description	Delete a webhook and its delivery log
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBWebhookDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/webhook [delete]
+func (h *KnowledgeBaseHandler) KBWebhookDelete(c echo.Context) error {
+	var req v1.KBWebhookDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.webhookUsecase.DeleteWebhook(c.Request().Context(), req.KBId, req.WebhookID); err != nil {
+		return h.NewResponseWithError(c, "delete kb webhook failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// KBWebhookTest
+//
+//	@Summary		KBWebhookTest
+//	@Description	Send a ping event to the webhook right away and return the result of the attempt
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBWebhookTestReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=domain.KBWebhookDelivery}
+//	@Router			/api/v1/knowledge_base/webhook/test [post]
+func (h *KnowledgeBaseHandler) KBWebhookTest(c echo.Context) error {
+	var req v1.KBWebhookTestReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	delivery, err := h.webhookUsecase.TestWebhook(c.Request().Context(), req.KBId, req.WebhookID)
+	if err != nil {
+		return h.NewResponseWithError(c, "test kb webhook failed", err)
+	}
+	return h.NewResponseWithData(c, delivery)
+}
+
+// KBWebhookDeliveryList
+//
+//	@Summary		KBWebhookDeliveryList
+//	@Description	Delivery log of a webhook, latest first, deliveries are kept for 30 days
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.KBWebhookDeliveryListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.KBWebhookDeliveryListResp}
+//	@Router			/api/v1/knowledge_base/webhook/deliveries [get]
+func (h *KnowledgeBaseHandler) KBWebhookDeliveryList(c echo.Context) error {
+	var req v1.KBWebhookDeliveryListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	deliveries, err := h.webhookUsecase.GetDeliveryList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get kb webhook deliveries failed", err)
+	}
+	return h.NewResponseWithData(c, deliveries)
+}
+
+// KBWebhookRedeliver
+//
+//	@Summary		KBWebhookRedeliver
+//	@Description	Queue a delivery again with the same payload
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.KBWebhookRedeliverReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/webhook/redeliver [post]
+func (h *KnowledgeBaseHandler) KBWebhookRedeliver(c echo.Context) error {
+	var req v1.KBWebhookRedeliverReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.webhookUsecase.Redeliver(c.Request().Context(), req.KBId, req.DeliveryID); err != nil {
+		return h.NewResponseWithError(c, "redeliver kb webhook failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
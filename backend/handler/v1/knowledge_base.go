@@ -16,10 +16,13 @@ import (
 
 type KnowledgeBaseHandler struct {
 	*handler.BaseHandler
-	usecase    *usecase.KnowledgeBaseUsecase
-	llmUsecase *usecase.LLMUsecase
-	logger     *log.Logger
-	auth       middleware.AuthMiddleware
+	usecase         *usecase.KnowledgeBaseUsecase
+	llmUsecase      *usecase.LLMUsecase
+	exportUsecase   *usecase.KBExportUsecase
+	webhookUsecase  *usecase.WebhookUsecase
+	redirectUsecase *usecase.KBRedirectUsecase
+	logger          *log.Logger
+	auth            middleware.AuthMiddleware
 }
 
 func NewKnowledgeBaseHandler(
@@ -27,15 +30,21 @@ func NewKnowledgeBaseHandler(
 	echo *echo.Echo,
 	usecase *usecase.KnowledgeBaseUsecase,
 	llmUsecase *usecase.LLMUsecase,
+	exportUsecase *usecase.KBExportUsecase,
+	webhookUsecase *usecase.WebhookUsecase,
+	redirectUsecase *usecase.KBRedirectUsecase,
 	auth middleware.AuthMiddleware,
 	logger *log.Logger,
 ) *KnowledgeBaseHandler {
 	h := &KnowledgeBaseHandler{
-		BaseHandler: baseHandler,
-		logger:      logger.WithModule("handler.v1.knowledge_base"),
-		usecase:     usecase,
-		llmUsecase:  llmUsecase,
-		auth:        auth,
+		BaseHandler:     baseHandler,
+		logger:          logger.WithModule("handler.v1.knowledge_base"),
+		usecase:         usecase,
+		llmUsecase:      llmUsecase,
+		exportUsecase:   exportUsecase,
+		webhookUsecase:  webhookUsecase,
+		redirectUsecase: redirectUsecase,
+		auth:            auth,
 	}
 
 	group := echo.Group("/api/v1/knowledge_base", h.auth.Authorize)
@@ -57,6 +66,37 @@ func NewKnowledgeBaseHandler(
 	releaseGroup.POST("", h.CreateKBRelease)
 	releaseGroup.GET("/list", h.GetKBReleaseList)
 
+	// export
+	exportGroup := group.Group("/export", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	exportGroup.POST("", h.KBExportCreate)
+	exportGroup.GET("/list", h.KBExportList)
+	exportGroup.GET("/detail", h.KBExportDetail)
+	exportGroup.GET("/download", h.KBExportDownload)
+	exportGroup.DELETE("", h.KBExportDelete)
+
+	// import
+	importGroup := group.Group("/import", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	importGroup.POST("", h.KBImport)
+	importGroup.GET("/list", h.KBImportList)
+	importGroup.GET("/detail", h.KBImportDetail)
+
+	// webhook
+	webhookGroup := group.Group("/webhook", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	webhookGroup.POST("", h.KBWebhookCreate)
+	webhookGroup.PUT("", h.KBWebhookUpdate)
+	webhookGroup.GET("/list", h.KBWebhookList)
+	webhookGroup.DELETE("", h.KBWebhookDelete)
+	webhookGroup.POST("/test", h.KBWebhookTest)
+	webhookGroup.GET("/deliveries", h.KBWebhookDeliveryList)
+	webhookGroup.POST("/redeliver", h.KBWebhookRedeliver)
+
+	// redirect
+	redirectGroup := group.Group("/redirect", h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	redirectGroup.POST("", h.KBRedirectCreate)
+	redirectGroup.PUT("", h.KBRedirectUpdate)
+	redirectGroup.GET("/list", h.KBRedirectList)
+	redirectGroup.DELETE("", h.KBRedirectDelete)
+
 	return h
 }
 
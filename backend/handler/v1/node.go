@@ -2,6 +2,7 @@ package v1
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 
@@ -16,23 +17,41 @@ import (
 
 type NodeHandler struct {
 	*handler.BaseHandler
-	logger  *log.Logger
-	usecase *usecase.NodeUsecase
-	auth    middleware.AuthMiddleware
+	logger             *log.Logger
+	usecase            *usecase.NodeUsecase
+	pushUsecase        *usecase.NodePushUsecase
+	lintUsecase        *usecase.NodeLintUsecase
+	seoUsecase         *usecase.NodeSEOUsecase
+	translationUsecase *usecase.NodeTranslationUsecase
+	feedbackUsecase    *usecase.DocumentFeedbackUsecase
+	redirectUsecase    *usecase.KBRedirectUsecase
+	auth               middleware.AuthMiddleware
 }
 
 func NewNodeHandler(
 	baseHandler *handler.BaseHandler,
 	echo *echo.Echo,
 	usecase *usecase.NodeUsecase,
+	pushUsecase *usecase.NodePushUsecase,
+	lintUsecase *usecase.NodeLintUsecase,
+	seoUsecase *usecase.NodeSEOUsecase,
+	translationUsecase *usecase.NodeTranslationUsecase,
+	feedbackUsecase *usecase.DocumentFeedbackUsecase,
+	redirectUsecase *usecase.KBRedirectUsecase,
 	auth middleware.AuthMiddleware,
 	logger *log.Logger,
 ) *NodeHandler {
 	h := &NodeHandler{
-		BaseHandler: baseHandler,
-		logger:      logger.WithModule("handler.v1.node"),
-		usecase:     usecase,
-		auth:        auth,
+		BaseHandler:        baseHandler,
+		logger:             logger.WithModule("handler.v1.node"),
+		usecase:            usecase,
+		pushUsecase:        pushUsecase,
+		lintUsecase:        lintUsecase,
+		seoUsecase:         seoUsecase,
+		translationUsecase: translationUsecase,
+		feedbackUsecase:    feedbackUsecase,
+		redirectUsecase:    redirectUsecase,
+		auth:               auth,
 	}
 
 	group := echo.Group("/api/v1/node", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
@@ -46,6 +65,9 @@ func NewNodeHandler(
 	group.POST("/move", h.MoveNode)
 	group.POST("/batch_move", h.BatchMoveNode)
 
+	// bulk push from build pipelines
+	group.POST("/push", h.NodePush)
+
 	group.GET("/recommend_nodes", h.RecommendNodes)
 	group.POST("/restudy", h.NodeRestudy)
 
@@ -53,6 +75,55 @@ func NewNodeHandler(
 	group.GET("/permission", h.NodePermission)
 	group.PATCH("/permission/edit", h.NodePermissionEdit)
 
+	// node template
+	group.GET("/template/list", h.NodeTemplateList)
+	group.GET("/template/detail", h.NodeTemplateDetail)
+	group.POST("/template", h.NodeTemplateCreate)
+	group.PUT("/template", h.NodeTemplateUpdate)
+	group.DELETE("/template", h.NodeTemplateDelete)
+	group.POST("/template/from_node", h.NodeTemplateFromNode)
+
+	// node links
+	group.GET("/link/backlinks", h.NodeBacklinks)
+	group.GET("/link/inbound", h.NodeInboundLinks)
+	group.GET("/link/broken", h.NodeBrokenLinks)
+
+	// content quality report
+	group.GET("/lint/report", h.NodeLintReport)
+	group.POST("/lint/refresh", h.NodeLintRefresh)
+
+	// seo overrides
+	group.GET("/seo", h.NodeSEO)
+	group.PUT("/seo", h.NodeSEOUpdate)
+
+	// language variants
+	group.GET("/translation/list", h.NodeTranslationList)
+	group.POST("/translation/translate", h.NodeTranslate)
+	group.POST("/translation/link", h.NodeTranslationLink)
+	group.PUT("/translation/synced", h.NodeTranslationSynced)
+	group.DELETE("/translation", h.NodeTranslationUnlink)
+
+	// url alias
+	group.GET("/slug", h.NodeSlug)
+	group.PUT("/slug", h.NodeSlugUpdate)
+
+	// page feedback triage
+	group.GET("/feedback/list", h.NodeFeedbackList)
+	group.PUT("/feedback/status", h.NodeFeedbackStatus)
+	group.PUT("/feedback/link", h.NodeFeedbackLink)
+
+	// node tags and custom fields
+	group.GET("/tag/list", h.NodeTagList)
+	group.GET("/field/list", h.NodeFieldList)
+	group.POST("/field", h.NodeFieldCreate)
+	group.PUT("/field", h.NodeFieldUpdate)
+	group.DELETE("/field", h.NodeFieldDelete)
+
+	// trash
+	group.GET("/trash/list", h.NodeTrashList)
+	group.POST("/trash/restore", h.NodeTrashRestore)
+	group.DELETE("/trash", h.NodeTrashDelete)
+
 	return h
 }
 
@@ -89,6 +160,9 @@ func (h *NodeHandler) CreateNode(c echo.Context) error {
 		if errors.Is(err, domain.ErrMaxNodeLimitReached) {
 			return h.NewResponseWithError(c, "已达到最大文档数量限制，请升级到更高版本", nil)
 		}
+		if errors.Is(err, domain.ErrInvalidNodeField) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
 		return h.NewResponseWithError(c, "create node failed", err)
 	}
 	return h.NewResponseWithData(c, map[string]any{
@@ -161,9 +235,15 @@ func (h *NodeHandler) GetNodeDetail(c echo.Context) error {
 //	@Produce		json
 //	@Security		bearerAuth
 //	@Param			action	body		domain.NodeActionReq	true	"Action"
-//	@Success		200		{object}	domain.PWResponse{data=map[string]string}
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeActionResp}
 //	@Router			/api/v1/node/action [post]
 func (h *NodeHandler) NodeAction(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
 	req := &domain.NodeActionReq{}
 	if err := c.Bind(req); err != nil {
 		return h.NewResponseWithError(c, "request body is invalid", err)
@@ -171,11 +251,14 @@ func (h *NodeHandler) NodeAction(c echo.Context) error {
 	if err := c.Validate(req); err != nil {
 		return h.NewResponseWithError(c, "validate request body failed", err)
 	}
-	ctx := c.Request().Context()
-	if err := h.usecase.NodeAction(ctx, req); err != nil {
+	resp, err := h.usecase.NodeAction(ctx, req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRedirect) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
 		return h.NewResponseWithError(c, "node action failed", err)
 	}
-	return h.NewResponseWithData(c, nil)
+	return h.NewResponseWithData(c, resp)
 }
 
 // UpdateNodeDetail
@@ -187,7 +270,8 @@ func (h *NodeHandler) NodeAction(c echo.Context) error {
 //	@Produce		json
 //	@Security		bearerAuth
 //	@Param			body	body		domain.UpdateNodeReq	true	"Node"
-//	@Success		200		{object}	domain.Response
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeUpdateResp}
+//	@Failure		409		{object}	domain.PWResponse{data=v1.NodeUpdateConflictResp}
 //	@Router			/api/v1/node/detail [put]
 func (h *NodeHandler) UpdateNodeDetail(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -204,10 +288,26 @@ func (h *NodeHandler) UpdateNodeDetail(c echo.Context) error {
 		return h.NewResponseWithError(c, "validate request body failed", err)
 	}
 
-	if err := h.usecase.Update(ctx, req, authInfo.UserId); err != nil {
+	resp, err := h.usecase.Update(ctx, req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNodeVersionConflict) {
+			conflict, err := h.usecase.GetUpdateConflict(ctx, req)
+			if err != nil {
+				return h.NewResponseWithError(c, "get node update conflict failed", err)
+			}
+			return c.JSON(http.StatusConflict, domain.PWResponse{
+				Success: false,
+				Message: "文档已被其他人修改，请合并后重新保存",
+				Data:    conflict,
+				Code:    domain.ErrCodeConflict.Code,
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidNodeField) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
 		return h.NewResponseWithError(c, "update node detail failed", err)
 	}
-	return h.NewResponseWithData(c, nil)
+	return h.NewResponseWithData(c, resp)
 }
 
 // MoveNode
@@ -378,11 +478,11 @@ func (h *NodeHandler) NodePermissionEdit(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	err := h.usecase.NodePermissionsEdit(ctx, req)
+	resp, err := h.usecase.NodePermissionsEdit(ctx, req)
 	if err != nil {
 		return h.NewResponseWithError(c, "update node permission failed", err)
 	}
-	return h.NewResponseWithData(c, nil)
+	return h.NewResponseWithData(c, resp)
 }
 
 // NodeRestudy 文档重新学习
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeFeedbackList 文档反馈列表
+//
+//	@Tags			NodeFeedback
+//	@Summary		文档反馈列表
+//	@Description	读者对文档“是否有帮助”的反馈，按状态、评分和原因筛选，用于分拣处理
+//	@ID				v1-NodeFeedbackList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeFeedbackListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeFeedbackListResp}
+//	@Router			/api/v1/node/feedback/list [get]
+func (h *NodeHandler) NodeFeedbackList(c echo.Context) error {
+	var req v1.NodeFeedbackListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	resp, err := h.feedbackUsecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get document feedback failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// NodeFeedbackStatus 更新文档反馈状态
+//
+//	@Tags			NodeFeedback
+//	@Summary		更新文档反馈状态
+//	@Description	批量将反馈标记为已解决或重新打开
+//	@ID				v1-NodeFeedbackStatus
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeFeedbackStatusReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/feedback/status [put]
+func (h *NodeHandler) NodeFeedbackStatus(c echo.Context) error {
+	var req v1.NodeFeedbackStatusReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	if err := h.feedbackUsecase.UpdateStatus(ctx, &req, authInfo.UserId); err != nil {
+		return h.NewResponseWithError(c, "update document feedback status failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeFeedbackLink 关联修正反馈的文档版本
+//
+//	@Tags			NodeFeedback
+//	@Summary		关联修正反馈的文档版本
+//	@Description	将反馈关联到修正它的文档发布版本，并标记为已解决
+//	@ID				v1-NodeFeedbackLink
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeFeedbackLinkReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/feedback/link [put]
+func (h *NodeHandler) NodeFeedbackLink(c echo.Context) error {
+	var req v1.NodeFeedbackLinkReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	if err := h.feedbackUsecase.Link(ctx, &req, authInfo.UserId); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return h.NewResponseWithError(c, "feedback or release not found", nil)
+		case errors.Is(err, domain.ErrInvalidDocumentFeedbackRelease):
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "link document feedback failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
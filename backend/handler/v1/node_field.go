@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeTagList 文档标签列表
+//
+//	@Tags			NodeField
+//	@Summary		文档标签列表
+//	@Description	知识库内已使用的标签及文档数量
+//	@ID				v1-NodeTagList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTagListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.NodeTagCount}
+//	@Router			/api/v1/node/tag/list [get]
+func (h *NodeHandler) NodeTagList(c echo.Context) error {
+	var req v1.NodeTagListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	tags, err := h.usecase.GetNodeTagList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node tag list failed", err)
+	}
+	return h.NewResponseWithData(c, tags)
+}
+
+// NodeFieldList 自定义字段列表
+//
+//	@Tags			NodeField
+//	@Summary		自定义字段列表
+//	@Description	自定义字段列表
+//	@ID				v1-NodeFieldList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeFieldListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.NodeField}
+//	@Router			/api/v1/node/field/list [get]
+func (h *NodeHandler) NodeFieldList(c echo.Context) error {
+	var req v1.NodeFieldListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	fields, err := h.usecase.GetNodeFieldList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node field list failed", err)
+	}
+	return h.NewResponseWithData(c, fields)
+}
+
+// NodeFieldCreate 创建自定义字段
+//
+//	@Tags			NodeField
+//	@Summary		创建自定义字段
+//	@Description	创建自定义字段，如负责人、产品版本、受众、复审日期
+//	@ID				v1-NodeFieldCreate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeFieldCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeFieldCreateResp}
+//	@Router			/api/v1/node/field [post]
+func (h *NodeHandler) NodeFieldCreate(c echo.Context) error {
+	var req v1.NodeFieldCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.usecase.CreateNodeField(c.Request().Context(), &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNodeField) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "create node field failed", err)
+	}
+	return h.NewResponseWithData(c, v1.NodeFieldCreateResp{ID: id})
+}
+
+// NodeFieldUpdate 更新自定义字段
+//
+//	@Tags			NodeField
+//	@Summary		更新自定义字段
+//	@Description	更新自定义字段，key 与类型不可修改
+//	@ID				v1-NodeFieldUpdate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeFieldUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/field [put]
+func (h *NodeHandler) NodeFieldUpdate(c echo.Context) error {
+	var req v1.NodeFieldUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.usecase.UpdateNodeField(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, domain.ErrInvalidNodeField) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update node field failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeFieldDelete 删除自定义字段
+//
+//	@Tags			NodeField
+//	@Summary		删除自定义字段
+//	@Description	删除自定义字段，同时清除文档中该字段的值
+//	@ID				v1-NodeFieldDelete
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeFieldDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/field [delete]
+func (h *NodeHandler) NodeFieldDelete(c echo.Context) error {
+	var req v1.NodeFieldDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.usecase.DeleteNodeField(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "delete node field failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
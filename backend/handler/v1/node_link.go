@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+)
+
+// NodeBacklinks 文档反向链接
+//
+//	@Tags			NodeLink
+//	@Summary		文档反向链接
+//	@Description	返回链接到该文档的其他文档
+//	@ID				v1-NodeBacklinks
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeBacklinksReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.NodeLinkItem}
+//	@Router			/api/v1/node/link/backlinks [get]
+func (h *NodeHandler) NodeBacklinks(c echo.Context) error {
+	var req v1.NodeBacklinksReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	links, err := h.usecase.GetNodeBacklinks(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node backlinks failed", err)
+	}
+	return h.NewResponseWithData(c, links)
+}
+
+// NodeInboundLinks 文档入链检查
+//
+//	@Tags			NodeLink
+//	@Summary		文档入链检查
+//	@Description	删除或取消发布前调用，返回文档及其子文档被删除后会失效的链接
+//	@ID				v1-NodeInboundLinks
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeInboundLinksReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.NodeLinkItem}
+//	@Router			/api/v1/node/link/inbound [get]
+func (h *NodeHandler) NodeInboundLinks(c echo.Context) error {
+	var req v1.NodeInboundLinksReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	links, err := h.usecase.GetNodeInboundLinks(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node inbound links failed", err)
+	}
+	return h.NewResponseWithData(c, links)
+}
+
+// NodeBrokenLinks 失效链接报告
+//
+//	@Tags			NodeLink
+//	@Summary		失效链接报告
+//	@Description	知识库内失效的内部链接与检查失败的外部链接
+//	@ID				v1-NodeBrokenLinks
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeBrokenLinksReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.NodeBrokenLinkItem}
+//	@Router			/api/v1/node/link/broken [get]
+func (h *NodeHandler) NodeBrokenLinks(c echo.Context) error {
+	var req v1.NodeBrokenLinksReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	links, err := h.usecase.GetNodeBrokenLinks(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node broken links failed", err)
+	}
+	return h.NewResponseWithData(c, links)
+}
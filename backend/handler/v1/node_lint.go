@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+)
+
+// NodeLintReport 内容质量报告
+//
+//	@Tags			NodeLint
+//	@Summary		内容质量报告
+//	@Description	文档保存或发布时检查出的内容问题，按问题数量排序
+//	@ID				v1-NodeLintReport
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeLintReportReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeLintReportResp}
+//	@Router			/api/v1/node/lint/report [get]
+func (h *NodeHandler) NodeLintReport(c echo.Context) error {
+	var req v1.NodeLintReportReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	report, err := h.lintUsecase.GetReport(c.Request().Context(), req.KbId)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node lint report failed", err)
+	}
+	return h.NewResponseWithData(c, report)
+}
+
+// NodeLintRefresh 重新检查全部文档
+//
+//	@Tags			NodeLint
+//	@Summary		重新检查全部文档
+//	@Description	重新检查知识库内全部文档的当前内容，用于规则或屏蔽词变更后刷新报告
+//	@ID				v1-NodeLintRefresh
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeLintRefreshReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeLintRefreshResp}
+//	@Router			/api/v1/node/lint/refresh [post]
+func (h *NodeHandler) NodeLintRefresh(c echo.Context) error {
+	var req v1.NodeLintRefreshReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	resp, err := h.lintUsecase.RefreshKB(c.Request().Context(), req.KbId)
+	if err != nil {
+		return h.NewResponseWithError(c, "refresh node lint failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
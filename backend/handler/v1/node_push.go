@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodePush
+//
+//	@Summary		NodePush
+//	@Description	Bulk create or update documents by external id, pushing the same batch again is a no-op. Works with api tokens which have doc_manage permission
+//	@Tags			node
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodePushReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodePushResp}
+//	@Router			/api/v1/node/push [post]
+func (h *NodeHandler) NodePush(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.NodePushReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	resp, err := h.pushUsecase.Push(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNodePush) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "push nodes failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeSEO 文档 SEO 设置
+//
+//	@Tags			NodeSEO
+//	@Summary		文档 SEO 设置
+//	@Description	管理员为文档设置的 SEO 覆盖项，为空的字段使用自动生成的值
+//	@ID				v1-NodeSEO
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeSEOReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeSEO}
+//	@Router			/api/v1/node/seo [get]
+func (h *NodeHandler) NodeSEO(c echo.Context) error {
+	var req v1.NodeSEOReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	seo, err := h.seoUsecase.GetOverrides(c.Request().Context(), req.KbId, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, domain.ErrPermissionDenied) {
+			return h.NewResponseWithError(c, "node not found", nil)
+		}
+		return h.NewResponseWithError(c, "get node seo failed", err)
+	}
+	return h.NewResponseWithData(c, seo)
+}
+
+// NodeSEOUpdate 更新文档 SEO 设置
+//
+//	@Tags			NodeSEO
+//	@Summary		更新文档 SEO 设置
+//	@Description	覆盖文档自动生成的标题、描述、关键词、图片和规范链接，或禁止搜索引擎收录
+//	@ID				v1-NodeSEOUpdate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeSEOUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/seo [put]
+func (h *NodeHandler) NodeSEOUpdate(c echo.Context) error {
+	var req v1.NodeSEOUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.seoUsecase.UpdateOverrides(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, domain.ErrPermissionDenied) {
+			return h.NewResponseWithError(c, "node not found", nil)
+		}
+		return h.NewResponseWithError(c, "update node seo failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
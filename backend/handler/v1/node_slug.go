@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeSlug 文档别名
+//
+//	@Tags			NodeSlug
+//	@Summary		文档别名
+//	@Description	文档的可读地址别名，未设置时使用 /node/<id>
+//	@ID				v1-NodeSlug
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeSlugReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeSlugResp}
+//	@Router			/api/v1/node/slug [get]
+func (h *NodeHandler) NodeSlug(c echo.Context) error {
+	var req v1.NodeSlugReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	resp, err := h.redirectUsecase.GetSlug(c.Request().Context(), req.KbId, req.ID)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node slug failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// NodeSlugUpdate 设置文档别名
+//
+//	@Tags			NodeSlug
+//	@Summary		设置文档别名
+//	@Description	设置文档的地址别名 /docs/<slug>，为空时删除。旧别名会自动 301 重定向到该文档
+//	@ID				v1-NodeSlugUpdate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeSlugUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/slug [put]
+func (h *NodeHandler) NodeSlugUpdate(c echo.Context) error {
+	var req v1.NodeSlugUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	if err := h.redirectUsecase.SetSlug(ctx, &req, authInfo.UserId); err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			return h.NewResponseWithError(c, "node not found", nil)
+		}
+		if errors.Is(err, domain.ErrInvalidNodeSlug) || errors.Is(err, domain.ErrNodeSlugExists) {
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "update node slug failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
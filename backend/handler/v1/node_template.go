@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeTemplateList 文档模板列表
+//
+//	@Tags			NodeTemplate
+//	@Summary		文档模板列表
+//	@Description	文档模板列表
+//	@ID				v1-NodeTemplateList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTemplateListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.NodeTemplate}
+//	@Router			/api/v1/node/template/list [get]
+func (h *NodeHandler) NodeTemplateList(c echo.Context) error {
+	var req v1.NodeTemplateListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	templates, err := h.usecase.GetNodeTemplateList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node template list failed", err)
+	}
+	return h.NewResponseWithData(c, templates)
+}
+
+// NodeTemplateDetail 文档模板详情
+//
+//	@Tags			NodeTemplate
+//	@Summary		文档模板详情
+//	@Description	文档模板详情
+//	@ID				v1-NodeTemplateDetail
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTemplateDetailReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeTemplate}
+//	@Router			/api/v1/node/template/detail [get]
+func (h *NodeHandler) NodeTemplateDetail(c echo.Context) error {
+	var req v1.NodeTemplateDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	template, err := h.usecase.GetNodeTemplate(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node template failed", err)
+	}
+	return h.NewResponseWithData(c, template)
+}
+
+// NodeTemplateCreate 创建文档模板
+//
+//	@Tags			NodeTemplate
+//	@Summary		创建文档模板
+//	@Description	创建文档模板，内容与摘要支持 {{date}} {{time}} {{datetime}} {{author}} {{title}} {{parent_name}} {{kb_name}} 占位符
+//	@ID				v1-NodeTemplateCreate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeTemplateCreateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeTemplateCreateResp}
+//	@Router			/api/v1/node/template [post]
+func (h *NodeHandler) NodeTemplateCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.NodeTemplateCreateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.usecase.CreateNodeTemplate(ctx, &req, authInfo.UserId)
+	if err != nil {
+		return h.NewResponseWithError(c, "create node template failed", err)
+	}
+	return h.NewResponseWithData(c, v1.NodeTemplateCreateResp{ID: id})
+}
+
+// NodeTemplateUpdate 更新文档模板
+//
+//	@Tags			NodeTemplate
+//	@Summary		更新文档模板
+//	@Description	更新文档模板
+//	@ID				v1-NodeTemplateUpdate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeTemplateUpdateReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/template [put]
+func (h *NodeHandler) NodeTemplateUpdate(c echo.Context) error {
+	var req v1.NodeTemplateUpdateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.usecase.UpdateNodeTemplate(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "update node template failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeTemplateDelete 删除文档模板
+//
+//	@Tags			NodeTemplate
+//	@Summary		删除文档模板
+//	@Description	删除文档模板
+//	@ID				v1-NodeTemplateDelete
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTemplateDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/template [delete]
+func (h *NodeHandler) NodeTemplateDelete(c echo.Context) error {
+	var req v1.NodeTemplateDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.usecase.DeleteNodeTemplate(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "delete node template failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeTemplateFromNode 将文档保存为模板
+//
+//	@Tags			NodeTemplate
+//	@Summary		将文档保存为模板
+//	@Description	将文档保存为模板
+//	@ID				v1-NodeTemplateFromNode
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeTemplateFromNodeReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeTemplateCreateResp}
+//	@Router			/api/v1/node/template/from_node [post]
+func (h *NodeHandler) NodeTemplateFromNode(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.NodeTemplateFromNodeReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	id, err := h.usecase.CreateNodeTemplateFromNode(ctx, &req, authInfo.UserId)
+	if err != nil {
+		return h.NewResponseWithError(c, "save node as template failed", err)
+	}
+	return h.NewResponseWithData(c, v1.NodeTemplateCreateResp{ID: id})
+}
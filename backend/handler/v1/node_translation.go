@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeTranslationList 文档译文列表
+//
+//	@Tags			NodeTranslation
+//	@Summary		文档译文列表
+//	@Description	源文档的各语言译文，源文档在翻译后有修改时译文标记为过期
+//	@ID				v1-NodeTranslationList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTranslationListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.NodeTranslationItem}
+//	@Router			/api/v1/node/translation/list [get]
+func (h *NodeHandler) NodeTranslationList(c echo.Context) error {
+	var req v1.NodeTranslationListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	items, err := h.translationUsecase.GetList(c.Request().Context(), req.KbId, req.ID)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node translations failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
+
+// NodeTranslate 机器翻译文档
+//
+//	@Tags			NodeTranslation
+//	@Summary		机器翻译文档
+//	@Description	使用对话模型在后台将文档翻译为目标语言，首次翻译时创建译文文档，之后覆盖译文。译文需要发布后才对外可见
+//	@ID				v1-NodeTranslate
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeTranslateReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.NodeTranslateResp}
+//	@Router			/api/v1/node/translation/translate [post]
+func (h *NodeHandler) NodeTranslate(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.NodeTranslateReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	nodeID, err := h.translationUsecase.Translate(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrPermissionDenied):
+			return h.NewResponseWithError(c, "node not found", nil)
+		case errors.Is(err, domain.ErrInvalidNodeTranslation), errors.Is(err, domain.ErrNodeTranslationRunning),
+			errors.Is(err, domain.ErrModelNotConfigured), errors.Is(err, domain.ErrMaxNodeLimitReached):
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "translate node failed", err)
+	}
+	return h.NewResponseWithData(c, &v1.NodeTranslateResp{NodeID: nodeID})
+}
+
+// NodeTranslationLink 关联已有文档为译文
+//
+//	@Tags			NodeTranslation
+//	@Summary		关联已有文档为译文
+//	@Description	将人工翻译的已有文档关联为源文档在某一语言的译文
+//	@ID				v1-NodeTranslationLink
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeTranslationLinkReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/translation/link [post]
+func (h *NodeHandler) NodeTranslationLink(c echo.Context) error {
+	var req v1.NodeTranslationLinkReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.translationUsecase.Link(c.Request().Context(), &req); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrPermissionDenied):
+			return h.NewResponseWithError(c, "node not found", nil)
+		case errors.Is(err, domain.ErrInvalidNodeTranslation):
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "link node translation failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeTranslationSynced 标记译文为最新
+//
+//	@Tags			NodeTranslation
+//	@Summary		标记译文为最新
+//	@Description	人工校对或更新译文后，将译文标记为与当前源文档一致，取消过期标记
+//	@ID				v1-NodeTranslationSynced
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.NodeTranslationReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/translation/synced [put]
+func (h *NodeHandler) NodeTranslationSynced(c echo.Context) error {
+	var req v1.NodeTranslationReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	if err := h.translationUsecase.MarkSynced(c.Request().Context(), req.KbId, req.NodeID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return h.NewResponseWithError(c, "node translation not found", nil)
+		case errors.Is(err, domain.ErrNodeTranslationRunning):
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "mark node translation synced failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeTranslationUnlink 取消译文关联
+//
+//	@Tags			NodeTranslation
+//	@Summary		取消译文关联
+//	@Description	取消文档与源文档的译文关联，文档本身保留为普通文档
+//	@ID				v1-NodeTranslationUnlink
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTranslationReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/translation [delete]
+func (h *NodeHandler) NodeTranslationUnlink(c echo.Context) error {
+	var req v1.NodeTranslationReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.translationUsecase.Unlink(c.Request().Context(), req.KbId, req.NodeID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return h.NewResponseWithError(c, "node translation not found", nil)
+		case errors.Is(err, domain.ErrNodeTranslationRunning):
+			return h.NewResponseWithError(c, err.Error(), nil)
+		}
+		return h.NewResponseWithError(c, "unlink node translation failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// NodeTrashList 回收站列表
+//
+//	@Tags			NodeTrash
+//	@Summary		回收站列表
+//	@Description	回收站中被删除的文档，子文档随父文档一同展示与恢复
+//	@ID				v1-NodeTrashList
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTrashListReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.NodeTrashListItem}
+//	@Router			/api/v1/node/trash/list [get]
+func (h *NodeHandler) NodeTrashList(c echo.Context) error {
+	var req v1.NodeTrashListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	items, err := h.usecase.GetNodeTrashList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node trash list failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
+
+// NodeTrashRestore 从回收站恢复
+//
+//	@Tags			NodeTrash
+//	@Summary		从回收站恢复
+//	@Description	恢复到原父文档与位置，父文档已不存在时恢复到根目录，文档 ID 已被其他文档使用时返回 409
+//	@ID				v1-NodeTrashRestore
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	body		v1.NodeTrashRestoreReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Failure		409		{object}	domain.PWResponse
+//	@Router			/api/v1/node/trash/restore [post]
+func (h *NodeHandler) NodeTrashRestore(c echo.Context) error {
+	var req v1.NodeTrashRestoreReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	ctx := c.Request().Context()
+	if err := h.usecase.RestoreNodeTrash(ctx, &req, domain.GetBaseEditionLimitation(ctx).MaxNode); err != nil {
+		if errors.Is(err, domain.ErrMaxNodeLimitReached) {
+			return h.NewResponseWithError(c, "已达到最大文档数量限制，请升级到更高版本", nil)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.NewResponseWithError(c, "回收站中不存在该文档", nil)
+		}
+		if errors.Is(err, domain.ErrNodeTrashIDInUse) {
+			return c.JSON(http.StatusConflict, domain.PWResponse{
+				Success: false,
+				Message: "文档 ID 已被其他文档使用，无法恢复",
+				Code:    domain.ErrCodeConflict.Code,
+			})
+		}
+		return h.NewResponseWithError(c, "restore node trash failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// NodeTrashDelete 彻底删除
+//
+//	@Tags			NodeTrash
+//	@Summary		彻底删除
+//	@Description	从回收站彻底删除文档及其向量数据，all 为 true 时清空回收站
+//	@ID				v1-NodeTrashDelete
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			param	query		v1.NodeTrashDeleteReq	true	"para"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/trash [delete]
+func (h *NodeHandler) NodeTrashDelete(c echo.Context) error {
+	var req v1.NodeTrashDeleteReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request params is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request params failed", err)
+	}
+
+	if err := h.usecase.DeleteNodeTrash(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "delete node trash failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
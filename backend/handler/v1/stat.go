@@ -40,6 +40,15 @@ func NewStatHandler(baseHandler *handler.BaseHandler, echo *echo.Echo, usecase *
 	group.GET("/hot_pages", h.StatHotPages)
 	group.GET("/referer_hosts", h.StatRefererHosts)
 	group.GET("/browsers", h.StatBrowsers)
+	group.GET("/node_engagement", h.StatNodeEngagement)
+	group.GET("/node_feedback", h.StatNodeFeedback)
+
+	// 搜索分析
+	group.GET("/search/top_queries", h.StatTopSearchQueries)
+	group.GET("/search/zero_result_queries", h.StatZeroResultSearchQueries)
+	group.GET("/search/negative_feedback_queries", h.StatNegativeFeedbackQueries)
+	// creating the gap doc writes a node, so it needs the doc permission instead
+	echo.POST("/api/v1/stat/search/gap_doc", h.StatSearchGapDoc, h.auth.Authorize, auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
 	return h
 }
 
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// StatNodeEngagement 文档阅读情况
+//
+//	@Summary		文档阅读情况
+//	@Description	访问最多的文档的停留时长、滚动深度、读完率和跳出率
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			para	query		v1.StatNodeEngagementReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.StatNodeEngagementItem}
+//	@Router			/api/v1/stat/node_engagement [get]
+func (h *StatHandler) StatNodeEngagement(c echo.Context) error {
+	var req v1.StatNodeEngagementReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request parameters", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validation failed", err)
+	}
+
+	if err := h.usecase.ValidateStatDay(req.Day, consts.GetLicenseEdition(c)); err != nil {
+		h.logger.Error("validate stat day failed")
+		return h.NewResponseWithErrCode(c, domain.ErrCodePermissionDenied)
+	}
+
+	items, err := h.usecase.GetNodeEngagements(c.Request().Context(), req.KbID, req.Day)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node engagement failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
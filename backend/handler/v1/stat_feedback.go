@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// StatNodeFeedback 文档反馈统计
+//
+//	@Summary		文档反馈统计
+//	@Description	按文档汇总“是否有帮助”的反馈，负面反馈最多的文档在前
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			para	query		v1.StatNodeFeedbackReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.StatNodeFeedbackItem}
+//	@Router			/api/v1/stat/node_feedback [get]
+func (h *StatHandler) StatNodeFeedback(c echo.Context) error {
+	var req v1.StatNodeFeedbackReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request parameters", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validation failed", err)
+	}
+
+	if err := h.usecase.ValidateStatDay(req.Day, consts.GetLicenseEdition(c)); err != nil {
+		h.logger.Error("validate stat day failed")
+		return h.NewResponseWithErrCode(c, domain.ErrCodePermissionDenied)
+	}
+
+	items, err := h.usecase.GetNodeFeedbackStats(c.Request().Context(), req.KbID, req.Day)
+	if err != nil {
+		return h.NewResponseWithError(c, "get node feedback stats failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
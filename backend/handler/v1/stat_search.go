@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// StatTopSearchQueries 热门查询
+//
+//	@Summary		热门查询
+//	@Description	按规范化后的查询聚合的热门搜索和问答
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			para	query		v1.StatSearchQueriesReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.StatSearchQueryItem}
+//	@Router			/api/v1/stat/search/top_queries [get]
+func (h *StatHandler) StatTopSearchQueries(c echo.Context) error {
+	return h.statSearchQueries(c, false)
+}
+
+// StatZeroResultSearchQueries 无结果查询
+//
+//	@Summary		无结果查询
+//	@Description	没有召回任何文档的搜索和问答
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			para	query		v1.StatSearchQueriesReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.StatSearchQueryItem}
+//	@Router			/api/v1/stat/search/zero_result_queries [get]
+func (h *StatHandler) StatZeroResultSearchQueries(c echo.Context) error {
+	return h.statSearchQueries(c, true)
+}
+
+func (h *StatHandler) statSearchQueries(c echo.Context, zeroResultOnly bool) error {
+	var req v1.StatSearchQueriesReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request parameters", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validation failed", err)
+	}
+
+	if err := h.usecase.ValidateStatDay(req.Day, consts.GetLicenseEdition(c)); err != nil {
+		h.logger.Error("validate stat day failed")
+		return h.NewResponseWithErrCode(c, domain.ErrCodePermissionDenied)
+	}
+
+	items, err := h.usecase.GetTopSearchQueries(c.Request().Context(), req.KbID, req.Day, req.Source, zeroResultOnly)
+	if err != nil {
+		return h.NewResponseWithError(c, "get search queries failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
+
+// StatNegativeFeedbackQueries 差评问题
+//
+//	@Summary		差评问题
+//	@Description	回答被点踩的问题
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			para	query		v1.StatNegativeFeedbackQueriesReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=[]v1.StatNegativeFeedbackQueryItem}
+//	@Router			/api/v1/stat/search/negative_feedback_queries [get]
+func (h *StatHandler) StatNegativeFeedbackQueries(c echo.Context) error {
+	var req v1.StatNegativeFeedbackQueriesReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request parameters", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validation failed", err)
+	}
+
+	if err := h.usecase.ValidateStatDay(req.Day, consts.GetLicenseEdition(c)); err != nil {
+		h.logger.Error("validate stat day failed")
+		return h.NewResponseWithErrCode(c, domain.ErrCodePermissionDenied)
+	}
+
+	items, err := h.usecase.GetNegativeFeedbackQueries(c.Request().Context(), req.KbID, req.Day)
+	if err != nil {
+		return h.NewResponseWithError(c, "get negative feedback queries failed", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
+
+// StatSearchGapDoc 为查询创建文档
+//
+//	@Summary		为查询创建文档
+//	@Description	为无结果或差评的查询创建草稿文档
+//	@Tags			stat
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		v1.StatSearchGapDocReq	true	"para"
+//	@Success		200		{object}	domain.PWResponse{data=v1.StatSearchGapDocResp}
+//	@Router			/api/v1/stat/search/gap_doc [post]
+func (h *StatHandler) StatSearchGapDoc(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req v1.StatSearchGapDocReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request parameters", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validation failed", err)
+	}
+
+	resp, err := h.usecase.CreateSearchGapDoc(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		if errors.Is(err, domain.ErrMaxNodeLimitReached) {
+			return h.NewResponseWithError(c, "已达到最大文档数量限制，请升级到更高版本", nil)
+		}
+		return h.NewResponseWithError(c, "create search gap doc failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
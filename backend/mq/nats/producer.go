@@ -34,6 +34,10 @@ func (p *MQProducer) EnsureStreams() error {
 			name:     "rag",
 			subjects: []string{"rag.doc.update"},
 		},
+		{
+			name:     "webhook",
+			subjects: []string{"apps.panda-wiki.webhook.>"},
+		},
 	}
 
 	for _, stream := range streams {
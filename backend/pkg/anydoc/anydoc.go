@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/mq"
 	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/pkg/docconv"
+	"github.com/chaitin/panda-wiki/store/cache"
+	"github.com/chaitin/panda-wiki/utils"
 )
 
 type Client struct {
@@ -27,6 +31,10 @@ type Client struct {
 	mutex       sync.RWMutex
 	subscribed  bool
 	subscribeMu sync.Mutex
+	uploader    FileUploader
+	cache       *cache.Cache
+	// publicClient downloads the files of the local parsers, it refuses private addresses
+	publicClient *http.Client
 }
 
 const (
@@ -55,7 +63,7 @@ const (
 	uploaderTypeHTTP
 )
 
-func NewClient(logger *log.Logger, mqConsumer mq.MQConsumer) (*Client, error) {
+func NewClient(logger *log.Logger, mqConsumer mq.MQConsumer, cache *cache.Cache, uploader FileUploader) (*Client, error) {
 	client := &Client{
 		logger: logger.WithModule("anydoc.client"),
 		httpClient: &http.Client{
@@ -65,14 +73,28 @@ func NewClient(logger *log.Logger, mqConsumer mq.MQConsumer) (*Client, error) {
 				},
 			},
 		},
-		taskWaiters: make(map[string]chan *domain.AnydocTaskExportEvent),
-		mqConsumer:  mqConsumer,
+		taskWaiters:  make(map[string]chan *domain.AnydocTaskExportEvent),
+		mqConsumer:   mqConsumer,
+		uploader:     uploader,
+		cache:        cache,
+		publicClient: utils.NewPublicHTTPClient(localDownloadTimeout),
 	}
 
 	return client, nil
 }
 
 func (c *Client) GetUrlList(ctx context.Context, targetURL, id string) (*ListDocResponse, error) {
+	// office and pdf files are parsed locally first
+	docs, err := c.localListDocs(ctx, targetURL, id)
+	if err == nil {
+		return docs, nil
+	}
+	if errors.Is(err, utils.ErrPrivateAddress) {
+		return nil, err
+	}
+	if !errors.Is(err, docconv.ErrUnsupported) {
+		c.logger.Warn("local parse failed, fallback to document service", "url", targetURL, "error", err)
+	}
 
 	u, err := url.Parse(crawlerServiceHost)
 	if err != nil {
@@ -113,6 +135,14 @@ func (c *Client) GetUrlList(ctx context.Context, targetURL, id string) (*ListDoc
 }
 
 func (c *Client) UrlExport(ctx context.Context, id, docID, kbId string) (*UrlExportRes, error) {
+	var doc localDoc
+	ok, err := c.getLocal(ctx, localDocKeyPrefix+id, &doc)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return c.localExport(ctx, &doc, docID, kbId)
+	}
 
 	u, err := url.Parse(crawlerServiceHost)
 	if err != nil {
@@ -270,6 +300,41 @@ func (c *Client) handleTaskExportEvent(ctx context.Context, msg types.Message) e
 }
 
 func (c *Client) TaskList(ctx context.Context, ids []string) (*TaskRes, error) {
+	localTasks, err := c.localTaskList(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(localTasks) == 0 {
+		return c.remoteTaskList(ctx, ids)
+	}
+	remoteIDs := make([]string, 0, len(ids)-len(localTasks))
+	for _, id := range ids {
+		if _, ok := localTasks[id]; !ok {
+			remoteIDs = append(remoteIDs, id)
+		}
+	}
+	remoteTasks := make(map[string]TaskData)
+	if len(remoteIDs) > 0 {
+		res, err := c.remoteTaskList(ctx, remoteIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range res.Data {
+			remoteTasks[task.TaskId] = task
+		}
+	}
+	res := &TaskRes{Success: true, Data: make([]TaskData, 0, len(ids))}
+	for _, id := range ids {
+		if task, ok := localTasks[id]; ok {
+			res.Data = append(res.Data, task)
+		} else if task, ok := remoteTasks[id]; ok {
+			res.Data = append(res.Data, task)
+		}
+	}
+	return res, nil
+}
+
+func (c *Client) remoteTaskList(ctx context.Context, ids []string) (*TaskRes, error) {
 	u, err := url.Parse(crawlerServiceHost)
 	if err != nil {
 		return nil, err
@@ -316,6 +381,9 @@ func (c *Client) TaskList(ctx context.Context, ids []string) (*TaskRes, error) {
 }
 
 func (c *Client) DownloadDoc(ctx context.Context, filepath string) ([]byte, error) {
+	if strings.HasPrefix(filepath, localMarkdownPrefix) {
+		return c.localMarkdown(ctx, filepath)
+	}
 	u, err := url.Parse(crawlerServiceHost)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,202 @@
+package anydoc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/chaitin/panda-wiki/pkg/docconv"
+)
+
+// FileUploader uploads the images extracted by the local parsers and returns their url
+type FileUploader func(ctx context.Context, kbID, filename string, data []byte) (string, error)
+
+// StaticFileURLPrefix is where uploaded files are parsed from, the only private address the local parsers download from
+const StaticFileURLPrefix = "http://panda-wiki-minio:9000/static-file/"
+
+const (
+	localTaskPrefix      = "local-"
+	localMarkdownPrefix  = "/local/"
+	localResultTTL       = 2 * time.Hour
+	localMaxFileSize     = 200 << 20
+	localDownloadTimeout = 5 * time.Minute
+	localDocKeyPrefix    = "anydoc:local:doc:"
+	localTaskKeyPrefix   = "anydoc:local:task:"
+)
+
+// localDoc is a document parsed by the local parsers, the source is kept in the cache instead of the parse result
+// so any replica can export it, the file is downloaded and converted again on export
+type localDoc struct {
+	URL string `json:"url"`
+}
+
+// localTask is the export result of a local document
+type localTask struct {
+	DocID    string `json:"doc_id"`
+	Markdown string `json:"markdown"`
+}
+
+func (c *Client) setLocal(ctx context.Context, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.cache.Set(ctx, key, data, localResultTTL).Err()
+}
+
+// getLocal reports false if the key does not exist or expired
+func (c *Client) getLocal(ctx context.Context, key string, value any) (bool, error) {
+	data, err := c.cache.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// localListDocs converts office and pdf files in process, docconv.ErrUnsupported means the document service has to parse it
+func (c *Client) localListDocs(ctx context.Context, targetURL, id string) (*ListDocResponse, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil || !docconv.Supported(u.Path) {
+		return nil, docconv.ErrUnsupported
+	}
+	doc, err := c.localConvert(ctx, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	// the document service parses it if the replicas could not find the local result
+	if err := c.setLocal(ctx, localDocKeyPrefix+id, &localDoc{URL: targetURL}); err != nil {
+		return nil, err
+	}
+	c.logger.Info("parsed file with local parser", "url", targetURL, "images", len(doc.Images))
+
+	filename := path.Base(u.Path)
+	return &ListDocResponse{
+		Success: true,
+		Data: ListDocsData{
+			Docs: Child{
+				Value: Value{
+					ID:       id,
+					File:     true,
+					FileType: strings.TrimPrefix(strings.ToLower(path.Ext(filename)), "."),
+					Title:    doc.Title,
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *Client) localConvert(ctx context.Context, targetURL string) (*docconv.Document, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.download(ctx, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	return docconv.Convert(path.Base(u.Path), data)
+}
+
+// download fetches the file, only uploaded files may be read from a private address
+func (c *Client) download(ctx context.Context, targetURL string) ([]byte, error) {
+	client := c.publicClient
+	if strings.HasPrefix(targetURL, StaticFileURLPrefix) {
+		client = c.httpClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download file failed, status: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, localMaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > localMaxFileSize {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", docconv.ErrUnsupported, localMaxFileSize)
+	}
+	return data, nil
+}
+
+// localExport converts the document again and uploads its images, the task is completed right away
+func (c *Client) localExport(ctx context.Context, src *localDoc, docID, kbID string) (*UrlExportRes, error) {
+	doc, err := c.localConvert(ctx, src.URL)
+	if err != nil {
+		return nil, err
+	}
+	markdown, errs := doc.ResolveImages(func(img *docconv.Image) (string, error) {
+		if c.uploader == nil {
+			return "", fmt.Errorf("file uploader not initialized")
+		}
+		return c.uploader(ctx, kbID, img.Name, img.Data)
+	})
+	for _, err := range errs {
+		c.logger.Warn("upload image of local parser failed", "doc_id", docID, "error", err)
+	}
+	taskID := localTaskPrefix + uuid.New().String()
+	if err := c.setLocal(ctx, localTaskKeyPrefix+taskID, &localTask{DocID: docID, Markdown: markdown}); err != nil {
+		return nil, err
+	}
+	return &UrlExportRes{Success: true, Data: taskID}, nil
+}
+
+// localTaskList returns the local tasks, unknown or expired local tasks are failed
+func (c *Client) localTaskList(ctx context.Context, ids []string) (map[string]TaskData, error) {
+	tasks := make(map[string]TaskData)
+	for _, id := range ids {
+		if !strings.HasPrefix(id, localTaskPrefix) {
+			continue
+		}
+		var task localTask
+		ok, err := c.getLocal(ctx, localTaskKeyPrefix+id, &task)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			tasks[id] = TaskData{TaskId: id, Status: StatusFailed, Err: "local parse result expired"}
+			continue
+		}
+		tasks[id] = TaskData{
+			TaskId:   id,
+			DocId:    task.DocID,
+			Status:   StatusCompleted,
+			Markdown: localMarkdownPrefix + id,
+		}
+	}
+	return tasks, nil
+}
+
+func (c *Client) localMarkdown(ctx context.Context, filepath string) ([]byte, error) {
+	var task localTask
+	ok, err := c.getLocal(ctx, localTaskKeyPrefix+strings.TrimPrefix(filepath, localMarkdownPrefix), &task)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("local parse result expired")
+	}
+	return []byte(task.Markdown), nil
+}
@@ -24,17 +24,19 @@ type UrlExportRes struct {
 	TraceId interface{} `json:"trace_id"`
 }
 type TaskRes struct {
-	Success bool `json:"success"`
-	Data    []struct {
-		TaskId     string `json:"task_id"`
-		PlatformId string `json:"platform_id"`
-		DocId      string `json:"doc_id"`
-		Status     Status `json:"status"`
-		Err        string `json:"err"`
-		Markdown   string `json:"markdown"`
-		Json       string `json:"json"`
-	} `json:"data"`
-	Msg string `json:"msg"`
+	Success bool       `json:"success"`
+	Data    []TaskData `json:"data"`
+	Msg     string     `json:"msg"`
+}
+
+type TaskData struct {
+	TaskId     string `json:"task_id"`
+	PlatformId string `json:"platform_id"`
+	DocId      string `json:"doc_id"`
+	Status     Status `json:"status"`
+	Err        string `json:"err"`
+	Markdown   string `json:"markdown"`
+	Json       string `json:"json"`
 }
 
 type ListDocResponse struct {
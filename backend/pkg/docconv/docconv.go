@@ -0,0 +1,427 @@
+// Package docconv converts office documents and text based pdf files to markdown in process
+package docconv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned for formats and files the local parsers can not handle,
+// like scanned pdf files without a text layer
+var ErrUnsupported = errors.New("document is not supported by the local parsers")
+
+// ErrTooLarge is returned when a zip entry or pdf stream decompresses to more than the parsers accept,
+// like zip and flate bombs
+var ErrTooLarge = errors.New("document is too large after decompression")
+
+var (
+	maxEntrySize    int64 = 64 << 20  // decompressed size of a single zip entry or pdf stream
+	maxDocumentSize int64 = 256 << 20 // decompressed size of all entries or streams of a document
+)
+
+const (
+	imageRefPrefix  = "docconv-image://"
+	relationshipsNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+var imageRefRegex = regexp.MustCompile(regexp.QuoteMeta(imageRefPrefix) + `(\d+)`)
+
+type Document struct {
+	Title    string
+	Markdown string // images are referenced by placeholders until ResolveImages
+	Images   []*Image
+}
+
+type Image struct {
+	Name string
+	Data []byte
+}
+
+// Supported reports whether the file can be converted by the local parsers
+func Supported(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".docx", ".xlsx", ".pptx", ".pdf":
+		return true
+	}
+	return false
+}
+
+// Convert converts the file to markdown, the format is detected by the file extension
+func Convert(filename string, data []byte) (*Document, error) {
+	var (
+		doc *Document
+		err error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".docx":
+		doc, err = convertDocx(data)
+	case ".xlsx":
+		doc, err = convertXlsx(data)
+	case ".pptx":
+		doc, err = convertPptx(data)
+	case ".pdf":
+		doc, err = convertPDF(data)
+	default:
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	if doc.Title == "" {
+		doc.Title = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	doc.Markdown = strings.TrimSpace(doc.Markdown) + "\n"
+	return doc, nil
+}
+
+// ResolveImages replaces the image placeholders with the urls returned by upload,
+// images failing to upload are dropped from the markdown
+func (d *Document) ResolveImages(upload func(img *Image) (string, error)) (string, []error) {
+	urls := make([]string, len(d.Images))
+	var errs []error
+	for i, img := range d.Images {
+		url, err := upload(img)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("upload %s failed: %w", img.Name, err))
+			continue
+		}
+		urls[i] = url
+	}
+	markdown := imageRefRegex.ReplaceAllStringFunc(d.Markdown, func(ref string) string {
+		i, err := strconv.Atoi(strings.TrimPrefix(ref, imageRefPrefix))
+		if err != nil || i >= len(urls) {
+			return ""
+		}
+		return urls[i]
+	})
+	// images without url leave an empty link
+	markdown = strings.ReplaceAll(markdown, "![]()", "")
+	return markdown, errs
+}
+
+// addImage keeps the image and returns the markdown referencing it
+func (d *Document) addImage(name string, data []byte) string {
+	d.Images = append(d.Images, &Image{Name: name, Data: data})
+	return fmt.Sprintf("![](%s%d)", imageRefPrefix, len(d.Images)-1)
+}
+
+// xmlNode is a generic element tree of the office xml parts
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []*xmlNode `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+func (n *xmlNode) name() string {
+	return n.XMLName.Local
+}
+
+func (n *xmlNode) attr(local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// relID returns the r:id attribute, elements like the slide ids of presentations carry a plain id too
+func (n *xmlNode) relID() string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == "id" && (a.Name.Space == relationshipsNS || a.Name.Space == "r") {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child element with the local name
+func (n *xmlNode) child(local string) *xmlNode {
+	for _, c := range n.Nodes {
+		if c.name() == local {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *xmlNode) children(local string) []*xmlNode {
+	var nodes []*xmlNode
+	for _, c := range n.Nodes {
+		if c.name() == local {
+			nodes = append(nodes, c)
+		}
+	}
+	return nodes
+}
+
+// find returns the first descendant with the local name, depth first
+func (n *xmlNode) find(local string) *xmlNode {
+	for _, c := range n.Nodes {
+		if c.name() == local {
+			return c
+		}
+		if found := c.find(local); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// path follows the chain of child elements
+func (n *xmlNode) path(locals ...string) *xmlNode {
+	cur := n
+	for _, local := range locals {
+		if cur == nil {
+			return nil
+		}
+		cur = cur.child(local)
+	}
+	return cur
+}
+
+// ooxmlPackage is a zip based office document
+type ooxmlPackage struct {
+	files  map[string]*zip.File
+	budget int64 // decompressed bytes the document may still read
+}
+
+type relationship struct {
+	Type     string
+	Target   string
+	External bool
+}
+
+func openPackage(data []byte) (*ooxmlPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open office document failed: %w", err)
+	}
+	pkg := &ooxmlPackage{files: make(map[string]*zip.File, len(zr.File)), budget: maxDocumentSize}
+	for _, f := range zr.File {
+		pkg.files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+	return pkg, nil
+}
+
+func (p *ooxmlPackage) read(name string) ([]byte, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in document", name)
+	}
+	// the size in the header is only a hint, readLimited enforces the limits on the actual data
+	if f.UncompressedSize64 > uint64(entryLimit(p.budget)) {
+		p.budget = -1
+		return nil, fmt.Errorf("%w: %s", ErrTooLarge, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := readLimited(rc, &p.budget)
+	if err != nil {
+		return nil, fmt.Errorf("read %s failed: %w", name, err)
+	}
+	return data, nil
+}
+
+// readLimited reads r up to the entry limit and the remaining budget of the document,
+// exceeding either exhausts the budget so the whole document is rejected
+func readLimited(r io.Reader, budget *int64) ([]byte, error) {
+	limit := entryLimit(*budget)
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if int64(len(data)) > limit {
+		*budget = -1
+		return nil, ErrTooLarge
+	}
+	*budget -= int64(len(data))
+	return data, err
+}
+
+// entryLimit is the size a single entry may decompress to with the remaining budget of the document
+func entryLimit(budget int64) int64 {
+	return max(0, min(maxEntrySize, budget))
+}
+
+// exceeded reports whether an entry was over the limits, entries failing to read are
+// usually skipped by the converters so they check it at the end
+func (p *ooxmlPackage) exceeded() bool {
+	return p.budget < 0
+}
+
+func (p *ooxmlPackage) readXML(name string) (*xmlNode, error) {
+	data, err := p.read(name)
+	if err != nil {
+		return nil, err
+	}
+	var root xmlNode
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", name, err)
+	}
+	return &root, nil
+}
+
+// rels returns the relationships of the part by id, targets are resolved to package paths
+func (p *ooxmlPackage) rels(part string) map[string]relationship {
+	rels := make(map[string]relationship)
+	root, err := p.readXML(path.Join(path.Dir(part), "_rels", path.Base(part)+".rels"))
+	if err != nil {
+		return rels
+	}
+	for _, rel := range root.children("Relationship") {
+		r := relationship{Type: path.Base(rel.attr("Type")), Target: rel.attr("Target")}
+		if rel.attr("TargetMode") == "External" {
+			r.External = true
+		} else if strings.HasPrefix(r.Target, "/") {
+			r.Target = strings.TrimPrefix(r.Target, "/")
+		} else {
+			r.Target = path.Join(path.Dir(part), r.Target)
+		}
+		rels[rel.attr("Id")] = r
+	}
+	return rels
+}
+
+// title returns the title of the document properties
+func (p *ooxmlPackage) title() string {
+	core, err := p.readXML("docProps/core.xml")
+	if err != nil {
+		return ""
+	}
+	if title := core.child("title"); title != nil {
+		return strings.TrimSpace(title.Text)
+	}
+	return ""
+}
+
+// image returns the markdown of the related image, external images keep their url
+func (p *ooxmlPackage) image(doc *Document, rels map[string]relationship, id string, seen map[string]string) string {
+	rel, ok := rels[id]
+	if !ok {
+		return ""
+	}
+	if rel.External {
+		if strings.HasPrefix(rel.Target, "http://") || strings.HasPrefix(rel.Target, "https://") {
+			return fmt.Sprintf("![](%s)", rel.Target)
+		}
+		return ""
+	}
+	if ref, ok := seen[rel.Target]; ok {
+		return ref
+	}
+	ref := ""
+	switch strings.ToLower(path.Ext(rel.Target)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".bmp", ".svg", ".webp":
+		if data, err := p.read(rel.Target); err == nil {
+			ref = doc.addImage(path.Base(rel.Target), data)
+		}
+	}
+	// emf and wmf previews can not be shown in browsers
+	seen[rel.Target] = ref
+	return ref
+}
+
+// markdownTable renders the rows as a table with the first row as header
+func markdownTable(rows [][]string) string {
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = escapeTableCell(row[i])
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+func escapeTableCell(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// inlineSpan is a run of text with its emphasis, adjacent spans with the same emphasis are merged
+type inlineSpan struct {
+	text   string
+	bold   bool
+	italic bool
+}
+
+func renderSpans(spans []inlineSpan) string {
+	var merged []inlineSpan
+	for _, span := range spans {
+		if span.text == "" {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].bold == span.bold && merged[n-1].italic == span.italic {
+			merged[n-1].text += span.text
+			continue
+		}
+		merged = append(merged, span)
+	}
+	var sb strings.Builder
+	for _, span := range merged {
+		marker := ""
+		if span.bold {
+			marker += "**"
+		}
+		if span.italic {
+			marker += "*"
+		}
+		trimmed := strings.TrimSpace(span.text)
+		if marker == "" || trimmed == "" || strings.HasPrefix(trimmed, imageRefPrefix) || strings.HasPrefix(trimmed, "![") {
+			sb.WriteString(span.text)
+			continue
+		}
+		// emphasis markers must touch the text
+		lead := span.text[:len(span.text)-len(strings.TrimLeft(span.text, " \t"))]
+		trail := span.text[len(strings.TrimRight(span.text, " \t")):]
+		sb.WriteString(lead + marker + trimmed + reverseMarker(marker) + trail)
+	}
+	return sb.String()
+}
+
+func reverseMarker(marker string) string {
+	b := []byte(marker)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// listMarker returns the markdown list prefix of the nesting level
+func listMarker(level int, ordered bool) string {
+	marker := "- "
+	if ordered {
+		marker = "1. "
+	}
+	return strings.Repeat("    ", level) + marker
+}
@@ -0,0 +1,217 @@
+package docconv
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	wordNS  = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+	sheetNS = `xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	slideNS = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	relsNS  = `xmlns="http://schemas.openxmlformats.org/package/2006/relationships"`
+)
+
+// zipFixture builds an office document from the file names and contents
+func zipFixture(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// pdfFixture builds a pdf with one page showing the content stream, the stream is flate compressed if asked
+func pdfFixture(t *testing.T, content string, compress bool) []byte {
+	t.Helper()
+	stream, filter := []byte(content), ""
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, err := zw.Write(stream)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		stream, filter = buf.Bytes(), " /Filter /FlateDecode"
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length %d%s >>\nstream\n%s\nendstream", len(stream), filter, stream),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R /Size 6 >>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func docxFixture(t *testing.T) []byte {
+	return zipFixture(t, map[string]string{
+		"word/document.xml": `<w:document ` + wordNS + `><w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Guide</w:t></w:r></w:p>
+<w:p><w:r><w:t>Hello </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>world</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>a</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>b</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>2</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+</w:body></w:document>`,
+		"word/styles.xml": `<w:styles ` + wordNS + `><w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/></w:style></w:styles>`,
+	})
+}
+
+func xlsxFixture(t *testing.T) []byte {
+	return zipFixture(t, map[string]string{
+		"xl/workbook.xml":            `<workbook ` + sheetNS + `><sheets><sheet name="Prices" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships ` + relsNS + `><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst ` + sheetNS + `><si><t>Item</t></si><si><t>Price</t></si><si><t>Apple</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet ` + sheetNS + `><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>3</v></c></row>
+</sheetData></worksheet>`,
+	})
+}
+
+func pptxFixture(t *testing.T) []byte {
+	return zipFixture(t, map[string]string{
+		"ppt/presentation.xml":            `<p:presentation ` + slideNS + `><p:sldIdLst><p:sldId id="256" r:id="rId1"/></p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships ` + relsNS + `><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/></Relationships>`,
+		"ppt/slides/slide1.xml": `<p:sld ` + slideNS + `><p:cSld><p:spTree>
+<p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>Roadmap</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:nvSpPr><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>Ship it</a:t></a:r></a:p><a:p><a:r><a:t>Measure it</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:sld>`,
+	})
+}
+
+func TestConvert(t *testing.T) {
+	pdfContent := "BT /F1 12 Tf 72 720 Td (Hello from a pdf) Tj ET"
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		title    string
+		markdown []string // fragments the markdown must contain
+	}{
+		{
+			name:     "docx",
+			filename: "guide.docx",
+			data:     docxFixture(t),
+			title:    "guide",
+			markdown: []string{"# Guide", "Hello **world**", "| a | b |", "| 1 | 2 |"},
+		},
+		{
+			name:     "xlsx",
+			filename: "prices.xlsx",
+			data:     xlsxFixture(t),
+			title:    "prices",
+			markdown: []string{"| Item | Price |", "| Apple | 3 |"},
+		},
+		{
+			name:     "pptx",
+			filename: "roadmap.pptx",
+			data:     pptxFixture(t),
+			title:    "roadmap",
+			markdown: []string{"## Roadmap", "- Ship it", "- Measure it"},
+		},
+		{
+			name:     "pdf",
+			filename: "hello.pdf",
+			data:     pdfFixture(t, pdfContent, false),
+			title:    "hello",
+			markdown: []string{"Hello from a pdf"},
+		},
+		{
+			name:     "flate compressed pdf",
+			filename: "hello.PDF",
+			data:     pdfFixture(t, pdfContent, true),
+			title:    "hello",
+			markdown: []string{"Hello from a pdf"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Convert(tt.filename, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.title, doc.Title)
+			for _, fragment := range tt.markdown {
+				assert.Contains(t, doc.Markdown, fragment)
+			}
+		})
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+	}{
+		{name: "unknown extension", filename: "notes.txt", data: []byte("notes")},
+		{name: "not a pdf", filename: "fake.pdf", data: []byte("plain text")},
+		{name: "pdf without text", filename: "scan.pdf", data: pdfFixture(t, "q 1 0 0 1 0 0 cm Q", false)},
+		{name: "docx without body", filename: "empty.docx", data: zipFixture(t, map[string]string{"word/document.xml": `<w:document ` + wordNS + `/>`})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Convert(tt.filename, tt.data)
+			assert.ErrorIs(t, err, ErrUnsupported)
+		})
+	}
+}
+
+func TestConvertTooLarge(t *testing.T) {
+	entrySize, documentSize := maxEntrySize, maxDocumentSize
+	t.Cleanup(func() { maxEntrySize, maxDocumentSize = entrySize, documentSize })
+	maxEntrySize, maxDocumentSize = 4<<10, 8<<10
+
+	filler := strings.Repeat("x", 3<<10)
+	bigParagraphs := func(n int) string {
+		var sb strings.Builder
+		sb.WriteString(`<w:document ` + wordNS + `><w:body>`)
+		for range n {
+			sb.WriteString(`<w:p><w:r><w:t>` + filler + `</w:t></w:r></w:p>`)
+		}
+		sb.WriteString(`</w:body></w:document>`)
+		return sb.String()
+	}
+	// every sheet fits the entry limit, together they exceed the document limit
+	sheets := map[string]string{"xl/sharedStrings.xml": `<sst ` + sheetNS + `/>`}
+	var sheetRefs, sheetRels strings.Builder
+	for i := 1; i <= 4; i++ {
+		fmt.Fprintf(&sheetRefs, `<sheet name="s%d" sheetId="%d" r:id="rId%d"/>`, i, i, i)
+		fmt.Fprintf(&sheetRels, `<Relationship Id="rId%d" Type="worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+		sheets[fmt.Sprintf("xl/worksheets/sheet%d.xml", i)] = `<worksheet ` + sheetNS + `><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>` + filler + `</t></is></c></row></sheetData></worksheet>`
+	}
+	sheets["xl/workbook.xml"] = `<workbook ` + sheetNS + `><sheets>` + sheetRefs.String() + `</sheets></workbook>`
+	sheets["xl/_rels/workbook.xml.rels"] = `<Relationships ` + relsNS + `>` + sheetRels.String() + `</Relationships>`
+
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+	}{
+		{name: "zip entry over the entry limit", filename: "big.docx", data: zipFixture(t, map[string]string{"word/document.xml": bigParagraphs(2)})},
+		{name: "zip entries over the document limit", filename: "big.xlsx", data: zipFixture(t, sheets)},
+		{name: "flate stream over the entry limit", filename: "big.pdf", data: pdfFixture(t, strings.Repeat("BT /F1 12 Tf 72 720 Td (bomb) Tj ET\n", 200), true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Convert(tt.filename, tt.data)
+			assert.ErrorIs(t, err, ErrTooLarge)
+		})
+	}
+}
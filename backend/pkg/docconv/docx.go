@@ -0,0 +1,297 @@
+package docconv
+
+import (
+	"strconv"
+	"strings"
+)
+
+type docxConverter struct {
+	pkg      *ooxmlPackage
+	doc      *Document
+	rels     map[string]relationship
+	headings map[string]int    // style id -> heading level
+	numFmts  map[string]string // num id + level -> number format
+	images   map[string]string
+}
+
+func convertDocx(data []byte) (*Document, error) {
+	pkg, err := openPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	root, err := pkg.readXML("word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	c := &docxConverter{
+		pkg:      pkg,
+		doc:      &Document{Title: pkg.title()},
+		rels:     pkg.rels("word/document.xml"),
+		headings: docxHeadingStyles(pkg),
+		numFmts:  docxNumberFormats(pkg),
+		images:   make(map[string]string),
+	}
+	body := root.child("body")
+	if body == nil {
+		return nil, ErrUnsupported
+	}
+	var blocks []string
+	c.blocks(body, &blocks)
+	if pkg.exceeded() {
+		return nil, ErrTooLarge
+	}
+	c.doc.Markdown = joinBlocks(blocks)
+	return c.doc, nil
+}
+
+// joinBlocks separates blocks by blank lines, consecutive list items stay together
+func joinBlocks(blocks []string) string {
+	var sb strings.Builder
+	prevList := false
+	for i, block := range blocks {
+		isList := isListBlock(block)
+		if i > 0 {
+			if isList && prevList {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(block)
+		prevList = isList
+	}
+	return sb.String()
+}
+
+func isListBlock(block string) bool {
+	trimmed := strings.TrimLeft(block, " ")
+	return strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "1. ")
+}
+
+func (c *docxConverter) blocks(parent *xmlNode, blocks *[]string) {
+	for _, n := range parent.Nodes {
+		switch n.name() {
+		case "p":
+			if block := c.paragraph(n); block != "" {
+				*blocks = append(*blocks, block)
+			}
+		case "tbl":
+			if table := c.table(n); table != "" {
+				*blocks = append(*blocks, strings.TrimSuffix(table, "\n"))
+			}
+		case "sdt":
+			if content := n.child("sdtContent"); content != nil {
+				c.blocks(content, blocks)
+			}
+		case "customXml", "ins", "smartTag":
+			c.blocks(n, blocks)
+		}
+	}
+}
+
+func (c *docxConverter) paragraph(p *xmlNode) string {
+	text := strings.TrimSpace(c.inline(p))
+	if text == "" {
+		return ""
+	}
+	pPr := p.child("pPr")
+	if pPr == nil {
+		return text
+	}
+	level := 0
+	if style := pPr.child("pStyle"); style != nil {
+		level = c.headings[style.attr("val")]
+	}
+	if outline := pPr.child("outlineLvl"); outline != nil && level == 0 {
+		if lvl, err := strconv.Atoi(outline.attr("val")); err == nil && lvl < 6 {
+			level = lvl + 1
+		}
+	}
+	if level > 0 {
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "<br>", " ")
+	}
+	if numPr := pPr.child("numPr"); numPr != nil {
+		numID, ilvl := "", "0"
+		if n := numPr.child("numId"); n != nil {
+			numID = n.attr("val")
+		}
+		if n := numPr.child("ilvl"); n != nil {
+			ilvl = n.attr("val")
+		}
+		// num id 0 removes the numbering
+		if numID != "" && numID != "0" {
+			lvl, _ := strconv.Atoi(ilvl)
+			format := c.numFmts[numID+":"+ilvl]
+			return listMarker(lvl, format != "" && format != "bullet" && format != "none") + text
+		}
+	}
+	return text
+}
+
+// inline renders the runs, links and images of the paragraph
+func (c *docxConverter) inline(parent *xmlNode) string {
+	var spans []inlineSpan
+	c.collectSpans(parent, &spans)
+	return renderSpans(spans)
+}
+
+func (c *docxConverter) collectSpans(parent *xmlNode, spans *[]inlineSpan) {
+	for _, n := range parent.Nodes {
+		switch n.name() {
+		case "r":
+			c.run(n, spans)
+		case "hyperlink":
+			text := strings.TrimSpace(c.inline(n))
+			if text == "" {
+				continue
+			}
+			target := ""
+			if rel, ok := c.rels[n.attr("id")]; ok && rel.External {
+				target = rel.Target
+			}
+			if target == "" {
+				*spans = append(*spans, inlineSpan{text: text})
+				continue
+			}
+			*spans = append(*spans, inlineSpan{text: "[" + text + "](" + target + ")"})
+		case "ins", "smartTag", "fldSimple", "customXml", "sdt", "sdtContent":
+			c.collectSpans(n, spans)
+		}
+	}
+}
+
+func (c *docxConverter) run(r *xmlNode, spans *[]inlineSpan) {
+	bold, italic := false, false
+	if rPr := r.child("rPr"); rPr != nil {
+		bold = docxToggle(rPr.child("b"))
+		italic = docxToggle(rPr.child("i"))
+	}
+	var sb strings.Builder
+	for _, n := range r.Nodes {
+		switch n.name() {
+		case "t":
+			sb.WriteString(n.Text)
+		case "tab":
+			sb.WriteString(" ")
+		case "br", "cr":
+			if n.attr("type") == "" || n.attr("type") == "textWrapping" {
+				sb.WriteString("<br>")
+			}
+		case "noBreakHyphen":
+			sb.WriteString("-")
+		case "drawing", "pict", "object":
+			if blip := n.find("blip"); blip != nil {
+				sb.WriteString(c.pkg.image(c.doc, c.rels, blip.attr("embed"), c.images))
+			} else if imageData := n.find("imagedata"); imageData != nil {
+				sb.WriteString(c.pkg.image(c.doc, c.rels, imageData.attr("id"), c.images))
+			}
+		}
+	}
+	*spans = append(*spans, inlineSpan{text: sb.String(), bold: bold, italic: italic})
+}
+
+// docxToggle reads on/off properties like <w:b/> and <w:b w:val="0"/>
+func docxToggle(n *xmlNode) bool {
+	if n == nil {
+		return false
+	}
+	switch n.attr("val") {
+	case "0", "false", "off", "none":
+		return false
+	}
+	return true
+}
+
+func (c *docxConverter) table(tbl *xmlNode) string {
+	var rows [][]string
+	for _, tr := range tbl.children("tr") {
+		var row []string
+		for _, tc := range tr.children("tc") {
+			var parts []string
+			var cellBlocks []string
+			c.blocks(tc, &cellBlocks)
+			for _, block := range cellBlocks {
+				// nested tables are flattened into the cell
+				parts = append(parts, strings.ReplaceAll(strings.TrimSpace(block), "\n", "<br>"))
+			}
+			row = append(row, strings.Join(parts, "<br>"))
+			if tcPr := tc.child("tcPr"); tcPr != nil {
+				if span := tcPr.child("gridSpan"); span != nil {
+					if n, err := strconv.Atoi(span.attr("val")); err == nil {
+						for i := 1; i < n; i++ {
+							row = append(row, "")
+						}
+					}
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return markdownTable(rows)
+}
+
+// docxHeadingStyles maps the ids of heading styles to their level
+func docxHeadingStyles(pkg *ooxmlPackage) map[string]int {
+	headings := make(map[string]int)
+	root, err := pkg.readXML("word/styles.xml")
+	if err != nil {
+		return headings
+	}
+	for _, style := range root.children("style") {
+		if style.attr("type") != "paragraph" {
+			continue
+		}
+		id := style.attr("styleId")
+		name := ""
+		if n := style.child("name"); n != nil {
+			name = strings.ToLower(n.attr("val"))
+		}
+		switch {
+		case name == "title":
+			headings[id] = 1
+		case strings.HasPrefix(name, "heading "):
+			if lvl, err := strconv.Atoi(strings.TrimPrefix(name, "heading ")); err == nil && lvl >= 1 {
+				headings[id] = min(lvl, 6)
+			}
+		default:
+			if outline := style.path("pPr", "outlineLvl"); outline != nil {
+				if lvl, err := strconv.Atoi(outline.attr("val")); err == nil && lvl < 6 {
+					headings[id] = lvl + 1
+				}
+			}
+		}
+	}
+	return headings
+}
+
+// docxNumberFormats maps num id and level to the number format of the list
+func docxNumberFormats(pkg *ooxmlPackage) map[string]string {
+	formats := make(map[string]string)
+	root, err := pkg.readXML("word/numbering.xml")
+	if err != nil {
+		return formats
+	}
+	abstract := make(map[string]map[string]string)
+	for _, an := range root.children("abstractNum") {
+		levels := make(map[string]string)
+		for _, lvl := range an.children("lvl") {
+			if numFmt := lvl.child("numFmt"); numFmt != nil {
+				levels[lvl.attr("ilvl")] = numFmt.attr("val")
+			}
+		}
+		abstract[an.attr("abstractNumId")] = levels
+	}
+	for _, num := range root.children("num") {
+		ref := num.child("abstractNumId")
+		if ref == nil {
+			continue
+		}
+		for ilvl, format := range abstract[ref.attr("val")] {
+			formats[num.attr("numId")+":"+ilvl] = format
+		}
+	}
+	return formats
+}
@@ -0,0 +1,724 @@
+package docconv
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+func (a pdfMatrix) mul(b pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		a[0]*b[0] + a[1]*b[2],
+		a[0]*b[1] + a[1]*b[3],
+		a[2]*b[0] + a[3]*b[2],
+		a[2]*b[1] + a[3]*b[3],
+		a[4]*b[0] + a[5]*b[2] + b[4],
+		a[4]*b[1] + a[5]*b[3] + b[5],
+	}
+}
+
+func pdfTranslate(tx, ty float64) pdfMatrix {
+	return pdfMatrix{1, 0, 0, 1, tx, ty}
+}
+
+// pdfSpan is the text of one show operator or an image, in device space
+type pdfSpan struct {
+	text  string
+	x, y  float64
+	endX  float64
+	size  float64
+	image bool
+}
+
+type pdfLine struct {
+	text  string
+	y     float64
+	size  float64
+	image bool
+}
+
+type pdfState struct {
+	ctm      pdfMatrix
+	font     *pdfFont
+	fontSize float64
+	tc, tw   float64 // character and word spacing
+	tz       float64 // horizontal scaling
+	tl       float64 // leading
+	ts       float64 // rise
+}
+
+type pdfConverter struct {
+	pdf    *pdfDoc
+	doc    *Document
+	fonts  map[pdfRef]*pdfFont
+	images map[pdfRef]string
+	spans  []pdfSpan
+}
+
+const pdfMaxFormDepth = 8
+
+var pdfPageNumberRegex = regexp.MustCompile(`(?i)^(page\s*)?\d+(\s*(/|of)\s*\d+)?$|^第\s*\d+\s*页$|^-\s*\d+\s*-$`)
+
+func convertPDF(data []byte) (*Document, error) {
+	pdf, err := loadPDF(data)
+	if err != nil {
+		return nil, err
+	}
+	c := &pdfConverter{
+		pdf:    pdf,
+		doc:    &Document{Title: pdf.title()},
+		fonts:  make(map[pdfRef]*pdfFont),
+		images: make(map[pdfRef]string),
+	}
+	pages := pdf.pages()
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: no pages found in pdf", ErrUnsupported)
+	}
+
+	pageLines := make([][]pdfLine, 0, len(pages))
+	hasText := false
+	for _, page := range pages {
+		c.spans = c.spans[:0]
+		content := pdf.pageContent(page.dict)
+		c.run(content, page.resources, pdfIdentity, 0)
+		lines := groupPDFLines(c.spans)
+		lines = dropPDFPageNumbers(lines)
+		for _, line := range lines {
+			if !line.image {
+				hasText = true
+			}
+		}
+		pageLines = append(pageLines, lines)
+	}
+	// streams failing to decode are skipped, a document with a stream over the limits is rejected as a whole
+	if pdf.budget < 0 {
+		return nil, ErrTooLarge
+	}
+	// scanned files need ocr, which the document service provides
+	if !hasText {
+		return nil, fmt.Errorf("%w: pdf has no text layer", ErrUnsupported)
+	}
+
+	bodySize := pdfBodySize(pageLines)
+	var blocks []string
+	for _, lines := range pageLines {
+		blocks = append(blocks, pdfBlocks(lines, bodySize)...)
+	}
+	c.doc.Markdown = joinBlocks(blocks)
+	return c.doc, nil
+}
+
+func (d *pdfDoc) catalog() pdfDict {
+	for i := len(d.trailers) - 1; i >= 0; i-- {
+		if root := d.dict(d.trailers[i]["Root"]); root != nil && root["Pages"] != nil {
+			return root
+		}
+	}
+	for _, obj := range d.objects {
+		if dict, ok := obj.(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			return dict
+		}
+	}
+	return nil
+}
+
+func (d *pdfDoc) title() string {
+	for i := len(d.trailers) - 1; i >= 0; i-- {
+		if info := d.dict(d.trailers[i]["Info"]); info != nil {
+			if title, ok := d.resolve(info["Title"]).(pdfString); ok {
+				return strings.TrimSpace(pdfTextString(title))
+			}
+		}
+	}
+	return ""
+}
+
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages walks the page tree, resources are inherited from the parent nodes
+func (d *pdfDoc) pages() []pdfPage {
+	catalog := d.catalog()
+	if catalog == nil {
+		return nil
+	}
+	var pages []pdfPage
+	visited := make(map[pdfRef]bool)
+	var walk func(node any, resources pdfDict)
+	walk = func(node any, resources pdfDict) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict := d.dict(node)
+		if dict == nil {
+			return
+		}
+		if res := d.dict(dict["Resources"]); res != nil {
+			resources = res
+		}
+		kids := d.array(dict["Kids"])
+		if kids == nil || dict["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: dict, resources: resources})
+			return
+		}
+		for _, kid := range kids {
+			walk(kid, resources)
+		}
+	}
+	walk(catalog["Pages"], nil)
+	return pages
+}
+
+func (d *pdfDoc) pageContent(page pdfDict) []byte {
+	var streams []any
+	switch contents := d.resolve(page["Contents"]).(type) {
+	case *pdfStream:
+		streams = append(streams, contents)
+	case pdfArray:
+		streams = contents
+	}
+	var buf bytes.Buffer
+	for _, item := range streams {
+		stream, ok := d.resolve(item).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := d.decode(stream)
+		if err != nil {
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func (c *pdfConverter) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts := c.pdf.dict(resources["Font"])
+	if fonts == nil {
+		return c.pdf.loadFont(nil)
+	}
+	obj := fonts[name]
+	ref, isRef := obj.(pdfRef)
+	if isRef {
+		if f, ok := c.fonts[ref]; ok {
+			return f
+		}
+	}
+	f := c.pdf.loadFont(obj)
+	if isRef {
+		c.fonts[ref] = f
+	}
+	return f
+}
+
+// run interprets the content stream, collecting the shown text and images
+func (c *pdfConverter) run(content []byte, resources pdfDict, ctm pdfMatrix, depth int) {
+	state := pdfState{ctm: ctm, tz: 1}
+	var stack []pdfState
+	var tm, tlm pdfMatrix
+	var operands []any
+	l := &pdfLexer{data: content}
+
+	nextLine := func(tx, ty float64) {
+		tlm = pdfTranslate(tx, ty).mul(tlm)
+		tm = tlm
+	}
+	show := func(s pdfString) {
+		if state.font == nil {
+			state.font = c.pdf.loadFont(nil)
+		}
+		start := pdfMatrix{state.fontSize * state.tz, 0, 0, state.fontSize, 0, state.ts}.mul(tm).mul(state.ctm)
+		var sb strings.Builder
+		for _, g := range state.font.decode(s) {
+			sb.WriteString(g.text)
+			tx := g.width*state.fontSize + state.tc
+			if g.space {
+				tx += state.tw
+			}
+			tm = pdfTranslate(tx*state.tz, 0).mul(tm)
+		}
+		end := pdfMatrix{1, 0, 0, 1, 0, state.ts}.mul(tm).mul(state.ctm)
+		c.addText(sb.String(), start, end[4])
+	}
+
+	for {
+		obj, err := l.object()
+		if err != nil {
+			return
+		}
+		op, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+		nums := pdfNumbers(operands)
+		switch op {
+		case "q":
+			stack = append(stack, state)
+		case "Q":
+			if n := len(stack); n > 0 {
+				state = stack[n-1]
+				stack = stack[:n-1]
+			}
+		case "cm":
+			if len(nums) == 6 {
+				state.ctm = pdfMatrix(nums).mul(state.ctm)
+			}
+		case "BT":
+			tm, tlm = pdfIdentity, pdfIdentity
+		case "Tf":
+			if len(operands) == 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					state.font = c.font(resources, name)
+				}
+				if size, ok := operands[1].(float64); ok {
+					state.fontSize = size
+				}
+			}
+		case "Tc":
+			if len(nums) == 1 {
+				state.tc = nums[0]
+			}
+		case "Tw":
+			if len(nums) == 1 {
+				state.tw = nums[0]
+			}
+		case "Tz":
+			if len(nums) == 1 {
+				state.tz = nums[0] / 100
+			}
+		case "TL":
+			if len(nums) == 1 {
+				state.tl = nums[0]
+			}
+		case "Ts":
+			if len(nums) == 1 {
+				state.ts = nums[0]
+			}
+		case "Td":
+			if len(nums) == 2 {
+				nextLine(nums[0], nums[1])
+			}
+		case "TD":
+			if len(nums) == 2 {
+				state.tl = -nums[1]
+				nextLine(nums[0], nums[1])
+			}
+		case "Tm":
+			if len(nums) == 6 {
+				tlm = pdfMatrix(nums)
+				tm = tlm
+			}
+		case "T*":
+			nextLine(0, -state.tl)
+		case "Tj":
+			if len(operands) == 1 {
+				if s, ok := operands[0].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "'":
+			nextLine(0, -state.tl)
+			if len(operands) == 1 {
+				if s, ok := operands[0].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "\"":
+			nextLine(0, -state.tl)
+			if len(operands) == 3 {
+				state.tw, _ = operands[0].(float64)
+				state.tc, _ = operands[1].(float64)
+				if s, ok := operands[2].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "TJ":
+			if len(operands) != 1 {
+				break
+			}
+			arr, _ := operands[0].(pdfArray)
+			for _, item := range arr {
+				switch v := item.(type) {
+				case pdfString:
+					show(v)
+				case float64:
+					tx := -v / 1000 * state.fontSize * state.tz
+					tm = pdfTranslate(tx, 0).mul(tm)
+					// kerning wider than a quarter em separates words
+					if n := len(c.spans); -v > 250 && n > 0 && !c.spans[n-1].image {
+						c.spans[n-1].text += " "
+					}
+				}
+			}
+		case "Do":
+			if len(operands) == 1 {
+				if name, ok := operands[0].(pdfName); ok {
+					c.xobject(resources, name, state.ctm, depth)
+				}
+			}
+		case "BI":
+			c.skipInlineImage(l)
+		}
+		operands = operands[:0]
+	}
+}
+
+func pdfNumbers(operands []any) []float64 {
+	nums := make([]float64, 0, len(operands))
+	for _, op := range operands {
+		if f, ok := op.(float64); ok {
+			nums = append(nums, f)
+		}
+	}
+	if len(nums) != len(operands) {
+		return nil
+	}
+	return nums
+}
+
+// skipInlineImage moves the lexer past the binary data of an inline image
+func (c *pdfConverter) skipInlineImage(l *pdfLexer) {
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return
+		}
+		if tok == pdfKeyword("ID") {
+			break
+		}
+	}
+	for i := l.pos; i+2 < len(l.data); i++ {
+		if l.data[i] == 'E' && l.data[i+1] == 'I' && isPDFSpace(l.data[i-1]) &&
+			(i+2 == len(l.data) || isPDFSpace(l.data[i+2])) {
+			l.pos = i + 2
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+func (c *pdfConverter) addText(text string, m pdfMatrix, endX float64) {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || r == '\t':
+			return ' '
+		case unicode.IsControl(r) || r == utf8.RuneError:
+			return -1
+		}
+		return r
+	}, text)
+	if text == "" {
+		return
+	}
+	size := math.Hypot(m[2], m[3])
+	c.spans = append(c.spans, pdfSpan{text: text, x: m[4], y: m[5], endX: endX, size: size})
+}
+
+func (c *pdfConverter) xobject(resources pdfDict, name pdfName, ctm pdfMatrix, depth int) {
+	xobjects := c.pdf.dict(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+	obj := xobjects[name]
+	stream, ok := c.pdf.resolve(obj).(*pdfStream)
+	if !ok {
+		return
+	}
+	switch c.pdf.resolve(stream.dict["Subtype"]) {
+	case pdfName("Form"):
+		if depth >= pdfMaxFormDepth {
+			return
+		}
+		data, err := c.pdf.decode(stream)
+		if err != nil {
+			return
+		}
+		formRes := c.pdf.dict(stream.dict["Resources"])
+		if formRes == nil {
+			formRes = resources
+		}
+		matrix := pdfIdentity
+		if nums := pdfNumbers(c.pdf.array(stream.dict["Matrix"])); len(nums) == 6 {
+			matrix = pdfMatrix(nums)
+		}
+		c.run(data, formRes, matrix.mul(ctm), depth+1)
+	case pdfName("Image"):
+		ref, isRef := obj.(pdfRef)
+		md, seen := c.images[ref]
+		if !isRef || !seen {
+			md = c.image(stream, string(name))
+			if isRef {
+				c.images[ref] = md
+			}
+		}
+		if md == "" {
+			return
+		}
+		// images are placed by their top edge
+		c.spans = append(c.spans, pdfSpan{text: md, x: ctm[4], y: ctm[5] + ctm[3], endX: ctm[4] + ctm[0], image: true})
+	}
+}
+
+// image returns the markdown of the image, jpeg data is kept and 8 bit gray and rgb samples are encoded as png
+func (c *pdfConverter) image(stream *pdfStream, name string) string {
+	width, _ := c.pdf.number(stream.dict["Width"])
+	height, _ := c.pdf.number(stream.dict["Height"])
+	// tiny images are lines and decorations
+	if width < 32 || height < 32 {
+		return ""
+	}
+	filters := c.pdf.filters(stream)
+	for _, f := range filters {
+		if f == "JPXDecode" {
+			return ""
+		}
+	}
+	data, err := c.pdf.decode(stream)
+	if err != nil {
+		return ""
+	}
+	if n := len(filters); n > 0 && (filters[n-1] == "DCTDecode" || filters[n-1] == "DCT") {
+		return c.doc.addImage(name+".jpg", data)
+	}
+	if bpc, _ := c.pdf.number(stream.dict["BitsPerComponent"]); bpc != 8 {
+		return ""
+	}
+	components := c.pdf.colorComponents(stream.dict["ColorSpace"])
+	w, h := int(width), int(height)
+	if components == 0 || len(data) < w*h*components {
+		return ""
+	}
+	var img image.Image
+	if components == 1 {
+		gray := image.NewGray(image.Rect(0, 0, w, h))
+		copy(gray.Pix, data)
+		img = gray
+	} else {
+		rgba := image.NewNRGBA(image.Rect(0, 0, w, h))
+		for i := 0; i < w*h; i++ {
+			rgba.SetNRGBA(i%w, i/w, color.NRGBA{R: data[i*3], G: data[i*3+1], B: data[i*3+2], A: 255})
+		}
+		img = rgba
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return ""
+	}
+	return c.doc.addImage(name+".png", buf.Bytes())
+}
+
+// colorComponents returns 1 for gray and 3 for rgb color spaces, 0 for the others
+func (d *pdfDoc) colorComponents(obj any) int {
+	switch cs := d.resolve(obj).(type) {
+	case pdfName:
+		switch cs {
+		case "DeviceGray", "CalGray", "G":
+			return 1
+		case "DeviceRGB", "CalRGB", "RGB":
+			return 3
+		}
+	case pdfArray:
+		if len(cs) < 2 {
+			return 0
+		}
+		switch d.resolve(cs[0]) {
+		case pdfName("ICCBased"):
+			if n, _ := d.number(d.dict(cs[1])["N"]); n == 1 || n == 3 {
+				return int(n)
+			}
+		case pdfName("CalGray"):
+			return 1
+		case pdfName("CalRGB"):
+			return 3
+		}
+	}
+	return 0
+}
+
+// groupPDFLines joins the spans on the same baseline, the content order is kept as reading order
+func groupPDFLines(spans []pdfSpan) []pdfLine {
+	var lines []pdfLine
+	var cur *pdfLine
+	var endX float64
+	for _, span := range spans {
+		if span.image {
+			lines = append(lines, pdfLine{text: span.text, y: span.y, image: true})
+			cur = nil
+			continue
+		}
+		tolerance := max(span.size, 1) * 0.5
+		if cur != nil && math.Abs(span.y-cur.y) <= tolerance {
+			if span.x > endX+span.size*0.15 && !strings.HasSuffix(cur.text, " ") && !strings.HasPrefix(span.text, " ") {
+				cur.text += " "
+			}
+			cur.text += span.text
+			cur.size = max(cur.size, span.size)
+			endX = span.endX
+			continue
+		}
+		if strings.TrimSpace(span.text) == "" {
+			continue
+		}
+		lines = append(lines, pdfLine{text: span.text, y: span.y, size: span.size})
+		cur = &lines[len(lines)-1]
+		endX = span.endX
+	}
+	result := lines[:0]
+	for _, line := range lines {
+		line.text = strings.Join(strings.Fields(line.text), " ")
+		if line.text != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// dropPDFPageNumbers removes page numbers at the top and the bottom of the page
+func dropPDFPageNumbers(lines []pdfLine) []pdfLine {
+	if n := len(lines); n > 0 && !lines[n-1].image && pdfPageNumberRegex.MatchString(lines[n-1].text) {
+		lines = lines[:n-1]
+	}
+	if len(lines) > 0 && !lines[0].image && pdfPageNumberRegex.MatchString(lines[0].text) {
+		lines = lines[1:]
+	}
+	return lines
+}
+
+// pdfBodySize returns the font size used by most of the text
+func pdfBodySize(pages [][]pdfLine) float64 {
+	counts := make(map[float64]int)
+	for _, lines := range pages {
+		for _, line := range lines {
+			if !line.image {
+				counts[math.Round(line.size*2)/2] += utf8.RuneCountInString(line.text)
+			}
+		}
+	}
+	sizes := make([]float64, 0, len(counts))
+	for size := range counts {
+		sizes = append(sizes, size)
+	}
+	sort.Float64s(sizes)
+	body, best := 0.0, -1
+	for _, size := range sizes {
+		if counts[size] > best {
+			body, best = size, counts[size]
+		}
+	}
+	return body
+}
+
+func pdfHeadingLevel(size, bodySize float64) int {
+	if bodySize <= 0 {
+		return 0
+	}
+	switch ratio := size / bodySize; {
+	case ratio >= 1.6:
+		return 1
+	case ratio >= 1.3:
+		return 2
+	case ratio >= 1.12:
+		return 3
+	}
+	return 0
+}
+
+var pdfBulletPrefixes = []string{"•", "●", "▪", "■", "◦", "○", "‣", "-", "*", "·"}
+
+func pdfBullet(text string) (string, bool) {
+	for _, prefix := range pdfBulletPrefixes {
+		if rest, ok := strings.CutPrefix(text, prefix); ok && (rest == "" || rest[0] == ' ') {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return text, false
+}
+
+// pdfBlocks merges the lines into paragraphs, headings and list items
+func pdfBlocks(lines []pdfLine, bodySize float64) []string {
+	var blocks []string
+	var para *pdfLine
+	bullet := false
+	flush := func() {
+		if para == nil {
+			return
+		}
+		text := para.text
+		level := pdfHeadingLevel(para.size, bodySize)
+		switch {
+		case bullet:
+			text = listMarker(0, false) + text
+		case level > 0 && utf8.RuneCountInString(text) <= 120:
+			text = strings.Repeat("#", level) + " " + text
+		case strings.HasPrefix(text, "#"):
+			text = `\` + text
+		}
+		blocks = append(blocks, text)
+		para = nil
+	}
+	for _, line := range lines {
+		if line.image {
+			flush()
+			blocks = append(blocks, line.text)
+			continue
+		}
+		text, isBullet := pdfBullet(line.text)
+		if isBullet {
+			flush()
+			if text == "" {
+				continue
+			}
+			line.text = text
+			para, bullet = &line, true
+			continue
+		}
+		if para != nil {
+			gap := para.y - line.y
+			lineHeight := max(para.size, line.size)
+			if gap > 0 && gap <= lineHeight*1.6 && math.Abs(para.size-line.size) <= 0.5 {
+				para.text = joinPDFLines(para.text, line.text)
+				para.y = line.y
+				continue
+			}
+		}
+		flush()
+		para, bullet = &line, false
+	}
+	flush()
+	return blocks
+}
+
+// joinPDFLines joins wrapped lines, cjk text is joined without space and hyphenated words are merged
+func joinPDFLines(a, b string) string {
+	last, _ := utf8.DecodeLastRuneInString(a)
+	first, _ := utf8.DecodeRuneInString(b)
+	if unicode.Is(unicode.Han, last) || unicode.Is(unicode.Han, first) {
+		return a + b
+	}
+	if strings.HasSuffix(a, "-") && len(a) > 1 && unicode.IsLower(first) {
+		prev, _ := utf8.DecodeLastRuneInString(a[:len(a)-1])
+		if unicode.IsLetter(prev) {
+			return a[:len(a)-1] + b
+		}
+	}
+	return a + " " + b
+}
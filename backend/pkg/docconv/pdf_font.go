@@ -0,0 +1,390 @@
+package docconv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+type pdfFont struct {
+	composite    bool
+	codeLens     []int // byte lengths of the codes of composite fonts
+	toUnicode    map[string]string
+	encoding     [256]rune // simple fonts without ToUnicode mapping
+	widths       map[int]float64
+	defaultWidth float64
+}
+
+type pdfGlyph struct {
+	text  string
+	width float64 // in text space units per font size
+	space bool    // single byte code 32, word spacing applies
+}
+
+func (d *pdfDoc) loadFont(obj any) *pdfFont {
+	dict := d.dict(obj)
+	f := &pdfFont{encoding: winAnsiEncoding, widths: make(map[int]float64), defaultWidth: 0.5}
+	if dict == nil {
+		return f
+	}
+	if stream, ok := d.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := d.decode(stream); err == nil {
+			f.toUnicode, f.codeLens = parseToUnicode(data)
+		}
+	}
+
+	if subtype, _ := d.resolve(dict["Subtype"]).(pdfName); subtype == "Type0" {
+		f.composite = true
+		f.defaultWidth = 1
+		if len(f.codeLens) == 0 {
+			f.codeLens = []int{2}
+		}
+		descendants := d.array(dict["DescendantFonts"])
+		if len(descendants) > 0 {
+			cid := d.dict(descendants[0])
+			if dw, ok := d.number(cid["DW"]); ok {
+				f.defaultWidth = dw / 1000
+			}
+			f.loadCIDWidths(d, d.array(cid["W"]))
+		}
+		return f
+	}
+
+	switch enc := d.resolve(dict["Encoding"]).(type) {
+	case pdfName:
+		if enc == "MacRomanEncoding" {
+			f.encoding = macRomanEncoding()
+		}
+	case pdfDict:
+		if base, _ := d.resolve(enc["BaseEncoding"]).(pdfName); base == "MacRomanEncoding" {
+			f.encoding = macRomanEncoding()
+		}
+		code := 0
+		for _, item := range d.array(enc["Differences"]) {
+			switch v := d.resolve(item).(type) {
+			case float64:
+				code = int(v)
+			case pdfName:
+				if code >= 0 && code < 256 {
+					f.encoding[code] = glyphNameToRune(string(v))
+				}
+				code++
+			}
+		}
+	}
+	first, _ := d.number(dict["FirstChar"])
+	for i, w := range d.array(dict["Widths"]) {
+		if width, ok := d.number(w); ok {
+			f.widths[int(first)+i] = width / 1000
+		}
+	}
+	if desc := d.dict(dict["FontDescriptor"]); desc != nil {
+		if missing, ok := d.number(desc["MissingWidth"]); ok && missing > 0 {
+			f.defaultWidth = missing / 1000
+		}
+	}
+	return f
+}
+
+// loadCIDWidths reads the W array: c [w1 w2 ...] or cfirst clast w
+func (f *pdfFont) loadCIDWidths(d *pdfDoc, w pdfArray) {
+	for i := 0; i < len(w); {
+		first, ok := d.number(w[i])
+		if !ok || i+1 >= len(w) {
+			return
+		}
+		if arr := d.array(w[i+1]); arr != nil {
+			for j, item := range arr {
+				if width, ok := d.number(item); ok {
+					f.widths[int(first)+j] = width / 1000
+				}
+			}
+			i += 2
+			continue
+		}
+		last, ok1 := d.number(w[i+1])
+		if i+2 >= len(w) || !ok1 {
+			return
+		}
+		width, ok2 := d.number(w[i+2])
+		if ok2 && last-first < 65536 {
+			for c := int(first); c <= int(last); c++ {
+				f.widths[c] = width / 1000
+			}
+		}
+		i += 3
+	}
+}
+
+func (f *pdfFont) decode(s []byte) []pdfGlyph {
+	glyphs := make([]pdfGlyph, 0, len(s))
+	for i := 0; i < len(s); {
+		n := 1
+		if f.composite {
+			n = f.codeLen(s[i:])
+		}
+		code := s[i:min(i+n, len(s))]
+		i += n
+		codeInt := 0
+		for _, b := range code {
+			codeInt = codeInt<<8 | int(b)
+		}
+		g := pdfGlyph{width: f.defaultWidth, space: !f.composite && codeInt == 32}
+		if w, ok := f.widths[codeInt]; ok {
+			g.width = w
+		}
+		if text, ok := f.toUnicode[string(code)]; ok {
+			g.text = text
+		} else if !f.composite {
+			if r := f.encoding[codeInt]; r != 0 {
+				g.text = string(r)
+			}
+		}
+		glyphs = append(glyphs, g)
+	}
+	return glyphs
+}
+
+// codeLen picks the code length with a unicode mapping, the longest one otherwise
+func (f *pdfFont) codeLen(s []byte) int {
+	if len(f.codeLens) == 1 {
+		return f.codeLens[0]
+	}
+	for _, n := range f.codeLens {
+		if n <= len(s) {
+			if _, ok := f.toUnicode[string(s[:n])]; ok {
+				return n
+			}
+		}
+	}
+	return f.codeLens[len(f.codeLens)-1]
+}
+
+// parseToUnicode reads the bfchar and bfrange mappings and the code lengths of a ToUnicode cmap
+func parseToUnicode(data []byte) (map[string]string, []int) {
+	mapping := make(map[string]string)
+	lens := make(map[int]bool)
+	l := &pdfLexer{data: data}
+	var operands []any
+	for {
+		tok, err := l.object()
+		if err != nil {
+			break
+		}
+		kw, ok := tok.(pdfKeyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if lo, ok := operands[i].(pdfString); ok && len(lo) > 0 {
+					lens[len(lo)] = true
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok := operands[i].(pdfString)
+				if !ok {
+					continue
+				}
+				switch dst := operands[i+1].(type) {
+				case pdfString:
+					mapping[string(src)] = utf16BE(dst)
+				case pdfName:
+					if r := glyphNameToRune(string(dst)); r != 0 {
+						mapping[string(src)] = string(r)
+					}
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(pdfString)
+				hi, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 || len(lo) > 4 {
+					continue
+				}
+				start, end := bytesToInt(lo), bytesToInt(hi)
+				if end < start || end-start > 65535 {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case pdfString:
+					units := utf16Units(dst)
+					if len(units) == 0 {
+						continue
+					}
+					for c := start; c <= end; c++ {
+						u := append([]uint16(nil), units...)
+						u[len(u)-1] += uint16(c - start)
+						mapping[string(intToBytes(c, len(lo)))] = string(utf16.Decode(u))
+					}
+				case pdfArray:
+					for j, item := range dst {
+						if s, ok := item.(pdfString); ok && start+j <= end {
+							mapping[string(intToBytes(start+j, len(lo)))] = utf16BE(s)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	codeLens := make([]int, 0, len(lens))
+	for n := range lens {
+		codeLens = append(codeLens, n)
+	}
+	if len(codeLens) == 0 {
+		for code := range mapping {
+			lens[len(code)] = true
+		}
+		for n := range lens {
+			codeLens = append(codeLens, n)
+		}
+	}
+	sort.Ints(codeLens)
+	return mapping, codeLens
+}
+
+func utf16Units(b []byte) []uint16 {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func utf16BE(b []byte) string {
+	if len(b) == 1 {
+		return string(rune(b[0]))
+	}
+	return string(utf16.Decode(utf16Units(b)))
+}
+
+func bytesToInt(b []byte) int {
+	v := 0
+	for _, c := range b {
+		v = v<<8 | int(c)
+	}
+	return v
+}
+
+func intToBytes(v, n int) []byte {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// pdfTextString decodes text strings of the document info, utf-16 with bom or pdf doc encoding
+func pdfTextString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xfe && b[1] == 0xff {
+		return utf16BE(b[2:])
+	}
+	if len(b) >= 3 && b[0] == 0xef && b[1] == 0xbb && b[2] == 0xbf {
+		return string(b[3:])
+	}
+	runes := make([]rune, 0, len(b))
+	for _, c := range b {
+		runes = append(runes, winAnsiEncoding[c])
+	}
+	return string(runes)
+}
+
+var winAnsiEncoding = func() [256]rune {
+	var enc [256]rune
+	for i := 32; i < 256; i++ {
+		enc[i] = rune(i)
+	}
+	high := []rune{
+		'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+		0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+	}
+	for i, r := range high {
+		enc[0x80+i] = r
+	}
+	return enc
+}()
+
+func macRomanEncoding() [256]rune {
+	enc := winAnsiEncoding
+	high := []rune("ÄÅÇÉÑÖÜáàâäãåçéèêëíìîïñóòôöõúùûü†°¢£§•¶ß®©™´¨≠ÆØ∞±≤≥¥µ∂∑∏π∫ªºΩæø¿¡¬√ƒ≈∆«»… ÀÃÕŒœ–—“”‘’÷◊ÿŸ⁄€‹›ﬁﬂ‡·‚„‰ÂÊÁËÈÍÎÏÌÓÔÒÚÛÙıˆ˜¯˘˙˚¸˝˛ˇ")
+	for i, r := range high {
+		if 0x80+i < 256 {
+			enc[0x80+i] = r
+		}
+	}
+	return enc
+}
+
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$', "percent": '%',
+	"ampersand": '&', "quotesingle": '\'', "quoteright": '’', "parenleft": '(', "parenright": ')',
+	"asterisk": '*', "plus": '+', "comma": ',', "hyphen": '-', "minus": '−', "period": '.', "slash": '/',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4', "five": '5', "six": '6', "seven": '7',
+	"eight": '8', "nine": '9', "colon": ':', "semicolon": ';', "less": '<', "equal": '=', "greater": '>',
+	"question": '?', "at": '@', "bracketleft": '[', "backslash": '\\', "bracketright": ']',
+	"asciicircum": '^', "underscore": '_', "grave": '`', "quoteleft": '‘', "braceleft": '{', "bar": '|',
+	"braceright": '}', "asciitilde": '~', "bullet": '•', "endash": '–', "emdash": '—', "ellipsis": '…',
+	"quotedblleft": '“', "quotedblright": '”', "quotesinglbase": '‚', "quotedblbase": '„',
+	"dagger": '†', "daggerdbl": '‡', "degree": '°', "copyright": '©', "registered": '®', "trademark": '™',
+	"section": '§', "paragraph": '¶', "periodcentered": '·', "multiply": '×', "divide": '÷',
+	"fi": 'ﬁ', "fl": 'ﬂ', "ff": 'ﬀ', "ffi": 'ﬃ', "ffl": 'ﬄ', "nbspace": ' ', "Euro": '€',
+	"sterling": '£', "yen": '¥', "cent": '¢', "currency": '¤', "guillemotleft": '«', "guillemotright": '»',
+	"exclamdown": '¡', "questiondown": '¿', "dotlessi": 'ı', "germandbls": 'ß', "ae": 'æ', "AE": 'Æ',
+	"oslash": 'ø', "Oslash": 'Ø', "oe": 'œ', "OE": 'Œ', "arrowright": '→', "arrowleft": '←',
+	"checkmark": '✓', "circle": '○', "square": '□', "triangle": '△',
+}
+
+// glyphNameToRune maps adobe glyph names to runes, 0 when unknown
+func glyphNameToRune(name string) rune {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	if len(name) == 1 {
+		return rune(name[0])
+	}
+	if r, ok := glyphNames[name]; ok {
+		return r
+	}
+	if strings.HasPrefix(name, "uni") && len(name) >= 7 {
+		if v, err := strconv.ParseUint(name[3:7], 16, 32); err == nil {
+			return rune(v)
+		}
+	}
+	if strings.HasPrefix(name, "u") && len(name) >= 5 && len(name) <= 7 {
+		if v, err := strconv.ParseUint(name[1:], 16, 32); err == nil {
+			return rune(v)
+		}
+	}
+	// accented letters like eacute and Adieresis
+	for _, suffix := range []string{"acute", "grave", "circumflex", "dieresis", "tilde", "ring", "cedilla", "caron"} {
+		if base, ok := strings.CutSuffix(name, suffix); ok && len(base) == 1 {
+			return accented(rune(base[0]), suffix)
+		}
+	}
+	return 0
+}
+
+func accented(base rune, accent string) rune {
+	marks := map[string]rune{
+		"grave": '̀', "acute": '́', "circumflex": '̂', "tilde": '̃',
+		"dieresis": '̈', "ring": '̊', "cedilla": '̧', "caron": '̌',
+	}
+	composed := map[string]rune{
+		"á": 'á', "é": 'é', "í": 'í', "ó": 'ó', "ú": 'ú',
+		"à": 'à', "è": 'è', "ì": 'ì', "ò": 'ò', "ù": 'ù',
+		"â": 'â', "ê": 'ê', "î": 'î', "ô": 'ô', "û": 'û',
+		"ä": 'ä', "ë": 'ë', "ï": 'ï', "ö": 'ö', "ü": 'ü',
+		"Ä": 'Ä', "Ö": 'Ö', "Ü": 'Ü', "É": 'É', "ñ": 'ñ',
+		"Ñ": 'Ñ', "ç": 'ç', "Ç": 'Ç', "å": 'å', "Å": 'Å',
+	}
+	if r, ok := composed[string(base)+string(marks[accent])]; ok {
+		return r
+	}
+	return base
+}
@@ -0,0 +1,561 @@
+package docconv
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfString  []byte
+	pdfArray   []any
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		data []byte // raw data, see pdfDoc.decode
+	}
+)
+
+// lexer delimiters, returned as keywords
+const (
+	pdfArrayStart pdfKeyword = "["
+	pdfArrayEnd   pdfKeyword = "]"
+	pdfDictStart  pdfKeyword = "<<"
+	pdfDictEnd    pdfKeyword = ">>"
+)
+
+var errPDFEOF = errors.New("unexpected end of pdf data")
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isPDFSpace(c) {
+			l.pos++
+			continue
+		}
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+// next returns the next token: float64, pdfName, pdfString, pdfKeyword
+func (l *pdfLexer) next() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errPDFEOF
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.name(), nil
+	case c == '(':
+		return l.literalString(), nil
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return pdfDictStart, nil
+		}
+		return l.hexString(), nil
+	case c == '>':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return pdfDictEnd, nil
+		}
+		l.pos++
+		return l.next()
+	case c == '[':
+		l.pos++
+		return pdfArrayStart, nil
+	case c == ']':
+		l.pos++
+		return pdfArrayEnd, nil
+	case c == '{' || c == '}' || c == ')':
+		// postscript calculator braces and stray parens carry no text
+		l.pos++
+		return l.next()
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+			l.pos++
+		}
+		f, err := strconv.ParseFloat(string(l.data[start:l.pos]), 64)
+		if err != nil {
+			return pdfKeyword(l.data[start:l.pos]), nil
+		}
+		return f, nil
+	}
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return pdfKeyword(l.data[start:l.pos]), nil
+}
+
+func (l *pdfLexer) name() pdfName {
+	l.pos++
+	var buf []byte
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if b, err := hex.DecodeString(string(l.data[l.pos+1 : l.pos+3])); err == nil {
+				buf = append(buf, b[0])
+				l.pos += 3
+				continue
+			}
+		}
+		buf = append(buf, c)
+		l.pos++
+	}
+	return pdfName(buf)
+}
+
+func (l *pdfLexer) literalString() pdfString {
+	l.pos++
+	var buf []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return buf
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return buf
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				buf = append(buf, '\n')
+			case 'r':
+				buf = append(buf, '\r')
+			case 't':
+				buf = append(buf, '\t')
+			case 'b':
+				buf = append(buf, '\b')
+			case 'f':
+				buf = append(buf, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					buf = append(buf, byte(v))
+				} else {
+					buf = append(buf, e)
+				}
+			}
+			continue
+		}
+		buf = append(buf, c)
+	}
+	return buf
+}
+
+func (l *pdfLexer) hexString() pdfString {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		c := l.data[l.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b, _ := hex.DecodeString(string(digits))
+	return b
+}
+
+// object parses the next object, references are kept as pdfRef
+func (l *pdfLexer) object() (any, error) {
+	tok, err := l.next()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case pdfArrayStart:
+		arr := pdfArray{}
+		for {
+			save := l.pos
+			tok, err := l.next()
+			if err != nil {
+				return arr, nil
+			}
+			if tok == pdfArrayEnd {
+				return arr, nil
+			}
+			l.pos = save
+			obj, err := l.object()
+			if err != nil {
+				return arr, nil
+			}
+			arr = append(arr, obj)
+		}
+	case pdfDictStart:
+		dict := pdfDict{}
+		for {
+			tok, err := l.next()
+			if err != nil || tok == pdfDictEnd {
+				return dict, nil
+			}
+			key, ok := tok.(pdfName)
+			if !ok {
+				continue
+			}
+			value, err := l.object()
+			if err != nil {
+				return dict, nil
+			}
+			if kw, ok := value.(pdfKeyword); ok && kw == pdfDictEnd {
+				return dict, nil
+			}
+			dict[key] = value
+		}
+	}
+	if num, ok := tok.(float64); ok && num >= 0 && num == float64(int(num)) {
+		// look ahead for "num gen R"
+		save := l.pos
+		if gen, err := l.next(); err == nil {
+			if g, ok := gen.(float64); ok && g >= 0 && g == float64(int(g)) {
+				if r, err := l.next(); err == nil && r == pdfKeyword("R") {
+					return pdfRef{num: int(num), gen: int(g)}, nil
+				}
+			}
+		}
+		l.pos = save
+	}
+	return tok, nil
+}
+
+type pdfDoc struct {
+	data     []byte
+	objects  map[int]any
+	trailers []pdfDict
+	budget   int64 // decompressed bytes the document may still decode
+}
+
+var (
+	pdfObjRegex     = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfTrailerRegex = regexp.MustCompile(`trailer\s*<<`)
+)
+
+// loadPDF scans the file for objects instead of trusting the xref table, which is often broken,
+// later definitions win like in incremental updates
+func loadPDF(data []byte) (*pdfDoc, error) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF")) {
+		return nil, fmt.Errorf("%w: not a pdf file", ErrUnsupported)
+	}
+	doc := &pdfDoc{data: data, objects: make(map[int]any), budget: maxDocumentSize}
+	for _, m := range pdfObjRegex.FindAllSubmatchIndex(data, -1) {
+		num, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		l := &pdfLexer{data: data, pos: m[1]}
+		obj, err := l.object()
+		if err != nil {
+			continue
+		}
+		if dict, ok := obj.(pdfDict); ok {
+			save := l.pos
+			if tok, err := l.next(); err == nil && tok == pdfKeyword("stream") {
+				obj = &pdfStream{dict: dict, data: doc.streamData(dict, l.pos)}
+			} else {
+				l.pos = save
+			}
+		}
+		doc.objects[num] = obj
+	}
+	for _, m := range pdfTrailerRegex.FindAllIndex(data, -1) {
+		l := &pdfLexer{data: data, pos: m[0] + len("trailer")}
+		if obj, err := l.object(); err == nil {
+			if dict, ok := obj.(pdfDict); ok {
+				doc.trailers = append(doc.trailers, dict)
+			}
+		}
+	}
+	doc.loadObjectStreams()
+	for _, obj := range doc.objects {
+		if stream, ok := obj.(*pdfStream); ok && stream.dict["Type"] == pdfName("XRef") {
+			doc.trailers = append(doc.trailers, stream.dict)
+		}
+	}
+	for _, trailer := range doc.trailers {
+		if _, ok := trailer["Encrypt"]; ok {
+			return nil, fmt.Errorf("%w: encrypted pdf", ErrUnsupported)
+		}
+	}
+	return doc, nil
+}
+
+// streamData returns the raw stream data starting after the stream keyword
+func (d *pdfDoc) streamData(dict pdfDict, pos int) []byte {
+	if pos < len(d.data) && d.data[pos] == '\r' {
+		pos++
+	}
+	if pos < len(d.data) && d.data[pos] == '\n' {
+		pos++
+	}
+	if length, ok := dict["Length"].(float64); ok {
+		end := pos + int(length)
+		if end <= len(d.data) && end >= pos {
+			rest := bytes.TrimLeft(d.data[end:min(len(d.data), end+32)], "\x00\t\n\f\r ")
+			if bytes.HasPrefix(rest, []byte("endstream")) {
+				return d.data[pos:end]
+			}
+		}
+	}
+	// indirect or wrong lengths, search the end instead
+	end := bytes.Index(d.data[pos:], []byte("endstream"))
+	if end < 0 {
+		return d.data[pos:]
+	}
+	return bytes.TrimRight(d.data[pos:pos+end], "\r\n")
+}
+
+// loadObjectStreams adds the objects compressed in object streams which are not defined directly
+func (d *pdfDoc) loadObjectStreams() {
+	var streams []*pdfStream
+	for _, obj := range d.objects {
+		if stream, ok := obj.(*pdfStream); ok && stream.dict["Type"] == pdfName("ObjStm") {
+			streams = append(streams, stream)
+		}
+	}
+	for _, stream := range streams {
+		data, err := d.decode(stream)
+		if err != nil {
+			continue
+		}
+		n, _ := d.resolve(stream.dict["N"]).(float64)
+		first, _ := d.resolve(stream.dict["First"]).(float64)
+		l := &pdfLexer{data: data}
+		type entry struct{ num, offset int }
+		entries := make([]entry, 0, int(n))
+		for i := 0; i < int(n); i++ {
+			num, err1 := l.next()
+			offset, err2 := l.next()
+			if err1 != nil || err2 != nil {
+				break
+			}
+			nf, ok1 := num.(float64)
+			of, ok2 := offset.(float64)
+			if !ok1 || !ok2 {
+				break
+			}
+			entries = append(entries, entry{num: int(nf), offset: int(of)})
+		}
+		for _, e := range entries {
+			if _, ok := d.objects[e.num]; ok {
+				continue
+			}
+			pos := int(first) + e.offset
+			if pos < 0 || pos >= len(data) {
+				continue
+			}
+			ol := &pdfLexer{data: data, pos: pos}
+			if obj, err := ol.object(); err == nil {
+				d.objects[e.num] = obj
+			}
+		}
+	}
+}
+
+// resolve follows references
+func (d *pdfDoc) resolve(obj any) any {
+	for i := 0; i < 16; i++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+		obj = d.objects[ref.num]
+	}
+	return nil
+}
+
+func (d *pdfDoc) dict(obj any) pdfDict {
+	switch v := d.resolve(obj).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.dict
+	}
+	return nil
+}
+
+func (d *pdfDoc) array(obj any) pdfArray {
+	arr, _ := d.resolve(obj).(pdfArray)
+	return arr
+}
+
+func (d *pdfDoc) number(obj any) (float64, bool) {
+	f, ok := d.resolve(obj).(float64)
+	return f, ok
+}
+
+// filters returns the filter names of the stream
+func (d *pdfDoc) filters(stream *pdfStream) []pdfName {
+	switch f := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		return []pdfName{f}
+	case pdfArray:
+		names := make([]pdfName, 0, len(f))
+		for _, item := range f {
+			if name, ok := d.resolve(item).(pdfName); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// decode applies the stream filters, image filters like DCTDecode are left in place
+func (d *pdfDoc) decode(stream *pdfStream) ([]byte, error) {
+	data := stream.data
+	for _, filter := range d.filters(stream) {
+		switch filter {
+		case "FlateDecode", "Fl":
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			out, err := readLimited(zr, &d.budget)
+			// broken streams often still carry the content
+			if err != nil && len(out) == 0 {
+				return nil, err
+			}
+			data = out
+			if params := d.dict(stream.dict["DecodeParms"]); params != nil {
+				if predictor, _ := d.number(params["Predictor"]); predictor >= 10 {
+					columns, ok := d.number(params["Columns"])
+					if !ok {
+						columns = 1
+					}
+					data = pngUnpredict(data, int(columns))
+				}
+			}
+		case "ASCIIHexDecode", "AHx":
+			data = (&pdfLexer{data: append(append([]byte{'<'}, data...), '>')}).hexString()
+		case "ASCII85Decode", "A85":
+			trimmed := bytes.TrimSpace(data)
+			trimmed = bytes.TrimPrefix(trimmed, []byte("<~"))
+			trimmed = bytes.TrimSuffix(trimmed, []byte("~>"))
+			out := make([]byte, len(trimmed)*5/4+4)
+			n, _, err := ascii85.Decode(out, trimmed, true)
+			if err != nil {
+				return nil, err
+			}
+			data = out[:n]
+		case "DCTDecode", "DCT", "JPXDecode":
+			return data, nil
+		default:
+			return nil, fmt.Errorf("unsupported pdf filter %s", filter)
+		}
+	}
+	return data, nil
+}
+
+// pngUnpredict reverses the png row filters used by xref and object streams
+func pngUnpredict(data []byte, columns int) []byte {
+	rowLen := columns + 1
+	if columns <= 0 || len(data)%rowLen != 0 {
+		return data
+	}
+	out := make([]byte, 0, len(data)/rowLen*columns)
+	prev := make([]byte, columns)
+	for i := 0; i+rowLen <= len(data); i += rowLen {
+		filter := data[i]
+		row := append([]byte(nil), data[i+1:i+rowLen]...)
+		for j := range row {
+			var left, up, upLeft byte
+			if j > 0 {
+				left, upLeft = row[j-1], prev[j-1]
+			}
+			up = prev[j]
+			switch filter {
+			case 1:
+				row[j] += left
+			case 2:
+				row[j] += up
+			case 3:
+				row[j] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[j] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+	return c
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
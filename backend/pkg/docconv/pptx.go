@@ -0,0 +1,197 @@
+package docconv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type pptxSlide struct {
+	pkg    *ooxmlPackage
+	doc    *Document
+	rels   map[string]relationship
+	images map[string]string
+	title  string
+	blocks []string
+}
+
+func convertPptx(data []byte) (*Document, error) {
+	pkg, err := openPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	presentation, err := pkg.readXML("ppt/presentation.xml")
+	if err != nil {
+		return nil, err
+	}
+	sldIDs := presentation.child("sldIdLst")
+	if sldIDs == nil {
+		return nil, ErrUnsupported
+	}
+	rels := pkg.rels("ppt/presentation.xml")
+
+	doc := &Document{Title: pkg.title()}
+	images := make(map[string]string)
+	var sections []string
+	for i, sldID := range sldIDs.children("sldId") {
+		rel, ok := rels[sldID.relID()]
+		if !ok || rel.External {
+			continue
+		}
+		root, err := pkg.readXML(rel.Target)
+		if err != nil {
+			continue
+		}
+		if root.attr("show") == "0" {
+			continue
+		}
+		slide := &pptxSlide{pkg: pkg, doc: doc, rels: pkg.rels(rel.Target), images: images}
+		if tree := root.path("cSld", "spTree"); tree != nil {
+			slide.shapes(tree)
+		}
+		title := slide.title
+		if title == "" {
+			title = fmt.Sprintf("Slide %d", i+1)
+		}
+		section := "## " + title
+		if len(slide.blocks) > 0 {
+			section += "\n\n" + joinBlocks(slide.blocks)
+		}
+		sections = append(sections, section)
+	}
+	if pkg.exceeded() {
+		return nil, ErrTooLarge
+	}
+	doc.Markdown = strings.Join(sections, "\n\n")
+	return doc, nil
+}
+
+func (s *pptxSlide) shapes(tree *xmlNode) {
+	for _, n := range tree.Nodes {
+		switch n.name() {
+		case "sp":
+			s.shape(n)
+		case "pic":
+			if blip := n.find("blip"); blip != nil {
+				if ref := s.pkg.image(s.doc, s.rels, blip.attr("embed"), s.images); ref != "" {
+					s.blocks = append(s.blocks, ref)
+				}
+			}
+		case "graphicFrame":
+			if tbl := n.find("tbl"); tbl != nil {
+				if table := s.table(tbl); table != "" {
+					s.blocks = append(s.blocks, strings.TrimSuffix(table, "\n"))
+				}
+			}
+		case "grpSp":
+			s.shapes(n)
+		case "AlternateContent":
+			if choice := n.child("Choice"); choice != nil {
+				s.shapes(choice)
+			}
+		}
+	}
+}
+
+func (s *pptxSlide) shape(sp *xmlNode) {
+	txBody := sp.child("txBody")
+	if txBody == nil {
+		return
+	}
+	phType := ""
+	isPlaceholder := false
+	if ph := sp.path("nvSpPr", "nvPr", "ph"); ph != nil {
+		isPlaceholder = true
+		phType = ph.attr("type")
+	}
+	switch phType {
+	case "title", "ctrTitle":
+		if s.title == "" {
+			var lines []string
+			for _, p := range txBody.children("p") {
+				if text := strings.TrimSpace(pptxParagraphText(p)); text != "" {
+					lines = append(lines, strings.ReplaceAll(text, "<br>", " "))
+				}
+			}
+			s.title = strings.Join(lines, " ")
+			return
+		}
+	case "sldNum", "dt", "ftr", "hdr":
+		return
+	}
+	// body placeholders are bulleted unless turned off
+	bodyBullets := isPlaceholder && (phType == "" || phType == "body" || phType == "obj")
+	for _, p := range txBody.children("p") {
+		text := strings.TrimSpace(pptxParagraphText(p))
+		if text == "" {
+			continue
+		}
+		level := 0
+		bullet, ordered := bodyBullets, false
+		if pPr := p.child("pPr"); pPr != nil {
+			if lvl, err := strconv.Atoi(pPr.attr("lvl")); err == nil {
+				level = lvl
+			}
+			switch {
+			case pPr.child("buNone") != nil:
+				bullet = false
+			case pPr.child("buAutoNum") != nil:
+				bullet, ordered = true, true
+			case pPr.child("buChar") != nil || pPr.child("buBlip") != nil:
+				bullet = true
+			}
+		}
+		if bullet {
+			text = listMarker(level, ordered) + text
+		}
+		s.blocks = append(s.blocks, text)
+	}
+}
+
+func pptxParagraphText(p *xmlNode) string {
+	var spans []inlineSpan
+	for _, n := range p.Nodes {
+		switch n.name() {
+		case "r", "fld":
+			bold, italic := false, false
+			if rPr := n.child("rPr"); rPr != nil {
+				bold = rPr.attr("b") == "1" || rPr.attr("b") == "true"
+				italic = rPr.attr("i") == "1" || rPr.attr("i") == "true"
+			}
+			if t := n.child("t"); t != nil {
+				spans = append(spans, inlineSpan{text: t.Text, bold: bold, italic: italic})
+			}
+		case "br":
+			spans = append(spans, inlineSpan{text: "<br>"})
+		}
+	}
+	return renderSpans(spans)
+}
+
+func (s *pptxSlide) table(tbl *xmlNode) string {
+	var rows [][]string
+	for _, tr := range tbl.children("tr") {
+		var row []string
+		for _, tc := range tr.children("tc") {
+			// merged cells continue the cell before them
+			if tc.attr("hMerge") == "1" || tc.attr("vMerge") == "1" {
+				row = append(row, "")
+				continue
+			}
+			var lines []string
+			if txBody := tc.child("txBody"); txBody != nil {
+				for _, p := range txBody.children("p") {
+					if text := strings.TrimSpace(pptxParagraphText(p)); text != "" {
+						lines = append(lines, text)
+					}
+				}
+			}
+			row = append(row, strings.Join(lines, "<br>"))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return markdownTable(rows)
+}
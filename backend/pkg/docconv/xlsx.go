@@ -0,0 +1,180 @@
+package docconv
+
+import (
+	"strconv"
+	"strings"
+)
+
+func convertXlsx(data []byte) (*Document, error) {
+	pkg, err := openPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	workbook, err := pkg.readXML("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheets := workbook.child("sheets")
+	if sheets == nil {
+		return nil, ErrUnsupported
+	}
+	rels := pkg.rels("xl/workbook.xml")
+	shared := xlsxSharedStrings(pkg)
+
+	doc := &Document{Title: pkg.title()}
+	type sheetTable struct {
+		name  string
+		table string
+	}
+	var tables []sheetTable
+	for _, sheet := range sheets.children("sheet") {
+		if state := sheet.attr("state"); state == "hidden" || state == "veryHidden" {
+			continue
+		}
+		rel, ok := rels[sheet.attr("id")]
+		if !ok || rel.External {
+			continue
+		}
+		root, err := pkg.readXML(rel.Target)
+		if err != nil {
+			continue
+		}
+		rows := xlsxRows(root, shared)
+		if len(rows) == 0 {
+			continue
+		}
+		tables = append(tables, sheetTable{name: sheet.attr("name"), table: markdownTable(rows)})
+	}
+
+	var sb strings.Builder
+	for i, t := range tables {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		// a single sheet needs no heading
+		if len(tables) > 1 {
+			sb.WriteString("## " + t.name + "\n\n")
+		}
+		sb.WriteString(t.table)
+	}
+	if pkg.exceeded() {
+		return nil, ErrTooLarge
+	}
+	doc.Markdown = sb.String()
+	return doc, nil
+}
+
+func xlsxSharedStrings(pkg *ooxmlPackage) []string {
+	root, err := pkg.readXML("xl/sharedStrings.xml")
+	if err != nil {
+		return nil
+	}
+	items := root.children("si")
+	strs := make([]string, 0, len(items))
+	for _, si := range items {
+		strs = append(strs, xlsxText(si))
+	}
+	return strs
+}
+
+// xlsxText joins the text of plain and rich text strings, phonetic hints are skipped
+func xlsxText(n *xmlNode) string {
+	var sb strings.Builder
+	for _, c := range n.Nodes {
+		switch c.name() {
+		case "t":
+			sb.WriteString(c.Text)
+		case "r":
+			sb.WriteString(xlsxText(c))
+		}
+	}
+	return sb.String()
+}
+
+// xlsxRows returns the cell values of the sheet, empty trailing rows and columns are dropped
+func xlsxRows(sheet *xmlNode, shared []string) [][]string {
+	sheetData := sheet.child("sheetData")
+	if sheetData == nil {
+		return nil
+	}
+	var rows [][]string
+	maxCol := 0
+	for _, row := range sheetData.children("row") {
+		rowIdx := len(rows)
+		if r, err := strconv.Atoi(row.attr("r")); err == nil && r > 0 {
+			rowIdx = r - 1
+		}
+		for len(rows) <= rowIdx {
+			rows = append(rows, nil)
+		}
+		cells := rows[rowIdx]
+		for _, c := range row.children("c") {
+			col := len(cells)
+			if ref := c.attr("r"); ref != "" {
+				col = xlsxColumn(ref)
+			}
+			value := xlsxCellValue(c, shared)
+			if value == "" {
+				continue
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = value
+			maxCol = max(maxCol, col+1)
+		}
+		rows[rowIdx] = cells
+	}
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	// leading empty rows would become an empty header
+	for len(rows) > 0 && len(rows[0]) == 0 {
+		rows = rows[1:]
+	}
+	if maxCol == 0 {
+		return nil
+	}
+	return rows
+}
+
+func xlsxCellValue(c *xmlNode, shared []string) string {
+	v := c.child("v")
+	switch c.attr("t") {
+	case "s":
+		if v == nil {
+			return ""
+		}
+		i, err := strconv.Atoi(strings.TrimSpace(v.Text))
+		if err != nil || i < 0 || i >= len(shared) {
+			return ""
+		}
+		return shared[i]
+	case "inlineStr":
+		if is := c.child("is"); is != nil {
+			return xlsxText(is)
+		}
+		return ""
+	case "b":
+		if v != nil && strings.TrimSpace(v.Text) == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	if v == nil {
+		return ""
+	}
+	return v.Text
+}
+
+// xlsxColumn returns the zero based column of a cell reference like AB12
+func xlsxColumn(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return max(col-1, 0)
+}
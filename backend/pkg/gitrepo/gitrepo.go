@@ -0,0 +1,210 @@
+// Package gitrepo keeps bare mirrors of git repositories with the git command line client
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// allowedProtocols keeps remote helpers like ext:: from running commands
+const allowedProtocols = "http:https:ssh:file"
+
+type Credentials struct {
+	Username string
+	Password string // https password or token
+	SSHKey   string // ssh private key
+}
+
+type Repo struct {
+	dir  string
+	url  string
+	cred Credentials
+}
+
+type ChangeStatus string
+
+const (
+	ChangeAdded    ChangeStatus = "A"
+	ChangeModified ChangeStatus = "M"
+	ChangeDeleted  ChangeStatus = "D"
+)
+
+type Change struct {
+	Status ChangeStatus
+	Path   string
+}
+
+// Open fetches the mirror in dir, the repo is cloned when the mirror does not exist yet
+func Open(ctx context.Context, dir, url string, cred Credentials) (*Repo, error) {
+	if strings.HasPrefix(url, "-") {
+		return nil, fmt.Errorf("invalid repo url: %s", url)
+	}
+	r := &Repo{dir: dir, url: url, cred: cred}
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err == nil {
+		if _, err := r.run(ctx, false, "--git-dir", dir, "remote", "set-url", "origin", url); err != nil {
+			return nil, err
+		}
+		if _, err := r.run(ctx, true, "--git-dir", dir, "fetch", "--prune", "origin"); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return nil, err
+	}
+	if _, err := r.run(ctx, true, "clone", "--mirror", "--", url, dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return r, nil
+}
+
+// ResolveCommit returns the commit of the branch, the default branch of the remote when branch is empty
+func (r *Repo) ResolveCommit(ctx context.Context, branch string) (string, error) {
+	ref := "HEAD"
+	if branch != "" {
+		ref = "refs/heads/" + branch
+	}
+	out, err := r.git(ctx, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("branch %q not found: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// HasCommit reports whether the commit is still in the repo, history may be rewritten by force pushes
+func (r *Repo) HasCommit(ctx context.Context, commit string) bool {
+	_, err := r.git(ctx, "cat-file", "-e", "--end-of-options", commit+"^{commit}")
+	return err == nil
+}
+
+// ListFiles returns the paths of all files of the commit below dir
+func (r *Repo) ListFiles(ctx context.Context, commit, dir string) ([]string, error) {
+	args := []string{"ls-tree", "-r", "-z", "--name-only", "--end-of-options", commit}
+	if dir != "" {
+		args = append(args, "--", dir)
+	}
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return splitNUL(out), nil
+}
+
+// Diff returns the files below dir changed between the commits, renames are reported as delete and add
+func (r *Repo) Diff(ctx context.Context, from, to, dir string) ([]*Change, error) {
+	args := []string{"diff", "--name-status", "-z", "--no-renames", "--end-of-options", from, to}
+	if dir != "" {
+		args = append(args, "--", dir)
+	}
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	fields := splitNUL(out)
+	changes := make([]*Change, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		status := ChangeStatus(fields[i][:1])
+		// type changes are updates of the same path
+		if status == "T" {
+			status = ChangeModified
+		}
+		changes = append(changes, &Change{Status: status, Path: fields[i+1]})
+	}
+	return changes, nil
+}
+
+// ReadFile returns the content of the file at the commit
+func (r *Repo) ReadFile(ctx context.Context, commit, path string) ([]byte, error) {
+	return r.git(ctx, "cat-file", "blob", commit+":"+path)
+}
+
+func (r *Repo) git(ctx context.Context, args ...string) ([]byte, error) {
+	return r.run(ctx, false, append([]string{"--git-dir", r.dir}, args...)...)
+}
+
+// run executes git, remote commands get the credentials through the environment so they never end up in the mirror config
+func (r *Repo) run(ctx context.Context, remote bool, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	env := append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL="+allowedProtocols,
+		"GIT_CONFIG_NOSYSTEM=1",
+	)
+	if remote {
+		authEnv, cleanup, err := r.authEnv()
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		env = append(env, authEnv...)
+	}
+	cmd.Env = env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func (r *Repo) authEnv() ([]string, func(), error) {
+	cleanup := func() {}
+	var env []string
+	if r.cred.Password != "" {
+		token := base64.StdEncoding.EncodeToString([]byte(r.cred.Username + ":" + r.cred.Password))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+token,
+		)
+	}
+	knownHosts := filepath.Join(filepath.Dir(r.dir), "known_hosts")
+	sshCommand := fmt.Sprintf("ssh -o BatchMode=yes -o StrictHostKeyChecking=accept-new -o UserKnownHostsFile=%s", shellQuote(knownHosts))
+	if r.cred.SSHKey != "" {
+		keyFile, err := os.CreateTemp("", "git-ssh-key-*")
+		if err != nil {
+			return nil, nil, err
+		}
+		cleanup = func() { os.Remove(keyFile.Name()) }
+		key := strings.TrimSpace(strings.ReplaceAll(r.cred.SSHKey, "\r\n", "\n")) + "\n"
+		_, err = keyFile.WriteString(key)
+		if closeErr := keyFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		sshCommand += fmt.Sprintf(" -o IdentitiesOnly=yes -i %s", shellQuote(keyFile.Name()))
+	}
+	env = append(env, "GIT_SSH_COMMAND="+sshCommand)
+	return env, cleanup, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func splitNUL(out []byte) []string {
+	out = bytes.TrimSuffix(out, []byte{0})
+	if len(out) == 0 {
+		return nil
+	}
+	return strings.Split(string(out), "\x00")
+}
@@ -0,0 +1,683 @@
+// Package openapi reads OpenAPI 3 and Swagger 2 specs into a normalized model and renders operations as markdown
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidSpec = errors.New("invalid openapi spec")
+
+const DefaultTag = "default"
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type Spec struct {
+	Title       string
+	Version     string
+	Description string
+	Tags        []*Tag // tags with at least one operation, in spec order
+	Operations  []*Operation
+}
+
+type Tag struct {
+	Name        string
+	Description string
+}
+
+type Operation struct {
+	Key         string // operationId, or method and path when the operation has none
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	Deprecated  bool
+	Parameters  []*Parameter
+	RequestBody *RequestBody
+	Responses   []*Response
+}
+
+// Name is the title of the operation page
+func (o *Operation) Name() string {
+	if o.Summary != "" {
+		return o.Summary
+	}
+	if o.Key != "" && !strings.Contains(o.Key, " ") {
+		return o.Key
+	}
+	return strings.ToUpper(o.Method) + " " + o.Path
+}
+
+type Parameter struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Deprecated  bool
+	Schema      *Schema
+	Example     any
+}
+
+type RequestBody struct {
+	Description string
+	Required    bool
+	Contents    []*MediaType
+}
+
+type Response struct {
+	Status      string
+	Description string
+	Contents    []*MediaType
+}
+
+type MediaType struct {
+	Type    string
+	Schema  *Schema
+	Example any // explicit example of the media type or the schema
+}
+
+type Schema struct {
+	Ref         string // name of the referenced schema
+	Recursive   bool   // the reference points back to a schema being expanded
+	Type        string
+	Format      string
+	Description string
+	Enum        []any
+	Default     any
+	Example     any
+	Nullable    bool
+	ReadOnly    bool
+	WriteOnly   bool
+	Required    []string
+	Properties  []*Property
+	Items       *Schema
+	OneOf       []*Schema
+	AnyOf       []*Schema
+	Additional  *Schema // additionalProperties schema of maps
+}
+
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Parse reads a json or yaml spec
+func Parse(data []byte) (*Spec, error) {
+	root, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	p := &parser{root: root, building: make(map[*yaml.Node]*Schema)}
+	switch {
+	case strings.HasPrefix(scalar(get(root, "openapi")), "3."):
+	case scalar(get(root, "swagger")) == "2.0":
+		p.swagger2 = true
+	default:
+		return nil, fmt.Errorf("%w: only openapi 3 and swagger 2.0 are supported", ErrInvalidSpec)
+	}
+	paths := get(root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: paths are missing", ErrInvalidSpec)
+	}
+
+	info := get(root, "info")
+	spec := &Spec{
+		Title:       strings.TrimSpace(scalar(get(info, "title"))),
+		Version:     strings.TrimSpace(scalar(get(info, "version"))),
+		Description: strings.TrimSpace(scalar(get(info, "description"))),
+	}
+
+	tags := make(map[string]*Tag)
+	var tagOrder []string
+	addTag := func(name, description string) {
+		if tag, ok := tags[name]; ok {
+			if tag.Description == "" {
+				tag.Description = description
+			}
+			return
+		}
+		tags[name] = &Tag{Name: name, Description: description}
+		tagOrder = append(tagOrder, name)
+	}
+	for _, tag := range items(get(root, "tags")) {
+		if name := strings.TrimSpace(scalar(get(tag, "name"))); name != "" {
+			addTag(name, strings.TrimSpace(scalar(get(tag, "description"))))
+		}
+	}
+
+	used := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, path := range pairs(paths) {
+		item := p.deref(path.value)
+		pathParams := items(get(item, "parameters"))
+		for _, method := range httpMethods {
+			node := get(item, method)
+			if node == nil || node.Kind != yaml.MappingNode {
+				continue
+			}
+			op := p.operation(method, path.key, node, pathParams)
+			if keys[op.Key] {
+				// duplicate operation ids fall back to method and path
+				op.Key = strings.ToUpper(method) + " " + path.key
+			}
+			keys[op.Key] = true
+			if _, ok := tags[op.Tag]; !ok {
+				addTag(op.Tag, "")
+			}
+			used[op.Tag] = true
+			spec.Operations = append(spec.Operations, op)
+		}
+	}
+	for _, name := range tagOrder {
+		if used[name] {
+			spec.Tags = append(spec.Tags, tags[name])
+		}
+	}
+	return spec, nil
+}
+
+type parser struct {
+	root     *yaml.Node
+	swagger2 bool
+	building map[*yaml.Node]*Schema // referenced schemas being expanded, to stop at cycles
+}
+
+func (p *parser) operation(method, path string, node *yaml.Node, pathParams []*yaml.Node) *Operation {
+	op := &Operation{
+		Key:         strings.TrimSpace(scalar(get(node, "operationId"))),
+		Method:      method,
+		Path:        path,
+		Summary:     strings.TrimSpace(scalar(get(node, "summary"))),
+		Description: strings.TrimSpace(scalar(get(node, "description"))),
+		Tag:         DefaultTag,
+		Deprecated:  scalar(get(node, "deprecated")) == "true",
+	}
+	if op.Key == "" {
+		op.Key = strings.ToUpper(method) + " " + path
+	}
+	if tags := items(get(node, "tags")); len(tags) > 0 {
+		if name := strings.TrimSpace(scalar(tags[0])); name != "" {
+			op.Tag = name
+		}
+	}
+
+	// operation parameters override the path ones with the same name and location
+	var params []*yaml.Node
+	seen := make(map[string]int)
+	for _, raw := range append(append([]*yaml.Node{}, pathParams...), items(get(node, "parameters"))...) {
+		param := p.deref(raw)
+		id := scalar(get(param, "in")) + ":" + scalar(get(param, "name"))
+		if i, ok := seen[id]; ok {
+			params[i] = param
+			continue
+		}
+		seen[id] = len(params)
+		params = append(params, param)
+	}
+
+	var formParams []*yaml.Node
+	for _, param := range params {
+		in := scalar(get(param, "in"))
+		switch {
+		case p.swagger2 && in == "body":
+			op.RequestBody = &RequestBody{
+				Description: strings.TrimSpace(scalar(get(param, "description"))),
+				Required:    scalar(get(param, "required")) == "true",
+				Contents: []*MediaType{{
+					Type:   p.mediaTypes(node, "consumes", "application/json")[0],
+					Schema: p.schema(get(param, "schema")),
+				}},
+			}
+		case p.swagger2 && in == "formData":
+			formParams = append(formParams, param)
+		default:
+			op.Parameters = append(op.Parameters, p.parameter(param))
+		}
+	}
+	if len(formParams) > 0 {
+		op.RequestBody = p.formBody(node, formParams)
+	}
+	if !p.swagger2 {
+		if body := p.deref(get(node, "requestBody")); body != nil {
+			op.RequestBody = &RequestBody{
+				Description: strings.TrimSpace(scalar(get(body, "description"))),
+				Required:    scalar(get(body, "required")) == "true",
+				Contents:    p.contents(get(body, "content")),
+			}
+		}
+	}
+
+	for _, resp := range pairs(get(node, "responses")) {
+		r := p.deref(resp.value)
+		response := &Response{Status: resp.key, Description: strings.TrimSpace(scalar(get(r, "description")))}
+		if p.swagger2 {
+			if schema := get(r, "schema"); schema != nil {
+				mediaType := p.mediaTypes(node, "produces", "application/json")[0]
+				media := &MediaType{Type: mediaType, Schema: p.schema(schema)}
+				for _, example := range pairs(get(r, "examples")) {
+					if media.Example == nil || example.key == mediaType {
+						media.Example = value(example.value)
+					}
+				}
+				response.Contents = append(response.Contents, media)
+			}
+		} else {
+			response.Contents = p.contents(get(r, "content"))
+		}
+		op.Responses = append(op.Responses, response)
+	}
+	return op
+}
+
+func (p *parser) parameter(param *yaml.Node) *Parameter {
+	result := &Parameter{
+		Name:        scalar(get(param, "name")),
+		In:          scalar(get(param, "in")),
+		Description: strings.TrimSpace(scalar(get(param, "description"))),
+		Required:    scalar(get(param, "required")) == "true",
+		Deprecated:  scalar(get(param, "deprecated")) == "true",
+		Example:     value(get(param, "example")),
+	}
+	switch {
+	case p.swagger2:
+		// swagger 2 parameters carry the schema fields themselves
+		result.Schema = p.schema(param)
+		result.Schema.Description = ""
+	case get(param, "schema") != nil:
+		result.Schema = p.schema(get(param, "schema"))
+	default:
+		if contents := p.contents(get(param, "content")); len(contents) > 0 {
+			result.Schema = contents[0].Schema
+		}
+	}
+	if result.Example == nil {
+		for _, example := range pairs(get(param, "examples")) {
+			result.Example = value(get(p.deref(example.value), "value"))
+			break
+		}
+	}
+	return result
+}
+
+// formBody turns swagger 2 form parameters into an object schema
+func (p *parser) formBody(op *yaml.Node, params []*yaml.Node) *RequestBody {
+	schema := &Schema{Type: "object"}
+	for _, param := range params {
+		name := scalar(get(param, "name"))
+		prop := p.schema(param)
+		schema.Properties = append(schema.Properties, &Property{Name: name, Schema: prop})
+		if scalar(get(param, "required")) == "true" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	mediaType := "application/x-www-form-urlencoded"
+	for _, t := range p.mediaTypes(op, "consumes", "") {
+		if t == "multipart/form-data" {
+			mediaType = t
+		}
+	}
+	return &RequestBody{Required: len(schema.Required) > 0, Contents: []*MediaType{{Type: mediaType, Schema: schema}}}
+}
+
+// mediaTypes returns the consumes or produces of the operation or the spec
+func (p *parser) mediaTypes(op *yaml.Node, key, fallback string) []string {
+	var types []string
+	for _, node := range []*yaml.Node{get(op, key), get(p.root, key)} {
+		for _, item := range items(node) {
+			types = append(types, scalar(item))
+		}
+		if len(types) > 0 {
+			return types
+		}
+	}
+	return []string{fallback}
+}
+
+func (p *parser) contents(content *yaml.Node) []*MediaType {
+	var result []*MediaType
+	for _, c := range pairs(content) {
+		media := &MediaType{Type: c.key, Schema: p.schema(get(c.value, "schema"))}
+		media.Example = value(get(c.value, "example"))
+		if media.Example == nil {
+			for _, example := range pairs(get(c.value, "examples")) {
+				media.Example = value(get(p.deref(example.value), "value"))
+				break
+			}
+		}
+		if media.Example == nil && media.Schema != nil {
+			media.Example = media.Schema.Example
+		}
+		result = append(result, media)
+	}
+	return result
+}
+
+func (p *parser) schema(node *yaml.Node) *Schema {
+	if node == nil {
+		return nil
+	}
+	ref := refName(node)
+	target := p.deref(node)
+	if target == nil {
+		return &Schema{Ref: ref, Type: "object"}
+	}
+	if ref != "" {
+		if s, ok := p.building[target]; ok {
+			return &Schema{Ref: s.Ref, Type: s.Type, Recursive: true}
+		}
+	}
+	s := &Schema{
+		Ref:         ref,
+		Type:        scalar(get(target, "type")),
+		Format:      scalar(get(target, "format")),
+		Description: strings.TrimSpace(scalar(get(target, "description"))),
+		Default:     value(get(target, "default")),
+		Example:     value(get(target, "example")),
+		Nullable:    scalar(get(target, "nullable")) == "true" || scalar(get(target, "x-nullable")) == "true",
+		ReadOnly:    scalar(get(target, "readOnly")) == "true",
+		WriteOnly:   scalar(get(target, "writeOnly")) == "true",
+	}
+	// openapi 3.1 allows a list of types like [string, "null"]
+	if t := get(target, "type"); t != nil && t.Kind == yaml.SequenceNode {
+		for _, item := range items(t) {
+			if scalar(item) == "null" {
+				s.Nullable = true
+			} else if s.Type == "" {
+				s.Type = scalar(item)
+			}
+		}
+	}
+	if ref != "" {
+		p.building[target] = s
+		defer delete(p.building, target)
+	}
+	for _, e := range items(get(target, "enum")) {
+		s.Enum = append(s.Enum, value(e))
+	}
+	for _, r := range items(get(target, "required")) {
+		s.Required = append(s.Required, scalar(r))
+	}
+	for _, prop := range pairs(get(target, "properties")) {
+		s.Properties = append(s.Properties, &Property{Name: prop.key, Schema: p.schema(prop.value)})
+	}
+	if itemsNode := get(target, "items"); itemsNode != nil {
+		s.Items = p.schema(itemsNode)
+		if s.Type == "" {
+			s.Type = "array"
+		}
+	}
+	if additional := get(target, "additionalProperties"); additional != nil && additional.Kind == yaml.MappingNode {
+		s.Additional = p.schema(additional)
+	}
+	// allOf is merged into the schema itself
+	for _, part := range items(get(target, "allOf")) {
+		sub := p.schema(part)
+		if sub == nil {
+			continue
+		}
+		if s.Type == "" {
+			s.Type = sub.Type
+		}
+		if s.Description == "" && sub.Ref == "" {
+			s.Description = sub.Description
+		}
+		s.Required = append(s.Required, sub.Required...)
+		s.Properties = append(s.Properties, sub.Properties...)
+		if s.Items == nil {
+			s.Items = sub.Items
+		}
+	}
+	for _, part := range items(get(target, "oneOf")) {
+		s.OneOf = append(s.OneOf, p.schema(part))
+	}
+	for _, part := range items(get(target, "anyOf")) {
+		s.AnyOf = append(s.AnyOf, p.schema(part))
+	}
+	if s.Type == "" && (len(s.Properties) > 0 || s.Additional != nil) {
+		s.Type = "object"
+	}
+	return s
+}
+
+// deref follows local references like #/components/schemas/Pet, external references are left unresolved
+func (p *parser) deref(node *yaml.Node) *yaml.Node {
+	for i := 0; node != nil && i < 16; i++ {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+			continue
+		}
+		ref := scalar(get(node, "$ref"))
+		if ref == "" {
+			return node
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+		node = p.pointer(ref[2:])
+	}
+	return node
+}
+
+func (p *parser) pointer(ptr string) *yaml.Node {
+	node := p.root
+	for _, part := range strings.Split(ptr, "/") {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			part = unescaped
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		node = get(node, part)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func refName(node *yaml.Node) string {
+	ref := scalar(get(node, "$ref"))
+	if ref == "" {
+		return ""
+	}
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+type pair struct {
+	key   string
+	value *yaml.Node
+}
+
+func get(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func pairs(node *yaml.Node) []pair {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	result := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		// extension fields are not part of the api
+		if strings.HasPrefix(node.Content[i].Value, "x-") {
+			continue
+		}
+		result = append(result, pair{key: node.Content[i].Value, value: node.Content[i+1]})
+	}
+	return result
+}
+
+func items(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+func scalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// OrderedMap keeps the key order of objects in examples
+type OrderedMap struct {
+	Keys   []string
+	Values map[string]any
+}
+
+func (m *OrderedMap) Set(key string, v any) {
+	if m.Values == nil {
+		m.Values = make(map[string]any)
+	}
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = v
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// value converts the node to plain values, mappings keep their order
+func value(node *yaml.Node) any {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.AliasNode:
+		return value(node.Alias)
+	case yaml.MappingNode:
+		m := &OrderedMap{Values: make(map[string]any)}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m.Set(node.Content[i].Value, value(node.Content[i+1]))
+		}
+		return m
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			list = append(list, value(item))
+		}
+		return list
+	}
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return node.Value
+	}
+	return v
+}
+
+// decode parses the spec into a node tree, json is read token by token as yaml parsers reject some valid json
+func decode(data []byte) (*yaml.Node, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		return jsonNode(dec)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(trimmed, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("spec is not an object")
+	}
+	return doc.Content[0], nil
+}
+
+func jsonNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				val, err := jsonNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, val)
+			}
+			_, err := dec.Token()
+			return node, err
+		case '[':
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for dec.More() {
+				val, err := jsonNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, val)
+			}
+			_, err := dec.Token()
+			return node, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", v)
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstore3 = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+tags:
+  - name: pet
+    description: Pets of the store
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          format: int64
+    put:
+      operationId: updatePet
+      summary: Update a pet
+      tags: [pet]
+      parameters:
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+            default: false
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: updated pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Pet:
+      description: A pet
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          properties:
+            status:
+              type: string
+              enum: [available, sold]
+            parent:
+              $ref: '#/components/schemas/Pet'
+`
+
+const petstore2 = `{
+  "swagger": "2.0",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "tags": [{"name": "pet", "description": "Pets of the store"}],
+  "consumes": ["application/json"],
+  "produces": ["application/json"],
+  "paths": {
+    "/pets/{petId}": {
+      "parameters": [
+        {"name": "petId", "in": "path", "required": true, "type": "integer", "format": "int64"}
+      ],
+      "put": {
+        "operationId": "updatePet",
+        "summary": "Update a pet",
+        "tags": ["pet"],
+        "parameters": [
+          {"name": "dryRun", "in": "query", "type": "boolean", "default": false},
+          {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+        ],
+        "responses": {
+          "200": {"description": "updated pet", "schema": {"$ref": "#/definitions/Pet"}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Named": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {"name": {"type": "string"}}
+    },
+    "Pet": {
+      "description": "A pet",
+      "allOf": [
+        {"$ref": "#/definitions/Named"},
+        {
+          "type": "object",
+          "properties": {
+            "status": {"type": "string", "enum": ["available", "sold"]},
+            "parent": {"$ref": "#/definitions/Pet"}
+          }
+        }
+      ]
+    }
+  }
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "openapi 3 yaml", spec: petstore3},
+		{name: "swagger 2 json", spec: petstore2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Parse([]byte(tt.spec))
+			require.NoError(t, err)
+			assert.Equal(t, "Petstore", spec.Title)
+			assert.Equal(t, "1.0.0", spec.Version)
+			require.Len(t, spec.Tags, 1)
+			assert.Equal(t, &Tag{Name: "pet", Description: "Pets of the store"}, spec.Tags[0])
+
+			require.Len(t, spec.Operations, 1)
+			op := spec.Operations[0]
+			assert.Equal(t, "updatePet", op.Key)
+			assert.Equal(t, "put", op.Method)
+			assert.Equal(t, "/pets/{petId}", op.Path)
+			assert.Equal(t, "pet", op.Tag)
+			assert.Equal(t, "Update a pet", op.Name())
+
+			// path parameters come first, the body parameter of swagger 2 becomes the request body
+			require.Len(t, op.Parameters, 2)
+			assert.Equal(t, "petId", op.Parameters[0].Name)
+			assert.Equal(t, "path", op.Parameters[0].In)
+			assert.True(t, op.Parameters[0].Required)
+			assert.Equal(t, "integer(int64)", typeName(op.Parameters[0].Schema))
+			assert.Equal(t, "dryRun", op.Parameters[1].Name)
+			assert.Equal(t, false, op.Parameters[1].Schema.Default)
+
+			require.NotNil(t, op.RequestBody)
+			assert.True(t, op.RequestBody.Required)
+			require.Len(t, op.RequestBody.Contents, 1)
+			assert.Equal(t, "application/json", op.RequestBody.Contents[0].Type)
+			assert.Equal(t, "Pet", op.RequestBody.Contents[0].Schema.Ref)
+
+			require.Len(t, op.Responses, 1)
+			assert.Equal(t, "200", op.Responses[0].Status)
+			require.Len(t, op.Responses[0].Contents, 1)
+			assert.Equal(t, "Pet", op.Responses[0].Contents[0].Schema.Ref)
+		})
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	for name, data := range map[string]string{"openapi 3": petstore3, "swagger 2": petstore2} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := Parse([]byte(data))
+			require.NoError(t, err)
+			pet := spec.Operations[0].RequestBody.Contents[0].Schema
+
+			// allOf parts are merged into the schema, the description of a referenced part is not taken over
+			assert.Equal(t, "Pet", pet.Ref)
+			assert.Equal(t, "object", pet.Type)
+			assert.Equal(t, "A pet", pet.Description)
+			assert.Equal(t, []string{"name"}, pet.Required)
+			require.Len(t, pet.Properties, 3)
+			assert.Equal(t, "name", pet.Properties[0].Name)
+			assert.Equal(t, "status", pet.Properties[1].Name)
+			assert.Equal(t, []any{"available", "sold"}, pet.Properties[1].Schema.Enum)
+
+			// the reference back to Pet stops the expansion
+			parent := pet.Properties[2].Schema
+			assert.Equal(t, "parent", pet.Properties[2].Name)
+			assert.True(t, parent.Recursive)
+			assert.Equal(t, "Pet", parent.Ref)
+			assert.Empty(t, parent.Properties)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "not yaml or json", spec: "{"},
+		{name: "unknown version", spec: "swagger: '1.2'\npaths: {}\n"},
+		{name: "missing paths", spec: "openapi: 3.1.0\ninfo:\n  title: x\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.spec))
+			assert.ErrorIs(t, err, ErrInvalidSpec)
+		})
+	}
+}
+
+func TestOperationMarkdown(t *testing.T) {
+	spec, err := Parse([]byte(petstore3))
+	require.NoError(t, err)
+	markdown := spec.Operations[0].Markdown()
+
+	for _, fragment := range []string{
+		"`PUT` `/pets/{petId}`",
+		"## Parameters",
+		"| `petId` | path | integer(int64) | Yes |",
+		"| `dryRun` | query | boolean | No | Default: `false` |",
+		"## Request Body",
+		"The request body is required.",
+		"| `name` | string | Yes |",
+		"| `status` | string | No | Enum: `available`, `sold` |",
+		"| `parent` | Pet | No |",
+		"### 200 OK",
+		`"status": "available"`,
+	} {
+		assert.Contains(t, markdown, fragment)
+	}
+	// the recursive field is not expanded
+	assert.NotContains(t, markdown, "`parent.name`")
+}
@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const maxSchemaDepth = 6
+
+// Markdown renders the reference page of the operation
+func (o *Operation) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "`%s` `%s`\n\n", strings.ToUpper(o.Method), o.Path)
+	if o.Deprecated {
+		sb.WriteString("> **Deprecated**: this operation will be removed in a future version.\n\n")
+	}
+	if o.Description != "" {
+		sb.WriteString(o.Description + "\n\n")
+	}
+
+	if len(o.Parameters) > 0 {
+		sb.WriteString("## Parameters\n\n")
+		rows := [][]string{{"Name", "In", "Type", "Required", "Description"}}
+		for _, param := range o.Parameters {
+			description := param.Description
+			if param.Deprecated {
+				description = joinText("**Deprecated**", description)
+			}
+			description = joinText(description, schemaNotes(param.Schema))
+			if param.Example != nil {
+				description = joinText(description, "Example: `"+inlineValue(param.Example)+"`")
+			}
+			rows = append(rows, []string{"`" + param.Name + "`", param.In, typeName(param.Schema), yesNo(param.Required), description})
+		}
+		sb.WriteString(table(rows) + "\n")
+	}
+
+	if o.RequestBody != nil {
+		sb.WriteString("## Request Body\n\n")
+		if o.RequestBody.Description != "" {
+			sb.WriteString(o.RequestBody.Description + "\n\n")
+		}
+		if o.RequestBody.Required {
+			sb.WriteString("The request body is required.\n\n")
+		}
+		writeContents(&sb, o.RequestBody.Contents, "###")
+	}
+
+	if len(o.Responses) > 0 {
+		sb.WriteString("## Responses\n\n")
+		for _, resp := range o.Responses {
+			title := resp.Status
+			if code, err := strconv.Atoi(resp.Status); err == nil && http.StatusText(code) != "" {
+				title += " " + http.StatusText(code)
+			}
+			sb.WriteString("### " + title + "\n\n")
+			if resp.Description != "" {
+				sb.WriteString(resp.Description + "\n\n")
+			}
+			writeContents(&sb, resp.Contents, "####")
+		}
+	}
+	return strings.TrimSpace(sb.String()) + "\n"
+}
+
+func writeContents(sb *strings.Builder, contents []*MediaType, heading string) {
+	for _, media := range contents {
+		if len(contents) > 1 {
+			sb.WriteString(heading + " `" + media.Type + "`\n\n")
+		} else {
+			sb.WriteString("Content type: `" + media.Type + "`\n\n")
+		}
+		if rows := schemaTable(media.Schema); rows != "" {
+			sb.WriteString(rows + "\n")
+		}
+		example := media.Example
+		// examples are only generated for json bodies, forms and files have no useful one
+		if example == nil && media.Schema != nil && strings.Contains(media.Type, "json") &&
+			(media.Schema.Type == "object" || media.Schema.Type == "array") {
+			example = exampleValue(media.Schema, 0)
+		}
+		if example == nil {
+			continue
+		}
+		lang := "json"
+		text, ok := example.(string)
+		if ok && !strings.Contains(media.Type, "json") {
+			lang = ""
+			if strings.Contains(media.Type, "xml") {
+				lang = "xml"
+			}
+		} else {
+			data, err := json.MarshalIndent(example, "", "  ")
+			if err != nil {
+				continue
+			}
+			text = string(data)
+		}
+		sb.WriteString("Example:\n\n```" + lang + "\n" + strings.TrimSpace(text) + "\n```\n\n")
+	}
+}
+
+// schemaTable lists the fields of the schema, nested objects are flattened with dotted names
+func schemaTable(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	rows := [][]string{{"Field", "Type", "Required", "Description"}}
+	switch {
+	case len(schema.Properties) > 0:
+		rows = appendFields(rows, schema, "", 0)
+	case schema.Type == "array" && schema.Items != nil && len(schema.Items.Properties) > 0:
+		rows = appendFields(rows, schema.Items, "[].", 0)
+	default:
+		description := joinText(schema.Description, schemaNotes(schema))
+		if schema.Type == "" && schema.Ref == "" && description == "" {
+			return ""
+		}
+		rows = append(rows, []string{"(body)", typeName(schema), "", description})
+	}
+	if len(rows) == 1 {
+		return ""
+	}
+	return table(rows)
+}
+
+func appendFields(rows [][]string, schema *Schema, prefix string, depth int) [][]string {
+	if depth >= maxSchemaDepth {
+		return rows
+	}
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for _, prop := range schema.Properties {
+		s := prop.Schema
+		name := prefix + prop.Name
+		description := ""
+		if s != nil {
+			description = joinText(s.Description, schemaNotes(s))
+		}
+		rows = append(rows, []string{"`" + name + "`", typeName(s), yesNo(required[prop.Name]), description})
+		if s == nil || s.Recursive {
+			continue
+		}
+		switch {
+		case len(s.Properties) > 0:
+			rows = appendFields(rows, s, name+".", depth+1)
+		case s.Type == "array" && s.Items != nil && !s.Items.Recursive && len(s.Items.Properties) > 0:
+			rows = appendFields(rows, s.Items, name+"[].", depth+1)
+		}
+	}
+	return rows
+}
+
+// typeName describes the type like integer(int64), array[Pet] or Pet
+func typeName(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	var name string
+	switch {
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		variants := schema.OneOf
+		if len(variants) == 0 {
+			variants = schema.AnyOf
+		}
+		names := make([]string, 0, len(variants))
+		for _, v := range variants {
+			names = append(names, typeName(v))
+		}
+		name = strings.Join(names, " \\| ")
+	case schema.Type == "array":
+		name = "array[" + typeName(schema.Items) + "]"
+		if schema.Items == nil {
+			name = "array"
+		}
+	case schema.Ref != "" && (schema.Type == "object" || schema.Type == ""):
+		name = schema.Ref
+	case schema.Type == "object" && schema.Additional != nil:
+		name = "map[string, " + typeName(schema.Additional) + "]"
+	default:
+		name = schema.Type
+		if schema.Format != "" {
+			name += "(" + schema.Format + ")"
+		}
+	}
+	if name == "" {
+		name = "any"
+	}
+	if schema.Nullable {
+		name += " \\| null"
+	}
+	return name
+}
+
+// schemaNotes lists enum values, defaults and access modes of the schema
+func schemaNotes(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	var notes []string
+	if len(schema.Enum) > 0 {
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, "`"+inlineValue(v)+"`")
+		}
+		notes = append(notes, "Enum: "+strings.Join(values, ", "))
+	}
+	if schema.Default != nil {
+		notes = append(notes, "Default: `"+inlineValue(schema.Default)+"`")
+	}
+	if schema.ReadOnly {
+		notes = append(notes, "Read only")
+	}
+	if schema.WriteOnly {
+		notes = append(notes, "Write only")
+	}
+	return strings.Join(notes, "<br>")
+}
+
+// exampleValue builds an example from the schema like the swagger ui does
+func exampleValue(schema *Schema, depth int) any {
+	if schema == nil {
+		return nil
+	}
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if len(schema.OneOf) > 0 {
+		return exampleValue(schema.OneOf[0], depth)
+	}
+	if len(schema.AnyOf) > 0 {
+		return exampleValue(schema.AnyOf[0], depth)
+	}
+	switch schema.Type {
+	case "object", "":
+		m := &OrderedMap{Values: make(map[string]any)}
+		if schema.Recursive || depth >= maxSchemaDepth {
+			return m
+		}
+		for _, prop := range schema.Properties {
+			m.Set(prop.Name, exampleValue(prop.Schema, depth+1))
+		}
+		if len(schema.Properties) == 0 && schema.Additional != nil {
+			m.Set("key", exampleValue(schema.Additional, depth+1))
+		}
+		return m
+	case "array":
+		if schema.Items == nil || depth >= maxSchemaDepth {
+			return []any{}
+		}
+		return []any{exampleValue(schema.Items, depth+1)}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return true
+	case "string":
+		switch schema.Format {
+		case "date":
+			return "2024-01-01"
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "uuid":
+			return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		case "email":
+			return "user@example.com"
+		case "uri", "url":
+			return "https://example.com"
+		case "binary", "byte":
+			return "<binary>"
+		}
+		return "string"
+	}
+	return nil
+}
+
+func inlineValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func joinText(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "<br>" + b
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+func table(rows [][]string) string {
+	var sb strings.Builder
+	for i, row := range rows {
+		sb.WriteString("|")
+		for _, cell := range row {
+			cell = strings.ReplaceAll(strings.TrimSpace(cell), "\r\n", "\n")
+			cell = strings.ReplaceAll(cell, "\n", "<br>")
+			// pipes of type names are escaped already
+			cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\\|", "\x00"), "|", "\\|")
+			sb.WriteString(" " + strings.ReplaceAll(cell, "\x00", "\\|") + " |")
+		}
+		sb.WriteString("\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", len(row)) + "\n")
+		}
+	}
+	return sb.String()
+}
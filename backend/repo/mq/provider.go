@@ -12,4 +12,5 @@ var ProviderSet = wire.NewSet(
 
 	cache.ProviderSet,
 	NewRAGRepository,
+	NewWebhookRepository,
 )
@@ -64,6 +64,17 @@ func (r *AuthRepo) GetAuthGroupByAuthId(ctx context.Context, authID uint) ([]dom
 	return authGroups, nil
 }
 
+func (r *AuthRepo) GetAuthGroupIDsByKBID(ctx context.Context, kbID string) ([]int, error) {
+	ids := make([]int, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.AuthGroup{}).
+		Where("kb_id = ?", kbID).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // getAllAuthGroupsAsMap fetches all auth groups and returns them as a map for quick lookup
 func (r *AuthRepo) getAllAuthGroupsAsMap(ctx context.Context) (map[uint]*domain.AuthGroup, error) {
 	var allGroups []domain.AuthGroup
@@ -0,0 +1,252 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type CrawlerSyncRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewCrawlerSyncRepository(db *pg.DB, logger *log.Logger) *CrawlerSyncRepository {
+	return &CrawlerSyncRepository{db: db, logger: logger.WithModule("repo.pg.crawler_sync")}
+}
+
+func (r *CrawlerSyncRepository) Create(ctx context.Context, sync *domain.KBCrawlerSync) error {
+	return r.db.WithContext(ctx).Create(sync).Error
+}
+
+func (r *CrawlerSyncRepository) Update(ctx context.Context, id string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSync{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *CrawlerSyncRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBCrawlerSync, error) {
+	var sync *domain.KBCrawlerSync
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSync{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&sync).Error; err != nil {
+		return nil, err
+	}
+	return sync, nil
+}
+
+func (r *CrawlerSyncRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBCrawlerSync, error) {
+	syncs := make([]*domain.KBCrawlerSync, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSync{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&syncs).Error; err != nil {
+		return nil, err
+	}
+	return syncs, nil
+}
+
+// GetDueList returns the syncs queued by an admin and the scheduled ones whose next run has come
+func (r *CrawlerSyncRepository) GetDueList(ctx context.Context, now time.Time) ([]*domain.KBCrawlerSync, error) {
+	syncs := make([]*domain.KBCrawlerSync, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSync{}).
+		Where("status = ? OR (status = ? AND next_run_at <= ?)", domain.CrawlerSyncStatusQueued, domain.CrawlerSyncStatusIdle, now).
+		Order("next_run_at ASC").
+		Find(&syncs).Error; err != nil {
+		return nil, err
+	}
+	return syncs, nil
+}
+
+// Delete removes the sync, its doc mapping and run history, synced nodes are kept
+func (r *CrawlerSyncRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sync_id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBCrawlerSyncRun{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("sync_id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBCrawlerSyncDoc{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBCrawlerSync{}).Error
+	})
+}
+
+// QueueRun asks the consumer to run the sync, it reports false when the sync is already queued or running
+func (r *CrawlerSyncRepository) QueueRun(ctx context.Context, kbID, id string, updateMap map[string]any) (bool, error) {
+	updateMap["status"] = domain.CrawlerSyncStatusQueued
+	updateMap["updated_at"] = time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSync{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		Where("status = ?", domain.CrawlerSyncStatusIdle).
+		Updates(updateMap)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// StartRun marks the sync as running and records the run, it reports false when another run has claimed the sync
+func (r *CrawlerSyncRepository) StartRun(ctx context.Context, sync *domain.KBCrawlerSync, run *domain.KBCrawlerSyncRun) (bool, error) {
+	started := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.KBCrawlerSync{}).
+			Where("id = ?", sync.ID).
+			Where("status = ?", sync.Status).
+			Updates(map[string]any{
+				"status":     domain.CrawlerSyncStatusRunning,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		started = true
+		return tx.Create(run).Error
+	})
+	return started, err
+}
+
+// FinishRun stores the result of the run and makes the sync idle again
+func (r *CrawlerSyncRepository) FinishRun(ctx context.Context, run *domain.KBCrawlerSyncRun, nextRunAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.KBCrawlerSyncRun{}).
+			Where("id = ?", run.ID).
+			Updates(map[string]any{
+				"status":      run.Status,
+				"created":     run.Created,
+				"updated":     run.Updated,
+				"deleted":     run.Deleted,
+				"unchanged":   run.Unchanged,
+				"failed":      run.Failed,
+				"doc_errors":  run.DocErrors,
+				"error":       run.Error,
+				"release_id":  run.ReleaseID,
+				"finished_at": run.FinishedAt,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.KBCrawlerSync{}).
+			Where("id = ?", run.SyncID).
+			Updates(map[string]any{
+				"status":          domain.CrawlerSyncStatusIdle,
+				"next_run_at":     nextRunAt,
+				"last_run_at":     run.StartedAt,
+				"last_run_status": run.Status,
+				"updated_at":      time.Now(),
+			}).Error
+	})
+}
+
+// FailStale marks the runs which are running since before staleBefore as failed and makes their syncs idle again
+func (r *CrawlerSyncRepository) FailStale(ctx context.Context, staleBefore time.Time, reason string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var runs []*domain.KBCrawlerSyncRun
+		if err := tx.Model(&runs).
+			Where("status = ?", domain.CrawlerSyncRunStatusRunning).
+			Where("started_at < ?", staleBefore).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "sync_id"}}}).
+			Updates(map[string]any{
+				"status":      domain.CrawlerSyncRunStatusFailed,
+				"error":       reason,
+				"finished_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			return nil
+		}
+		syncIDs := lo.Uniq(lo.Map(runs, func(run *domain.KBCrawlerSyncRun, _ int) string {
+			return run.SyncID
+		}))
+		return tx.Model(&domain.KBCrawlerSync{}).
+			Where("id IN ?", syncIDs).
+			Where("status = ?", domain.CrawlerSyncStatusRunning).
+			Updates(map[string]any{
+				"status":          domain.CrawlerSyncStatusIdle,
+				"last_run_status": domain.CrawlerSyncRunStatusFailed,
+				"updated_at":      now,
+			}).Error
+	})
+}
+
+func (r *CrawlerSyncRepository) GetRunList(ctx context.Context, kbID, syncID string, offset, limit int) (int64, []*domain.KBCrawlerSyncRun, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSyncRun{}).
+		Where("kb_id = ?", kbID).
+		Where("sync_id = ?", syncID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, err
+	}
+	runs := make([]*domain.KBCrawlerSyncRun, 0)
+	if err := query.
+		Order("started_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return 0, nil, err
+	}
+	return total, runs, nil
+}
+
+// TrimRuns keeps the latest runs of the sync
+func (r *CrawlerSyncRepository) TrimRuns(ctx context.Context, syncID string, keep int) error {
+	return r.db.WithContext(ctx).
+		Where("sync_id = ?", syncID).
+		Where("id NOT IN (?)", r.db.Model(&domain.KBCrawlerSyncRun{}).
+			Select("id").
+			Where("sync_id = ?", syncID).
+			Order("started_at DESC").
+			Limit(keep)).
+		Delete(&domain.KBCrawlerSyncRun{}).Error
+}
+
+func (r *CrawlerSyncRepository) GetDocs(ctx context.Context, syncID string) ([]*domain.KBCrawlerSyncDoc, error) {
+	docs := make([]*domain.KBCrawlerSyncDoc, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBCrawlerSyncDoc{}).
+		Where("sync_id = ?", syncID).
+		Find(&docs).Error; err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (r *CrawlerSyncRepository) SaveDoc(ctx context.Context, doc *domain.KBCrawlerSyncDoc) error {
+	doc.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(doc).Error
+}
+
+func (r *CrawlerSyncRepository) DeleteDocs(ctx context.Context, syncID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("sync_id = ?", syncID).
+		Where("doc_key IN ?", keys).
+		Delete(&domain.KBCrawlerSyncDoc{}).Error
+}
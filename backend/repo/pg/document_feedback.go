@@ -0,0 +1,180 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	nodeV1 "github.com/chaitin/panda-wiki/api/node/v1"
+	statV1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type DocumentFeedbackRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewDocumentFeedbackRepository(db *pg.DB, logger *log.Logger) *DocumentFeedbackRepository {
+	return &DocumentFeedbackRepository{db: db, logger: logger.WithModule("repo.pg.document_feedback")}
+}
+
+func (r *DocumentFeedbackRepository) Create(ctx context.Context, feedback *domain.DocumentFeedback) error {
+	return r.db.WithContext(ctx).Create(feedback).Error
+}
+
+func (r *DocumentFeedbackRepository) Update(ctx context.Context, id int64, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.DocumentFeedback{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *DocumentFeedbackRepository) GetByID(ctx context.Context, kbID string, id int64) (*domain.DocumentFeedback, error) {
+	var feedback domain.DocumentFeedback
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("id = ?", id).
+		First(&feedback).Error; err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// GetOpenBySession returns the open feedback a session left on the node, nil if there is none
+func (r *DocumentFeedbackRepository) GetOpenBySession(ctx context.Context, nodeID, sessionID string) (*domain.DocumentFeedback, error) {
+	var feedback domain.DocumentFeedback
+	if err := r.db.WithContext(ctx).
+		Where("node_id = ?", nodeID).
+		Where("session_id = ?", sessionID).
+		Where("status = ?", domain.DocumentFeedbackStatusOpen).
+		Order("created_at DESC").
+		First(&feedback).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+func (r *DocumentFeedbackRepository) GetList(ctx context.Context, req *nodeV1.NodeFeedbackListReq) ([]*nodeV1.NodeFeedbackListItem, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.DocumentFeedback{}).
+		Where("document_feedbacks.kb_id = ?", req.KbID).
+		Where("document_feedbacks.score != 0") // rows without score were left by the legacy feedback form
+	if req.NodeID != "" {
+		query = query.Where("document_feedbacks.node_id = ?", req.NodeID)
+	}
+	if req.Status != "" {
+		query = query.Where("document_feedbacks.status = ?", req.Status)
+	}
+	if req.Score != 0 {
+		query = query.Where("document_feedbacks.score = ?", req.Score)
+	}
+	if req.Reason != "" {
+		query = query.Where("document_feedbacks.reason = ?", req.Reason)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*nodeV1.NodeFeedbackListItem, 0)
+	if err := query.
+		Joins("LEFT JOIN nodes ON nodes.id = document_feedbacks.node_id").
+		Select("document_feedbacks.*, nodes.name AS node_name").
+		Order("document_feedbacks.created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, count, nil
+}
+
+func (r *DocumentFeedbackRepository) UpdateStatus(ctx context.Context, kbID string, ids []int64, status domain.DocumentFeedbackStatus, userID string) error {
+	updateMap := map[string]any{
+		"status":      status,
+		"resolved_by": "",
+		"resolved_at": nil,
+		"updated_at":  time.Now(),
+	}
+	if status == domain.DocumentFeedbackStatusResolved {
+		updateMap["resolved_by"] = userID
+		updateMap["resolved_at"] = time.Now()
+	} else {
+		updateMap["resolved_release_id"] = ""
+	}
+	return r.db.WithContext(ctx).
+		Model(&domain.DocumentFeedback{}).
+		Where("kb_id = ?", kbID).
+		Where("id IN ?", ids).
+		Updates(updateMap).Error
+}
+
+// GetNodeStats counts the feedback since the given time by node, the least helpful docs first
+func (r *DocumentFeedbackRepository) GetNodeStats(ctx context.Context, kbID string, since time.Time, limit int) ([]*statV1.StatNodeFeedbackItem, error) {
+	items := make([]*statV1.StatNodeFeedbackItem, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.DocumentFeedback{}).
+		Select(`node_id,
+			COUNT(*) FILTER (WHERE score = ?) AS helpful,
+			COUNT(*) FILTER (WHERE score = ?) AS not_helpful,
+			COUNT(*) FILTER (WHERE score = ? AND status = ?) AS open_count`,
+			domain.Like, domain.DisLike, domain.DisLike, domain.DocumentFeedbackStatusOpen).
+		Where("kb_id = ?", kbID).
+		Where("created_at >= ?", since).
+		Where("score != 0").
+		Group("node_id").
+		Order("not_helpful DESC, helpful DESC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	nodeIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		nodeIDs = append(nodeIDs, item.NodeID)
+	}
+	var reasons []struct {
+		NodeID string
+		Reason string
+		Count  int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.DocumentFeedback{}).
+		Select("node_id, reason, COUNT(*) AS count").
+		Where("kb_id = ?", kbID).
+		Where("created_at >= ?", since).
+		Where("node_id IN ?", nodeIDs).
+		Where("score = ?", domain.DisLike).
+		Where("reason != ''").
+		Group("node_id, reason").
+		Find(&reasons).Error; err != nil {
+		return nil, err
+	}
+	reasonMap := make(map[string]domain.MapStrInt64)
+	for _, reason := range reasons {
+		if reasonMap[reason.NodeID] == nil {
+			reasonMap[reason.NodeID] = make(domain.MapStrInt64)
+		}
+		reasonMap[reason.NodeID][reason.Reason] = reason.Count
+	}
+	for _, item := range items {
+		item.Reasons = reasonMap[item.NodeID]
+		if item.Reasons == nil {
+			item.Reasons = make(domain.MapStrInt64)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,128 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type GitSourceRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewGitSourceRepository(db *pg.DB, logger *log.Logger) *GitSourceRepository {
+	return &GitSourceRepository{db: db, logger: logger.WithModule("repo.pg.git_source")}
+}
+
+func (r *GitSourceRepository) Create(ctx context.Context, source *domain.KBGitSource) error {
+	return r.db.WithContext(ctx).Create(source).Error
+}
+
+func (r *GitSourceRepository) Update(ctx context.Context, id string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBGitSource{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+// StartSync marks the source as running, it reports false when a sync is already running.
+// A sync which is running since before staleBefore is taken over
+func (r *GitSourceRepository) StartSync(ctx context.Context, kbID, id string, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.KBGitSource{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		Where("(status != ? OR updated_at < ?)", domain.GitSyncStatusRunning, staleBefore).
+		Updates(map[string]any{
+			"status":     domain.GitSyncStatusRunning,
+			"error":      "",
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GitSourceRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBGitSource, error) {
+	var source *domain.KBGitSource
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBGitSource{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&source).Error; err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (r *GitSourceRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBGitSource, error) {
+	sources := make([]*domain.KBGitSource, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBGitSource{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&sources).Error; err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// Delete removes the source and its path mapping, synced nodes are kept
+func (r *GitSourceRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBGitSourceNode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBGitSource{}).Error
+	})
+}
+
+// FailStale marks the syncs which are running since before staleBefore as failed
+func (r *GitSourceRepository) FailStale(ctx context.Context, staleBefore time.Time, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBGitSource{}).
+		Where("status = ?", domain.GitSyncStatusRunning).
+		Where("updated_at < ?", staleBefore).
+		Updates(map[string]any{
+			"status":     domain.GitSyncStatusFailed,
+			"error":      reason,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *GitSourceRepository) GetSourceNodes(ctx context.Context, sourceID string) ([]*domain.KBGitSourceNode, error) {
+	nodes := make([]*domain.KBGitSourceNode, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBGitSourceNode{}).
+		Where("source_id = ?", sourceID).
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (r *GitSourceRepository) SaveSourceNode(ctx context.Context, node *domain.KBGitSourceNode) error {
+	return r.db.WithContext(ctx).Save(node).Error
+}
+
+func (r *GitSourceRepository) DeleteSourceNodes(ctx context.Context, sourceID string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("source_id = ?", sourceID).
+		Where("path IN ?", paths).
+		Delete(&domain.KBGitSourceNode{}).Error
+}
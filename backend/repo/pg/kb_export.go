@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type KBExportRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewKBExportRepository(db *pg.DB, logger *log.Logger) *KBExportRepository {
+	return &KBExportRepository{db: db, logger: logger.WithModule("repo.pg.kb_export")}
+}
+
+func (r *KBExportRepository) Create(ctx context.Context, export *domain.KBExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+func (r *KBExportRepository) Update(ctx context.Context, id string, updateMap map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBExport{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *KBExportRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBExport, error) {
+	var export *domain.KBExport
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBExport{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&export).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (r *KBExportRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBExport, error) {
+	exports := make([]*domain.KBExport, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBExport{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *KBExportRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		Delete(&domain.KBExport{}).Error
+}
+
+// FailStale marks the exports which are unfinished and created before staleBefore as failed
+func (r *KBExportRepository) FailStale(ctx context.Context, staleBefore time.Time, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBExport{}).
+		Where("status IN (?)", []domain.KBExportStatus{domain.KBExportStatusPending, domain.KBExportStatusRunning}).
+		Where("created_at < ?", staleBefore).
+		Updates(map[string]any{
+			"status":      domain.KBExportStatusFailed,
+			"error":       reason,
+			"finished_at": time.Now(),
+		}).Error
+}
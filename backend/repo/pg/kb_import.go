@@ -0,0 +1,67 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type KBImportRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewKBImportRepository(db *pg.DB, logger *log.Logger) *KBImportRepository {
+	return &KBImportRepository{db: db, logger: logger.WithModule("repo.pg.kb_import")}
+}
+
+func (r *KBImportRepository) Create(ctx context.Context, kbImport *domain.KBImport) error {
+	return r.db.WithContext(ctx).Create(kbImport).Error
+}
+
+func (r *KBImportRepository) Update(ctx context.Context, id string, updateMap map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBImport{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *KBImportRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBImport, error) {
+	var kbImport *domain.KBImport
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBImport{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&kbImport).Error; err != nil {
+		return nil, err
+	}
+	return kbImport, nil
+}
+
+func (r *KBImportRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBImport, error) {
+	imports := make([]*domain.KBImport, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBImport{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Find(&imports).Error; err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+// FailStale marks the imports which are unfinished and created before staleBefore as failed
+func (r *KBImportRepository) FailStale(ctx context.Context, staleBefore time.Time, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBImport{}).
+		Where("status IN (?)", []domain.KBExportStatus{domain.KBExportStatusPending, domain.KBExportStatusRunning}).
+		Where("created_at < ?", staleBefore).
+		Updates(map[string]any{
+			"status":      domain.KBExportStatusFailed,
+			"error":       reason,
+			"finished_at": time.Now(),
+		}).Error
+}
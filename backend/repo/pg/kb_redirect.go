@@ -0,0 +1,166 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type KBRedirectRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewKBRedirectRepository(db *pg.DB, logger *log.Logger) *KBRedirectRepository {
+	return &KBRedirectRepository{db: db, logger: logger.WithModule("repo.pg.kb_redirect")}
+}
+
+func (r *KBRedirectRepository) GetList(ctx context.Context, kbID string) ([]*v1.KBRedirectItem, error) {
+	items := make([]*v1.KBRedirectItem, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBRedirect{}).
+		Select("kb_redirects.*, COALESCE(nodes.name, '') AS target_node_name").
+		Joins("LEFT JOIN nodes ON nodes.id = kb_redirects.target_node_id AND kb_redirects.target_node_id != ''").
+		Where("kb_redirects.kb_id = ?", kbID).
+		Order("kb_redirects.created_at DESC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *KBRedirectRepository) Create(ctx context.Context, redirect *domain.KBRedirect) error {
+	return r.db.WithContext(ctx).Create(redirect).Error
+}
+
+func (r *KBRedirectRepository) Update(ctx context.Context, kbID string, id int64, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBRedirect{}).
+		Where("kb_id = ?", kbID).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *KBRedirectRepository) Delete(ctx context.Context, kbID string, id int64) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("id = ?", id).
+		Delete(&domain.KBRedirect{}).Error
+}
+
+// Upsert points the redirects of existing source paths to the new targets
+func (r *KBRedirectRepository) Upsert(ctx context.Context, redirects []*domain.KBRedirect) error {
+	if len(redirects) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "kb_id"}, {Name: "source_path"}},
+			DoUpdates: clause.AssignmentColumns([]string{"target_node_id", "target_url", "permanent", "updated_at"}),
+		}).
+		Create(&redirects).Error
+}
+
+func (r *KBRedirectRepository) DeleteBySourcePaths(ctx context.Context, kbID string, paths []string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("source_path IN ?", paths).
+		Delete(&domain.KBRedirect{}).Error
+}
+
+// GetBySourcePath returns the redirect of the path, nil if there is none
+func (r *KBRedirectRepository) GetBySourcePath(ctx context.Context, kbID, path string) (*domain.KBRedirect, error) {
+	var redirect domain.KBRedirect
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("source_path = ?", path).
+		First(&redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+func (r *KBRedirectRepository) Hit(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.KBRedirect{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"hits":        gorm.Expr("hits + 1"),
+			"last_hit_at": time.Now(),
+		}).Error
+}
+
+func (r *KBRedirectRepository) GetSlug(ctx context.Context, kbID, nodeID string) (string, error) {
+	var slugs []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeSlug{}).
+		Where("kb_id = ?", kbID).
+		Where("node_id = ?", nodeID).
+		Pluck("slug", &slugs).Error; err != nil {
+		return "", err
+	}
+	if len(slugs) == 0 {
+		return "", nil
+	}
+	return slugs[0], nil
+}
+
+// GetSlugs returns node id -> slug of the kb
+func (r *KBRedirectRepository) GetSlugs(ctx context.Context, kbID string) (map[string]string, error) {
+	var nodeSlugs []*domain.NodeSlug
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeSlug{}).
+		Where("kb_id = ?", kbID).
+		Find(&nodeSlugs).Error; err != nil {
+		return nil, err
+	}
+	slugs := make(map[string]string, len(nodeSlugs))
+	for _, nodeSlug := range nodeSlugs {
+		slugs[nodeSlug.NodeID] = nodeSlug.Slug
+	}
+	return slugs, nil
+}
+
+// GetNodeIDBySlug returns the node of the slug, empty if there is none
+func (r *KBRedirectRepository) GetNodeIDBySlug(ctx context.Context, kbID, slug string) (string, error) {
+	var nodeIDs []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeSlug{}).
+		Where("kb_id = ?", kbID).
+		Where("slug = ?", slug).
+		Pluck("node_id", &nodeIDs).Error; err != nil {
+		return "", err
+	}
+	if len(nodeIDs) == 0 {
+		return "", nil
+	}
+	return nodeIDs[0], nil
+}
+
+func (r *KBRedirectRepository) UpsertSlug(ctx context.Context, nodeSlug *domain.NodeSlug) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "node_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"slug", "updated_at"}),
+		}).
+		Create(nodeSlug).Error
+}
+
+func (r *KBRedirectRepository) DeleteSlug(ctx context.Context, kbID, nodeID string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("node_id = ?", nodeID).
+		Delete(&domain.NodeSlug{}).Error
+}
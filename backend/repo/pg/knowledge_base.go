@@ -169,7 +169,7 @@ func (r *KnowledgeBaseRepository) SyncKBAccessSettingsToCaddy(ctx context.Contex
 							{
 								"match": []map[string]any{
 									{
-										"path": []string{"/share/v1/chat/completions", "/share/v1/app/wechat/app", "/share/v1/app/wechat/service", "/sitemap.xml", "/share/v1/app/wechat/official_account", "/share/v1/app/wechat/service/answer", "/mcp"},
+										"path": []string{"/share/v1/chat/completions", "/share/v1/app/wechat/app", "/share/v1/app/wechat/service", "/sitemap.xml", "/feed.xml", "/feed.json", "/llms.txt", "/llms-full.txt", "/node/*.md", "/share/v1/app/wechat/official_account", "/share/v1/app/wechat/service/answer", "/mcp"},
 									},
 								},
 								"handle": []map[string]any{
@@ -569,6 +569,72 @@ func (r *KnowledgeBaseRepository) DeleteKnowledgeBase(ctx context.Context, kbID
 		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.App{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeTemplate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeLink{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeLintIssue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeSEO{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.DocumentFeedback{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeSlug{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBRedirect{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBSearchQuery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeField{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.NodeTrash{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBExport{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBGitSourceNode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBGitSource{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBCrawlerSyncRun{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBCrawlerSyncDoc{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBCrawlerSync{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBOpenAPISpecNode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBOpenAPISpec{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBWebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBWebhook{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("kb_id = ?", kbID).Delete(&domain.KBNodePush{}).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("id = ?", kbID).Delete(&domain.KnowledgeBase{}).Error; err != nil {
 			return err
 		}
@@ -641,6 +707,18 @@ func (r *KnowledgeBaseRepository) GetKBReleaseList(ctx context.Context, kbID str
 	return total, releases, nil
 }
 
+func (r *KnowledgeBaseRepository) GetRecentReleases(ctx context.Context, kbID string, limit int) ([]*domain.KBRelease, error) {
+	releases := make([]*domain.KBRelease, 0)
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
 func (r *KnowledgeBaseRepository) GetLatestRelease(ctx context.Context, kbID string) (*domain.KBRelease, error) {
 	var release domain.KBRelease
 	if err := r.db.WithContext(ctx).
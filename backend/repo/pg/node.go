@@ -2,8 +2,11 @@ package pg
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,6 +24,7 @@ import (
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/store/pg"
+	"github.com/chaitin/panda-wiki/utils"
 )
 
 type NodeRepository struct {
@@ -33,12 +37,15 @@ func NewNodeRepository(db *pg.DB, logger *log.Logger) *NodeRepository {
 }
 
 func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq, userId string) (string, error) {
-	nodeID, err := uuid.NewV7()
-	if err != nil {
-		return "", err
+	nodeIDStr := req.ID
+	if nodeIDStr == "" {
+		nodeID, err := uuid.NewV7()
+		if err != nil {
+			return "", err
+		}
+		nodeIDStr = nodeID.String()
 	}
-	nodeIDStr := nodeID.String()
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// check count
 		var count int64
 		if err := tx.Model(&domain.Node{}).
@@ -82,7 +89,7 @@ func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq,
 		}
 
 		now := time.Now()
-		meta := domain.NodeMeta{Emoji: req.Emoji}
+		meta := domain.NodeMeta{Emoji: req.Emoji, Tags: req.Tags, Fields: req.Fields}
 		if req.Summary != nil {
 			meta.Summary = *req.Summary
 		}
@@ -114,6 +121,7 @@ func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq,
 				Visitable:  consts.NodeAccessPermOpen,
 				Visible:    consts.NodeAccessPermOpen,
 			},
+			Version: 1,
 		}
 
 		return tx.Create(node).Error
@@ -127,15 +135,33 @@ func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq,
 
 func (r *NodeRepository) GetList(ctx context.Context, req *domain.GetNodeListReq) ([]*domain.NodeListItemResp, error) {
 	var nodes []*domain.NodeListItemResp
+	columns := "cu.account AS creator, eu.account AS editor, nodes.editor_id, nodes.rag_info, nodes.creator_id, nodes.id, nodes.permissions, nodes.type, nodes.status, nodes.name, nodes.parent_id, nodes.position, nodes.created_at, nodes.edit_time as updated_at, nodes.meta->>'summary' as summary, nodes.meta->>'emoji' as emoji, nodes.meta->>'content_type' as content_type, nodes.meta->'tags' as tags, nodes.meta->'fields' as fields"
+	terms := utils.ParseSearchTerms(req.Search)
+	if len(terms) > 0 {
+		// content is used for the matched snippets
+		columns += ", nodes.content"
+	}
 	query := r.db.WithContext(ctx).
 		Model(&domain.Node{}).
 		Joins("LEFT JOIN users cu ON nodes.creator_id = cu.id").
 		Joins("LEFT JOIN users eu ON nodes.editor_id = eu.id").
 		Where("nodes.kb_id = ?", req.KBID).
-		Select("cu.account AS creator, eu.account AS editor, nodes.editor_id, nodes.rag_info, nodes.creator_id, nodes.id, nodes.permissions, nodes.type, nodes.status, nodes.name, nodes.parent_id, nodes.position, nodes.created_at, nodes.edit_time as updated_at, nodes.meta->>'summary' as summary, nodes.meta->>'emoji' as emoji, nodes.meta->>'content_type' as content_type")
-	if req.Search != "" {
-		searchPattern := "%" + req.Search + "%"
-		query = query.Where("name LIKE ? OR content LIKE ?", searchPattern, searchPattern)
+		Select(columns)
+	if len(terms) > 0 {
+		query = applySearchTerms(query, terms, "nodes.name", "nodes.content")
+	}
+	filter := &domain.NodeMetaFilter{Tags: req.Tags}
+	for _, field := range req.Fields {
+		if key, value, ok := strings.Cut(field, ":"); ok {
+			if filter.Fields == nil {
+				filter.Fields = make(map[string]string)
+			}
+			filter.Fields[key] = value
+		}
+	}
+	query, err := applyNodeMetaFilter(query, "nodes", filter)
+	if err != nil {
+		return nil, err
 	}
 	if err := query.Find(&nodes).Error; err != nil {
 		return nil, err
@@ -143,6 +169,94 @@ func (r *NodeRepository) GetList(ctx context.Context, req *domain.GetNodeListReq
 	return nodes, nil
 }
 
+// applySearchTerms requires every term to be contained in one of the columns, case-insensitive
+func applySearchTerms(query *gorm.DB, terms []string, columns ...string) *gorm.DB {
+	for _, term := range terms {
+		pattern := "%" + utils.EscapeLikePattern(term) + "%"
+		conds := make([]string, 0, len(columns))
+		args := make([]any, 0, len(columns))
+		for _, column := range columns {
+			conds = append(conds, column+" ILIKE ?")
+			args = append(args, pattern)
+		}
+		query = query.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+	return query
+}
+
+// searchOrderBy orders rows by the number of terms matched in the name column, then by orderBy
+func searchOrderBy(terms []string, column, orderBy string) clause.OrderBy {
+	conds := make([]string, 0, len(terms))
+	vars := make([]any, 0, len(terms))
+	for _, term := range terms {
+		conds = append(conds, "(CASE WHEN "+column+" ILIKE ? THEN 1 ELSE 0 END)")
+		vars = append(vars, "%"+utils.EscapeLikePattern(term)+"%")
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:  "(" + strings.Join(conds, " + ") + ") DESC, " + orderBy,
+		Vars: vars,
+	}}
+}
+
+// applyNodeMetaFilter adds conditions on tags and custom fields of the meta column of table
+func applyNodeMetaFilter(query *gorm.DB, table string, filter *domain.NodeMetaFilter) (*gorm.DB, error) {
+	if filter.IsEmpty() {
+		return query, nil
+	}
+	if len(filter.Tags) > 0 {
+		tags, err := json.Marshal(filter.Tags)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(table+".meta->'tags' @> ?::jsonb", string(tags))
+	}
+	if len(filter.Fields) > 0 {
+		fields, err := json.Marshal(filter.Fields)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(table+".meta->'fields' @> ?::jsonb", string(fields))
+	}
+	return query, nil
+}
+
+// GetTagsByKBID returns all tags used by nodes of the kb with the node count
+func (r *NodeRepository) GetTagsByKBID(ctx context.Context, kbID string) ([]*domain.NodeTagCount, error) {
+	tags := make([]*domain.NodeTagCount, 0)
+	if err := r.db.WithContext(ctx).
+		Table("nodes, jsonb_array_elements_text(COALESCE(nodes.meta->'tags', '[]'::jsonb)) AS tag").
+		Select("tag, COUNT(*) AS count").
+		Where("nodes.kb_id = ?", kbID).
+		Group("tag").
+		Order("count DESC, tag ASC").
+		Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetNodeReleaseDocIDsByMeta returns rag doc ids of the latest releases matching the filter in the given datasets
+func (r *NodeRepository) GetNodeReleaseDocIDsByMeta(ctx context.Context, datasetIDs []string, filter *domain.NodeMetaFilter) ([]string, error) {
+	latest := r.db.WithContext(ctx).
+		Model(&domain.NodeRelease{}).
+		Select("DISTINCT ON (node_releases.node_id) node_releases.doc_id, node_releases.meta").
+		Joins("JOIN knowledge_bases ON knowledge_bases.id = node_releases.kb_id").
+		Where("knowledge_bases.dataset_id IN ?", datasetIDs).
+		Order("node_releases.node_id, node_releases.updated_at DESC")
+
+	query, err := applyNodeMetaFilter(r.db.WithContext(ctx).Table("(?) AS node_releases", latest), "node_releases", filter)
+	if err != nil {
+		return nil, err
+	}
+	var docIDs []string
+	if err := query.
+		Where("node_releases.doc_id != ''").
+		Pluck("node_releases.doc_id", &docIDs).Error; err != nil {
+		return nil, err
+	}
+	return docIDs, nil
+}
+
 func (r *NodeRepository) GetLatestNodeReleaseByNodeIDs(ctx context.Context, kbID string, ids []string) ([]*domain.NodeRelease, error) {
 	var nodeReleases []*domain.NodeRelease
 	if err := r.db.WithContext(ctx).
@@ -183,7 +297,10 @@ func (r *NodeRepository) GetNodeReleasePublisherMap(ctx context.Context, kbID st
 	return publisherMap, nil
 }
 
-func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.UpdateNodeReq, userId string) error {
+// UpdateNodeContent updates node fields and returns the node version after update.
+// If req.Version is set and stale, domain.ErrNodeVersionConflict is returned.
+func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.UpdateNodeReq, userId string) (int64, error) {
+	var version int64
 	// Use transaction to ensure data consistency
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Get current node data with row-level lock
@@ -196,9 +313,16 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 			First(&currentNode).Error; err != nil {
 			return err
 		}
+		version = currentNode.Version
+
+		// optimistic concurrency: editor must start from the latest version
+		if req.Version != nil && (req.Name != nil || req.Content != nil) && *req.Version != currentNode.Version {
+			return domain.ErrNodeVersionConflict
+		}
 
 		updateMap := make(map[string]any)
 		updateStatus := false
+		updateVersion := false
 
 		updateMap["editor_id"] = userId
 
@@ -206,12 +330,16 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 		if req.Name != nil && *req.Name != currentNode.Name {
 			updateMap["name"] = *req.Name
 			updateStatus = true
+			updateVersion = true
 		}
 
 		// Compare and update Content
+		contentUpdated := false
 		if req.Content != nil && *req.Content != currentNode.Content {
 			updateMap["content"] = *req.Content
 			updateStatus = true
+			updateVersion = true
+			contentUpdated = true
 		}
 
 		if req.Position != nil && *req.Position != currentNode.Position { // user specify position
@@ -223,7 +351,7 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 		}
 
 		// Handle multiple meta field updates
-		if req.Emoji != nil || req.Summary != nil || req.ContentType != nil {
+		if req.Emoji != nil || req.Summary != nil || req.ContentType != nil || req.Tags != nil || req.Fields != nil {
 			metaExpr := "meta"
 			var args []any
 			metaUpdated := false
@@ -254,6 +382,28 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 				}
 			}
 
+			// Compare and update Tags
+			if req.Tags != nil && !slices.Equal(*req.Tags, []string(currentNode.Meta.Tags)) {
+				tags, err := json.Marshal(*req.Tags)
+				if err != nil {
+					return err
+				}
+				metaExpr = "jsonb_set(" + metaExpr + ", '{tags}', ?::jsonb)"
+				args = append(args, string(tags))
+				metaUpdated = true
+			}
+
+			// Compare and update custom Fields
+			if req.Fields != nil && !maps.Equal(*req.Fields, map[string]string(currentNode.Meta.Fields)) {
+				fields, err := json.Marshal(*req.Fields)
+				if err != nil {
+					return err
+				}
+				metaExpr = "jsonb_set(" + metaExpr + ", '{fields}', ?::jsonb)"
+				args = append(args, string(fields))
+				metaUpdated = true
+			}
+
 			if metaUpdated {
 				updateMap["meta"] = gorm.Expr(metaExpr, args...)
 				updateStatus = true
@@ -266,6 +416,17 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 			updateMap["edit_time"] = time.Now()
 		}
 
+		if updateVersion {
+			version = currentNode.Version + 1
+			updateMap["version"] = version
+		}
+
+		if contentUpdated {
+			if err := r.saveNodeVersions(tx, &currentNode, version, *req.Content, userId); err != nil {
+				return err
+			}
+		}
+
 		// Perform update if there are changes
 		if len(updateMap) > 0 {
 			// Use the transaction's DB instance for the update
@@ -278,7 +439,56 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 	})
 
 	// Return any error from the transaction
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// maxNodeVersionsKept 每个文档保留的历史内容版本数量，用于冲突时三方合并
+const maxNodeVersionsKept = 50
+
+// saveNodeVersions keeps the content before and after update as merge bases
+func (r *NodeRepository) saveNodeVersions(tx *gorm.DB, current *domain.Node, newVersion int64, newContent, userId string) error {
+	now := time.Now()
+	versions := []*domain.NodeVersion{
+		{
+			KBID:      current.KBID,
+			NodeID:    current.ID,
+			Version:   current.Version,
+			Content:   current.Content,
+			EditorId:  current.EditorId,
+			CreatedAt: now,
+		},
+		{
+			KBID:      current.KBID,
+			NodeID:    current.ID,
+			Version:   newVersion,
+			Content:   newContent,
+			EditorId:  userId,
+			CreatedAt: now,
+		},
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&versions).Error; err != nil {
+		return err
+	}
+	return tx.Where("node_id = ?", current.ID).
+		Where("version <= ?", newVersion-maxNodeVersionsKept).
+		Delete(&domain.NodeVersion{}).Error
+}
+
+// GetNodeVersionContent returns the latest saved content at or before the given version
+func (r *NodeRepository) GetNodeVersionContent(ctx context.Context, nodeID string, version int64) (*domain.NodeVersion, error) {
+	var nodeVersion *domain.NodeVersion
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeVersion{}).
+		Where("node_id = ?", nodeID).
+		Where("version <= ?", version).
+		Order("version DESC").
+		First(&nodeVersion).Error; err != nil {
+		return nil, err
+	}
+	return nodeVersion, nil
 }
 
 func (r *NodeRepository) GetByID(ctx context.Context, id, kbId string) (*v1.NodeDetailResp, error) {
@@ -296,9 +506,10 @@ func (r *NodeRepository) GetByID(ctx context.Context, id, kbId string) (*v1.Node
 	return node, nil
 }
 
-func (r *NodeRepository) Delete(ctx context.Context, kbID string, ids []string) ([]string, error) {
-	docIDs := make([]string, 0)
-	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+// Delete moves the nodes and all of their children to the trash.
+// Node releases are kept in the trash snapshot, so their rag documents stay valid until purged.
+func (r *NodeRepository) Delete(ctx context.Context, kbID string, ids []string, userId string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// recursively collect all child node IDs
 		allIDs := r.collectAllChildNodeIDs(tx, kbID, ids)
 
@@ -306,35 +517,264 @@ func (r *NodeRepository) Delete(ctx context.Context, kbID string, ids []string)
 		if err := tx.Model(&domain.Node{}).
 			Where("id IN ?", allIDs).
 			Where("kb_id = ?", kbID).
-			Clauses(clause.Returning{Columns: []clause.Column{{Name: "doc_id"}}}).
+			Clauses(clause.Returning{}).
 			Delete(&nodes).Error; err != nil {
 			return err
 		}
-		// delete node release
+		if len(nodes) == 0 {
+			return nil
+		}
 		var nodeReleases []*domain.NodeRelease
 		if err := tx.Model(&domain.NodeRelease{}).
 			Where("node_id IN ?", allIDs).
-			Clauses(clause.Returning{Columns: []clause.Column{{Name: "doc_id"}}}).
+			Clauses(clause.Returning{}).
 			Delete(&nodeReleases).Error; err != nil {
 			return err
 		}
+		// delete outbound links, inbound links are kept for broken link report
+		if err := tx.Where("node_id IN ?", allIDs).
+			Delete(&domain.NodeLink{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("node_id IN ?", allIDs).
+			Delete(&domain.NodeLintIssue{}).Error; err != nil {
+			return err
+		}
+
+		nodeMap := lo.SliceToMap(nodes, func(node *domain.Node) (string, *domain.Node) {
+			return node.ID, node
+		})
+		releaseMap := lo.GroupBy(nodeReleases, func(release *domain.NodeRelease) string {
+			return release.NodeID
+		})
+		now := time.Now()
+		trash := make([]*domain.NodeTrash, 0, len(nodes))
 		for _, node := range nodes {
-			if node.DocID != "" {
-				docIDs = append(docIDs, node.DocID)
+			// the root is the top most ancestor deleted in this operation
+			root := node
+			for parent, ok := nodeMap[root.ParentID]; ok; parent, ok = nodeMap[root.ParentID] {
+				root = parent
+			}
+			trash = append(trash, &domain.NodeTrash{
+				NodeID:   node.ID,
+				KBID:     kbID,
+				RootID:   root.ID,
+				Name:     node.Name,
+				Type:     node.Type,
+				ParentID: node.ParentID,
+				Snapshot: domain.NodeTrashSnapshot{
+					Node:     node,
+					Releases: releaseMap[node.ID],
+				},
+				DeletedBy: userId,
+				DeletedAt: now,
+			})
+		}
+		return tx.CreateInBatches(&trash, 100).Error
+	})
+}
+
+func (r *NodeRepository) GetTrashList(ctx context.Context, kbID string) ([]*v1.NodeTrashListItem, error) {
+	items := make([]*v1.NodeTrashListItem, 0)
+	if err := r.db.WithContext(ctx).
+		Table("node_trash AS t").
+		Select(`t.node_id AS id, t.name, t.type, t.snapshot->'node'->'meta'->>'emoji' AS emoji,
+			t.parent_id, COALESCE(p.name, '') AS parent_name,
+			(SELECT COUNT(*) - 1 FROM node_trash c WHERE c.root_id = t.node_id) AS child_count,
+			t.deleted_by, COALESCE(u.account, '') AS deleted_by_account, t.deleted_at`).
+		Joins("LEFT JOIN nodes p ON p.id = t.parent_id").
+		Joins("LEFT JOIN users u ON u.id = t.deleted_by").
+		Where("t.kb_id = ?", kbID).
+		Where("t.node_id = t.root_id").
+		Order("t.deleted_at DESC").
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// RestoreTrash moves the trashed nodes of the given roots back, a root whose parent is gone is restored to the kb root
+func (r *NodeRepository) RestoreTrash(ctx context.Context, kbID string, rootIDs []string, maxNode int) ([]*domain.Node, []*domain.NodeRelease, error) {
+	nodes := make([]*domain.Node, 0)
+	nodeReleases := make([]*domain.NodeRelease, 0)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var trash []*domain.NodeTrash
+		if err := tx.Model(&domain.NodeTrash{}).
+			Where("kb_id = ?", kbID).
+			Where("root_id IN ?", rootIDs).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Find(&trash).Error; err != nil {
+			return err
+		}
+		if len(trash) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var count int64
+		if err := tx.Model(&domain.Node{}).
+			Where("kb_id = ?", kbID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count+int64(len(trash)) > int64(maxNode) {
+			return domain.ErrMaxNodeLimitReached
+		}
+
+		restoredIDs := lo.Map(trash, func(item *domain.NodeTrash, _ int) string {
+			return item.NodeID
+		})
+		// the id may have been taken while the node was in the trash, e.g. by an import preserving ids
+		var usedIDs []string
+		if err := tx.Model(&domain.Node{}).
+			Where("id IN ?", restoredIDs).
+			Pluck("id", &usedIDs).Error; err != nil {
+			return err
+		}
+		if len(usedIDs) > 0 {
+			return fmt.Errorf("%w: %s", domain.ErrNodeTrashIDInUse, strings.Join(usedIDs, ", "))
+		}
+		parentIDs := lo.Uniq(lo.FilterMap(trash, func(item *domain.NodeTrash, _ int) (string, bool) {
+			return item.ParentID, item.NodeID == item.RootID && item.ParentID != ""
+		}))
+		var existParentIDs []string
+		if len(parentIDs) > 0 {
+			if err := tx.Model(&domain.Node{}).
+				Where("kb_id = ?", kbID).
+				Where("id IN ?", parentIDs).
+				Pluck("id", &existParentIDs).Error; err != nil {
+				return err
 			}
 		}
-		for _, nodeRelease := range nodeReleases {
-			if nodeRelease.DocID != "" {
-				docIDs = append(docIDs, nodeRelease.DocID)
+
+		for _, item := range trash {
+			node := item.Snapshot.Node
+			if node == nil {
+				continue
 			}
+			if item.NodeID == item.RootID && node.ParentID != "" &&
+				!slices.Contains(existParentIDs, node.ParentID) && !slices.Contains(restoredIDs, node.ParentID) {
+				node.ParentID = ""
+			}
+			nodes = append(nodes, node)
+			nodeReleases = append(nodeReleases, item.Snapshot.Releases...)
 		}
-		return nil
+		if len(nodes) > 0 {
+			if err := tx.CreateInBatches(&nodes, 100).Error; err != nil {
+				return err
+			}
+		}
+		if len(nodeReleases) > 0 {
+			if err := tx.CreateInBatches(&nodeReleases, 100).Error; err != nil {
+				return err
+			}
+		}
+		// restored nodes take their urls back from the redirects added on deletion
+		var slugs []string
+		if err := tx.Model(&domain.NodeSlug{}).
+			Where("node_id IN ?", restoredIDs).
+			Pluck("slug", &slugs).Error; err != nil {
+			return err
+		}
+		sourcePaths := lo.Map(restoredIDs, func(id string, _ int) string {
+			return domain.NodePath(id, "")
+		})
+		for _, slug := range slugs {
+			sourcePaths = append(sourcePaths, domain.NodeSlugPath(slug))
+		}
+		if err := tx.Where("kb_id = ?", kbID).
+			Where("source_path IN ?", sourcePaths).
+			Delete(&domain.KBRedirect{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("kb_id = ?", kbID).
+			Where("node_id IN ?", restoredIDs).
+			Delete(&domain.NodeTrash{}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, nodeReleases, nil
+}
+
+// PurgeTrash permanently deletes the trashed nodes of the given roots and returns their rag doc ids
+func (r *NodeRepository) PurgeTrash(ctx context.Context, kbID string, rootIDs []string) ([]string, error) {
+	docIDs := make([]string, 0)
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var trash []*domain.NodeTrash
+		if err := tx.Model(&domain.NodeTrash{}).
+			Where("kb_id = ?", kbID).
+			Where("root_id IN ?", rootIDs).
+			Clauses(clause.Returning{}).
+			Delete(&trash).Error; err != nil {
+			return err
+		}
+		if len(trash) == 0 {
+			return nil
+		}
+		nodeIDs := make([]string, 0, len(trash))
+		for _, item := range trash {
+			nodeIDs = append(nodeIDs, item.NodeID)
+			docIDs = append(docIDs, item.Snapshot.DocIDs()...)
+		}
+		// delete content versions, seo overrides, slugs, redirects to the nodes, page feedback, translation links
+		// and the mappings of synced sources, translations of a purged source are kept as plain nodes
+		if err := tx.Where("node_id IN ?", nodeIDs).
+			Delete(&domain.NodeVersion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("node_id IN ? OR source_node_id IN ?", nodeIDs, nodeIDs).
+			Delete(&domain.NodeTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("node_id IN ?", nodeIDs).
+			Delete(&domain.DocumentFeedback{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("node_id IN ?", nodeIDs).
+			Delete(&domain.NodeSlug{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_node_id IN ?", nodeIDs).
+			Delete(&domain.KBRedirect{}).Error; err != nil {
+			return err
+		}
+		for _, mapping := range []any{&domain.KBGitSourceNode{}, &domain.KBCrawlerSyncDoc{}, &domain.KBOpenAPISpecNode{}, &domain.KBNodePush{}} {
+			if err := tx.Where("node_id IN ?", nodeIDs).
+				Delete(mapping).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("node_id IN ?", nodeIDs).
+			Delete(&domain.NodeSEO{}).Error
 	}); err != nil {
 		return nil, err
 	}
 	return lo.Uniq(docIDs), nil
 }
 
+// GetExpiredTrashRootIDs returns root ids of trash deleted before the given time grouped by kb id
+func (r *NodeRepository) GetExpiredTrashRootIDs(ctx context.Context, before time.Time) (map[string][]string, error) {
+	var trash []*domain.NodeTrash
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTrash{}).
+		Select("kb_id, root_id").
+		Where("node_id = root_id").
+		Where("deleted_at < ?", before).
+		Find(&trash).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string)
+	for _, item := range trash {
+		result[item.KBID] = append(result[item.KBID], item.RootID)
+	}
+	return result, nil
+}
+
+// GetAllChildNodeIDs returns the given node IDs together with all of their descendants
+func (r *NodeRepository) GetAllChildNodeIDs(ctx context.Context, kbID string, ids []string) []string {
+	return r.collectAllChildNodeIDs(r.db.WithContext(ctx), kbID, ids)
+}
+
 // collectAllChildNodeIDs recursively collects all child node IDs for the given parent IDs
 func (r *NodeRepository) collectAllChildNodeIDs(tx *gorm.DB, kbID string, parentIDs []string) []string {
 	allIDs := make([]string, 0)
@@ -362,6 +802,45 @@ func (r *NodeRepository) collectAllChildNodeIDs(tx *gorm.DB, kbID string, parent
 	return lo.Uniq(allIDs)
 }
 
+// GetTakenNodeIDs returns the ids already used by nodes or trashed nodes of any kb
+func (r *NodeRepository) GetTakenNodeIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	taken := make(map[string]bool)
+	if len(ids) == 0 {
+		return taken, nil
+	}
+	var nodeIDs []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Where("id IN (?)", ids).
+		Pluck("id", &nodeIDs).Error; err != nil {
+		return nil, err
+	}
+	var trashIDs []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTrash{}).
+		Where("node_id IN (?)", ids).
+		Pluck("node_id", &trashIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range append(nodeIDs, trashIDs...) {
+		taken[id] = true
+	}
+	return taken, nil
+}
+
+// GetNodesByKBID returns all nodes of a kb with content, ordered by position
+func (r *NodeRepository) GetNodesByKBID(ctx context.Context, kbID string) ([]*domain.Node, error) {
+	nodes := make([]*domain.Node, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Where("kb_id = ?", kbID).
+		Order("position ASC").
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
 func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
 	var node *domain.Node
 	if err := r.db.WithContext(ctx).
@@ -432,6 +911,59 @@ func (r *NodeRepository) GetNodeNameByNodeIDs(ctx context.Context, ids []string)
 	return nodesMap, nil
 }
 
+func (r *NodeRepository) GetNodesByIDs(ctx context.Context, kbID string, ids []string) ([]*domain.Node, error) {
+	nodes := make([]*domain.Node, 0, len(ids))
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Where("kb_id = ?", kbID).
+		Where("id IN ?", ids).
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetNodePublishedStates returns whether the existing nodes among ids have been published
+func (r *NodeRepository) GetNodePublishedStates(ctx context.Context, kbID string, ids []string) (map[string]bool, error) {
+	states := make(map[string]bool, len(ids))
+	for _, chunk := range lo.Chunk(ids, 1000) {
+		var rows []struct {
+			ID        string
+			Published bool
+		}
+		if err := r.db.WithContext(ctx).
+			Model(&domain.Node{}).
+			Select("id, EXISTS (SELECT 1 FROM node_releases r WHERE r.node_id = nodes.id) AS published").
+			Where("kb_id = ?", kbID).
+			Where("id IN ?", chunk).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			states[row.ID] = row.Published
+		}
+	}
+	return states, nil
+}
+
+// GetNodeBriefsByIDs returns id, name, type and parent_id of the nodes
+func (r *NodeRepository) GetNodeBriefsByIDs(ctx context.Context, kbID string, ids []string) ([]*domain.Node, error) {
+	nodes := make([]*domain.Node, 0, len(ids))
+	for _, chunk := range lo.Chunk(ids, 1000) {
+		var chunkNodes []*domain.Node
+		if err := r.db.WithContext(ctx).
+			Model(&domain.Node{}).
+			Where("id IN ?", chunk).
+			Where("kb_id = ?", kbID).
+			Select("id, name, type, parent_id").
+			Find(&chunkNodes).Error; err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, chunkNodes...)
+	}
+	return nodes, nil
+}
+
 func (r *NodeRepository) GetNodeReleaseByID(ctx context.Context, id string) (*domain.NodeRelease, error) {
 	var nodeRelease *domain.NodeRelease
 	if err := r.db.WithContext(ctx).
@@ -443,6 +975,17 @@ func (r *NodeRepository) GetNodeReleaseByID(ctx context.Context, id string) (*do
 	return nodeRelease, nil
 }
 
+func (r *NodeRepository) GetNodeReleasesByIDs(ctx context.Context, ids []string) ([]*domain.NodeRelease, error) {
+	var nodeReleases []*domain.NodeRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeRelease{}).
+		Where("id IN ?", ids).
+		Find(&nodeReleases).Error; err != nil {
+		return nil, err
+	}
+	return nodeReleases, nil
+}
+
 func (r *NodeRepository) GetLatestNodeReleaseByNodeID(ctx context.Context, nodeID string) (*domain.NodeRelease, error) {
 	var nodeRelease *domain.NodeRelease
 	if err := r.db.WithContext(ctx).
@@ -686,7 +1229,7 @@ func (r *NodeRepository) GetRecommendNodeListByParentIDs(ctx context.Context, kb
 	return nodesMap, nil
 }
 
-// GetNodeReleaseListByKBID get node list by kb id
+// GetNodeReleaseListByKBID get node list by kb id, translated nodes are excluded
 func (r *NodeRepository) GetNodeReleaseListByKBID(ctx context.Context, kbID string) ([]*domain.ShareNodeListItemResp, error) {
 	// get kb release
 	var kbRelease *domain.KBRelease
@@ -709,6 +1252,8 @@ func (r *NodeRepository) GetNodeReleaseListByKBID(ctx context.Context, kbID stri
 		Where("kb_release_node_releases.kb_id = ?", kbID).
 		Where("kb_release_node_releases.release_id = ?", kbRelease.ID).
 		Where("nodes.permissions->>'visible' != ?", consts.NodeAccessPermClosed).
+		// translations are shown in place of their source node
+		Where("NOT EXISTS (SELECT 1 FROM node_translations WHERE node_translations.node_id = kb_release_node_releases.node_id)").
 		Select("node_releases.node_id as id, node_releases.name, node_releases.type, node_releases.parent_id, nodes.position, node_releases.meta->>'emoji' as emoji, node_releases.updated_at, nodes.permissions, nodes.meta").
 		Find(&nodes).Error; err != nil {
 		return nil, err
@@ -716,6 +1261,196 @@ func (r *NodeRepository) GetNodeReleaseListByKBID(ctx context.Context, kbID stri
 	return nodes, nil
 }
 
+type NodeReleaseSearchParams struct {
+	KBID       string
+	Terms      []string
+	NodeIDs    []string // limits the search to these nodes when not nil
+	Tags       []string
+	PartialIDs []string // nodes with partial visitable permission the user can visit
+	Offset     int
+	Limit      int
+}
+
+type NodeReleaseSearchHit struct {
+	ID        string          `gorm:"column:id"`
+	Name      string          `gorm:"column:name"`
+	ParentID  string          `gorm:"column:parent_id"`
+	Content   string          `gorm:"column:content"`
+	Meta      domain.NodeMeta `gorm:"column:meta;type:jsonb"`
+	UpdatedAt time.Time       `gorm:"column:updated_at"`
+}
+
+// SearchNodeReleases searches the visitable documents of the latest kb release, name matches rank first
+func (r *NodeRepository) SearchNodeReleases(ctx context.Context, params *NodeReleaseSearchParams) (int64, []*NodeReleaseSearchHit, error) {
+	hits := make([]*NodeReleaseSearchHit, 0)
+	if len(params.Terms) == 0 || (params.NodeIDs != nil && len(params.NodeIDs) == 0) {
+		return 0, hits, nil
+	}
+	latestRelease := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Select("id").
+		Where("kb_id = ?", params.KBID).
+		Order("created_at DESC").
+		Limit(1)
+	query := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("JOIN nodes ON nodes.id = kb_release_node_releases.node_id").
+		Where("kb_release_node_releases.kb_id = ?", params.KBID).
+		Where("kb_release_node_releases.release_id = (?)", latestRelease).
+		Where("node_releases.type = ?", domain.NodeTypeDocument).
+		Where("nodes.permissions->>'visitable' = ? OR (nodes.permissions->>'visitable' = ? AND nodes.id IN ?)",
+			consts.NodeAccessPermOpen, consts.NodeAccessPermPartial, params.PartialIDs)
+	if params.NodeIDs != nil {
+		query = query.Where("kb_release_node_releases.node_id IN ?", params.NodeIDs)
+	}
+	query, err := applyNodeMetaFilter(query, "node_releases", &domain.NodeMetaFilter{Tags: params.Tags})
+	if err != nil {
+		return 0, nil, err
+	}
+	query = applySearchTerms(query, params.Terms, "node_releases.name", "node_releases.content")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, err
+	}
+	if err := query.
+		Select("node_releases.node_id AS id, node_releases.name, node_releases.parent_id, node_releases.content, node_releases.meta, node_releases.updated_at").
+		Order(searchOrderBy(params.Terms, "node_releases.name", "node_releases.updated_at DESC")).
+		Offset(params.Offset).
+		Limit(params.Limit).
+		Scan(&hits).Error; err != nil {
+		return 0, nil, err
+	}
+	return total, hits, nil
+}
+
+type SitemapNodeRelease struct {
+	ID        string    `gorm:"column:id"`
+	UpdatedAt time.Time `gorm:"column:updated_at"` // time of the release
+}
+
+// GetSitemapNodeReleases returns the documents of the latest kb release which are listed and publicly visitable
+func (r *NodeRepository) GetSitemapNodeReleases(ctx context.Context, kbID string) ([]*SitemapNodeRelease, error) {
+	latestRelease := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Select("id").
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(1)
+	nodes := make([]*SitemapNodeRelease, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("JOIN nodes ON nodes.id = kb_release_node_releases.node_id").
+		Where("kb_release_node_releases.kb_id = ?", kbID).
+		Where("kb_release_node_releases.release_id = (?)", latestRelease).
+		Where("node_releases.type = ?", domain.NodeTypeDocument).
+		Where("nodes.permissions->>'visible' != ?", consts.NodeAccessPermClosed).
+		Where("nodes.permissions->>'visitable' = ?", consts.NodeAccessPermOpen).
+		Select("node_releases.node_id AS id, node_releases.updated_at").
+		Order("node_releases.node_id ASC").
+		Scan(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetLatestNodeReleaseContents returns the content of the nodes in the latest kb release by node id
+func (r *NodeRepository) GetLatestNodeReleaseContents(ctx context.Context, kbID string, nodeIDs []string) (map[string]string, error) {
+	latestRelease := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Select("id").
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(1)
+	var rows []struct {
+		ID      string
+		Content string
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Where("kb_release_node_releases.kb_id = ?", kbID).
+		Where("kb_release_node_releases.release_id = (?)", latestRelease).
+		Where("kb_release_node_releases.node_id IN ?", nodeIDs).
+		Select("node_releases.node_id AS id, node_releases.content").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	contents := make(map[string]string, len(rows))
+	for _, row := range rows {
+		contents[row.ID] = row.Content
+	}
+	return contents, nil
+}
+
+type FeedNodeRelease struct {
+	ID          string          `gorm:"column:id"`
+	Name        string          `gorm:"column:name"`
+	Content     string          `gorm:"column:content"` // the beginning of the content, used when there is no summary
+	Meta        domain.NodeMeta `gorm:"column:meta;type:jsonb"`
+	PublishedAt time.Time       `gorm:"column:published_at"` // first release of the node
+	ReleasedAt  time.Time       `gorm:"column:released_at"`  // release of the current version
+}
+
+// GetFeedNodeReleases returns the publicly visible and visitable documents of the latest kb release, recently released first.
+// node_releases.created_at is copied from the node, updated_at is the time of the release.
+func (r *NodeRepository) GetFeedNodeReleases(ctx context.Context, kbID string, limit int) ([]*FeedNodeRelease, error) {
+	latestRelease := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Select("id").
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(1)
+	nodes := make([]*FeedNodeRelease, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("JOIN nodes ON nodes.id = kb_release_node_releases.node_id").
+		Where("kb_release_node_releases.kb_id = ?", kbID).
+		Where("kb_release_node_releases.release_id = (?)", latestRelease).
+		Where("node_releases.type = ?", domain.NodeTypeDocument).
+		Where("nodes.permissions->>'visible' != ?", consts.NodeAccessPermClosed).
+		Where("nodes.permissions->>'visitable' = ?", consts.NodeAccessPermOpen).
+		Select(`node_releases.node_id AS id, node_releases.name, LEFT(node_releases.content, 2000) AS content, node_releases.meta,
+			(SELECT MIN(nr.updated_at) FROM node_releases nr WHERE nr.node_id = node_releases.node_id) AS published_at,
+			node_releases.updated_at AS released_at`).
+		Order("node_releases.updated_at DESC").
+		Limit(limit).
+		Scan(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetLatestKBReleaseNodes returns the published nodes of the latest kb release with content
+func (r *NodeRepository) GetLatestKBReleaseNodes(ctx context.Context, kbID string) (*domain.KBRelease, []*domain.KBExportNode, error) {
+	var kbRelease *domain.KBRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		First(&kbRelease).Error; err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make([]*domain.KBExportNode, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Joins("LEFT JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("LEFT JOIN nodes ON nodes.id = kb_release_node_releases.node_id").
+		Where("kb_release_node_releases.kb_id = ?", kbID).
+		Where("kb_release_node_releases.release_id = ?", kbRelease.ID).
+		Where("nodes.id IS NOT NULL"). // deleted after the release
+		Select("node_releases.node_id as id, node_releases.parent_id, node_releases.type, node_releases.name, node_releases.content, node_releases.meta, nodes.position, nodes.permissions, node_releases.updated_at").
+		Order("nodes.position ASC").
+		Find(&nodes).Error; err != nil {
+		return nil, nil, err
+	}
+	return kbRelease, nodes, nil
+}
+
 func (r *NodeRepository) GetNodeReleaseDetailByKBIDAndID(ctx context.Context, kbID, id string) (*shareV1.ShareNodeDetailResp, error) {
 	// get kb release
 	var kbRelease *domain.KBRelease
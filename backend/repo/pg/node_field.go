@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeFieldRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeFieldRepository(db *pg.DB, logger *log.Logger) *NodeFieldRepository {
+	return &NodeFieldRepository{db: db, logger: logger.WithModule("repo.pg.node_field")}
+}
+
+func (r *NodeFieldRepository) Create(ctx context.Context, field *domain.NodeField) error {
+	return r.db.WithContext(ctx).Create(field).Error
+}
+
+func (r *NodeFieldRepository) Update(ctx context.Context, kbID, id string, updateMap map[string]any) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeField{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		Updates(updateMap).Error
+}
+
+// Delete removes the field definition and its values from node drafts
+func (r *NodeFieldRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var field domain.NodeField
+		if err := tx.Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			First(&field).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Node{}).
+			Where("kb_id = ?", kbID).
+			Where("jsonb_exists(meta->'fields', ?)", field.Key).
+			Update("meta", gorm.Expr("meta #- ARRAY['fields', ?::text]", field.Key)).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&field).Error
+	})
+}
+
+func (r *NodeFieldRepository) GetByID(ctx context.Context, kbID, id string) (*domain.NodeField, error) {
+	var field *domain.NodeField
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeField{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&field).Error; err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (r *NodeFieldRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.NodeField, error) {
+	fields := make([]*domain.NodeField, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeField{}).
+		Where("kb_id = ?", kbID).
+		Order("position ASC, created_at ASC").
+		Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -46,3 +46,16 @@ func (r *NodeRepository) GetNodeAuthGroupIdsByNodeId(ctx context.Context, nodeId
 	}
 	return nil, nil
 }
+
+func (r *NodeRepository) GetNodeAuthGroupsByKBID(ctx context.Context, kbID string) ([]domain.NodeAuthGroup, error) {
+	nodeGroups := make([]domain.NodeAuthGroup, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeAuthGroup{}).
+		Joins("join nodes on nodes.id = node_auth_groups.node_id").
+		Where("nodes.kb_id = ?", kbID).
+		Order("node_auth_groups.auth_group_id ASC").
+		Find(&nodeGroups).Error; err != nil {
+		return nil, err
+	}
+	return nodeGroups, nil
+}
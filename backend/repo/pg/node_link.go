@@ -0,0 +1,166 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeLinkRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeLinkRepository(db *pg.DB, logger *log.Logger) *NodeLinkRepository {
+	return &NodeLinkRepository{db: db, logger: logger.WithModule("repo.pg.node_link")}
+}
+
+// ReplaceNodeLinks replaces the links of a node in the given scope,
+// external links keep the last check result of the same url
+func (r *NodeLinkRepository) ReplaceNodeLinks(ctx context.Context, kbID, nodeID string, scope domain.NodeLinkScope, links []*domain.NodeLink) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kb_id = ?", kbID).
+			Where("node_id = ?", nodeID).
+			Where("scope = ?", scope).
+			Delete(&domain.NodeLink{}).Error; err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			return nil
+		}
+
+		externalURLs := make([]string, 0)
+		for _, link := range links {
+			if link.Type == domain.NodeLinkTypeExternal {
+				externalURLs = append(externalURLs, link.URL)
+			}
+		}
+		if len(externalURLs) > 0 {
+			var checked []*domain.NodeLink
+			if err := tx.Model(&domain.NodeLink{}).
+				Select("DISTINCT ON (url) url, status, status_code, error, checked_at").
+				Where("type = ?", domain.NodeLinkTypeExternal).
+				Where("url IN ?", externalURLs).
+				Where("checked_at IS NOT NULL").
+				Order("url, checked_at DESC").
+				Find(&checked).Error; err != nil {
+				return err
+			}
+			checkedMap := make(map[string]*domain.NodeLink, len(checked))
+			for _, c := range checked {
+				checkedMap[c.URL] = c
+			}
+			for _, link := range links {
+				if c, ok := checkedMap[link.URL]; ok && link.Type == domain.NodeLinkTypeExternal {
+					link.Status = c.Status
+					link.StatusCode = c.StatusCode
+					link.Error = c.Error
+					link.CheckedAt = c.CheckedAt
+				}
+			}
+		}
+
+		return tx.CreateInBatches(&links, 100).Error
+	})
+}
+
+// GetInboundLinks returns links pointing to targetIDs from nodes not in excludeNodeIDs
+func (r *NodeLinkRepository) GetInboundLinks(ctx context.Context, kbID string, targetIDs, excludeNodeIDs []string) ([]*v1.NodeLinkItem, error) {
+	links := make([]*v1.NodeLinkItem, 0)
+	query := r.db.WithContext(ctx).
+		Table("node_links AS l").
+		Select(`l.node_id, n.name AS node_name, n.meta->>'emoji' AS node_emoji,
+			l.target_node_id, COALESCE(t.name, '') AS target_node_name, MIN(l.url) AS url,
+			bool_or(l.scope = ?) AS in_draft, bool_or(l.scope = ?) AS in_release`,
+			domain.NodeLinkScopeDraft, domain.NodeLinkScopeRelease).
+		Joins("JOIN nodes n ON n.id = l.node_id").
+		Joins("LEFT JOIN nodes t ON t.id = l.target_node_id").
+		Where("l.kb_id = ?", kbID).
+		Where("l.type = ?", domain.NodeLinkTypeInternal).
+		Where("l.target_node_id IN ?", targetIDs)
+	if len(excludeNodeIDs) > 0 {
+		query = query.Where("l.node_id NOT IN ?", excludeNodeIDs)
+	}
+	if err := query.
+		Group("l.node_id, n.name, n.meta->>'emoji', l.target_node_id, t.name").
+		Order("n.name ASC").
+		Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetBrokenLinks returns internal links to missing, unpublished or closed nodes and external links failed to check
+func (r *NodeLinkRepository) GetBrokenLinks(ctx context.Context, kbID string) ([]*v1.NodeBrokenLinkItem, error) {
+	reason := r.db.WithContext(ctx).
+		Table("node_links AS l").
+		Select(`l.node_id, n.name AS node_name, l.scope, l.type, l.url, l.target_node_id,
+			l.status_code, l.error, l.checked_at,
+			CASE
+				WHEN l.type = @external THEN CASE WHEN l.status = @broken THEN @http_error END
+				WHEN t.id IS NULL THEN @not_found
+				WHEN l.scope = @release AND NOT EXISTS (SELECT 1 FROM node_releases r WHERE r.node_id = t.id) THEN @unpublished
+				WHEN l.scope = @release AND t.permissions->>'visitable' = @closed THEN @not_visitable
+			END AS reason`,
+			map[string]any{
+				"external":      domain.NodeLinkTypeExternal,
+				"broken":        domain.NodeLinkStatusBroken,
+				"http_error":    v1.NodeBrokenLinkReasonHTTPError,
+				"not_found":     v1.NodeBrokenLinkReasonNotFound,
+				"release":       domain.NodeLinkScopeRelease,
+				"unpublished":   v1.NodeBrokenLinkReasonUnpublished,
+				"closed":        consts.NodeAccessPermClosed,
+				"not_visitable": v1.NodeBrokenLinkReasonNotVisitable,
+			}).
+		Joins("JOIN nodes n ON n.id = l.node_id").
+		Joins("LEFT JOIN nodes t ON t.id = l.target_node_id AND l.type = ?", domain.NodeLinkTypeInternal).
+		Where("l.kb_id = ?", kbID)
+
+	links := make([]*v1.NodeBrokenLinkItem, 0)
+	if err := r.db.WithContext(ctx).
+		Table("(?) AS b", reason).
+		Where("b.reason IS NOT NULL").
+		Order("b.node_name ASC, b.scope ASC, b.url ASC").
+		Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetExternalURLsToCheck returns distinct external urls checked before the given time or with a link never checked,
+// a link added later for an url checked before is checked on the next run
+func (r *NodeLinkRepository) GetExternalURLsToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]string, error) {
+	var urls []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeLink{}).
+		Select("url").
+		Where("type = ?", domain.NodeLinkTypeExternal).
+		Group("url").
+		Having("bool_or(checked_at IS NULL) OR MIN(checked_at) < ?", checkedBefore).
+		Order("bool_or(checked_at IS NULL) DESC, MIN(checked_at) ASC").
+		Limit(limit).
+		Pluck("url", &urls).Error; err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+func (r *NodeLinkRepository) UpdateExternalLinkStatus(ctx context.Context, url string, status domain.NodeLinkStatus, statusCode int, errMsg string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeLink{}).
+		Where("type = ?", domain.NodeLinkTypeExternal).
+		Where("url = ?", url).
+		Updates(map[string]any{
+			"status":      status,
+			"status_code": statusCode,
+			"error":       errMsg,
+			"checked_at":  time.Now(),
+		}).Error
+}
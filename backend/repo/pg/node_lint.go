@@ -0,0 +1,64 @@
+package pg
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeLintRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeLintRepository(db *pg.DB, logger *log.Logger) *NodeLintRepository {
+	return &NodeLintRepository{db: db, logger: logger.WithModule("repo.pg.node_lint")}
+}
+
+// ReplaceNodeIssues replaces the lint issues of a node
+func (r *NodeLintRepository) ReplaceNodeIssues(ctx context.Context, kbID, nodeID string, issues []*domain.NodeLintIssue) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kb_id = ?", kbID).
+			Where("node_id = ?", nodeID).
+			Delete(&domain.NodeLintIssue{}).Error; err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&issues, 100).Error
+	})
+}
+
+func (r *NodeLintRepository) GetIssuesByKBID(ctx context.Context, kbID string) ([]*domain.NodeLintIssue, error) {
+	issues := make([]*domain.NodeLintIssue, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeLintIssue{}).
+		Where("kb_id = ?", kbID).
+		Order("node_id ASC, line ASC, id ASC").
+		Find(&issues).Error; err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// GetNodeIDsByTargets returns the nodes with link issues pointing to the given nodes
+func (r *NodeLintRepository) GetNodeIDsByTargets(ctx context.Context, kbID string, targetIDs []string) ([]string, error) {
+	var nodeIDs []string
+	if len(targetIDs) == 0 {
+		return nodeIDs, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeLintIssue{}).
+		Distinct("node_id").
+		Where("kb_id = ?", kbID).
+		Where("target_node_id IN ?", targetIDs).
+		Pluck("node_id", &nodeIDs).Error; err != nil {
+		return nil, err
+	}
+	return nodeIDs, nil
+}
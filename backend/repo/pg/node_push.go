@@ -0,0 +1,36 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodePushRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodePushRepository(db *pg.DB, logger *log.Logger) *NodePushRepository {
+	return &NodePushRepository{db: db, logger: logger.WithModule("repo.pg.node_push")}
+}
+
+func (r *NodePushRepository) GetListBySource(ctx context.Context, kbID, source string) ([]*domain.KBNodePush, error) {
+	pushes := make([]*domain.KBNodePush, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBNodePush{}).
+		Where("kb_id = ?", kbID).
+		Where("source = ?", source).
+		Find(&pushes).Error; err != nil {
+		return nil, err
+	}
+	return pushes, nil
+}
+
+func (r *NodePushRepository) Save(ctx context.Context, push *domain.KBNodePush) error {
+	push.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(push).Error
+}
@@ -0,0 +1,46 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeSEORepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeSEORepository(db *pg.DB, logger *log.Logger) *NodeSEORepository {
+	return &NodeSEORepository{db: db, logger: logger.WithModule("repo.pg.node_seo")}
+}
+
+// GetByNodeID returns the seo overrides of a node, nil if there are none
+func (r *NodeSEORepository) GetByNodeID(ctx context.Context, kbID, nodeID string) (*domain.NodeSEO, error) {
+	var seo domain.NodeSEO
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("node_id = ?", nodeID).
+		First(&seo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &seo, nil
+}
+
+func (r *NodeSEORepository) Upsert(ctx context.Context, seo *domain.NodeSEO) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "node_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "description", "keywords", "image", "canonical_url", "no_index", "updated_at"}),
+		}).
+		Create(seo).Error
+}
@@ -0,0 +1,192 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeTranslationRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeTranslationRepository(db *pg.DB, logger *log.Logger) *NodeTranslationRepository {
+	return &NodeTranslationRepository{db: db, logger: logger.WithModule("repo.pg.node_translation")}
+}
+
+func (r *NodeTranslationRepository) Create(ctx context.Context, translation *domain.NodeTranslation) error {
+	return r.db.WithContext(ctx).Create(translation).Error
+}
+
+func (r *NodeTranslationRepository) Update(ctx context.Context, nodeID string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("node_id = ?", nodeID).
+		Updates(updateMap).Error
+}
+
+func (r *NodeTranslationRepository) Delete(ctx context.Context, kbID, nodeID string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("node_id = ?", nodeID).
+		Delete(&domain.NodeTranslation{}).Error
+}
+
+// GetByNodeID returns the translation link of a translated node, nil if the node is not a translation
+func (r *NodeTranslationRepository) GetByNodeID(ctx context.Context, kbID, nodeID string) (*domain.NodeTranslation, error) {
+	var translation domain.NodeTranslation
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("node_id = ?", nodeID).
+		First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetBySourceAndLocale returns the translation of a source node in the locale, nil if there is none
+func (r *NodeTranslationRepository) GetBySourceAndLocale(ctx context.Context, kbID, sourceNodeID, locale string) (*domain.NodeTranslation, error) {
+	var translation domain.NodeTranslation
+	if err := r.db.WithContext(ctx).
+		Where("kb_id = ?", kbID).
+		Where("source_node_id = ?", sourceNodeID).
+		Where("locale = ?", locale).
+		First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetByNodeIDs returns the translation links of the nodes, as translation or as source
+func (r *NodeTranslationRepository) GetByNodeIDs(ctx context.Context, nodeIDs []string) ([]*domain.NodeTranslation, error) {
+	translations := make([]*domain.NodeTranslation, 0)
+	if len(nodeIDs) == 0 {
+		return translations, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("node_id IN ? OR source_node_id IN ?", nodeIDs, nodeIDs).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// StartTranslate marks the translation as translating, it reports false when a translation is already running.
+// A translation which is translating since before staleBefore is taken over
+func (r *NodeTranslationRepository) StartTranslate(ctx context.Context, nodeID string, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("node_id = ?", nodeID).
+		Where("(status != ? OR updated_at < ?)", domain.NodeTranslationStatusTranslating, staleBefore).
+		Updates(map[string]any{
+			"status":     domain.NodeTranslationStatusTranslating,
+			"error":      "",
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FailStale marks the translations which are translating since before staleBefore as failed
+func (r *NodeTranslationRepository) FailStale(ctx context.Context, staleBefore time.Time, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("status = ?", domain.NodeTranslationStatusTranslating).
+		Where("updated_at < ?", staleBefore).
+		Updates(map[string]any{
+			"status":     domain.NodeTranslationStatusFailed,
+			"error":      reason,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// GetListBySourceNodeID returns the translations of a source node, stale ones are behind the source version
+func (r *NodeTranslationRepository) GetListBySourceNodeID(ctx context.Context, kbID, sourceNodeID string) ([]*v1.NodeTranslationItem, error) {
+	var sourceVersion int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Select("version").
+		Where("kb_id = ?", kbID).
+		Where("id = ?", sourceNodeID).
+		Scan(&sourceVersion).Error; err != nil {
+		return nil, err
+	}
+	items := make([]*v1.NodeTranslationItem, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Joins("JOIN nodes ON nodes.id = node_translations.node_id").
+		Where("node_translations.kb_id = ?", kbID).
+		Where("node_translations.source_node_id = ?", sourceNodeID).
+		Select("node_translations.node_id, node_translations.locale, nodes.name, nodes.status AS node_status, node_translations.status, node_translations.error, node_translations.source_version, node_translations.updated_at").
+		Order("node_translations.locale ASC").
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Stale = item.SourceVersion < sourceVersion
+	}
+	return items, nil
+}
+
+// PublishedNodeTranslation is a translation whose source and translated nodes are both in the latest kb release
+type PublishedNodeTranslation struct {
+	NodeID       string                 `gorm:"column:node_id"`
+	SourceNodeID string                 `gorm:"column:source_node_id"`
+	Locale       string                 `gorm:"column:locale"`
+	Name         string                 `gorm:"column:name"`
+	Meta         domain.NodeMeta        `gorm:"column:meta;type:jsonb"`
+	UpdatedAt    time.Time              `gorm:"column:updated_at"`
+	Permissions  domain.NodePermissions `gorm:"column:permissions;type:jsonb"`
+}
+
+// GetPublished returns the published translations of the kb, limited to these nodes as source or translation when nodeIDs is not nil
+func (r *NodeTranslationRepository) GetPublished(ctx context.Context, kbID string, nodeIDs []string) ([]*PublishedNodeTranslation, error) {
+	translations := make([]*PublishedNodeTranslation, 0)
+	if nodeIDs != nil && len(nodeIDs) == 0 {
+		return translations, nil
+	}
+	latestRelease := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Select("id").
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(1)
+	publishedNodes := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Select("node_id").
+		Where("release_id = (?)", latestRelease)
+	query := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Joins("JOIN kb_release_node_releases ON kb_release_node_releases.node_id = node_translations.node_id AND kb_release_node_releases.release_id = (?)", latestRelease).
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("JOIN nodes ON nodes.id = node_translations.node_id").
+		Where("node_translations.kb_id = ?", kbID).
+		Where("node_translations.source_node_id IN (?)", publishedNodes)
+	if nodeIDs != nil {
+		query = query.Where("node_translations.node_id IN ? OR node_translations.source_node_id IN ?", nodeIDs, nodeIDs)
+	}
+	if err := query.
+		Select("node_translations.node_id, node_translations.source_node_id, node_translations.locale, node_releases.name, node_releases.meta, node_releases.updated_at, nodes.permissions").
+		Order("node_translations.locale ASC").
+		Scan(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type OpenAPISpecRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewOpenAPISpecRepository(db *pg.DB, logger *log.Logger) *OpenAPISpecRepository {
+	return &OpenAPISpecRepository{db: db, logger: logger.WithModule("repo.pg.openapi_spec")}
+}
+
+func (r *OpenAPISpecRepository) Create(ctx context.Context, spec *domain.KBOpenAPISpec) error {
+	return r.db.WithContext(ctx).Create(spec).Error
+}
+
+func (r *OpenAPISpecRepository) Update(ctx context.Context, id string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBOpenAPISpec{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *OpenAPISpecRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBOpenAPISpec, error) {
+	var spec *domain.KBOpenAPISpec
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBOpenAPISpec{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&spec).Error; err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (r *OpenAPISpecRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBOpenAPISpec, error) {
+	specs := make([]*domain.KBOpenAPISpec, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBOpenAPISpec{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&specs).Error; err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// Delete removes the spec and its operation mapping, generated nodes are kept
+func (r *OpenAPISpecRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("spec_id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBOpenAPISpecNode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBOpenAPISpec{}).Error
+	})
+}
+
+func (r *OpenAPISpecRepository) GetSpecNodes(ctx context.Context, specID string) ([]*domain.KBOpenAPISpecNode, error) {
+	nodes := make([]*domain.KBOpenAPISpecNode, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBOpenAPISpecNode{}).
+		Where("spec_id = ?", specID).
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (r *OpenAPISpecRepository) SaveSpecNode(ctx context.Context, node *domain.KBOpenAPISpecNode) error {
+	return r.db.WithContext(ctx).Save(node).Error
+}
+
+func (r *OpenAPISpecRepository) DeleteSpecNodes(ctx context.Context, specID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("spec_id = ?", specID).
+		Where("key IN ?", keys).
+		Delete(&domain.KBOpenAPISpecNode{}).Error
+}
@@ -25,4 +25,20 @@ var ProviderSet = wire.NewSet(
 	NewAPITokenRepo,
 	NewSystemSettingRepo,
 	NewMCPRepository,
+	NewNodeTemplateRepository,
+	NewNodeLinkRepository,
+	NewNodeLintRepository,
+	NewNodeSEORepository,
+	NewNodeTranslationRepository,
+	NewDocumentFeedbackRepository,
+	NewKBRedirectRepository,
+	NewSearchQueryRepository,
+	NewNodeFieldRepository,
+	NewKBExportRepository,
+	NewKBImportRepository,
+	NewGitSourceRepository,
+	NewCrawlerSyncRepository,
+	NewOpenAPISpecRepository,
+	NewWebhookRepository,
+	NewNodePushRepository,
 )
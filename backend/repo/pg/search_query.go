@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type SearchQueryRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewSearchQueryRepository(db *pg.DB, logger *log.Logger) *SearchQueryRepository {
+	return &SearchQueryRepository{db: db, logger: logger.WithModule("repo.pg.search_query")}
+}
+
+func (r *SearchQueryRepository) Create(ctx context.Context, query *domain.KBSearchQuery) error {
+	return r.db.WithContext(ctx).Create(query).Error
+}
+
+// GetTopQueries groups the queries since the given time by normalized query, most frequent first
+func (r *SearchQueryRepository) GetTopQueries(ctx context.Context, kbID string, since time.Time, source domain.SearchQuerySource, zeroResultOnly bool, limit int) ([]*v1.StatSearchQueryItem, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.KBSearchQuery{}).
+		Select(`(ARRAY_AGG(query ORDER BY created_at DESC))[1] AS query,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE result_count = 0) AS zero_count,
+			AVG(result_count) AS avg_result_count,
+			AVG(top_score) AS avg_top_score,
+			MAX(created_at) AS last_at`).
+		Where("kb_id = ?", kbID).
+		Where("created_at >= ?", since).
+		Group("normalized_query")
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if zeroResultOnly {
+		query = query.Where("result_count = 0")
+	}
+	items := make([]*v1.StatSearchQueryItem, 0)
+	if err := query.
+		Order("count DESC, last_at DESC").
+		Limit(limit).
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetNegativeFeedbackQueries groups the chat questions whose answers were disliked
+func (r *SearchQueryRepository) GetNegativeFeedbackQueries(ctx context.Context, kbID string, since time.Time, limit int) ([]*v1.StatNegativeFeedbackQueryItem, error) {
+	items := make([]*v1.StatNegativeFeedbackQueryItem, 0)
+	if err := r.db.WithContext(ctx).
+		Table("kb_search_queries AS q").
+		Joins("JOIN conversation_messages m ON m.id = q.message_id").
+		Select(`(ARRAY_AGG(q.query ORDER BY q.created_at DESC))[1] AS query,
+			COUNT(*) AS count,
+			AVG(q.top_score) AS avg_top_score,
+			(ARRAY_AGG(q.conversation_id ORDER BY q.created_at DESC))[1] AS last_conversation_id,
+			(ARRAY_AGG(m.info->>'feedback_content' ORDER BY q.created_at DESC))[1] AS last_feedback,
+			MAX(q.created_at) AS last_at`).
+		Where("q.kb_id = ?", kbID).
+		Where("q.created_at >= ?", since).
+		Where("q.message_id <> ''").
+		Where("m.info->>'score' = ?", "-1").
+		Group("q.normalized_query").
+		Order("count DESC, last_at DESC").
+		Limit(limit).
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetQueryStats returns the stats of a normalized query since the given time
+func (r *SearchQueryRepository) GetQueryStats(ctx context.Context, kbID, normalizedQuery string, since time.Time) (*v1.StatSearchQueryItem, error) {
+	var item v1.StatSearchQueryItem
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBSearchQuery{}).
+		Select(`COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE result_count = 0) AS zero_count,
+			COALESCE(AVG(result_count), 0) AS avg_result_count,
+			COALESCE(AVG(top_score), 0) AS avg_top_score`).
+		Where("kb_id = ?", kbID).
+		Where("normalized_query = ?", normalizedQuery).
+		Where("created_at >= ?", since).
+		Scan(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *SearchQueryRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&domain.KBSearchQuery{}).Error
+}
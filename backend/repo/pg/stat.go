@@ -157,6 +157,11 @@ func (r *StatRepository) RemoveOldData(ctx context.Context) error {
 		Delete(&domain.StatPage{}).Error; err != nil {
 		return err
 	}
+	if err := r.db.WithContext(ctx).Model(&domain.StatPageEngagement{}).
+		Where("created_at < ?", utils.GetTimeHourOffset(-24)).
+		Delete(&domain.StatPageEngagement{}).Error; err != nil {
+		return err
+	}
 	return nil
 }
 
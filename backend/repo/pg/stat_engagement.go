@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+type nodeEngagementRow struct {
+	NodeID string
+	domain.NodeEngagement
+}
+
+func (r *StatRepository) CreateStatPageEngagement(ctx context.Context, engagement *domain.StatPageEngagement) error {
+	return r.db.WithContext(ctx).Create(engagement).Error
+}
+
+// getNodeEngagements sums the engagement beacons of [start, end) by node
+func (r *StatRepository) getNodeEngagements(ctx context.Context, kbID string, start, end time.Time) (domain.NodeEngagements, error) {
+	var rows []nodeEngagementRow
+	if err := r.db.WithContext(ctx).Model(&domain.StatPageEngagement{}).
+		Select(`node_id,
+			COUNT(*) AS views,
+			SUM(dwell_seconds) AS dwell_seconds,
+			SUM(scroll_depth) AS scroll_depth,
+			COUNT(*) FILTER (WHERE dwell_seconds >= ? OR scroll_depth >= ?) AS reads,
+			COUNT(*) FILTER (WHERE dwell_seconds < ? AND scroll_depth < ?) AS bounces,
+			COUNT(*) FILTER (WHERE exit) AS exits,
+			SUM(copy_count) AS copies,
+			SUM(outbound_click_count) AS outbound_clicks`,
+			domain.StatEngagementReadDwellSeconds, domain.StatEngagementReadScrollDepth,
+			domain.StatEngagementBounceDwellSeconds, domain.StatEngagementBounceScrollDepth).
+		Where("kb_id = ?", kbID).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Group("node_id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	engagements := make(domain.NodeEngagements, len(rows))
+	for i := range rows {
+		engagements[rows[i].NodeID] = &rows[i].NodeEngagement
+	}
+	return engagements, nil
+}
+
+func (r *StatRepository) GetNodeEngagementOneHour(ctx context.Context, kbID string) (domain.NodeEngagements, error) {
+	return r.getNodeEngagements(ctx, kbID, utils.GetTimeHourOffset(-1), utils.GetTimeHourOffset(0))
+}
+
+// GetNodeEngagements 最近24小时的实时数据
+func (r *StatRepository) GetNodeEngagements(ctx context.Context, kbID string) (domain.NodeEngagements, error) {
+	return r.getNodeEngagements(ctx, kbID, utils.GetTimeHourOffset(-24), time.Now())
+}
+
+func (r *StatRepository) GetNodeEngagementsByHour(ctx context.Context, kbID string, startHour int64) (domain.NodeEngagements, error) {
+	// 查询小时统计表中的聚合数据
+	engagements := make(domain.NodeEngagements)
+	engagementMaps := make([]domain.NodeEngagements, 0)
+	if err := r.db.WithContext(ctx).Model(&domain.StatPageHour{}).
+		Where("kb_id = ?", kbID).
+		Where("hour >= ? and hour < ?", utils.GetTimeHourOffset(-startHour), utils.GetTimeHourOffset(-24)).
+		Where("node_engagement IS NOT NULL").
+		Pluck("node_engagement", &engagementMaps).Error; err != nil {
+		return nil, err
+	}
+	for i := range engagementMaps {
+		engagements.Merge(engagementMaps[i])
+	}
+
+	return engagements, nil
+}
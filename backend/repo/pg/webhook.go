@@ -0,0 +1,162 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type WebhookRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewWebhookRepository(db *pg.DB, logger *log.Logger) *WebhookRepository {
+	return &WebhookRepository{db: db, logger: logger.WithModule("repo.pg.webhook")}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.KBWebhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, kbID, id string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBWebhook{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		Updates(updateMap).Error
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, kbID, id string) (*domain.KBWebhook, error) {
+	var webhook *domain.KBWebhook
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBWebhook{}).
+		Where("id = ?", id).
+		Where("kb_id = ?", kbID).
+		First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) GetListByKBID(ctx context.Context, kbID string) ([]*domain.KBWebhook, error) {
+	webhooks := make([]*domain.KBWebhook, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBWebhook{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetEnabledListByKBID(ctx context.Context, kbID string) ([]*domain.KBWebhook, error) {
+	webhooks := make([]*domain.KBWebhook, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBWebhook{}).
+		Where("kb_id = ?", kbID).
+		Where("enabled = ?", true).
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Delete removes the webhook and its delivery log
+func (r *WebhookRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBWebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			Delete(&domain.KBWebhook{}).Error
+	})
+}
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain.KBWebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+func (r *WebhookRepository) GetDeliveryByID(ctx context.Context, id string) (*domain.KBWebhookDelivery, error) {
+	var delivery *domain.KBWebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBWebhookDelivery{}).
+		Where("id = ?", id).
+		First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, id string, updateMap map[string]any) error {
+	updateMap["updated_at"] = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.KBWebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(updateMap).Error
+}
+
+func (r *WebhookRepository) GetDeliveryList(ctx context.Context, kbID, webhookID string, offset, limit int) (int64, []*domain.KBWebhookDelivery, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.KBWebhookDelivery{}).
+		Where("kb_id = ?", kbID).
+		Where("webhook_id = ?", webhookID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, err
+	}
+	deliveries := make([]*domain.KBWebhookDelivery, 0)
+	if err := query.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return 0, nil, err
+	}
+	return total, deliveries, nil
+}
+
+// ClaimDueDeliveries returns the deliveries waiting for an attempt and postpones them until claimUntil,
+// so a delivery is queued again only if the queued attempt got lost
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, claimUntil time.Time, limit int) ([]string, error) {
+	ids := make([]string, 0)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.KBWebhookDelivery{}).
+			Where("status IN ?", []domain.WebhookDeliveryStatus{domain.WebhookDeliveryStatusPending, domain.WebhookDeliveryStatusRetrying}).
+			Where("next_retry_at <= ?", now).
+			Order("next_retry_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&domain.KBWebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_retry_at", claimUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *WebhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&domain.KBWebhookDelivery{}).Error
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS node_versions;
+ALTER TABLE nodes DROP COLUMN IF EXISTS version;
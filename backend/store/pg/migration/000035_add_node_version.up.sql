@@ -0,0 +1,13 @@
+ALTER TABLE nodes ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS node_versions (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    content TEXT NOT NULL DEFAULT '',
+    editor_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_uniq_node_versions_node_id_version ON node_versions(node_id, version);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS node_templates (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    emoji TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL DEFAULT '',
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_templates_kb_id ON node_templates(kb_id);
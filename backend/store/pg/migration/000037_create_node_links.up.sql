@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS node_links (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    type TEXT NOT NULL,
+    url TEXT NOT NULL,
+    target_node_id TEXT NOT NULL DEFAULT '',
+    status SMALLINT NOT NULL DEFAULT 0,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    checked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_links_kb_id_node_id ON node_links(kb_id, node_id);
+CREATE INDEX IF NOT EXISTS idx_node_links_target_node_id ON node_links(target_node_id);
+CREATE INDEX IF NOT EXISTS idx_node_links_url ON node_links(url) WHERE type = 'external';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_node_releases_meta_tags;
+DROP INDEX IF EXISTS idx_nodes_meta_tags;
+DROP TABLE IF EXISTS node_fields;
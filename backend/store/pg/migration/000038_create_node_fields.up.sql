@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS node_fields (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    position INT NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_uniq_node_fields_kb_id_key ON node_fields(kb_id, key);
+
+CREATE INDEX IF NOT EXISTS idx_nodes_meta_tags ON nodes USING GIN ((meta->'tags'));
+CREATE INDEX IF NOT EXISTS idx_node_releases_meta_tags ON node_releases USING GIN ((meta->'tags'));
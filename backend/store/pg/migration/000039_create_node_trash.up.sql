@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS node_trash (
+    node_id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    root_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    type SMALLINT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    snapshot JSONB NOT NULL,
+    deleted_by TEXT NOT NULL DEFAULT '',
+    deleted_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_trash_kb_id_root_id ON node_trash(kb_id, root_id);
+CREATE INDEX IF NOT EXISTS idx_node_trash_deleted_at ON node_trash(deleted_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS kb_exports (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    format TEXT NOT NULL,
+    status TEXT NOT NULL,
+    file_key TEXT NOT NULL DEFAULT '',
+    file_size BIGINT NOT NULL DEFAULT 0,
+    node_count INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    finished_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_exports_kb_id_created_at ON kb_exports(kb_id, created_at);
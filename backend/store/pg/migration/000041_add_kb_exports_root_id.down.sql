@@ -0,0 +1 @@
+ALTER TABLE kb_exports DROP COLUMN IF EXISTS root_id;
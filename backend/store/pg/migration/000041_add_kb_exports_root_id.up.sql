@@ -0,0 +1 @@
+ALTER TABLE kb_exports ADD COLUMN IF NOT EXISTS root_id TEXT NOT NULL DEFAULT '';
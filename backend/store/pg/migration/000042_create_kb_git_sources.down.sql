@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS kb_git_source_nodes;
+DROP TABLE IF EXISTS kb_git_sources;
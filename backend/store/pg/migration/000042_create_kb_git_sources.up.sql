@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS kb_git_sources (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    repo_url TEXT NOT NULL,
+    branch TEXT NOT NULL DEFAULT '',
+    sub_dir TEXT NOT NULL DEFAULT '',
+    parent_id TEXT NOT NULL DEFAULT '',
+    auth_type TEXT NOT NULL DEFAULT 'none',
+    username TEXT NOT NULL DEFAULT '',
+    password TEXT NOT NULL DEFAULT '',
+    ssh_key TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'idle',
+    error TEXT NOT NULL DEFAULT '',
+    last_commit TEXT NOT NULL DEFAULT '',
+    last_result JSONB NOT NULL DEFAULT '{}',
+    last_synced_at timestamptz,
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_git_sources_kb_id ON kb_git_sources(kb_id);
+
+CREATE TABLE IF NOT EXISTS kb_git_source_nodes (
+    source_id TEXT NOT NULL,
+    path TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type SMALLINT NOT NULL,
+    PRIMARY KEY (source_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_git_source_nodes_kb_id ON kb_git_source_nodes(kb_id);
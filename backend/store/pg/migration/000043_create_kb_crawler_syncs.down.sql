@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS kb_crawler_sync_runs;
+DROP TABLE IF EXISTS kb_crawler_sync_docs;
+DROP TABLE IF EXISTS kb_crawler_syncs;
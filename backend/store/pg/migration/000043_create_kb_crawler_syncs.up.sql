@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS kb_crawler_syncs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    crawler_source TEXT NOT NULL,
+    key TEXT NOT NULL DEFAULT '',
+    filename TEXT NOT NULL DEFAULT '',
+    feishu_setting JSONB NOT NULL DEFAULT '{}',
+    parent_id TEXT NOT NULL DEFAULT '',
+    schedule TEXT NOT NULL DEFAULT '',
+    auto_publish BOOLEAN NOT NULL DEFAULT FALSE,
+    max_node INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'idle',
+    next_run_at timestamptz,
+    last_run_at timestamptz,
+    last_run_status TEXT NOT NULL DEFAULT '',
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_crawler_syncs_kb_id ON kb_crawler_syncs(kb_id);
+CREATE INDEX IF NOT EXISTS idx_kb_crawler_syncs_next_run_at ON kb_crawler_syncs(next_run_at);
+
+CREATE TABLE IF NOT EXISTS kb_crawler_sync_docs (
+    sync_id TEXT NOT NULL,
+    doc_key TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type SMALLINT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    content_hash TEXT NOT NULL DEFAULT '',
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sync_id, doc_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_crawler_sync_docs_kb_id ON kb_crawler_sync_docs(kb_id);
+
+CREATE TABLE IF NOT EXISTS kb_crawler_sync_runs (
+    id TEXT PRIMARY KEY,
+    sync_id TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    trigger TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    deleted INT NOT NULL DEFAULT 0,
+    unchanged INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    doc_errors JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    release_id TEXT NOT NULL DEFAULT '',
+    started_at timestamptz NOT NULL DEFAULT NOW(),
+    finished_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_crawler_sync_runs_sync_id_started_at ON kb_crawler_sync_runs(sync_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_kb_crawler_sync_runs_kb_id ON kb_crawler_sync_runs(kb_id);
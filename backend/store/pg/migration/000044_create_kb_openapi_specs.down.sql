@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS kb_openapi_spec_nodes;
+DROP TABLE IF EXISTS kb_openapi_specs;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS kb_openapi_specs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    parent_id TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    last_result JSONB NOT NULL DEFAULT '{}',
+    last_imported_at timestamptz,
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_openapi_specs_kb_id ON kb_openapi_specs(kb_id);
+
+CREATE TABLE IF NOT EXISTS kb_openapi_spec_nodes (
+    spec_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type SMALLINT NOT NULL,
+    removed BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (spec_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_openapi_spec_nodes_kb_id ON kb_openapi_spec_nodes(kb_id);
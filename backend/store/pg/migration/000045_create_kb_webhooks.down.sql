@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS kb_webhook_deliveries;
+DROP TABLE IF EXISTS kb_webhooks;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS kb_webhooks (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    events TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_webhooks_kb_id ON kb_webhooks(kb_id);
+
+CREATE TABLE IF NOT EXISTS kb_webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    webhook_id TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    duration BIGINT NOT NULL DEFAULT 0,
+    next_retry_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_webhook_deliveries_webhook_id_created_at ON kb_webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_kb_webhook_deliveries_kb_id ON kb_webhook_deliveries(kb_id);
+CREATE INDEX IF NOT EXISTS idx_kb_webhook_deliveries_next_retry_at ON kb_webhook_deliveries(next_retry_at) WHERE status IN ('pending', 'retrying');
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS kb_node_pushes (
+    kb_id TEXT NOT NULL,
+    source TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type SMALLINT NOT NULL,
+    path TEXT NOT NULL DEFAULT '',
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kb_id, source, external_id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS node_lint_issues (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    rule TEXT NOT NULL,
+    line INT NOT NULL DEFAULT 0,
+    text TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    target_node_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_lint_issues_kb_id_node_id ON node_lint_issues(kb_id, node_id);
+CREATE INDEX IF NOT EXISTS idx_node_lint_issues_target_node_id ON node_lint_issues(target_node_id) WHERE target_node_id <> '';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS kb_search_queries (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source TEXT NOT NULL,
+    app_type SMALLINT NOT NULL DEFAULT 0,
+    query TEXT NOT NULL,
+    normalized_query TEXT NOT NULL,
+    result_count INT NOT NULL DEFAULT 0,
+    top_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    conversation_id TEXT NOT NULL DEFAULT '',
+    message_id TEXT NOT NULL DEFAULT '',
+    remote_ip TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_search_queries_kb_id_created_at ON kb_search_queries(kb_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_kb_search_queries_message_id ON kb_search_queries(message_id) WHERE message_id <> '';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS node_seo (
+    node_id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    keywords TEXT NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    canonical_url TEXT NOT NULL DEFAULT '',
+    no_index BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_seo_kb_id ON node_seo(kb_id);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS node_translations (
+    node_id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source_node_id TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    source_version BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'ready',
+    error TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_uniq_node_translations_source_locale ON node_translations(source_node_id, locale);
+CREATE INDEX IF NOT EXISTS idx_node_translations_kb_id ON node_translations(kb_id);
@@ -0,0 +1,2 @@
+ALTER TABLE stat_page_hours DROP COLUMN IF EXISTS node_engagement;
+DROP TABLE IF EXISTS stat_page_engagements;
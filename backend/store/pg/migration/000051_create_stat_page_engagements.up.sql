@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS stat_page_engagements (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    dwell_seconds INT NOT NULL DEFAULT 0,
+    scroll_depth INT NOT NULL DEFAULT 0,
+    copy_count INT NOT NULL DEFAULT 0,
+    outbound_click_count INT NOT NULL DEFAULT 0,
+    exit BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stat_page_engagements_kb_id_created_at ON stat_page_engagements(kb_id, created_at);
+
+ALTER TABLE stat_page_hours ADD COLUMN IF NOT EXISTS node_engagement JSONB NULL;
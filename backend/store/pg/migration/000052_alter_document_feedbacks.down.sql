@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_document_feedbacks_node_id_session_id;
+DROP INDEX IF EXISTS idx_document_feedbacks_kb_id_created_at;
+
+ALTER TABLE document_feedbacks
+    DROP COLUMN IF EXISTS node_release_id,
+    DROP COLUMN IF EXISTS session_id,
+    DROP COLUMN IF EXISTS score,
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS resolved_by,
+    DROP COLUMN IF EXISTS resolved_at,
+    DROP COLUMN IF EXISTS resolved_release_id,
+    DROP COLUMN IF EXISTS updated_at;
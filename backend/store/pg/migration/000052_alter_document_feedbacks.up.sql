@@ -0,0 +1,13 @@
+ALTER TABLE document_feedbacks
+    ADD COLUMN IF NOT EXISTS node_release_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS session_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS score SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'open',
+    ADD COLUMN IF NOT EXISTS resolved_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS resolved_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS resolved_release_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_document_feedbacks_kb_id_created_at ON document_feedbacks(kb_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_document_feedbacks_node_id_session_id ON document_feedbacks(node_id, session_id);
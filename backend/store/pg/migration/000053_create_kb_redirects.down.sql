@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS node_slugs;
+DROP TABLE IF EXISTS kb_redirects;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS kb_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source_path TEXT NOT NULL,
+    target_node_id TEXT NOT NULL DEFAULT '',
+    target_url TEXT NOT NULL DEFAULT '',
+    permanent BOOLEAN NOT NULL DEFAULT TRUE,
+    hits BIGINT NOT NULL DEFAULT 0,
+    last_hit_at timestamptz NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_uniq_kb_redirects_kb_id_source_path ON kb_redirects(kb_id, source_path);
+CREATE INDEX IF NOT EXISTS idx_kb_redirects_target_node_id ON kb_redirects(target_node_id);
+
+CREATE TABLE IF NOT EXISTS node_slugs (
+    node_id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_uniq_node_slugs_kb_id_slug ON node_slugs(kb_id, slug);
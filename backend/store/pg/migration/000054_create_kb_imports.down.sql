@@ -0,0 +1 @@
+DROP TABLE IF EXISTS kb_imports;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS kb_imports (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    file_key TEXT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    mode TEXT NOT NULL,
+    preserve_ids BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL,
+    result JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    creator_id TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    finished_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_imports_kb_id_created_at ON kb_imports(kb_id, created_at);
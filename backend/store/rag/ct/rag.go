@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/cloudwego/eino/schema"
@@ -47,7 +49,7 @@ func (s *CTRAG) CreateKnowledgeBase(ctx context.Context) (string, error) {
 	return dataset.ID, nil
 }
 
-func (s *CTRAG) QueryRecords(ctx context.Context, datasetIDs []string, query string, groupIds []int, similarityThreshold float64, historyMsgs []*schema.Message) ([]*domain.NodeContentChunk, error) {
+func (s *CTRAG) QueryRecords(ctx context.Context, datasetIDs []string, query string, groupIds []int, docIDs []string, similarityThreshold float64, historyMsgs []*schema.Message) ([]*domain.NodeContentChunk, error) {
 	var chatMsgs []rag.ChatMessage
 	for _, msg := range historyMsgs {
 		switch msg.Role {
@@ -71,6 +73,7 @@ func (s *CTRAG) QueryRecords(ctx context.Context, datasetIDs []string, query str
 		Question:     query,
 		TopK:         10,
 		UserGroupIDs: groupIds,
+		DocumentIDs:  docIDs,
 		ChatMessages: chatMsgs,
 	}
 	if similarityThreshold != 0 {
@@ -89,6 +92,7 @@ func (s *CTRAG) QueryRecords(ctx context.Context, datasetIDs []string, query str
 			ID:      chunk.ID,
 			Content: chunk.Content,
 			DocID:   chunk.DocumentID,
+			Score:   chunk.Similarity,
 		}
 	}
 	return nodeChunks, nil
@@ -108,6 +112,7 @@ func (s *CTRAG) UpsertRecords(ctx context.Context, datasetID string, nodeRelease
 			return "", fmt.Errorf("convert html to markdown failed: %w", err)
 		}
 	}
+	markdown = nodeMetaHeader(nodeRelease.Meta) + markdown
 	if _, err := tempFile.Write([]byte(markdown)); err != nil {
 		return "", fmt.Errorf("write temp file failed: %w", err)
 	}
@@ -236,3 +241,25 @@ func (s *CTRAG) ListDocuments(ctx context.Context, datasetID string, params map[
 	}
 	return docs, nil
 }
+
+// nodeMetaHeader renders tags and custom fields as a header of the document text,
+// so they can be matched and cited in retrieval
+func nodeMetaHeader(meta domain.NodeMeta) string {
+	if len(meta.Tags) == 0 && len(meta.Fields) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if len(meta.Tags) > 0 {
+		sb.WriteString("tags: " + strings.Join(meta.Tags, ", ") + "\n")
+	}
+	keys := make([]string, 0, len(meta.Fields))
+	for key := range meta.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sb.WriteString(key + ": " + meta.Fields[key] + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -552,6 +552,8 @@ func (u *AppUsecase) GetAppDetailByKBIDAndAppType(ctx context.Context, kbID stri
 
 		MCPServerSettings: app.Settings.MCPServerSettings,
 		StatsSetting:      app.Settings.StatsSetting,
+		ExportSettings:    app.Settings.ExportSettings,
+		LLMsTxtSettings:   app.Settings.LLMsTxtSettings,
 	}
 
 	if !domain.GetBaseEditionLimitation(ctx).AllowCustomCopyright {
@@ -677,6 +679,8 @@ func (u *AppUsecase) ShareGetWebAppInfo(ctx context.Context, kbID string, authId
 			HomePageSetting:     app.Settings.HomePageSetting,
 			ConversationSetting: app.Settings.ConversationSetting,
 			StatsSetting:        app.Settings.StatsSetting,
+			ExportSettings:      app.Settings.ExportSettings,
+			LLMsTxtSettings:     app.Settings.LLMsTxtSettings,
 		},
 	}
 	// init ai feedback string
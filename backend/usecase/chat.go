@@ -25,12 +25,13 @@ type ChatUsecase struct {
 	blockWordRepo       *pg.BlockWordRepo
 	kbRepo              *pg.KnowledgeBaseRepository
 	AuthRepo            *pg.AuthRepo
+	searchQueryRepo     *pg.SearchQueryRepository
 	logger              *log.Logger
 	modelkit            *modelkit.ModelKit
 }
 
 func NewChatUsecase(llmUsecase *LLMUsecase, kbRepo *pg.KnowledgeBaseRepository, conversationUsecase *ConversationUsecase, modelUsecase *ModelUsecase, appRepo *pg.AppRepository,
-	blockWordRepo *pg.BlockWordRepo, authRepo *pg.AuthRepo, logger *log.Logger) (*ChatUsecase, error) {
+	blockWordRepo *pg.BlockWordRepo, authRepo *pg.AuthRepo, searchQueryRepo *pg.SearchQueryRepository, logger *log.Logger) (*ChatUsecase, error) {
 	modelkit := modelkit.NewModelKit(logger.Logger)
 	u := &ChatUsecase{
 		llmUsecase:          llmUsecase,
@@ -40,6 +41,7 @@ func NewChatUsecase(llmUsecase *LLMUsecase, kbRepo *pg.KnowledgeBaseRepository,
 		blockWordRepo:       blockWordRepo,
 		kbRepo:              kbRepo,
 		AuthRepo:            authRepo,
+		searchQueryRepo:     searchQueryRepo,
 		logger:              logger.WithModule("usecase.chat"),
 		modelkit:            modelkit,
 	}
@@ -219,13 +221,23 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 		}
 
 		// 4. retrieve documents and format prompt
-		messages, rankedNodes, err := u.llmUsecase.FormatConversationMessages(ctx, req.ConversationID, req.KBID, groupIds, req.Prompt)
+		messages, rankedNodes, err := u.llmUsecase.FormatConversationMessages(ctx, req.ConversationID, req.KBID, groupIds, req.Prompt, req.Filter, req.Locale)
 		if err != nil {
 			u.logger.Error("failed to format chat messages", log.Error(err))
 			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to format chat messages"}
 			return
 		}
 
+		u.recordSearchQuery(ctx, &domain.KBSearchQuery{
+			KBID:           req.KBID,
+			Source:         domain.SearchQuerySourceChat,
+			AppType:        req.AppType,
+			Query:          req.Message,
+			ConversationID: req.ConversationID,
+			MessageID:      messageId,
+			RemoteIP:       req.RemoteIP,
+		}, rankedNodes)
+
 		u.logger.Debug("message:", log.Any("schema", messages))
 		for _, node := range rankedNodes {
 			chunkResult := domain.NodeContentChunkSSE{
@@ -343,12 +355,18 @@ func (u *ChatUsecase) ChatRagOnly(ctx context.Context, req *domain.ChatRagOnlyRe
 			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to get kb"}
 			return
 		}
-		rankedNodes, err := u.llmUsecase.GetRankNodes(ctx, []string{kb.DatasetID}, req.Message, groupIds, 0, nil)
+		rankedNodes, err := u.llmUsecase.GetRankNodes(ctx, []string{kb.DatasetID}, req.Message, groupIds, 0, nil, req.Filter, req.Locale)
 		if err != nil {
 			u.logger.Error("failed to get rank nodes", log.Error(err))
 			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to get rank nodes"}
 			return
 		}
+		u.recordSearchQuery(ctx, &domain.KBSearchQuery{
+			KBID:    req.KBID,
+			Source:  domain.SearchQuerySourceChat,
+			AppType: req.AppType,
+			Query:   req.Message,
+		}, rankedNodes)
 		documents := domain.FormatNodeChunks(rankedNodes, kb.AccessSettings.BaseURL)
 		u.logger.Debug("documents", log.String("documents", documents))
 
@@ -430,10 +448,16 @@ func (u *ChatUsecase) Search(ctx context.Context, req *domain.ChatSearchReq) (*d
 	if err != nil {
 		return nil, err
 	}
-	rankedNodes, err := u.llmUsecase.GetRankNodes(ctx, []string{kb.DatasetID}, req.Message, groupIds, 0.2, nil)
+	rankedNodes, err := u.llmUsecase.GetRankNodes(ctx, []string{kb.DatasetID}, req.Message, groupIds, 0.2, nil, req.Filter, req.Locale)
 	if err != nil {
 		return nil, err
 	}
+	u.recordSearchQuery(ctx, &domain.KBSearchQuery{
+		KBID:     req.KBID,
+		Source:   domain.SearchQuerySourceSearch,
+		Query:    req.Message,
+		RemoteIP: req.RemoteIP,
+	}, rankedNodes)
 	resp := domain.ChatSearchResp{}
 	for _, node := range rankedNodes {
 		chunkResult := domain.NodeContentChunkSSE{
@@ -447,3 +471,15 @@ func (u *ChatUsecase) Search(ctx context.Context, req *domain.ChatSearchReq) (*d
 	}
 	return &resp, nil
 }
+
+// recordSearchQuery logs the query with its retrieval result for search analytics, failures are logged only
+func (u *ChatUsecase) recordSearchQuery(ctx context.Context, query *domain.KBSearchQuery, rankedNodes []*domain.RankedNodeChunks) {
+	query.NormalizedQuery = utils.NormalizeSearchQuery(query.Query)
+	query.ResultCount = len(rankedNodes)
+	for _, node := range rankedNodes {
+		query.TopScore = max(query.TopScore, node.TopScore())
+	}
+	if err := u.searchQueryRepo.Create(ctx, query); err != nil {
+		u.logger.Error("record search query failed", log.String("kb_id", query.KBID), log.Error(err))
+	}
+}
@@ -16,21 +16,23 @@ import (
 )
 
 type CommentUsecase struct {
-	logger      *log.Logger
-	CommentRepo *pg.CommentRepository
-	NodeRepo    *pg.NodeRepository
-	ipRepo      *ipdb.IPAddressRepo
-	authRepo    *pg.AuthRepo
+	logger         *log.Logger
+	CommentRepo    *pg.CommentRepository
+	NodeRepo       *pg.NodeRepository
+	ipRepo         *ipdb.IPAddressRepo
+	authRepo       *pg.AuthRepo
+	webhookUsecase *WebhookUsecase
 }
 
 func NewCommentUsecase(commentRepo *pg.CommentRepository, logger *log.Logger,
-	nodeRepo *pg.NodeRepository, ipRepo *ipdb.IPAddressRepo, authRepo *pg.AuthRepo) *CommentUsecase {
+	nodeRepo *pg.NodeRepository, ipRepo *ipdb.IPAddressRepo, authRepo *pg.AuthRepo, webhookUsecase *WebhookUsecase) *CommentUsecase {
 	return &CommentUsecase{
-		logger:      logger.WithModule("usecase.comment"),
-		CommentRepo: commentRepo,
-		NodeRepo:    nodeRepo,
-		ipRepo:      ipRepo,
-		authRepo:    authRepo,
+		logger:         logger.WithModule("usecase.comment"),
+		CommentRepo:    commentRepo,
+		NodeRepo:       nodeRepo,
+		ipRepo:         ipRepo,
+		authRepo:       authRepo,
+		webhookUsecase: webhookUsecase,
 	}
 }
 
@@ -68,6 +70,15 @@ func (u *CommentUsecase) CreateComment(ctx context.Context, commentReq *domain.C
 		return "", err
 	}
 
+	u.webhookUsecase.Publish(ctx, KbID, domain.WebhookEventCommentCreated, &domain.WebhookCommentData{
+		ID:       CommentStr,
+		NodeID:   commentReq.NodeID,
+		ParentID: commentReq.ParentID,
+		UserName: commentReq.UserName,
+		Content:  commentReq.Content,
+		Status:   status,
+	})
+
 	// success
 	return CommentStr, nil
 }
@@ -15,12 +15,13 @@ import (
 )
 
 type ConversationUsecase struct {
-	repo         *pg.ConversationRepository
-	nodeRepo     *pg.NodeRepository
-	geoCacheRepo *cache.GeoRepo
-	logger       *log.Logger
-	ipRepo       *ipdb.IPAddressRepo
-	authRepo     *pg.AuthRepo
+	repo           *pg.ConversationRepository
+	nodeRepo       *pg.NodeRepository
+	geoCacheRepo   *cache.GeoRepo
+	logger         *log.Logger
+	ipRepo         *ipdb.IPAddressRepo
+	authRepo       *pg.AuthRepo
+	webhookUsecase *WebhookUsecase
 }
 
 func NewConversationUsecase(
@@ -30,14 +31,16 @@ func NewConversationUsecase(
 	logger *log.Logger,
 	ipRepo *ipdb.IPAddressRepo,
 	authRepo *pg.AuthRepo,
+	webhookUsecase *WebhookUsecase,
 ) *ConversationUsecase {
 	return &ConversationUsecase{
-		repo:         repo,
-		nodeRepo:     nodeRepo,
-		geoCacheRepo: geoCacheRepo,
-		ipRepo:       ipRepo,
-		authRepo:     authRepo,
-		logger:       logger.WithModule("usecase.conversation"),
+		repo:           repo,
+		nodeRepo:       nodeRepo,
+		geoCacheRepo:   geoCacheRepo,
+		ipRepo:         ipRepo,
+		authRepo:       authRepo,
+		webhookUsecase: webhookUsecase,
+		logger:         logger.WithModule("usecase.conversation"),
 	}
 }
 
@@ -183,6 +186,11 @@ func (u *ConversationUsecase) CreateConversation(ctx context.Context, conversati
 			u.logger.Warn("set geo cache failed", log.Error(err), log.String("conversation_id", conversation.ID), log.String("ip", remoteIP))
 		}
 	}
+	u.webhookUsecase.Publish(ctx, conversation.KBID, domain.WebhookEventConversationStarted, &domain.WebhookConversationData{
+		ID:      conversation.ID,
+		AppID:   conversation.AppID,
+		Subject: conversation.Subject,
+	})
 	return nil
 }
 
@@ -203,6 +211,13 @@ func (u *ConversationUsecase) FeedBack(ctx context.Context, feedback *domain.Fee
 	} else {
 		return fmt.Errorf("already voted for this message, please do not vote again")
 	}
+	u.webhookUsecase.Publish(ctx, messages.KBID, domain.WebhookEventFeedbackReceived, &domain.WebhookFeedbackData{
+		ConversationID: messages.ConversationID,
+		MessageID:      messages.ID,
+		Score:          feedback.Score,
+		Type:           feedback.Type,
+		Content:        feedback.FeedbackContent,
+	})
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 
 	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
 	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/mq"
 	"github.com/chaitin/panda-wiki/pkg/anydoc"
@@ -25,8 +26,16 @@ type CrawlerUsecase struct {
 	cache        *cache.Cache
 }
 
-func NewCrawlerUsecase(logger *log.Logger, mqConsumer mq.MQConsumer, cache *cache.Cache) (*CrawlerUsecase, error) {
-	anydocClient, err := anydoc.NewClient(logger, mqConsumer)
+func NewCrawlerUsecase(logger *log.Logger, mqConsumer mq.MQConsumer, cache *cache.Cache, fileUsecase *FileUsecase) (*CrawlerUsecase, error) {
+	// images extracted by the local parsers are stored like uploaded images
+	uploader := func(ctx context.Context, kbID, filename string, data []byte) (string, error) {
+		key, err := fileUsecase.UploadFileFromBytes(ctx, kbID, filename, data)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("/%s/%s", domain.Bucket, key), nil
+	}
+	anydocClient, err := anydoc.NewClient(logger, mqConsumer, cache, uploader)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +61,7 @@ func (u *CrawlerUsecase) ParseUrl(ctx context.Context, req *v1.CrawlerParseReq)
 
 	// 文件类型的解析会先走上传接口
 	if req.CrawlerSource.Type() == consts.CrawlerSourceTypeFile {
-		req.Key = fmt.Sprintf("http://panda-wiki-minio:9000/static-file/%s", req.Key)
+		req.Key = anydoc.StaticFileURLPrefix + req.Key
 	}
 
 	var (
@@ -124,6 +133,9 @@ func (u *CrawlerUsecase) ParseUrl(ctx context.Context, req *v1.CrawlerParseReq)
 			return nil, err
 		}
 
+	case consts.CrawlerSourceGit:
+		return nil, fmt.Errorf("git repositories are synced with /api/v1/crawler/git")
+
 	default:
 		return nil, fmt.Errorf("parse type %s is not supported", req.CrawlerSource)
 	}
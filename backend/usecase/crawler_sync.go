@@ -0,0 +1,627 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/anydoc"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+const (
+	crawlerSyncFolderPrefix   = "folder:"
+	crawlerSyncKeepRuns       = 50
+	crawlerSyncTaskBatchSize  = 50
+	crawlerSyncPollInterval   = 3 * time.Second
+	crawlerSyncExportDeadline = 30 * time.Minute
+	crawlerSyncTimeout        = 2 * time.Hour
+	// a run still running after this was interrupted, e.g. by a restart of the consumer running it
+	crawlerSyncStaleAfter = crawlerSyncTimeout + 5*time.Minute
+)
+
+type CrawlerSyncUsecase struct {
+	syncRepo       *pg.CrawlerSyncRepository
+	nodeRepo       *pg.NodeRepository
+	nodeUsecase    *NodeUsecase
+	kbUsecase      *KnowledgeBaseUsecase
+	crawlerUsecase *CrawlerUsecase
+	logger         *log.Logger
+}
+
+func NewCrawlerSyncUsecase(
+	syncRepo *pg.CrawlerSyncRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeUsecase *NodeUsecase,
+	kbUsecase *KnowledgeBaseUsecase,
+	crawlerUsecase *CrawlerUsecase,
+	logger *log.Logger,
+) *CrawlerSyncUsecase {
+	return &CrawlerSyncUsecase{
+		syncRepo:       syncRepo,
+		nodeRepo:       nodeRepo,
+		nodeUsecase:    nodeUsecase,
+		kbUsecase:      kbUsecase,
+		crawlerUsecase: crawlerUsecase,
+		logger:         logger.WithModule("usecase.crawler_sync"),
+	}
+}
+
+func (u *CrawlerSyncUsecase) CreateSync(ctx context.Context, req *v1.CrawlerSyncCreateReq, userID string, maxNode int) (string, error) {
+	sync := &domain.KBCrawlerSync{
+		ID:            uuid.New().String(),
+		KBID:          req.KbID,
+		Name:          req.Name,
+		CrawlerSource: req.CrawlerSource,
+		Key:           req.Key,
+		Filename:      req.Filename,
+		FeishuSetting: domain.CrawlerSyncFeishuSetting(req.FeishuSetting),
+		ParentID:      req.ParentID,
+		Schedule:      req.Schedule,
+		AutoPublish:   req.AutoPublish,
+		MaxNode:       maxNode,
+		Status:        domain.CrawlerSyncStatusIdle,
+		CreatorID:     userID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := validateCrawlerSync(sync); err != nil {
+		return "", err
+	}
+	if sync.Name == "" {
+		sync.Name = sync.Filename
+		if sync.Name == "" {
+			sync.Name = sync.Key
+		}
+	}
+	if sync.ParentID != "" {
+		parent, err := u.nodeRepo.GetNodeByID(ctx, sync.ParentID)
+		if err != nil || parent.KBID != sync.KBID || parent.Type != domain.NodeTypeFolder {
+			return "", fmt.Errorf("%w: parent folder not found", domain.ErrInvalidCrawlerSync)
+		}
+	}
+	sync.NextRunAt = crawlerSyncNextRunAt(sync.Schedule, time.Now())
+	if err := u.syncRepo.Create(ctx, sync); err != nil {
+		return "", err
+	}
+	return sync.ID, nil
+}
+
+// UpdateSync changes the sync settings, docs which are not listed by a changed key are removed on the next run
+func (u *CrawlerSyncUsecase) UpdateSync(ctx context.Context, req *v1.CrawlerSyncUpdateReq, maxNode int) error {
+	sync, err := u.syncRepo.GetByID(ctx, req.KbID, req.SyncID)
+	if err != nil {
+		return err
+	}
+	if sync.Status != domain.CrawlerSyncStatusIdle {
+		return domain.ErrCrawlerSyncRunning
+	}
+	if req.Name != nil {
+		sync.Name = *req.Name
+	}
+	if req.Key != nil {
+		sync.Key = *req.Key
+	}
+	if req.Filename != nil {
+		sync.Filename = *req.Filename
+	}
+	if req.FeishuSetting != nil {
+		sync.FeishuSetting.AppID = req.FeishuSetting.AppID
+		sync.FeishuSetting.SpaceId = req.FeishuSetting.SpaceId
+		if req.FeishuSetting.AppSecret != "" {
+			sync.FeishuSetting.AppSecret = req.FeishuSetting.AppSecret
+		}
+		if req.FeishuSetting.UserAccessToken != "" {
+			sync.FeishuSetting.UserAccessToken = req.FeishuSetting.UserAccessToken
+		}
+	}
+	if req.Schedule != nil {
+		sync.Schedule = *req.Schedule
+	}
+	if req.AutoPublish != nil {
+		sync.AutoPublish = *req.AutoPublish
+	}
+	if err := validateCrawlerSync(sync); err != nil {
+		return err
+	}
+	return u.syncRepo.Update(ctx, sync.ID, map[string]any{
+		"name":           sync.Name,
+		"key":            sync.Key,
+		"filename":       sync.Filename,
+		"feishu_setting": sync.FeishuSetting,
+		"schedule":       sync.Schedule,
+		"auto_publish":   sync.AutoPublish,
+		"max_node":       maxNode,
+		"next_run_at":    crawlerSyncNextRunAt(sync.Schedule, time.Now()),
+	})
+}
+
+func (u *CrawlerSyncUsecase) GetSyncList(ctx context.Context, req *v1.CrawlerSyncListReq) ([]*v1.CrawlerSyncListItem, error) {
+	syncs, err := u.syncRepo.GetListByKBID(ctx, req.KbID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.CrawlerSyncListItem, 0, len(syncs))
+	for _, sync := range syncs {
+		items = append(items, &v1.CrawlerSyncListItem{
+			KBCrawlerSync:   sync,
+			FeishuAppID:     sync.FeishuSetting.AppID,
+			FeishuSpaceID:   sync.FeishuSetting.SpaceId,
+			HasFeishuSecret: sync.FeishuSetting.AppSecret != "",
+		})
+	}
+	return items, nil
+}
+
+// DeleteSync removes the sync with its history, synced nodes are kept
+func (u *CrawlerSyncUsecase) DeleteSync(ctx context.Context, req *v1.CrawlerSyncDeleteReq) error {
+	sync, err := u.syncRepo.GetByID(ctx, req.KbID, req.SyncID)
+	if err != nil {
+		return err
+	}
+	if sync.Status == domain.CrawlerSyncStatusRunning {
+		return domain.ErrCrawlerSyncRunning
+	}
+	return u.syncRepo.Delete(ctx, req.KbID, req.SyncID)
+}
+
+// RunSync queues a run of the sync, it is picked up by the consumer within a minute
+func (u *CrawlerSyncUsecase) RunSync(ctx context.Context, req *v1.CrawlerSyncRunReq, maxNode int) error {
+	if _, err := u.syncRepo.GetByID(ctx, req.KbID, req.SyncID); err != nil {
+		return err
+	}
+	queued, err := u.syncRepo.QueueRun(ctx, req.KbID, req.SyncID, map[string]any{"max_node": maxNode})
+	if err != nil {
+		return err
+	}
+	if !queued {
+		return domain.ErrCrawlerSyncRunning
+	}
+	return nil
+}
+
+func (u *CrawlerSyncUsecase) GetRunList(ctx context.Context, req *v1.CrawlerSyncRunListReq) (*v1.CrawlerSyncRunListResp, error) {
+	total, runs, err := u.syncRepo.GetRunList(ctx, req.KbID, req.SyncID, req.Offset(), req.Limit())
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(runs, uint64(total)), nil
+}
+
+// FailStale marks the runs which are still running after the timeout as failed and makes their syncs idle again
+func (u *CrawlerSyncUsecase) FailStale(ctx context.Context) error {
+	return u.syncRepo.FailStale(ctx, time.Now().Add(-crawlerSyncStaleAfter), "sync interrupted or timed out")
+}
+
+// RunDueSyncs starts the queued and scheduled syncs in the background
+func (u *CrawlerSyncUsecase) RunDueSyncs(ctx context.Context) error {
+	syncs, err := u.syncRepo.GetDueList(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, sync := range syncs {
+		trigger := domain.CrawlerSyncTriggerSchedule
+		if sync.Status == domain.CrawlerSyncStatusQueued {
+			trigger = domain.CrawlerSyncTriggerManual
+		}
+		run := &domain.KBCrawlerSyncRun{
+			ID:        uuid.New().String(),
+			SyncID:    sync.ID,
+			KBID:      sync.KBID,
+			Trigger:   trigger,
+			Status:    domain.CrawlerSyncRunStatusRunning,
+			DocErrors: domain.CrawlerSyncDocErrors{},
+			StartedAt: time.Now(),
+		}
+		started, err := u.syncRepo.StartRun(ctx, sync, run)
+		if err != nil {
+			return err
+		}
+		if !started {
+			continue
+		}
+		go u.runSync(sync, run)
+	}
+	return nil
+}
+
+func (u *CrawlerSyncUsecase) runSync(sync *domain.KBCrawlerSync, run *domain.KBCrawlerSyncRun) {
+	ctx, cancel := context.WithTimeout(context.Background(), crawlerSyncTimeout)
+	defer cancel()
+	logger := u.logger.With(log.String("kb_id", sync.KBID), log.String("sync_id", sync.ID), log.String("run_id", run.ID))
+
+	err := u.sync(ctx, sync, run)
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.CrawlerSyncRunStatusCompleted
+	if err != nil {
+		logger.Error("crawler sync failed", log.Error(err))
+		run.Status = domain.CrawlerSyncRunStatusFailed
+		run.Error = err.Error()
+	} else {
+		logger.Info("crawler sync completed", log.Int("created", run.Created), log.Int("updated", run.Updated),
+			log.Int("deleted", run.Deleted), log.Int("failed", run.Failed))
+	}
+	if err := u.syncRepo.FinishRun(ctx, run, crawlerSyncNextRunAt(sync.Schedule, finishedAt)); err != nil {
+		logger.Error("finish crawler sync run failed", log.Error(err))
+	}
+	if err := u.syncRepo.TrimRuns(ctx, sync.ID, crawlerSyncKeepRuns); err != nil {
+		logger.Error("trim crawler sync runs failed", log.Error(err))
+	}
+}
+
+type crawlerSyncItem struct {
+	key       string
+	parentKey string
+	docID     string
+	title     string
+	fileType  string
+	folder    bool
+}
+
+// sync lists the source, exports every doc and applies the difference to the mapped nodes,
+// docs failing to export keep their nodes untouched
+func (u *CrawlerSyncUsecase) sync(ctx context.Context, sync *domain.KBCrawlerSync, run *domain.KBCrawlerSyncRun) error {
+	parsed, err := u.crawlerUsecase.ParseUrl(ctx, &v1.CrawlerParseReq{
+		Key:           sync.Key,
+		KbID:          sync.KBID,
+		CrawlerSource: sync.CrawlerSource,
+		Filename:      sync.Filename,
+		FeishuSetting: v1.FeishuSetting(sync.FeishuSetting),
+	})
+	if err != nil {
+		return fmt.Errorf("parse source failed: %w", err)
+	}
+	items := flattenCrawlerDocs(parsed.Docs)
+
+	contents := u.exportDocs(ctx, sync, parsed.ID, items, run)
+
+	docs, err := u.syncRepo.GetDocs(ctx, sync.ID)
+	if err != nil {
+		return err
+	}
+	nodeIDs := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		nodeIDs = append(nodeIDs, doc.NodeID)
+	}
+	// nodes deleted in the kb are created again
+	existing, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return err
+	}
+	mapped := make(map[string]*domain.KBCrawlerSyncDoc, len(docs))
+	for _, doc := range docs {
+		if _, ok := existing[doc.NodeID]; ok {
+			mapped[doc.DocKey] = doc
+		}
+	}
+
+	// nodes are never moved, admins may reorganize synced docs freely
+	contentType := domain.ContentTypeMD
+	changed := make([]string, 0)
+	for _, item := range items {
+		parentID := sync.ParentID
+		if item.parentKey != "" {
+			parent, ok := mapped[item.parentKey]
+			if !ok {
+				if !item.folder {
+					addCrawlerSyncDocError(run, item, errors.New("parent folder failed to sync"))
+				}
+				continue
+			}
+			parentID = parent.NodeID
+		}
+
+		content, ok := contents[item.key]
+		if !item.folder && !ok {
+			// export failed, already recorded
+			continue
+		}
+		hash := crawlerSyncContentHash(item.title, content)
+		if doc, ok := mapped[item.key]; ok {
+			if doc.ContentHash == hash {
+				run.Unchanged++
+				continue
+			}
+			req := &domain.UpdateNodeReq{ID: doc.NodeID, KBID: sync.KBID, Name: &item.title}
+			if !item.folder {
+				req.Content = &content
+				req.ContentType = &contentType
+			}
+			if _, err := u.nodeUsecase.Update(ctx, req, sync.CreatorID); err != nil {
+				addCrawlerSyncDocError(run, item, fmt.Errorf("update node failed: %w", err))
+				continue
+			}
+			doc.Title = item.title
+			doc.ContentHash = hash
+			if err := u.syncRepo.SaveDoc(ctx, doc); err != nil {
+				return err
+			}
+			run.Updated++
+			changed = append(changed, doc.NodeID)
+			continue
+		}
+
+		req := &domain.CreateNodeReq{
+			KBID:     sync.KBID,
+			ParentID: parentID,
+			Type:     domain.NodeTypeDocument,
+			Name:     item.title,
+			MaxNode:  sync.MaxNode,
+		}
+		if item.folder {
+			req.Type = domain.NodeTypeFolder
+		} else {
+			req.Content = content
+			req.ContentType = &contentType
+		}
+		nodeID, err := u.nodeUsecase.Create(ctx, req, sync.CreatorID)
+		if err != nil {
+			addCrawlerSyncDocError(run, item, fmt.Errorf("create node failed: %w", err))
+			continue
+		}
+		doc := &domain.KBCrawlerSyncDoc{
+			SyncID:      sync.ID,
+			DocKey:      item.key,
+			KBID:        sync.KBID,
+			NodeID:      nodeID,
+			Type:        req.Type,
+			Title:       item.title,
+			ContentHash: hash,
+		}
+		if err := u.syncRepo.SaveDoc(ctx, doc); err != nil {
+			return err
+		}
+		mapped[item.key] = doc
+		run.Created++
+		changed = append(changed, nodeID)
+	}
+
+	listed := make(map[string]bool, len(items))
+	for _, item := range items {
+		listed[item.key] = true
+	}
+	if err := u.deleteUnlisted(ctx, sync, docs, existing, listed, run); err != nil {
+		return err
+	}
+
+	if sync.AutoPublish && (len(changed) > 0 || run.Deleted > 0) {
+		releaseID, err := u.kbUsecase.CreateKBRelease(ctx, &domain.CreateKBReleaseReq{
+			KBID:    sync.KBID,
+			Message: fmt.Sprintf("crawler sync: %s", sync.Name),
+			Tag:     fmt.Sprintf("sync-%s", time.Now().Format("20060102150405")),
+			NodeIDs: changed,
+		}, sync.CreatorID)
+		if err != nil {
+			return fmt.Errorf("publish synced docs failed: %w", err)
+		}
+		run.ReleaseID = releaseID
+	}
+	return nil
+}
+
+// exportDocs exports the docs of the source and waits for the markdown, the content is keyed by doc key
+func (u *CrawlerSyncUsecase) exportDocs(ctx context.Context, sync *domain.KBCrawlerSync, parseID string, items []*crawlerSyncItem, run *domain.KBCrawlerSyncRun) map[string]string {
+	spaceID := ""
+	if sync.CrawlerSource == consts.CrawlerSourceFeishu {
+		spaceID = sync.FeishuSetting.SpaceId
+		if spaceID == "" {
+			spaceID = anydoc.SpaceIdCloud
+		}
+	}
+	tasks := make(map[string]*crawlerSyncItem)
+	for _, item := range items {
+		if item.folder {
+			continue
+		}
+		resp, err := u.crawlerUsecase.ExportDoc(ctx, &v1.CrawlerExportReq{
+			KbID:     sync.KBID,
+			ID:       parseID,
+			DocID:    item.docID,
+			SpaceId:  spaceID,
+			FileType: item.fileType,
+		})
+		if err != nil {
+			addCrawlerSyncDocError(run, item, fmt.Errorf("export failed: %w", err))
+			continue
+		}
+		tasks[resp.TaskId] = item
+	}
+
+	contents := make(map[string]string, len(tasks))
+	deadline := time.Now().Add(crawlerSyncExportDeadline)
+	for len(tasks) > 0 && time.Now().Before(deadline) {
+		time.Sleep(crawlerSyncPollInterval)
+		for _, taskIDs := range lo.Chunk(lo.Keys(tasks), crawlerSyncTaskBatchSize) {
+			res, err := u.crawlerUsecase.anydocClient.TaskList(ctx, taskIDs)
+			if err != nil {
+				// polled again in the next round
+				u.logger.Warn("get crawler sync tasks failed", log.String("sync_id", sync.ID), log.Error(err))
+				continue
+			}
+			for _, task := range res.Data {
+				item, ok := tasks[task.TaskId]
+				if !ok {
+					continue
+				}
+				switch task.Status {
+				case anydoc.StatusCompleted:
+					delete(tasks, task.TaskId)
+					data, err := u.crawlerUsecase.anydocClient.DownloadDoc(ctx, task.Markdown)
+					if err != nil {
+						addCrawlerSyncDocError(run, item, fmt.Errorf("download failed: %w", err))
+						continue
+					}
+					contents[item.key] = string(data)
+				case anydoc.StatusFailed:
+					delete(tasks, task.TaskId)
+					addCrawlerSyncDocError(run, item, fmt.Errorf("export failed: %s", task.Err))
+				}
+			}
+		}
+	}
+	for _, item := range tasks {
+		addCrawlerSyncDocError(run, item, errors.New("export timed out"))
+	}
+	return contents
+}
+
+// deleteUnlisted moves the nodes of docs gone from the source to the trash,
+// folders are kept while anything not synced from the source lives in them
+func (u *CrawlerSyncUsecase) deleteUnlisted(ctx context.Context, sync *domain.KBCrawlerSync, docs []*domain.KBCrawlerSyncDoc, existing map[string]string, listed map[string]bool, run *domain.KBCrawlerSyncRun) error {
+	removedKeys := make([]string, 0)
+	nodeIDs := make([]string, 0)
+	folders := make([]*domain.KBCrawlerSyncDoc, 0)
+	for _, doc := range docs {
+		if listed[doc.DocKey] {
+			continue
+		}
+		if _, ok := existing[doc.NodeID]; !ok {
+			removedKeys = append(removedKeys, doc.DocKey)
+			continue
+		}
+		if doc.Type == domain.NodeTypeFolder {
+			folders = append(folders, doc)
+			continue
+		}
+		removedKeys = append(removedKeys, doc.DocKey)
+		nodeIDs = append(nodeIDs, doc.NodeID)
+	}
+	if len(nodeIDs) > 0 {
+		if err := u.nodeRepo.Delete(ctx, sync.KBID, nodeIDs, sync.CreatorID); err != nil {
+			return err
+		}
+		run.Deleted += len(nodeIDs)
+	}
+
+	// nested folders become empty one level per round
+	for removed := true; removed && len(folders) > 0; {
+		removed = false
+		remaining := folders[:0]
+		for _, folder := range folders {
+			if ids := u.nodeRepo.GetAllChildNodeIDs(ctx, sync.KBID, []string{folder.NodeID}); len(ids) > 1 {
+				remaining = append(remaining, folder)
+				continue
+			}
+			if err := u.nodeRepo.Delete(ctx, sync.KBID, []string{folder.NodeID}, sync.CreatorID); err != nil {
+				return err
+			}
+			removedKeys = append(removedKeys, folder.DocKey)
+			run.Deleted++
+			removed = true
+		}
+		folders = remaining
+	}
+	// folders left with other docs are handed over to the admins
+	for _, folder := range folders {
+		removedKeys = append(removedKeys, folder.DocKey)
+	}
+	return u.syncRepo.DeleteDocs(ctx, sync.ID, removedKeys)
+}
+
+// flattenCrawlerDocs lists the parsed tree parents first, a doc with children also gets a folder holding them
+func flattenCrawlerDocs(root anydoc.Child) []*crawlerSyncItem {
+	items := make([]*crawlerSyncItem, 0)
+	seen := make(map[string]bool)
+	add := func(item *crawlerSyncItem) {
+		if seen[item.key] {
+			return
+		}
+		seen[item.key] = true
+		if item.title == "" {
+			item.title = item.docID
+		}
+		items = append(items, item)
+	}
+	var walk func(child anydoc.Child, parentKey string)
+	walk = func(child anydoc.Child, parentKey string) {
+		value := child.Value
+		if len(child.Children) == 0 || value.ID == "" {
+			if value.File && value.ID != "" {
+				add(&crawlerSyncItem{key: value.ID, parentKey: parentKey, docID: value.ID, title: value.Title, fileType: value.FileType})
+			}
+			for _, c := range child.Children {
+				walk(c, parentKey)
+			}
+			return
+		}
+		folderKey := crawlerSyncFolderPrefix + value.ID
+		add(&crawlerSyncItem{key: folderKey, parentKey: parentKey, docID: value.ID, title: value.Title, folder: true})
+		if value.File {
+			add(&crawlerSyncItem{key: value.ID, parentKey: folderKey, docID: value.ID, title: value.Title, fileType: value.FileType})
+		}
+		for _, c := range child.Children {
+			walk(c, folderKey)
+		}
+	}
+	// the root is the source itself, its children go straight into the parent folder
+	if root.Value.File && root.Value.ID != "" {
+		add(&crawlerSyncItem{key: root.Value.ID, docID: root.Value.ID, title: root.Value.Title, fileType: root.Value.FileType})
+	}
+	for _, c := range root.Children {
+		walk(c, "")
+	}
+	return items
+}
+
+func addCrawlerSyncDocError(run *domain.KBCrawlerSyncRun, item *crawlerSyncItem, err error) {
+	run.Failed++
+	run.DocErrors = append(run.DocErrors, domain.CrawlerSyncDocError{
+		DocID: item.docID,
+		Title: item.title,
+		Error: err.Error(),
+	})
+}
+
+func crawlerSyncContentHash(title, content string) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// crawlerSyncNextRunAt returns the next scheduled run, nil when the sync only runs manually
+func crawlerSyncNextRunAt(schedule string, now time.Time) *time.Time {
+	if schedule == "" {
+		return nil
+	}
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil
+	}
+	next := sched.Next(now)
+	return &next
+}
+
+func validateCrawlerSync(sync *domain.KBCrawlerSync) error {
+	switch sync.CrawlerSource.Type() {
+	case consts.CrawlerSourceTypeRepo:
+		return fmt.Errorf("%w: git repositories are synced with /api/v1/crawler/git", domain.ErrInvalidCrawlerSync)
+	case consts.CrawlerSourceTypeKey, consts.CrawlerSourceTypeUrl, consts.CrawlerSourceTypeFile:
+	default:
+		return fmt.Errorf("%w: crawler source %s is not supported", domain.ErrInvalidCrawlerSync, sync.CrawlerSource)
+	}
+	if sync.CrawlerSource == consts.CrawlerSourceFeishu {
+		setting := sync.FeishuSetting
+		if setting.AppID == "" || setting.AppSecret == "" || setting.UserAccessToken == "" {
+			return fmt.Errorf("%w: feishu app id, app secret and user access token are required", domain.ErrInvalidCrawlerSync)
+		}
+	} else if sync.Key == "" {
+		return fmt.Errorf("%w: key is required", domain.ErrInvalidCrawlerSync)
+	}
+	if sync.Schedule != "" {
+		if _, err := cron.ParseStandard(sync.Schedule); err != nil {
+			return fmt.Errorf("%w: invalid schedule: %v", domain.ErrInvalidCrawlerSync, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/ipdb"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+type DocumentFeedbackUsecase struct {
+	repo           *pg.DocumentFeedbackRepository
+	nodeRepo       *pg.NodeRepository
+	ipRepo         *ipdb.IPAddressRepo
+	authRepo       *pg.AuthRepo
+	webhookUsecase *WebhookUsecase
+	logger         *log.Logger
+}
+
+func NewDocumentFeedbackUsecase(repo *pg.DocumentFeedbackRepository, nodeRepo *pg.NodeRepository, ipRepo *ipdb.IPAddressRepo, authRepo *pg.AuthRepo, webhookUsecase *WebhookUsecase, logger *log.Logger) *DocumentFeedbackUsecase {
+	return &DocumentFeedbackUsecase{
+		repo:           repo,
+		nodeRepo:       nodeRepo,
+		ipRepo:         ipRepo,
+		authRepo:       authRepo,
+		webhookUsecase: webhookUsecase,
+		logger:         logger.WithModule("usecase.document_feedback"),
+	}
+}
+
+// CreateFeedback records the feedback against the published release of the node, a session that changes
+// its mind updates its open feedback instead of adding another one
+func (u *DocumentFeedbackUsecase) CreateFeedback(ctx context.Context, kbID string, req *domain.DocumentFeedbackReq, sessionID, remoteIP string, authUserID uint) (int64, error) {
+	releases, err := u.nodeRepo.GetLatestNodeReleaseByNodeIDs(ctx, kbID, []string{req.NodeID})
+	if err != nil {
+		return 0, err
+	}
+	if len(releases) == 0 {
+		return 0, domain.ErrDocumentFeedbackNodeNotPublished
+	}
+	releaseID := releases[0].ID
+
+	var feedback *domain.DocumentFeedback
+	if sessionID != "" {
+		feedback, err = u.repo.GetOpenBySession(ctx, req.NodeID, sessionID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if feedback != nil {
+		if err := u.repo.Update(ctx, feedback.ID, map[string]any{
+			"node_release_id": releaseID,
+			"score":           req.Score,
+			"reason":          req.Reason,
+			"content":         req.Content,
+		}); err != nil {
+			return 0, err
+		}
+	} else {
+		feedback = &domain.DocumentFeedback{
+			KbID:          kbID,
+			NodeID:        req.NodeID,
+			NodeReleaseID: releaseID,
+			SessionID:     sessionID,
+			Score:         req.Score,
+			Reason:        req.Reason,
+			Content:       req.Content,
+			Info: domain.DocumentFeedbackInfo{
+				AuthUserID: authUserID,
+				RemoteIP:   remoteIP,
+			},
+			Status:    domain.DocumentFeedbackStatusOpen,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := u.repo.Create(ctx, feedback); err != nil {
+			return 0, err
+		}
+	}
+
+	u.webhookUsecase.Publish(ctx, kbID, domain.WebhookEventNodeFeedbackReceived, &domain.WebhookNodeFeedbackData{
+		ID:            feedback.ID,
+		NodeID:        req.NodeID,
+		NodeReleaseID: releaseID,
+		Score:         req.Score,
+		Reason:        req.Reason,
+		Content:       req.Content,
+	})
+	return feedback.ID, nil
+}
+
+func (u *DocumentFeedbackUsecase) GetList(ctx context.Context, req *v1.NodeFeedbackListReq) (*v1.NodeFeedbackListResp, error) {
+	items, total, err := u.repo.GetList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return domain.NewPaginatedResult(items, uint64(total)), nil
+	}
+
+	// a newer release means the doc was edited after the feedback
+	nodeIDs := lo.Uniq(lo.Map(items, func(item *v1.NodeFeedbackListItem, _ int) string {
+		return item.NodeID
+	}))
+	releases, err := u.nodeRepo.GetLatestNodeReleaseByNodeIDs(ctx, req.KbID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	latestReleases := lo.SliceToMap(releases, func(release *domain.NodeRelease) (string, string) {
+		return release.NodeID, release.ID
+	})
+
+	authIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		if item.Info.AuthUserID != 0 {
+			authIDs = append(authIDs, item.Info.AuthUserID)
+		}
+	}
+	authMap, err := u.authRepo.GetAuthUserinfoByIDs(ctx, lo.Uniq(authIDs))
+	if err != nil {
+		u.logger.Error("get auth user info failed", log.Error(err))
+	}
+
+	ipAddressMap := make(map[string]*domain.IPAddress)
+	for _, item := range items {
+		latestID, ok := latestReleases[item.NodeID]
+		item.Republished = ok && item.NodeReleaseID != "" && latestID != item.NodeReleaseID
+		if auth, ok := authMap[item.Info.AuthUserID]; ok {
+			item.Info.UserName = auth.AuthUserInfo.Username
+			item.Info.Avatar = auth.AuthUserInfo.AvatarUrl
+			item.Info.Email = auth.AuthUserInfo.Email
+		}
+		if item.Info.RemoteIP == "" {
+			continue
+		}
+		if _, ok := ipAddressMap[item.Info.RemoteIP]; !ok {
+			ipAddress, err := u.ipRepo.GetIPAddress(ctx, item.Info.RemoteIP)
+			if err != nil {
+				u.logger.Error("get ip address failed", log.Error(err), log.String("ip", item.Info.RemoteIP))
+				continue
+			}
+			ipAddressMap[item.Info.RemoteIP] = ipAddress
+		}
+		item.IPAddress = ipAddressMap[item.Info.RemoteIP]
+	}
+	return domain.NewPaginatedResult(items, uint64(total)), nil
+}
+
+func (u *DocumentFeedbackUsecase) UpdateStatus(ctx context.Context, req *v1.NodeFeedbackStatusReq, userID string) error {
+	return u.repo.UpdateStatus(ctx, req.KbID, lo.Uniq(req.IDs), req.Status, userID)
+}
+
+// Link resolves the feedback with the release of the same doc that fixed it
+func (u *DocumentFeedbackUsecase) Link(ctx context.Context, req *v1.NodeFeedbackLinkReq, userID string) error {
+	feedback, err := u.repo.GetByID(ctx, req.KbID, req.ID)
+	if err != nil {
+		return err
+	}
+	release, err := u.nodeRepo.GetNodeReleaseByID(ctx, req.ReleaseID)
+	if err != nil {
+		return err
+	}
+	if release.KBID != req.KbID || release.NodeID != feedback.NodeID {
+		return domain.ErrInvalidDocumentFeedbackRelease
+	}
+	return u.repo.Update(ctx, feedback.ID, map[string]any{
+		"status":              domain.DocumentFeedbackStatusResolved,
+		"resolved_by":         userID,
+		"resolved_at":         time.Now(),
+		"resolved_release_id": release.ID,
+	})
+}
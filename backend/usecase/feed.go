@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	feedNodeLimit       = 50
+	feedReleaseLimit    = 20
+	feedSummaryMaxRunes = 300
+)
+
+// FeedUsecase publishes the recently released documents and release notes of a kb as Atom and JSON Feed
+type FeedUsecase struct {
+	nodeRepo *pg.NodeRepository
+	kbRepo   *pg.KnowledgeBaseRepository
+	appRepo  *pg.AppRepository
+	logger   *log.Logger
+}
+
+func NewFeedUsecase(nodeRepo *pg.NodeRepository, kbRepo *pg.KnowledgeBaseRepository, appRepo *pg.AppRepository, logger *log.Logger) *FeedUsecase {
+	return &FeedUsecase{
+		nodeRepo: nodeRepo,
+		kbRepo:   kbRepo,
+		appRepo:  appRepo,
+		logger:   logger.WithModule("usecase.feed"),
+	}
+}
+
+type feed struct {
+	Title       string
+	Description string
+	Link        string
+	Entries     []*feedEntry
+}
+
+type feedEntry struct {
+	ID        string
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+func (u *FeedUsecase) getFeed(ctx context.Context, kbID string) (*feed, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge base: %w", err)
+	}
+	baseURL := kb.AccessSettings.BaseURL
+	f := &feed{
+		Title:   kb.Name,
+		Link:    baseURL,
+		Entries: make([]*feedEntry, 0),
+	}
+	app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web app: %w", err)
+	}
+	if app.Settings.Title != "" {
+		f.Title = app.Settings.Title
+	}
+	f.Description = app.Settings.Desc
+
+	// nothing is public when visitors have to log in
+	settings := kb.AccessSettings
+	if settings.IsForbidden || settings.SimpleAuth.Enabled || settings.EnterpriseAuth.Enabled {
+		return f, nil
+	}
+
+	nodes, err := u.nodeRepo.GetFeedNodeReleases(ctx, kbID, feedNodeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node releases: %w", err)
+	}
+	for _, node := range nodes {
+		summary := node.Meta.Summary
+		if summary == "" {
+			summary = utils.ContentToText(node.Content)
+		}
+		f.Entries = append(f.Entries, &feedEntry{
+			ID:        fmt.Sprintf("%s/node/%s", baseURL, node.ID),
+			Title:     node.Name,
+			Link:      fmt.Sprintf("%s/node/%s", baseURL, node.ID),
+			Summary:   truncateFeedSummary(summary),
+			Published: node.PublishedAt,
+			Updated:   node.ReleasedAt,
+		})
+	}
+
+	releases, err := u.kbRepo.GetRecentReleases(ctx, kbID, feedReleaseLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kb releases: %w", err)
+	}
+	for _, release := range releases {
+		f.Entries = append(f.Entries, &feedEntry{
+			ID:        fmt.Sprintf("urn:panda-wiki:release:%s", release.ID),
+			Title:     fmt.Sprintf("发布 %s", release.Tag),
+			Link:      baseURL,
+			Summary:   truncateFeedSummary(release.Message),
+			Published: release.CreatedAt,
+			Updated:   release.CreatedAt,
+		})
+	}
+	slices.SortStableFunc(f.Entries, func(a, b *feedEntry) int {
+		return b.Updated.Compare(a.Updated)
+	})
+	return f, nil
+}
+
+func truncateFeedSummary(summary string) string {
+	runes := []rune(strings.TrimSpace(summary))
+	if len(runes) <= feedSummaryMaxRunes {
+		return string(runes)
+	}
+	return string(runes[:feedSummaryMaxRunes]) + "..."
+}
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"feed"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Links    []atomLink   `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Summary   string   `xml:"summary,omitempty"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+}
+
+// GetAtomFeed returns the feed of the kb in Atom 1.0
+func (u *FeedUsecase) GetAtomFeed(ctx context.Context, kbID string) ([]byte, error) {
+	f, err := u.getFeed(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	atom := &atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		ID:       f.Link + "/",
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  time.Now().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link},
+			{Href: f.Link + "/feed.xml", Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]*atomEntry, 0, len(f.Entries)),
+	}
+	if len(f.Entries) > 0 {
+		atom.Updated = f.Entries[0].Updated.Format(time.RFC3339)
+	}
+	for _, entry := range f.Entries {
+		atom.Entries = append(atom.Entries, &atomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Link:      atomLink{Href: entry.Link},
+			Summary:   entry.Summary,
+			Published: entry.Published.Format(time.RFC3339),
+			Updated:   entry.Updated.Format(time.RFC3339),
+		})
+	}
+	body, err := xml.MarshalIndent(atom, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	HomePageURL string          `json:"home_page_url"`
+	FeedURL     string          `json:"feed_url"`
+	Items       []*jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url"`
+	Title         string    `json:"title"`
+	ContentText   string    `json:"content_text"`
+	Summary       string    `json:"summary,omitempty"`
+	DatePublished time.Time `json:"date_published"`
+	DateModified  time.Time `json:"date_modified"`
+}
+
+// GetJSONFeed returns the feed of the kb in JSON Feed 1.1
+func (u *FeedUsecase) GetJSONFeed(ctx context.Context, kbID string) ([]byte, error) {
+	f, err := u.getFeed(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	jf := &jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		Description: f.Description,
+		HomePageURL: f.Link,
+		FeedURL:     f.Link + "/feed.json",
+		Items:       make([]*jsonFeedItem, 0, len(f.Entries)),
+	}
+	for _, entry := range f.Entries {
+		jf.Items = append(jf.Items, &jsonFeedItem{
+			ID:            entry.ID,
+			URL:           entry.Link,
+			Title:         entry.Title,
+			ContentText:   entry.Summary,
+			Summary:       entry.Summary,
+			DatePublished: entry.Published,
+			DateModified:  entry.Updated,
+		})
+	}
+	return json.Marshal(jf)
+}
@@ -0,0 +1,645 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/config"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/gitrepo"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+var (
+	gitSCPURLRegex = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+	// markdown link and image destinations, and src of html images
+	gitMDRefRegex  = regexp.MustCompile(`(\]\(\s*<?)([^)\s>]+)`)
+	gitImgSrcRegex = regexp.MustCompile(`(<img\s[^>]*?src=["'])([^"']+)`)
+)
+
+const (
+	gitSyncTimeout = time.Hour
+	// a sync still running after this was interrupted, e.g. by a restart of the server running it
+	gitSyncStaleAfter = gitSyncTimeout + 5*time.Minute
+)
+
+type GitSyncUsecase struct {
+	sourceRepo  *pg.GitSourceRepository
+	nodeRepo    *pg.NodeRepository
+	nodeUsecase *NodeUsecase
+	fileUsecase *FileUsecase
+	config      *config.Config
+	logger      *log.Logger
+}
+
+func NewGitSyncUsecase(
+	sourceRepo *pg.GitSourceRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeUsecase *NodeUsecase,
+	fileUsecase *FileUsecase,
+	config *config.Config,
+	logger *log.Logger,
+) *GitSyncUsecase {
+	return &GitSyncUsecase{
+		sourceRepo:  sourceRepo,
+		nodeRepo:    nodeRepo,
+		nodeUsecase: nodeUsecase,
+		fileUsecase: fileUsecase,
+		config:      config,
+		logger:      logger.WithModule("usecase.git_sync"),
+	}
+}
+
+// FailStale marks the syncs which are still running after the timeout as failed,
+// they run in the process of an api server and are lost when it restarts
+func (u *GitSyncUsecase) FailStale(ctx context.Context) error {
+	return u.sourceRepo.FailStale(ctx, time.Now().Add(-gitSyncStaleAfter), "sync interrupted or timed out")
+}
+
+func (u *GitSyncUsecase) CreateSource(ctx context.Context, req *v1.GitSourceCreateReq, userID string) (string, error) {
+	authType := req.AuthType
+	if authType == "" {
+		authType = domain.GitAuthTypeNone
+	}
+	source := &domain.KBGitSource{
+		ID:        uuid.New().String(),
+		KBID:      req.KbID,
+		Name:      req.Name,
+		RepoURL:   strings.TrimSpace(req.RepoURL),
+		Branch:    strings.TrimSpace(req.Branch),
+		SubDir:    req.SubDir,
+		ParentID:  req.ParentID,
+		AuthType:  authType,
+		Username:  req.Username,
+		Password:  req.Password,
+		SSHKey:    req.SSHKey,
+		Status:    domain.GitSyncStatusIdle,
+		CreatorID: userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := u.normalizeSource(source); err != nil {
+		return "", err
+	}
+	if source.ParentID != "" {
+		parent, err := u.nodeRepo.GetNodeByID(ctx, source.ParentID)
+		if err != nil || parent.KBID != source.KBID || parent.Type != domain.NodeTypeFolder {
+			return "", fmt.Errorf("%w: parent folder not found", domain.ErrInvalidGitSource)
+		}
+	}
+	if err := u.sourceRepo.Create(ctx, source); err != nil {
+		return "", err
+	}
+	return source.ID, nil
+}
+
+func (u *GitSyncUsecase) UpdateSource(ctx context.Context, req *v1.GitSourceUpdateReq) error {
+	source, err := u.sourceRepo.GetByID(ctx, req.KbID, req.SourceID)
+	if err != nil {
+		return err
+	}
+	if source.Status == domain.GitSyncStatusRunning {
+		return domain.ErrGitSyncRunning
+	}
+	before := *source
+	if req.Name != nil {
+		source.Name = *req.Name
+	}
+	if req.RepoURL != nil {
+		source.RepoURL = strings.TrimSpace(*req.RepoURL)
+	}
+	if req.Branch != nil {
+		source.Branch = strings.TrimSpace(*req.Branch)
+	}
+	if req.SubDir != nil {
+		source.SubDir = *req.SubDir
+	}
+	if req.AuthType != nil {
+		source.AuthType = *req.AuthType
+	}
+	if req.Username != nil {
+		source.Username = *req.Username
+	}
+	if req.Password != "" {
+		source.Password = req.Password
+	}
+	if req.SSHKey != "" {
+		source.SSHKey = req.SSHKey
+	}
+	if err := u.normalizeSource(source); err != nil {
+		return err
+	}
+	updateMap := map[string]any{
+		"name":      source.Name,
+		"repo_url":  source.RepoURL,
+		"branch":    source.Branch,
+		"sub_dir":   source.SubDir,
+		"auth_type": source.AuthType,
+		"username":  source.Username,
+		"password":  source.Password,
+		"ssh_key":   source.SSHKey,
+	}
+	if source.RepoURL != before.RepoURL || source.Branch != before.Branch || source.SubDir != before.SubDir {
+		updateMap["last_commit"] = ""
+	}
+	return u.sourceRepo.Update(ctx, source.ID, updateMap)
+}
+
+func (u *GitSyncUsecase) GetSourceList(ctx context.Context, req *v1.GitSourceListReq) ([]*v1.GitSourceListItem, error) {
+	sources, err := u.sourceRepo.GetListByKBID(ctx, req.KbID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.GitSourceListItem, 0, len(sources))
+	for _, source := range sources {
+		items = append(items, &v1.GitSourceListItem{
+			KBGitSource: source,
+			HasPassword: source.Password != "",
+			HasSSHKey:   source.SSHKey != "",
+		})
+	}
+	return items, nil
+}
+
+// DeleteSource removes the source and its mirror, synced nodes are kept
+func (u *GitSyncUsecase) DeleteSource(ctx context.Context, req *v1.GitSourceDeleteReq) error {
+	source, err := u.sourceRepo.GetByID(ctx, req.KbID, req.SourceID)
+	if err != nil {
+		return err
+	}
+	if source.Status == domain.GitSyncStatusRunning {
+		return domain.ErrGitSyncRunning
+	}
+	if err := u.sourceRepo.Delete(ctx, req.KbID, req.SourceID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(u.mirrorDir(source.ID)); err != nil {
+		u.logger.Warn("remove git mirror failed", log.String("source_id", source.ID), log.Error(err))
+	}
+	return nil
+}
+
+// Sync starts a sync of the source in the background, the result is reported on the source
+func (u *GitSyncUsecase) Sync(ctx context.Context, req *v1.GitSourceSyncReq, userID string, maxNode int) error {
+	source, err := u.sourceRepo.GetByID(ctx, req.KbID, req.SourceID)
+	if err != nil {
+		return err
+	}
+	// a stale sync is taken over
+	started, err := u.sourceRepo.StartSync(ctx, req.KbID, req.SourceID, time.Now().Add(-gitSyncStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !started {
+		return domain.ErrGitSyncRunning
+	}
+	go u.runSync(source, userID, req.Full, maxNode)
+	return nil
+}
+
+func (u *GitSyncUsecase) runSync(source *domain.KBGitSource, userID string, full bool, maxNode int) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitSyncTimeout)
+	defer cancel()
+	logger := u.logger.With(log.String("kb_id", source.KBID), log.String("source_id", source.ID))
+
+	result := &domain.GitSyncResult{}
+	commit, err := u.sync(ctx, source, userID, full, maxNode, result)
+	now := time.Now()
+	updateMap := map[string]any{
+		"status":         domain.GitSyncStatusCompleted,
+		"last_result":    result,
+		"last_synced_at": now,
+	}
+	if err != nil {
+		logger.Error("git sync failed", log.Error(err))
+		updateMap["status"] = domain.GitSyncStatusFailed
+		updateMap["error"] = err.Error()
+	} else {
+		updateMap["last_commit"] = commit
+		logger.Info("git sync completed", log.String("commit", commit),
+			log.Int("created", result.Created), log.Int("updated", result.Updated), log.Int("deleted", result.Deleted))
+	}
+	if err := u.sourceRepo.Update(ctx, source.ID, updateMap); err != nil {
+		logger.Error("update git source status failed", log.Error(err))
+	}
+}
+
+// sync applies the changes since the last synced commit, the whole tree is compared for the first sync,
+// when requested or when the last commit is gone after a force push
+func (u *GitSyncUsecase) sync(ctx context.Context, source *domain.KBGitSource, userID string, full bool, maxNode int, result *domain.GitSyncResult) (string, error) {
+	repo, err := gitrepo.Open(ctx, u.mirrorDir(source.ID), source.RepoURL, gitrepo.Credentials{
+		Username: source.Username,
+		Password: source.Password,
+		SSHKey:   source.SSHKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	commit, err := repo.ResolveCommit(ctx, source.Branch)
+	if err != nil {
+		return "", err
+	}
+
+	sourceNodes, err := u.sourceRepo.GetSourceNodes(ctx, source.ID)
+	if err != nil {
+		return "", err
+	}
+	s := &gitSyncer{
+		u:       u,
+		source:  source,
+		repo:    repo,
+		commit:  commit,
+		userID:  userID,
+		maxNode: maxNode,
+		result:  result,
+		mapped:  make(map[string]*domain.KBGitSourceNode, len(sourceNodes)),
+		pending: make(map[string]bool),
+		assets:  make(map[string]string),
+	}
+	nodeIDs := make([]string, 0, len(sourceNodes))
+	for _, node := range sourceNodes {
+		nodeIDs = append(nodeIDs, node.NodeID)
+	}
+	// nodes deleted in the kb are created again when their file changes
+	existing, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return "", err
+	}
+	for _, node := range sourceNodes {
+		if _, ok := existing[node.NodeID]; ok {
+			s.mapped[node.Path] = node
+		}
+	}
+
+	var upserts, deletes []string
+	if !full && source.LastCommit != "" && repo.HasCommit(ctx, source.LastCommit) {
+		changes, err := repo.Diff(ctx, source.LastCommit, commit, source.SubDir)
+		if err != nil {
+			return "", err
+		}
+		for _, change := range changes {
+			rel, ok := s.relPath(change.Path)
+			if !ok || !isGitMarkdownFile(rel) {
+				continue
+			}
+			if change.Status == gitrepo.ChangeDeleted {
+				deletes = append(deletes, rel)
+			} else {
+				upserts = append(upserts, rel)
+			}
+		}
+	} else {
+		files, err := repo.ListFiles(ctx, commit, source.SubDir)
+		if err != nil {
+			return "", err
+		}
+		present := make(map[string]bool, len(files))
+		for _, file := range files {
+			rel, ok := s.relPath(file)
+			if !ok || !isGitMarkdownFile(rel) {
+				continue
+			}
+			present[rel] = true
+			upserts = append(upserts, rel)
+		}
+		for _, node := range sourceNodes {
+			if node.Type == domain.NodeTypeDocument && !present[node.Path] {
+				deletes = append(deletes, node.Path)
+			}
+		}
+	}
+	sort.Strings(upserts)
+
+	// ids are assigned before writing, so links between new documents can be rewritten
+	for _, rel := range upserts {
+		if _, err := s.ensureFolder(ctx, path.Dir(rel)); err != nil {
+			return "", err
+		}
+		if _, ok := s.mapped[rel]; !ok {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return "", err
+			}
+			s.pending[rel] = true
+			s.mapped[rel] = &domain.KBGitSourceNode{SourceID: source.ID, Path: rel, KBID: source.KBID, NodeID: id.String(), Type: domain.NodeTypeDocument}
+		}
+	}
+	for _, rel := range upserts {
+		if err := s.writeDocument(ctx, rel); err != nil {
+			return "", err
+		}
+	}
+	if err := s.deleteDocuments(ctx, deletes); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+type gitSyncer struct {
+	u       *GitSyncUsecase
+	source  *domain.KBGitSource
+	repo    *gitrepo.Repo
+	commit  string
+	userID  string
+	maxNode int
+	result  *domain.GitSyncResult
+	mapped  map[string]*domain.KBGitSourceNode // path relative to the sub dir -> node
+	pending map[string]bool                    // documents with an assigned id which are not created yet
+	assets  map[string]string                  // repo path -> static file url
+}
+
+// relPath returns the path relative to the sub dir of the source
+func (s *gitSyncer) relPath(repoPath string) (string, bool) {
+	if s.source.SubDir == "" {
+		return repoPath, true
+	}
+	rel, ok := strings.CutPrefix(repoPath, s.source.SubDir+"/")
+	return rel, ok
+}
+
+func (s *gitSyncer) repoPath(rel string) string {
+	return path.Join(s.source.SubDir, rel)
+}
+
+// ensureFolder returns the folder node of the directory, missing folders are created
+func (s *gitSyncer) ensureFolder(ctx context.Context, dir string) (string, error) {
+	if dir == "." || dir == "" {
+		return s.source.ParentID, nil
+	}
+	if node, ok := s.mapped[dir]; ok {
+		return node.NodeID, nil
+	}
+	parentID, err := s.ensureFolder(ctx, path.Dir(dir))
+	if err != nil {
+		return "", err
+	}
+	nodeID, err := s.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+		KBID:     s.source.KBID,
+		ParentID: parentID,
+		Type:     domain.NodeTypeFolder,
+		Name:     path.Base(dir),
+		MaxNode:  s.maxNode,
+	}, s.userID)
+	if err != nil {
+		return "", fmt.Errorf("create folder %s failed: %w", dir, err)
+	}
+	node := &domain.KBGitSourceNode{SourceID: s.source.ID, Path: dir, KBID: s.source.KBID, NodeID: nodeID, Type: domain.NodeTypeFolder}
+	if err := s.u.sourceRepo.SaveSourceNode(ctx, node); err != nil {
+		return "", err
+	}
+	s.mapped[dir] = node
+	s.result.Created++
+	return nodeID, nil
+}
+
+func (s *gitSyncer) writeDocument(ctx context.Context, rel string) error {
+	data, err := s.repo.ReadFile(ctx, s.commit, s.repoPath(rel))
+	if err != nil {
+		return err
+	}
+	name, content := parseGitMarkdown(rel, string(data))
+	content = s.rewriteRefs(ctx, rel, content)
+	contentType := domain.ContentTypeMD
+	node := s.mapped[rel]
+
+	if s.pending[rel] {
+		parentID, err := s.ensureFolder(ctx, path.Dir(rel))
+		if err != nil {
+			return err
+		}
+		if _, err := s.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+			ID:          node.NodeID,
+			KBID:        s.source.KBID,
+			ParentID:    parentID,
+			Type:        domain.NodeTypeDocument,
+			Name:        name,
+			Content:     content,
+			ContentType: &contentType,
+			MaxNode:     s.maxNode,
+		}, s.userID); err != nil {
+			return fmt.Errorf("create document %s failed: %w", rel, err)
+		}
+		if err := s.u.sourceRepo.SaveSourceNode(ctx, node); err != nil {
+			return err
+		}
+		delete(s.pending, rel)
+		s.result.Created++
+		return nil
+	}
+
+	current, err := s.u.nodeRepo.GetNodeByID(ctx, node.NodeID)
+	if err != nil {
+		return err
+	}
+	if current.Name == name && current.Content == content {
+		return nil
+	}
+	if _, err := s.u.nodeUsecase.Update(ctx, &domain.UpdateNodeReq{
+		ID:          node.NodeID,
+		KBID:        s.source.KBID,
+		Name:        &name,
+		Content:     &content,
+		ContentType: &contentType,
+	}, s.userID); err != nil {
+		return fmt.Errorf("update document %s failed: %w", rel, err)
+	}
+	s.result.Updated++
+	return nil
+}
+
+// rewriteRefs turns relative links to synced documents into node links and uploads other referenced repo files
+func (s *gitSyncer) rewriteRefs(ctx context.Context, rel, content string) string {
+	replace := func(re *regexp.Regexp) {
+		content = re.ReplaceAllStringFunc(content, func(match string) string {
+			sub := re.FindStringSubmatch(match)
+			if newRef, ok := s.resolveRef(ctx, rel, sub[2]); ok {
+				return sub[1] + newRef
+			}
+			return match
+		})
+	}
+	replace(gitMDRefRegex)
+	replace(gitImgSrcRegex)
+	return content
+}
+
+func (s *gitSyncer) resolveRef(ctx context.Context, rel, ref string) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") || strings.Contains(ref, ":") {
+		return "", false
+	}
+	target, fragment, _ := strings.Cut(ref, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	targetRel := path.Join(path.Dir(rel), target)
+	if strings.HasPrefix(targetRel, "../") || targetRel == ".." {
+		return "", false
+	}
+	if fragment != "" {
+		fragment = "#" + fragment
+	}
+	if node, ok := s.mapped[targetRel]; ok {
+		return "/node/" + node.NodeID + fragment, true
+	}
+	if isGitMarkdownFile(targetRel) {
+		return "", false
+	}
+
+	repoPath := s.repoPath(targetRel)
+	if staticURL, ok := s.assets[repoPath]; ok {
+		return staticURL, staticURL != ""
+	}
+	s.assets[repoPath] = ""
+	data, err := s.repo.ReadFile(ctx, s.commit, repoPath)
+	if err != nil {
+		return "", false
+	}
+	key, err := s.u.fileUsecase.UploadFileFromBytes(ctx, s.source.KBID, path.Base(repoPath), data)
+	if err != nil {
+		s.result.Warnings = append(s.result.Warnings, fmt.Sprintf("%s: upload %s failed: %v", rel, targetRel, err))
+		return "", false
+	}
+	staticURL := fmt.Sprintf("/%s/%s", domain.Bucket, key)
+	s.assets[repoPath] = staticURL
+	return staticURL, true
+}
+
+// deleteDocuments moves the nodes of deleted files to the trash, folders left without children go with them
+func (s *gitSyncer) deleteDocuments(ctx context.Context, paths []string) error {
+	nodeIDs := make([]string, 0, len(paths))
+	for _, rel := range paths {
+		if node, ok := s.mapped[rel]; ok {
+			nodeIDs = append(nodeIDs, node.NodeID)
+			delete(s.mapped, rel)
+		}
+	}
+	if len(nodeIDs) > 0 {
+		if err := s.u.nodeRepo.Delete(ctx, s.source.KBID, nodeIDs, s.userID); err != nil {
+			return err
+		}
+		s.result.Deleted += len(nodeIDs)
+	}
+	if err := s.u.sourceRepo.DeleteSourceNodes(ctx, s.source.ID, paths); err != nil {
+		return err
+	}
+
+	// deepest folders first, a folder is kept while anything else lives in it
+	folders := make([]string, 0)
+	for rel, node := range s.mapped {
+		if node.Type == domain.NodeTypeFolder {
+			folders = append(folders, rel)
+		}
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return strings.Count(folders[i], "/") > strings.Count(folders[j], "/")
+	})
+	removed := make([]string, 0)
+	for _, rel := range folders {
+		node := s.mapped[rel]
+		if ids := s.u.nodeRepo.GetAllChildNodeIDs(ctx, s.source.KBID, []string{node.NodeID}); len(ids) > 1 {
+			continue
+		}
+		if err := s.u.nodeRepo.Delete(ctx, s.source.KBID, []string{node.NodeID}, s.userID); err != nil {
+			return err
+		}
+		delete(s.mapped, rel)
+		removed = append(removed, rel)
+		s.result.Deleted++
+	}
+	return s.u.sourceRepo.DeleteSourceNodes(ctx, s.source.ID, removed)
+}
+
+// normalizeSource validates the repo url against the auth type and cleans the sub dir
+func (u *GitSyncUsecase) normalizeSource(source *domain.KBGitSource) error {
+	repoURL := source.RepoURL
+	switch {
+	case strings.HasPrefix(repoURL, "https://") || strings.HasPrefix(repoURL, "http://"):
+		if source.AuthType == domain.GitAuthTypeSSH {
+			return fmt.Errorf("%w: ssh auth requires an ssh url", domain.ErrInvalidGitSource)
+		}
+	case strings.HasPrefix(repoURL, "ssh://") || gitSCPURLRegex.MatchString(repoURL):
+		if source.AuthType == domain.GitAuthTypeHTTPS {
+			return fmt.Errorf("%w: https auth requires an https url", domain.ErrInvalidGitSource)
+		}
+	case strings.HasPrefix(repoURL, "/") || strings.HasPrefix(repoURL, "file://"):
+		if u.config.GitSync.LocalRepoDir == "" {
+			return fmt.Errorf("%w: local repos are not enabled", domain.ErrInvalidGitSource)
+		}
+		localPath := filepath.Clean(strings.TrimPrefix(repoURL, "file://"))
+		rel, err := filepath.Rel(filepath.Clean(u.config.GitSync.LocalRepoDir), localPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			return fmt.Errorf("%w: local repo must be under %s", domain.ErrInvalidGitSource, u.config.GitSync.LocalRepoDir)
+		}
+		source.RepoURL = localPath
+		source.AuthType = domain.GitAuthTypeNone
+	default:
+		return fmt.Errorf("%w: unsupported repo url", domain.ErrInvalidGitSource)
+	}
+	if strings.HasPrefix(source.Branch, "-") || strings.ContainsAny(source.Branch, " \t\n~^:?*[\\") {
+		return fmt.Errorf("%w: invalid branch name", domain.ErrInvalidGitSource)
+	}
+	subDir := strings.Trim(path.Clean("/"+strings.TrimSpace(source.SubDir)), "/")
+	source.SubDir = subDir
+
+	switch source.AuthType {
+	case domain.GitAuthTypeNone:
+		source.Username, source.Password, source.SSHKey = "", "", ""
+	case domain.GitAuthTypeHTTPS:
+		source.SSHKey = ""
+		if source.Password == "" {
+			return fmt.Errorf("%w: password or token is required", domain.ErrInvalidGitSource)
+		}
+	case domain.GitAuthTypeSSH:
+		source.Username, source.Password = "", ""
+		if source.SSHKey == "" {
+			return fmt.Errorf("%w: ssh key is required", domain.ErrInvalidGitSource)
+		}
+	}
+	if source.Name == "" {
+		source.Name = strings.TrimSuffix(path.Base(strings.TrimSuffix(source.RepoURL, "/")), ".git")
+	}
+	return nil
+}
+
+func (u *GitSyncUsecase) mirrorDir(sourceID string) string {
+	return filepath.Join(u.config.GitSync.WorkDir, sourceID+".git")
+}
+
+func isGitMarkdownFile(p string) bool {
+	ext := strings.ToLower(path.Ext(p))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// parseGitMarkdown strips the front matter, its title is used as node name instead of the file name
+func parseGitMarkdown(rel, content string) (string, string) {
+	name := strings.TrimSuffix(path.Base(rel), path.Ext(rel))
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		if header, body, ok := strings.Cut(rest, "\n---\n"); ok {
+			var frontMatter struct {
+				Title string `yaml:"title"`
+			}
+			if err := yaml.Unmarshal([]byte(header), &frontMatter); err == nil {
+				if title := strings.TrimSpace(frontMatter.Title); title != "" {
+					name = title
+				}
+				content = strings.TrimLeft(body, "\n")
+			}
+		}
+	}
+	return name, content
+}
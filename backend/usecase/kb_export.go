@@ -0,0 +1,569 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"golang.org/x/sync/singleflight"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/config"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/store/rag/ct"
+	"github.com/chaitin/panda-wiki/store/s3"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+// staticFileRefRegex matches relative and internal minio urls of the static-file bucket,
+// the leading group makes sure the url is not part of an external link
+var staticFileRefRegex = regexp.MustCompile(`(^|[\s("'=<\[])((?:http://panda-wiki-minio:9000)?/` + domain.Bucket + `/([^\s"'()<>\[\]?#]+))`)
+
+var exportNameReplacer = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_")
+
+const (
+	exportNameMaxLen = 100
+	exportTimeout    = time.Hour
+	// an export still unfinished after this was interrupted, e.g. by a restart of the server running it
+	exportStaleAfter = exportTimeout + 5*time.Minute
+)
+
+type KBExportUsecase struct {
+	exportRepo    *pg.KBExportRepository
+	importRepo    *pg.KBImportRepository
+	nodeRepo      *pg.NodeRepository
+	nodeFieldRepo *pg.NodeFieldRepository
+	kbRepo        *pg.KnowledgeBaseRepository
+	authRepo      *pg.AuthRepo
+	appRepo       *pg.AppRepository
+	nodeUsecase   *NodeUsecase
+	fileUsecase   *FileUsecase
+	s3Client      *s3.MinioClient
+	mdConv        *converter.Converter
+	shareBuilds   singleflight.Group
+	pdfClient     *http.Client
+	config        *config.Config
+	logger        *log.Logger
+}
+
+func NewKBExportUsecase(
+	exportRepo *pg.KBExportRepository,
+	importRepo *pg.KBImportRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeFieldRepo *pg.NodeFieldRepository,
+	kbRepo *pg.KnowledgeBaseRepository,
+	authRepo *pg.AuthRepo,
+	appRepo *pg.AppRepository,
+	nodeUsecase *NodeUsecase,
+	fileUsecase *FileUsecase,
+	s3Client *s3.MinioClient,
+	config *config.Config,
+	logger *log.Logger,
+) *KBExportUsecase {
+	return &KBExportUsecase{
+		exportRepo:    exportRepo,
+		importRepo:    importRepo,
+		nodeRepo:      nodeRepo,
+		nodeFieldRepo: nodeFieldRepo,
+		kbRepo:        kbRepo,
+		authRepo:      authRepo,
+		appRepo:       appRepo,
+		nodeUsecase:   nodeUsecase,
+		fileUsecase:   fileUsecase,
+		s3Client:      s3Client,
+		mdConv:        ct.NewHTML2MDConverter(),
+		pdfClient:     &http.Client{Timeout: bookRenderTimeout},
+		config:        config,
+		logger:        logger.WithModule("usecase.kb_export"),
+	}
+}
+
+// FailStale marks the exports and imports which are unfinished after the timeout as failed,
+// they run in the process of an api server and are lost when it restarts
+func (u *KBExportUsecase) FailStale(ctx context.Context) error {
+	if err := u.exportRepo.FailStale(ctx, time.Now().Add(-exportStaleAfter), "export interrupted or timed out"); err != nil {
+		return err
+	}
+	return u.importRepo.FailStale(ctx, time.Now().Add(-importStaleAfter), "import interrupted or timed out")
+}
+
+func (u *KBExportUsecase) CreateExport(ctx context.Context, req *v1.KBExportCreateReq, userID string) (string, error) {
+	if _, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KBId); err != nil {
+		return "", err
+	}
+	exports, err := u.exportRepo.GetListByKBID(ctx, req.KBId)
+	if err != nil {
+		return "", err
+	}
+	staleBefore := time.Now().Add(-exportStaleAfter)
+	for _, export := range exports {
+		if (export.Status == domain.KBExportStatusPending || export.Status == domain.KBExportStatusRunning) && export.CreatedAt.After(staleBefore) {
+			return "", domain.ErrKBExportRunning
+		}
+	}
+
+	format := req.Format
+	if format == "" {
+		format = domain.KBExportFormatMarkdown
+	}
+	if format == domain.KBExportFormatPDF && u.config.Export.PDFRenderURL == "" {
+		return "", domain.ErrPDFRenderNotConfigured
+	}
+	rootID := ""
+	if format.IsBook() {
+		rootID = req.RootID
+	}
+	export := &domain.KBExport{
+		ID:        uuid.New().String(),
+		KBID:      req.KBId,
+		Format:    format,
+		RootID:    rootID,
+		Status:    domain.KBExportStatusPending,
+		CreatorID: userID,
+		CreatedAt: time.Now(),
+	}
+	if err := u.exportRepo.Create(ctx, export); err != nil {
+		return "", err
+	}
+	go u.runExport(export)
+	return export.ID, nil
+}
+
+func (u *KBExportUsecase) GetExportList(ctx context.Context, req *v1.KBExportListReq) ([]*domain.KBExport, error) {
+	return u.exportRepo.GetListByKBID(ctx, req.KBId)
+}
+
+func (u *KBExportUsecase) GetExport(ctx context.Context, req *v1.KBExportDetailReq) (*domain.KBExport, error) {
+	return u.exportRepo.GetByID(ctx, req.KBId, req.ExportID)
+}
+
+// GetExportFile returns the export file reader, its name and content type for download
+func (u *KBExportUsecase) GetExportFile(ctx context.Context, req *v1.KBExportDownloadReq) (io.ReadCloser, string, string, error) {
+	export, err := u.exportRepo.GetByID(ctx, req.KBId, req.ExportID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if export.Status != domain.KBExportStatusCompleted {
+		return nil, "", "", domain.ErrKBExportNotReady
+	}
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KBId)
+	if err != nil {
+		return nil, "", "", err
+	}
+	object, err := u.s3Client.GetObject(ctx, domain.ExportBucket, export.FileKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", "", fmt.Errorf("get export object failed: %w", err)
+	}
+	filename := fmt.Sprintf("%s-%s%s", exportFileName(kb.Name), export.CreatedAt.Format("20060102150405"), path.Ext(export.FileKey))
+	return object, filename, export.Format.ContentType(), nil
+}
+
+func (u *KBExportUsecase) DeleteExport(ctx context.Context, req *v1.KBExportDeleteReq) error {
+	export, err := u.exportRepo.GetByID(ctx, req.KBId, req.ExportID)
+	if err != nil {
+		return err
+	}
+	if export.Status == domain.KBExportStatusPending || export.Status == domain.KBExportStatusRunning {
+		return domain.ErrKBExportRunning
+	}
+	if export.FileKey != "" {
+		if err := u.s3Client.RemoveObject(ctx, domain.ExportBucket, export.FileKey, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("remove export object failed: %w", err)
+		}
+	}
+	return u.exportRepo.Delete(ctx, req.KBId, req.ExportID)
+}
+
+func (u *KBExportUsecase) runExport(export *domain.KBExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	logger := u.logger.With(log.String("kb_id", export.KBID), log.String("export_id", export.ID))
+	if err := u.exportRepo.Update(ctx, export.ID, map[string]any{"status": domain.KBExportStatusRunning}); err != nil {
+		logger.Error("update kb export status failed", log.Error(err))
+		return
+	}
+
+	nodeCount, fileKey, fileSize, err := u.export(ctx, export)
+	updateMap := map[string]any{
+		"status":      domain.KBExportStatusCompleted,
+		"file_key":    fileKey,
+		"file_size":   fileSize,
+		"node_count":  nodeCount,
+		"finished_at": time.Now(),
+	}
+	if err != nil {
+		logger.Error("kb export failed", log.Error(err))
+		updateMap["status"] = domain.KBExportStatusFailed
+		updateMap["error"] = err.Error()
+	} else {
+		logger.Info("kb export completed", log.Int("node_count", nodeCount), log.Int64("file_size", fileSize))
+	}
+	if err := u.exportRepo.Update(ctx, export.ID, updateMap); err != nil {
+		logger.Error("update kb export status failed", log.Error(err))
+	}
+}
+
+// export writes the file to a temp file and uploads it to the export bucket,
+// markdown archives contain the current nodes, books the published ones
+func (u *KBExportUsecase) export(ctx context.Context, export *domain.KBExport) (int, string, int64, error) {
+	tempFile, err := os.CreateTemp("", fmt.Sprintf("kb-export-%s-*%s", export.ID, export.Format.FileExt()))
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("create temp file failed: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	var nodeCount int
+	if export.Format.IsBook() {
+		_, nodes, err := u.getReleaseNodes(ctx, export.KBID)
+		if err != nil {
+			return 0, "", 0, err
+		}
+		book, err := buildBook(export.KBID, nodes, export.RootID, false)
+		if err != nil {
+			return 0, "", 0, err
+		}
+		if err := u.writeBook(ctx, tempFile, book, export.Format); err != nil {
+			return 0, "", 0, err
+		}
+		nodeCount = book.count
+	} else {
+		zw := zip.NewWriter(tempFile)
+		nodeCount, err = u.writeMarkdownArchive(ctx, zw, export.KBID)
+		if err != nil {
+			return 0, "", 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, "", 0, fmt.Errorf("close zip writer failed: %w", err)
+		}
+	}
+
+	fileSize, err := tempFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("get export file size failed: %w", err)
+	}
+	fileKey := fmt.Sprintf("%s/%s%s", export.KBID, export.ID, export.Format.FileExt())
+	if err := u.uploadExportFile(ctx, tempFile, fileKey, export.Format); err != nil {
+		return 0, "", 0, err
+	}
+	return nodeCount, fileKey, fileSize, nil
+}
+
+// uploadExportFile uploads the temp file written up to its current offset
+func (u *KBExportUsecase) uploadExportFile(ctx context.Context, file *os.File, fileKey string, format domain.KBExportFormat) error {
+	fileSize, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("get export file size failed: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek export file failed: %w", err)
+	}
+	if err := u.ensureExportBucket(ctx); err != nil {
+		return err
+	}
+	if _, err := u.s3Client.PutObject(ctx, domain.ExportBucket, fileKey, file, fileSize, minio.PutObjectOptions{
+		ContentType: format.ContentType(),
+	}); err != nil {
+		return fmt.Errorf("upload export file failed: %w", err)
+	}
+	return nil
+}
+
+// ensureExportBucket creates the private export bucket on first use
+func (u *KBExportUsecase) ensureExportBucket(ctx context.Context) error {
+	exists, err := u.s3Client.BucketExists(ctx, domain.ExportBucket)
+	if err != nil {
+		return fmt.Errorf("check export bucket failed: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := u.s3Client.MakeBucket(ctx, domain.ExportBucket, minio.MakeBucketOptions{Region: "us-east-1"}); err != nil {
+		return fmt.Errorf("make export bucket failed: %w", err)
+	}
+	return nil
+}
+
+// writeMarkdownArchive writes the node tree as folders of markdown files, referenced static files and the manifest
+func (u *KBExportUsecase) writeMarkdownArchive(ctx context.Context, zw *zip.Writer, kbID string) (int, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return 0, err
+	}
+	nodes, err := u.nodeRepo.GetNodesByKBID(ctx, kbID)
+	if err != nil {
+		return 0, err
+	}
+	nodeGroups, err := u.nodeRepo.GetNodeAuthGroupsByKBID(ctx, kbID)
+	if err != nil {
+		return 0, err
+	}
+	fields, err := u.nodeFieldRepo.GetListByKBID(ctx, kbID)
+	if err != nil {
+		return 0, err
+	}
+
+	groupMap := make(map[string]map[consts.NodePermName][]int)
+	for _, group := range nodeGroups {
+		if _, ok := groupMap[group.NodeID]; !ok {
+			groupMap[group.NodeID] = make(map[consts.NodePermName][]int)
+		}
+		groupMap[group.NodeID][group.Perm] = append(groupMap[group.NodeID][group.Perm], group.AuthGroupID)
+	}
+	nodeMap := make(map[string]*domain.Node, len(nodes))
+	for _, node := range nodes {
+		nodeMap[node.ID] = node
+	}
+	children := make(map[string][]*domain.Node)
+	for _, node := range nodes {
+		parentID := node.ParentID
+		if _, ok := nodeMap[parentID]; !ok {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], node)
+	}
+
+	w := &markdownArchiveWriter{
+		u:         u,
+		zw:        zw,
+		groupMap:  groupMap,
+		children:  children,
+		usedNames: map[string]map[string]bool{"": {strings.ToLower(domain.KBExportAssetsDir): true}},
+		assets:    make(map[string]*domain.KBExportManifestAsset),
+		manifest: &domain.KBExportManifest{
+			Version:    domain.KBExportManifestVersion,
+			ExportedAt: time.Now(),
+			KB:         domain.KBExportManifestKB{ID: kb.ID, Name: kb.Name},
+			Fields:     fields,
+			Nodes:      make([]*domain.KBExportManifestNode, 0, len(nodes)),
+			Assets:     make([]*domain.KBExportManifestAsset, 0),
+		},
+	}
+	if err := w.writeChildren(ctx, "", ""); err != nil {
+		return 0, err
+	}
+
+	manifest, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("marshal manifest failed: %w", err)
+	}
+	if err := writeZipFile(zw, domain.KBExportManifestFile, manifest); err != nil {
+		return 0, err
+	}
+	return len(w.manifest.Nodes), nil
+}
+
+type markdownArchiveWriter struct {
+	u         *KBExportUsecase
+	zw        *zip.Writer
+	groupMap  map[string]map[consts.NodePermName][]int
+	children  map[string][]*domain.Node
+	usedNames map[string]map[string]bool // dir -> lower case names
+	assets    map[string]*domain.KBExportManifestAsset
+	manifest  *domain.KBExportManifest
+}
+
+// writeChildren writes the children of parentID into dir, a node with children gets a directory of its own
+func (w *markdownArchiveWriter) writeChildren(ctx context.Context, parentID, dir string) error {
+	for _, node := range w.children[parentID] {
+		name := w.uniqueName(dir, exportFileName(node.Name))
+		nodeDir := path.Join(dir, name)
+		filePath := path.Join(dir, name+".md")
+		if node.Type == domain.NodeTypeFolder {
+			filePath = path.Join(nodeDir, domain.KBExportFolderIndexFile)
+			// keep children from taking the index file name
+			w.usedNames[nodeDir] = map[string]bool{strings.TrimSuffix(domain.KBExportFolderIndexFile, ".md"): true}
+		}
+		if err := w.writeNode(ctx, node, filePath); err != nil {
+			return err
+		}
+		w.manifest.Nodes = append(w.manifest.Nodes, &domain.KBExportManifestNode{
+			ID:       node.ID,
+			ParentID: node.ParentID,
+			Type:     node.Type,
+			Name:     node.Name,
+			Path:     filePath,
+			Status:   node.Status,
+			Position: node.Position,
+		})
+		if err := w.writeChildren(ctx, node.ID, nodeDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *markdownArchiveWriter) uniqueName(dir, name string) string {
+	used, ok := w.usedNames[dir]
+	if !ok {
+		used = make(map[string]bool)
+		w.usedNames[dir] = used
+	}
+	unique := name
+	for i := 2; used[strings.ToLower(unique)]; i++ {
+		unique = fmt.Sprintf("%s (%d)", name, i)
+	}
+	used[strings.ToLower(unique)] = true
+	return unique
+}
+
+func (w *markdownArchiveWriter) writeNode(ctx context.Context, node *domain.Node, filePath string) error {
+	content := node.Content
+	if node.Meta.ContentType != domain.ContentTypeMD && utils.IsLikelyHTML(content) {
+		var err error
+		content, err = w.u.mdConv.ConvertString(content)
+		if err != nil {
+			return fmt.Errorf("convert node %s to markdown failed: %w", node.ID, err)
+		}
+	}
+	content, err := w.rewriteStaticFiles(ctx, content, filePath)
+	if err != nil {
+		return err
+	}
+
+	groups := w.groupMap[node.ID]
+	frontMatter := &domain.NodeExportFrontMatter{
+		ID:          node.ID,
+		ParentID:    node.ParentID,
+		Name:        node.Name,
+		Type:        node.Type,
+		Status:      node.Status,
+		Position:    node.Position,
+		Emoji:       node.Meta.Emoji,
+		Summary:     node.Meta.Summary,
+		ContentType: node.Meta.ContentType,
+		Tags:        node.Meta.Tags,
+		Fields:      node.Meta.Fields,
+		Permissions: domain.NodeExportPermissions{
+			Answerable:       node.Permissions.Answerable,
+			Visitable:        node.Permissions.Visitable,
+			Visible:          node.Permissions.Visible,
+			AnswerableGroups: groups[consts.NodePermNameAnswerable],
+			VisitableGroups:  groups[consts.NodePermNameVisitable],
+			VisibleGroups:    groups[consts.NodePermNameVisible],
+		},
+		CreatedAt: node.CreatedAt,
+		UpdatedAt: node.UpdatedAt,
+	}
+	header, err := yaml.Marshal(frontMatter)
+	if err != nil {
+		return fmt.Errorf("marshal front matter of node %s failed: %w", node.ID, err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(content)
+	return writeZipFile(w.zw, filePath, buf.Bytes())
+}
+
+// rewriteStaticFiles copies referenced static files into the archive and rewrites them to paths relative to filePath
+func (w *markdownArchiveWriter) rewriteStaticFiles(ctx context.Context, content, filePath string) (string, error) {
+	var writeErr error
+	relPrefix := strings.Repeat("../", strings.Count(filePath, "/"))
+	content = staticFileRefRegex.ReplaceAllStringFunc(content, func(match string) string {
+		if writeErr != nil {
+			return match
+		}
+		sub := staticFileRefRegex.FindStringSubmatch(match)
+		key, err := url.PathUnescape(sub[3])
+		if err != nil {
+			key = sub[3]
+		}
+		asset, err := w.writeAsset(ctx, key)
+		if err != nil {
+			writeErr = err
+			return match
+		}
+		if asset.Missing {
+			return match
+		}
+		return sub[1] + (&url.URL{Path: relPrefix + asset.Path}).String()
+	})
+	return content, writeErr
+}
+
+// writeAsset copies a static file into the archive once, missing files are recorded instead of failing the export
+func (w *markdownArchiveWriter) writeAsset(ctx context.Context, key string) (*domain.KBExportManifestAsset, error) {
+	if asset, ok := w.assets[key]; ok {
+		return asset, nil
+	}
+	asset := &domain.KBExportManifestAsset{Key: key}
+	w.assets[key] = asset
+	w.manifest.Assets = append(w.manifest.Assets, asset)
+
+	data, err := w.u.readStaticFile(ctx, key)
+	if err != nil {
+		w.u.logger.Warn("read static file for export failed", log.String("key", key), log.Error(err))
+		asset.Missing = true
+		return asset, nil
+	}
+	name := w.uniqueName(domain.KBExportAssetsDir, exportFileName(path.Base(key)))
+	asset.Path = path.Join(domain.KBExportAssetsDir, name)
+	if err := writeZipFile(w.zw, asset.Path, data); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (u *KBExportUsecase) readStaticFile(ctx context.Context, key string) ([]byte, error) {
+	object, err := u.s3Client.GetObject(ctx, domain.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	return io.ReadAll(object)
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("create zip entry %s failed: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write zip entry %s failed: %w", name, err)
+	}
+	return nil
+}
+
+// exportFileName makes a node name safe to use as a file name on all platforms
+func exportFileName(name string) string {
+	name = exportNameReplacer.Replace(name)
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if runes := []rune(name); len(runes) > exportNameMaxLen {
+		name = strings.TrimSpace(string(runes[:exportNameMaxLen]))
+	}
+	if name == "" {
+		return "untitled"
+	}
+	return name
+}
@@ -0,0 +1,543 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+var (
+	bookNodeLinkRegex   = regexp.MustCompile(`^(?:https?://[^/]+)?/node/([0-9a-zA-Z-]+)(#.*)?$`)
+	bookStaticFileRegex = regexp.MustCompile(`^(?:http://panda-wiki-minio:9000)?/` + domain.Bucket + `/([^?#]+)`)
+)
+
+const (
+	bookImageDir      = "images/"
+	bookChapterDir    = "text/"
+	bookRenderTimeout = 5 * time.Minute
+	// the first download of a share book builds it, later downloads of the same book wait for the build
+	shareBookBuildTimeout = 10 * time.Minute
+	bookStyle             = `@page { size: A4; margin: 20mm 18mm; }
+body { font-family: sans-serif; font-size: 11pt; line-height: 1.6; }
+img { max-width: 100%; }
+pre { white-space: pre-wrap; word-wrap: break-word; background: #f6f6f6; padding: 8px; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #999; padding: 2px 6px; }
+a { color: inherit; }
+.cover { text-align: center; padding-top: 30%; page-break-after: always; }
+.cover img { max-width: 30%; }
+.toc { page-break-after: always; }
+.toc ol { list-style: none; padding-left: 1.5em; }
+.chapter-top { page-break-before: always; }
+`
+)
+
+type exportBookChapter struct {
+	node     *domain.KBExportNode
+	children []*exportBookChapter
+}
+
+// exportBook is the published node tree of a kb or a folder
+type exportBook struct {
+	kbID     string
+	root     *domain.KBExportNode
+	modified time.Time
+	chapters []*exportBookChapter
+	count    int
+}
+
+func (u *KBExportUsecase) getReleaseNodes(ctx context.Context, kbID string) (*domain.KBRelease, []*domain.KBExportNode, error) {
+	release, nodes, err := u.nodeRepo.GetLatestKBReleaseNodes(ctx, kbID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, domain.ErrKBNotPublished
+		}
+		return nil, nil, err
+	}
+	return release, nodes, nil
+}
+
+// buildBook arranges the published nodes under rootID into chapters,
+// publicOnly drops hidden nodes and nodes which can't be visited without login together with their children
+func buildBook(kbID string, nodes []*domain.KBExportNode, rootID string, publicOnly bool) (*exportBook, error) {
+	chapterMap := make(map[string]*exportBookChapter, len(nodes))
+	for _, node := range nodes {
+		if publicOnly && (node.Permissions.Visible == consts.NodeAccessPermClosed || node.Permissions.Visitable != consts.NodeAccessPermOpen) {
+			continue
+		}
+		chapterMap[node.ID] = &exportBookChapter{node: node}
+	}
+	book := &exportBook{kbID: kbID}
+	var roots []*exportBookChapter
+	// nodes are ordered by position, so children keep their order
+	for _, node := range nodes {
+		chapter, ok := chapterMap[node.ID]
+		if !ok {
+			continue
+		}
+		if parent, ok := chapterMap[node.ParentID]; ok {
+			parent.children = append(parent.children, chapter)
+		} else if node.ParentID == "" {
+			roots = append(roots, chapter)
+		}
+	}
+	if rootID != "" {
+		root, ok := chapterMap[rootID]
+		if !ok || root.node.Type != domain.NodeTypeFolder {
+			return nil, domain.ErrKBExportRootNotFound
+		}
+		book.root = root.node
+		roots = root.children
+	}
+	book.chapters = roots
+
+	var walk func(chapters []*exportBookChapter)
+	walk = func(chapters []*exportBookChapter) {
+		for _, chapter := range chapters {
+			book.count++
+			if chapter.node.UpdatedAt.After(book.modified) {
+				book.modified = chapter.node.UpdatedAt
+			}
+			walk(chapter.children)
+		}
+	}
+	walk(book.chapters)
+	if book.count == 0 {
+		return nil, domain.ErrKBNotPublished
+	}
+	return book, nil
+}
+
+// chapterIDs returns the ids of the nodes in the book, links to them are kept inside the book
+func (b *exportBook) chapterIDs() map[string]bool {
+	ids := make(map[string]bool, b.count)
+	var collect func(chapters []*exportBookChapter)
+	collect = func(chapters []*exportBookChapter) {
+		for _, chapter := range chapters {
+			ids[chapter.node.ID] = true
+			collect(chapter.children)
+		}
+	}
+	collect(b.chapters)
+	return ids
+}
+
+// writeBook renders the book in the given format
+func (u *KBExportUsecase) writeBook(ctx context.Context, w io.Writer, book *exportBook, format domain.KBExportFormat) error {
+	switch format {
+	case domain.KBExportFormatEPUB:
+		return u.writeEpub(ctx, w, book)
+	case domain.KBExportFormatPDF:
+		return u.writePDF(ctx, w, book)
+	default:
+		return fmt.Errorf("unsupported book format: %s", format)
+	}
+}
+
+// bookTitle returns the title of the web app, the kb name when not set
+func (u *KBExportUsecase) bookTitle(ctx context.Context, kbID string) (string, string, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return "", "", err
+	}
+	app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return "", "", err
+	}
+	title := app.Settings.Title
+	if title == "" {
+		title = kb.Name
+	}
+	return title, app.Settings.Icon, nil
+}
+
+func (u *KBExportUsecase) writeEpub(ctx context.Context, w io.Writer, book *exportBook) error {
+	title, icon, err := u.bookTitle(ctx, book.kbID)
+	if err != nil {
+		return err
+	}
+	epub := &utils.EpubBook{
+		Identifier: book.kbID,
+		Title:      title,
+		Modified:   book.modified,
+		Cover:      u.loadBookCover(ctx, icon, bookImageDir),
+	}
+	if book.root != nil {
+		epub.Identifier = book.root.ID
+		epub.Subtitle = book.root.Name
+	}
+	chapterIDs := book.chapterIDs()
+	assets := newBookAssets(u, bookImageDir)
+	rewrite := func(tag, attr, value string) string {
+		if tag == "img" && attr == "src" {
+			if res := assets.resolve(ctx, value); res != nil {
+				return "../" + res.FileName
+			}
+		}
+		if tag == "a" && attr == "href" {
+			if m := bookNodeLinkRegex.FindStringSubmatch(value); m != nil && chapterIDs[m[1]] {
+				return m[1] + ".xhtml" + m[2]
+			}
+		}
+		return value
+	}
+	var convert func(chapters []*exportBookChapter) ([]*utils.EpubChapter, error)
+	convert = func(chapters []*exportBookChapter) ([]*utils.EpubChapter, error) {
+		result := make([]*utils.EpubChapter, 0, len(chapters))
+		for _, chapter := range chapters {
+			body, err := utils.RenderXHTML(u.bookNodeHTML(chapter.node), rewrite)
+			if err != nil {
+				return nil, fmt.Errorf("render node %s failed: %w", chapter.node.ID, err)
+			}
+			children, err := convert(chapter.children)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &utils.EpubChapter{
+				FileName: bookChapterDir + chapter.node.ID + ".xhtml",
+				Title:    chapter.node.Name,
+				Body:     body,
+				Children: children,
+			})
+		}
+		return result, nil
+	}
+	if epub.Chapters, err = convert(book.chapters); err != nil {
+		return err
+	}
+	epub.Resources = assets.list
+	return utils.WriteEpub(w, epub)
+}
+
+// writePDF renders the book as one html document with cover, table of contents and sections,
+// and converts it with the configured render service
+func (u *KBExportUsecase) writePDF(ctx context.Context, w io.Writer, book *exportBook) error {
+	if u.config.Export.PDFRenderURL == "" {
+		return domain.ErrPDFRenderNotConfigured
+	}
+	title, icon, err := u.bookTitle(ctx, book.kbID)
+	if err != nil {
+		return err
+	}
+	assets := newBookAssets(u, "")
+	cover := u.loadBookCover(ctx, icon, "")
+	if cover != nil {
+		assets.list = append(assets.list, cover)
+	}
+	chapterIDs := book.chapterIDs()
+	rewrite := func(tag, attr, value string) string {
+		if tag == "img" && attr == "src" {
+			if res := assets.resolve(ctx, value); res != nil {
+				return res.FileName
+			}
+		}
+		if tag == "a" && attr == "href" {
+			if m := bookNodeLinkRegex.FindStringSubmatch(value); m != nil && chapterIDs[m[1]] {
+				return "#node-" + m[1]
+			}
+		}
+		return value
+	}
+
+	var doc strings.Builder
+	doc.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\"/>\n")
+	fmt.Fprintf(&doc, "<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", html.EscapeString(title), bookStyle)
+	doc.WriteString("<section class=\"cover\">\n")
+	if cover != nil {
+		fmt.Fprintf(&doc, "<img src=\"%s\" alt=\"\"/>\n", html.EscapeString(cover.FileName))
+	}
+	fmt.Fprintf(&doc, "<h1>%s</h1>\n", html.EscapeString(title))
+	if book.root != nil {
+		fmt.Fprintf(&doc, "<h2>%s</h2>\n", html.EscapeString(book.root.Name))
+	}
+	doc.WriteString("</section>\n<nav class=\"toc\">\n<h1>目录</h1>\n")
+	writeBookTOC(&doc, book.chapters)
+	doc.WriteString("</nav>\n")
+	if err := u.writeBookSections(&doc, book.chapters, 0, rewrite); err != nil {
+		return err
+	}
+	doc.WriteString("</body>\n</html>\n")
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	files := append([]*utils.EpubResource{{FileName: "index.html", Data: []byte(doc.String())}}, assets.list...)
+	for _, f := range files {
+		fw, err := mw.CreateFormFile("files", f.FileName)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.Data); err != nil {
+			return err
+		}
+	}
+	for key, value := range map[string]string{"preferCssPageSize": "true", "printBackground": "true"} {
+		if err := mw.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.config.Export.PDFRenderURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp, err := u.pdfClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("render pdf failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("render pdf failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("read rendered pdf failed: %w", err)
+	}
+	return nil
+}
+
+func writeBookTOC(b *strings.Builder, chapters []*exportBookChapter) {
+	if len(chapters) == 0 {
+		return
+	}
+	b.WriteString("<ol>\n")
+	for _, chapter := range chapters {
+		fmt.Fprintf(b, "<li><a href=\"#node-%s\">%s</a>", chapter.node.ID, html.EscapeString(chapter.node.Name))
+		writeBookTOC(b, chapter.children)
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ol>\n")
+}
+
+func (u *KBExportUsecase) writeBookSections(b *strings.Builder, chapters []*exportBookChapter, depth int, rewrite func(tag, attr, value string) string) error {
+	level := min(depth+1, 6)
+	for _, chapter := range chapters {
+		body, err := utils.RenderXHTML(u.bookNodeHTML(chapter.node), rewrite)
+		if err != nil {
+			return fmt.Errorf("render node %s failed: %w", chapter.node.ID, err)
+		}
+		class := "chapter"
+		if depth == 0 {
+			class += " chapter-top"
+		}
+		fmt.Fprintf(b, "<section class=\"%s\" id=\"node-%s\">\n<h%d>%s</h%d>\n%s\n</section>\n",
+			class, chapter.node.ID, level, html.EscapeString(chapter.node.Name), level, body)
+		if err := u.writeBookSections(b, chapter.children, depth+1, rewrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *KBExportUsecase) bookNodeHTML(node *domain.KBExportNode) string {
+	if node.Type == domain.NodeTypeFolder {
+		return ""
+	}
+	if node.Meta.ContentType == domain.ContentTypeMD {
+		return u.nodeUsecase.convertMDToHTML(node.Content)
+	}
+	return node.Content
+}
+
+// loadBookCover loads the web app icon, which is a data uri, a static file or an external url.
+// The book is still built without cover when the icon can't be loaded.
+func (u *KBExportUsecase) loadBookCover(ctx context.Context, icon, dir string) *utils.EpubResource {
+	if icon == "" {
+		return nil
+	}
+	var data []byte
+	var mediaType string
+	var err error
+	switch {
+	case strings.HasPrefix(icon, "data:"):
+		meta, payload, ok := strings.Cut(strings.TrimPrefix(icon, "data:"), ",")
+		if !ok || !strings.HasSuffix(meta, ";base64") {
+			return nil
+		}
+		mediaType = strings.TrimSuffix(meta, ";base64")
+		data, err = base64.StdEncoding.DecodeString(payload)
+	case bookStaticFileRegex.MatchString(icon):
+		data, err = u.readStaticFile(ctx, bookStaticFileRegex.FindStringSubmatch(icon)[1])
+	case strings.HasPrefix(icon, "http://") || strings.HasPrefix(icon, "https://"):
+		data, err = utils.HTTPGet(icon)
+	default:
+		return nil
+	}
+	if err != nil {
+		u.logger.Warn("load book cover failed", log.Error(err))
+		return nil
+	}
+	res := bookImageResource(dir+"cover", mediaType, data)
+	if res == nil {
+		u.logger.Warn("book cover is not an image")
+	}
+	return res
+}
+
+// bookImageResource detects the media type when unknown, nil is returned for non image data
+func bookImageResource(name, mediaType string, data []byte) *utils.EpubResource {
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		mediaType = http.DetectContentType(data)
+	}
+	mediaType, _, _ = mime.ParseMediaType(mediaType)
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil
+	}
+	ext := ".img"
+	if mediaType == "image/svg+xml" {
+		ext = ".svg"
+	} else if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		ext = exts[len(exts)-1]
+	}
+	return &utils.EpubResource{FileName: name + ext, MediaType: mediaType, Data: data}
+}
+
+// bookAssets embeds the static file images of a book, each file once
+type bookAssets struct {
+	u     *KBExportUsecase
+	dir   string
+	files map[string]*utils.EpubResource
+	list  []*utils.EpubResource
+}
+
+func newBookAssets(u *KBExportUsecase, dir string) *bookAssets {
+	return &bookAssets{u: u, dir: dir, files: make(map[string]*utils.EpubResource)}
+}
+
+// resolve returns nil for urls outside of the static file bucket and for files which can't be read
+func (a *bookAssets) resolve(ctx context.Context, src string) *utils.EpubResource {
+	m := bookStaticFileRegex.FindStringSubmatch(src)
+	if m == nil {
+		return nil
+	}
+	key, err := url.PathUnescape(m[1])
+	if err != nil {
+		key = m[1]
+	}
+	if res, ok := a.files[key]; ok {
+		return res
+	}
+	a.files[key] = nil
+	data, err := a.u.readStaticFile(ctx, key)
+	if err != nil {
+		a.u.logger.Warn("read static file for book failed", log.String("key", key), log.Error(err))
+		return nil
+	}
+	res := bookImageResource(fmt.Sprintf("%simg-%d", a.dir, len(a.list)+1), mime.TypeByExtension(path.Ext(key)), data)
+	if res == nil {
+		return nil
+	}
+	a.files[key] = res
+	a.list = append(a.list, res)
+	return res
+}
+
+// GetShareBook returns an ebook of the public part of the published kb for the share site.
+// Books are cached per release and rebuilt on the first download after the kb is published again.
+func (u *KBExportUsecase) GetShareBook(ctx context.Context, kbID string, format domain.KBExportFormat, rootID string) (io.ReadCloser, string, error) {
+	app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return nil, "", err
+	}
+	settings := app.Settings.ExportSettings
+	if (format == domain.KBExportFormatEPUB && !settings.EPUBEnabled) || (format == domain.KBExportFormatPDF && !settings.PDFEnabled) || !format.IsBook() {
+		return nil, "", domain.ErrKBExportDisabled
+	}
+	if format == domain.KBExportFormatPDF && u.config.Export.PDFRenderURL == "" {
+		return nil, "", domain.ErrPDFRenderNotConfigured
+	}
+	release, nodes, err := u.getReleaseNodes(ctx, kbID)
+	if err != nil {
+		return nil, "", err
+	}
+	book, err := buildBook(kbID, nodes, rootID, true)
+	if err != nil {
+		return nil, "", err
+	}
+	title, _, err := u.bookTitle(ctx, kbID)
+	if err != nil {
+		return nil, "", err
+	}
+	filename := exportFileName(title)
+	scope := "all"
+	if book.root != nil {
+		scope = book.root.ID
+		filename = exportFileName(title + "-" + book.root.Name)
+	}
+	sharePrefix := fmt.Sprintf("%s/share/", kbID)
+	fileKey := fmt.Sprintf("%s%s/%s%s", sharePrefix, release.ID, scope, format.FileExt())
+
+	if _, err := u.s3Client.StatObject(ctx, domain.ExportBucket, fileKey, minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			return nil, "", fmt.Errorf("stat share book failed: %w", err)
+		}
+		if _, err, _ := u.shareBuilds.Do(fileKey, func() (any, error) {
+			buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shareBookBuildTimeout)
+			defer cancel()
+			return nil, u.buildShareBook(buildCtx, book, format, fileKey, sharePrefix+release.ID+"/")
+		}); err != nil {
+			return nil, "", err
+		}
+	}
+	object, err := u.s3Client.GetObject(ctx, domain.ExportBucket, fileKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("get share book failed: %w", err)
+	}
+	return object, filename + format.FileExt(), nil
+}
+
+// buildShareBook uploads the book and removes the ones of older releases
+func (u *KBExportUsecase) buildShareBook(ctx context.Context, book *exportBook, format domain.KBExportFormat, fileKey, releasePrefix string) error {
+	tempFile, err := os.CreateTemp("", "kb-share-book-*"+format.FileExt())
+	if err != nil {
+		return fmt.Errorf("create temp file failed: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if err := u.writeBook(ctx, tempFile, book, format); err != nil {
+		return err
+	}
+	if err := u.uploadExportFile(ctx, tempFile, fileKey, format); err != nil {
+		return err
+	}
+
+	sharePrefix := path.Dir(strings.TrimSuffix(releasePrefix, "/")) + "/"
+	for object := range u.s3Client.ListObjects(ctx, domain.ExportBucket, minio.ListObjectsOptions{Prefix: sharePrefix, Recursive: true}) {
+		if object.Err != nil {
+			u.logger.Warn("list share books failed", log.Error(object.Err))
+			break
+		}
+		if strings.HasPrefix(object.Key, releasePrefix) {
+			continue
+		}
+		if err := u.s3Client.RemoveObject(ctx, domain.ExportBucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			u.logger.Warn("remove outdated share book failed", log.String("key", object.Key), log.Error(err))
+		}
+	}
+	return nil
+}
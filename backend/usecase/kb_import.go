@@ -0,0 +1,577 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/minio/minio-go/v7"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	nodeV1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+var (
+	importNodeLinkRegex = regexp.MustCompile(`/node/([0-9a-fA-F-]{36})`)
+	importMDLinkRegex   = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+)
+
+const (
+	importTimeout        = time.Hour
+	importStaleAfter     = importTimeout + 5*time.Minute
+	importMaxFileSize    = 100 << 20 // decompressed size of a single file of the archive
+	importMaxArchiveSize = 1 << 30   // decompressed size of all files read from the archive
+)
+
+type importNode struct {
+	manifest    *domain.KBExportManifestNode
+	frontMatter *domain.NodeExportFrontMatter
+	body        string
+	parentID    string
+	targetID    string
+	existing    *domain.Node
+}
+
+// CreateImport starts importing an archive uploaded with /api/v1/file/upload, nodes are written in the background
+func (u *KBExportUsecase) CreateImport(ctx context.Context, req *v1.KBImportReq, userID string, maxNode int) (string, error) {
+	if !strings.HasPrefix(req.Key, req.KBId+"/") {
+		return "", fmt.Errorf("%w: archive does not belong to the knowledge base", domain.ErrInvalidImportArchive)
+	}
+	imports, err := u.importRepo.GetListByKBID(ctx, req.KBId)
+	if err != nil {
+		return "", err
+	}
+	staleBefore := time.Now().Add(-importStaleAfter)
+	for _, kbImport := range imports {
+		if (kbImport.Status == domain.KBExportStatusPending || kbImport.Status == domain.KBExportStatusRunning) && kbImport.CreatedAt.After(staleBefore) {
+			return "", domain.ErrKBImportRunning
+		}
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = domain.KBImportModeSkip
+	}
+	kbImport := &domain.KBImport{
+		ID:          uuid.New().String(),
+		KBID:        req.KBId,
+		FileKey:     req.Key,
+		ParentID:    req.ParentID,
+		Mode:        mode,
+		PreserveIDs: req.PreserveIDs,
+		Status:      domain.KBExportStatusPending,
+		CreatorID:   userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := u.importRepo.Create(ctx, kbImport); err != nil {
+		return "", err
+	}
+	go u.runImport(kbImport, maxNode)
+	return kbImport.ID, nil
+}
+
+func (u *KBExportUsecase) GetImportList(ctx context.Context, req *v1.KBImportListReq) ([]*domain.KBImport, error) {
+	return u.importRepo.GetListByKBID(ctx, req.KBId)
+}
+
+func (u *KBExportUsecase) GetImport(ctx context.Context, req *v1.KBImportDetailReq) (*domain.KBImport, error) {
+	return u.importRepo.GetByID(ctx, req.KBId, req.ImportID)
+}
+
+func (u *KBExportUsecase) runImport(kbImport *domain.KBImport, maxNode int) {
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+	logger := u.logger.With(log.String("kb_id", kbImport.KBID), log.String("import_id", kbImport.ID))
+	if err := u.importRepo.Update(ctx, kbImport.ID, map[string]any{"status": domain.KBExportStatusRunning}); err != nil {
+		logger.Error("update kb import status failed", log.Error(err))
+		return
+	}
+
+	result, err := u.importArchive(ctx, kbImport, maxNode)
+	updateMap := map[string]any{
+		"status":      domain.KBExportStatusCompleted,
+		"finished_at": time.Now(),
+	}
+	if result != nil {
+		updateMap["result"] = result
+	}
+	if err != nil {
+		logger.Error("kb import failed", log.Error(err))
+		updateMap["status"] = domain.KBExportStatusFailed
+		updateMap["error"] = err.Error()
+	} else {
+		logger.Info("kb import completed", log.Int("created", result.Created), log.Int("updated", result.Updated), log.Int("skipped", result.Skipped))
+	}
+	if err := u.importRepo.Update(ctx, kbImport.ID, updateMap); err != nil {
+		logger.Error("update kb import status failed", log.Error(err))
+	}
+}
+
+// importArchive recreates the nodes of an export archive in the kb, the nodes written before a failure are kept
+// and reported in the result
+func (u *KBExportUsecase) importArchive(ctx context.Context, kbImport *domain.KBImport, maxNode int) (*domain.KBImportResult, error) {
+	object, err := u.s3Client.GetObject(ctx, domain.Bucket, kbImport.FileKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get archive failed: %w", err)
+	}
+	defer object.Close()
+	info, err := object.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat archive failed: %w", err)
+	}
+	zr, err := zip.NewReader(object, info.Size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportArchive, err)
+	}
+	archive := &importArchive{files: make(map[string]*zip.File, len(zr.File)), budget: importMaxArchiveSize}
+	for _, f := range zr.File {
+		archive.files[f.Name] = f
+	}
+
+	var manifest domain.KBExportManifest
+	data, err := archive.read(domain.KBExportManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: parse manifest failed: %v", domain.ErrInvalidImportArchive, err)
+	}
+	if manifest.Version < 1 || manifest.Version > domain.KBExportManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", domain.ErrInvalidImportArchive, manifest.Version)
+	}
+
+	result := &domain.KBImportResult{
+		IDMap:    make(map[string]string),
+		Warnings: make([]string, 0),
+	}
+	if err := u.importNodeFields(ctx, kbImport.KBID, manifest.Fields, result); err != nil {
+		return result, err
+	}
+
+	nodes, existingCount, err := u.planImportNodes(ctx, kbImport, archive, &manifest, result)
+	if err != nil {
+		return result, err
+	}
+	newCount := 0
+	for _, node := range nodes {
+		if node.existing == nil {
+			newCount++
+		}
+	}
+	if existingCount+newCount > maxNode {
+		return result, domain.ErrMaxNodeLimitReached
+	}
+
+	assets := &importAssets{u: u, kbID: kbImport.KBID, archive: archive, uploaded: make(map[string]string)}
+	for _, asset := range manifest.Assets {
+		if !asset.Missing {
+			assets.known = append(assets.known, asset.Path)
+		}
+	}
+	authGroupIDs, err := u.authRepo.GetAuthGroupIDsByKBID(ctx, kbImport.KBID)
+	if err != nil {
+		return result, err
+	}
+
+	permissionEdits := make(map[string]*nodeV1.NodePermissionEditReq)
+	for _, node := range nodes {
+		imported, err := u.importNode(ctx, kbImport.KBID, kbImport.Mode, node, assets, result, kbImport.CreatorID, maxNode)
+		if err != nil {
+			return result, err
+		}
+		if !imported {
+			continue
+		}
+		edit := importPermissionEdit(kbImport.KBID, node.frontMatter.Permissions, authGroupIDs)
+		if node.existing == nil && isOpenPermission(edit) {
+			// new nodes are created open
+			continue
+		}
+		// nodes with the same permissions are updated together
+		key := fmt.Sprintf("%v/%v/%v/%v", *edit.Permissions, *edit.AnswerableGroups, *edit.VisitableGroups, *edit.VisibleGroups)
+		if _, ok := permissionEdits[key]; !ok {
+			permissionEdits[key] = edit
+		}
+		permissionEdits[key].IDs = append(permissionEdits[key].IDs, node.targetID)
+	}
+	for _, edit := range permissionEdits {
+		if _, err := u.nodeUsecase.NodePermissionsEdit(ctx, *edit); err != nil {
+			return result, err
+		}
+	}
+
+	// the archive is no longer needed once imported
+	if err := u.s3Client.RemoveObject(ctx, domain.Bucket, kbImport.FileKey, minio.RemoveObjectOptions{}); err != nil {
+		u.logger.Warn("remove import archive failed", log.String("key", kbImport.FileKey), log.Error(err))
+	}
+	return result, nil
+}
+
+// importNodeFields creates the field definitions of the archive missing in the kb
+func (u *KBExportUsecase) importNodeFields(ctx context.Context, kbID string, fields []*domain.NodeField, result *domain.KBImportResult) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	existing, err := u.nodeFieldRepo.GetListByKBID(ctx, kbID)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if slices.ContainsFunc(existing, func(f *domain.NodeField) bool { return f.Key == field.Key }) {
+			continue
+		}
+		now := time.Now()
+		if err := u.nodeFieldRepo.Create(ctx, &domain.NodeField{
+			ID:        uuid.New().String(),
+			KBID:      kbID,
+			Key:       field.Key,
+			Name:      field.Name,
+			Type:      field.Type,
+			Options:   pq.StringArray(field.Options),
+			Position:  field.Position,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("create field %s failed: %v", field.Key, err))
+		}
+	}
+	return nil
+}
+
+// planImportNodes reads the nodes of the archive and decides their target ids before anything is written,
+// so links between imported nodes can be rewritten
+func (u *KBExportUsecase) planImportNodes(ctx context.Context, kbImport *domain.KBImport, archive *importArchive, manifest *domain.KBExportManifest, result *domain.KBImportResult) ([]*importNode, int, error) {
+	existingNodes, err := u.nodeRepo.GetNodesByKBID(ctx, kbImport.KBID)
+	if err != nil {
+		return nil, 0, err
+	}
+	existingByID := make(map[string]*domain.Node, len(existingNodes))
+	existingBySibling := make(map[string]*domain.Node, len(existingNodes))
+	for _, node := range existingNodes {
+		existingByID[node.ID] = node
+		existingBySibling[importSiblingKey(node.ParentID, node.Type, node.Name)] = node
+	}
+	if kbImport.ParentID != "" {
+		parent, ok := existingByID[kbImport.ParentID]
+		if !ok || parent.Type != domain.NodeTypeFolder {
+			return nil, 0, fmt.Errorf("%w: parent folder not found", domain.ErrInvalidImportArchive)
+		}
+	}
+
+	var takenIDs map[string]bool
+	if kbImport.PreserveIDs {
+		ids := make([]string, 0, len(manifest.Nodes))
+		for _, m := range manifest.Nodes {
+			ids = append(ids, m.ID)
+		}
+		if takenIDs, err = u.nodeRepo.GetTakenNodeIDs(ctx, ids); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	nodes := make([]*importNode, 0, len(manifest.Nodes))
+	for _, m := range manifest.Nodes {
+		if _, ok := result.IDMap[m.ID]; ok {
+			return nil, 0, fmt.Errorf("%w: duplicate node %s", domain.ErrInvalidImportArchive, m.ID)
+		}
+		data, err := archive.read(m.Path)
+		if err != nil {
+			return nil, 0, err
+		}
+		frontMatter, body, err := parseExportedMarkdown(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s: %v", domain.ErrInvalidImportArchive, m.Path, err)
+		}
+
+		node := &importNode{manifest: m, frontMatter: frontMatter, body: body, parentID: kbImport.ParentID}
+		if parentID, ok := result.IDMap[m.ParentID]; ok {
+			node.parentID = parentID
+		}
+		if existing, ok := existingByID[m.ID]; ok && kbImport.PreserveIDs {
+			node.existing = existing
+		} else if existing, ok := existingBySibling[importSiblingKey(node.parentID, m.Type, frontMatter.Name)]; ok {
+			node.existing = existing
+		}
+
+		switch {
+		case node.existing != nil:
+			node.targetID = node.existing.ID
+		case kbImport.PreserveIDs && utils.IsUUID(m.ID) && !takenIDs[m.ID]:
+			node.targetID = m.ID
+		default:
+			id, err := uuid.NewV7()
+			if err != nil {
+				return nil, 0, err
+			}
+			node.targetID = id.String()
+		}
+		result.IDMap[m.ID] = node.targetID
+		nodes = append(nodes, node)
+	}
+	return nodes, len(existingNodes), nil
+}
+
+// importNode creates or updates one node, it reports whether the node was written
+func (u *KBExportUsecase) importNode(ctx context.Context, kbID string, mode domain.KBImportMode, node *importNode, assets *importAssets, result *domain.KBImportResult, userID string, maxNode int) (bool, error) {
+	fm := node.frontMatter
+	if node.existing != nil {
+		if mode == domain.KBImportModeSkip || (mode == domain.KBImportModeMerge && !fm.UpdatedAt.After(node.existing.UpdatedAt)) {
+			result.Skipped++
+			return false, nil
+		}
+	}
+
+	content, err := u.importContent(ctx, node, assets, result.IDMap)
+	if err != nil {
+		return false, err
+	}
+	contentType := fm.ContentType
+	if contentType == "" {
+		contentType = domain.ContentTypeHTML
+	}
+	if node.existing == nil {
+		createReq := &domain.CreateNodeReq{
+			ID:          node.targetID,
+			KBID:        kbID,
+			ParentID:    node.parentID,
+			Type:        node.manifest.Type,
+			Name:        fm.Name,
+			Content:     content,
+			Emoji:       fm.Emoji,
+			Summary:     &fm.Summary,
+			ContentType: &contentType,
+			MaxNode:     maxNode,
+			Position:    &fm.Position,
+			Tags:        fm.Tags,
+			Fields:      fm.Fields,
+		}
+		if _, err := u.nodeUsecase.Create(ctx, createReq, userID); err != nil {
+			if !errors.Is(err, domain.ErrInvalidNodeField) {
+				return false, fmt.Errorf("create node %s failed: %w", fm.Name, err)
+			}
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: fields dropped: %v", node.manifest.Path, err))
+			createReq.Tags, createReq.Fields = nil, nil
+			if _, err := u.nodeUsecase.Create(ctx, createReq, userID); err != nil {
+				return false, fmt.Errorf("create node %s failed: %w", fm.Name, err)
+			}
+		}
+		result.Created++
+		return true, nil
+	}
+
+	tags, fields := fm.Tags, fm.Fields
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	updateReq := &domain.UpdateNodeReq{
+		ID:          node.targetID,
+		KBID:        kbID,
+		Name:        &fm.Name,
+		Emoji:       &fm.Emoji,
+		Summary:     &fm.Summary,
+		ContentType: &contentType,
+		Tags:        &tags,
+		Fields:      &fields,
+	}
+	if node.manifest.Type == domain.NodeTypeDocument {
+		updateReq.Content = &content
+	}
+	if _, err := u.nodeUsecase.Update(ctx, updateReq, userID); err != nil {
+		if !errors.Is(err, domain.ErrInvalidNodeField) {
+			return false, fmt.Errorf("update node %s failed: %w", fm.Name, err)
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s: fields dropped: %v", node.manifest.Path, err))
+		updateReq.Tags, updateReq.Fields = nil, nil
+		if _, err := u.nodeUsecase.Update(ctx, updateReq, userID); err != nil {
+			return false, fmt.Errorf("update node %s failed: %w", fm.Name, err)
+		}
+	}
+	result.Updated++
+	return true, nil
+}
+
+// importContent rewrites links to imported nodes and bundled files, then converts back to the original content type
+func (u *KBExportUsecase) importContent(ctx context.Context, node *importNode, assets *importAssets, idMap map[string]string) (string, error) {
+	content := importNodeLinkRegex.ReplaceAllStringFunc(node.body, func(match string) string {
+		if id, ok := idMap[strings.ToLower(match[len("/node/"):])]; ok {
+			return "/node/" + id
+		}
+		return match
+	})
+
+	if strings.Contains(content, domain.KBExportAssetsDir+"/") {
+		filePath := node.manifest.Path
+		exchanged, err := utils.ExchangeMarkDownImageUrl(ctx, []byte(content), func(ctx context.Context, originUrl *string) (string, error) {
+			if newURL, ok := assets.resolve(ctx, filePath, *originUrl); ok {
+				return newURL, nil
+			}
+			return *originUrl, nil
+		}, utils.WithGFM())
+		if err != nil {
+			return "", fmt.Errorf("exchange image url of %s failed: %w", filePath, err)
+		}
+		// attachments are links instead of images
+		content = importMDLinkRegex.ReplaceAllStringFunc(exchanged, func(match string) string {
+			dest := match[2 : len(match)-1]
+			if newURL, ok := assets.resolve(ctx, filePath, dest); ok {
+				return "](" + newURL + ")"
+			}
+			return match
+		})
+	}
+
+	if node.frontMatter.ContentType != domain.ContentTypeMD && content != "" {
+		content = u.nodeUsecase.convertMDToHTML(content)
+	}
+	return content, nil
+}
+
+// importAssets uploads the bundled files referenced by imported nodes, each file once
+type importAssets struct {
+	u        *KBExportUsecase
+	kbID     string
+	archive  *importArchive
+	known    []string
+	mu       sync.Mutex
+	uploaded map[string]string // archive path -> static file url
+}
+
+// resolve returns the static file url of a reference relative to filePath when it points into the archive assets
+func (a *importAssets) resolve(ctx context.Context, filePath, ref string) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "/") || strings.Contains(ref, "://") {
+		return "", false
+	}
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	assetPath := path.Join(path.Dir(filePath), ref)
+	if !slices.Contains(a.known, assetPath) {
+		return "", false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if staticURL, ok := a.uploaded[assetPath]; ok {
+		return staticURL, true
+	}
+	data, err := a.archive.read(assetPath)
+	if err != nil {
+		a.u.logger.Warn("read archive asset failed", log.String("path", assetPath), log.Error(err))
+		return "", false
+	}
+	key, err := a.u.fileUsecase.UploadFileFromBytes(ctx, a.kbID, path.Base(assetPath), data)
+	if err != nil {
+		a.u.logger.Warn("upload archive asset failed", log.String("path", assetPath), log.Error(err))
+		return "", false
+	}
+	staticURL := fmt.Sprintf("/%s/%s", domain.Bucket, key)
+	a.uploaded[assetPath] = staticURL
+	return staticURL, true
+}
+
+// importPermissionEdit keeps the auth groups which exist in the kb, groups of another instance are dropped
+func importPermissionEdit(kbID string, perms domain.NodeExportPermissions, authGroupIDs []int) *nodeV1.NodePermissionEditReq {
+	filter := func(ids []int) *[]int {
+		kept := make([]int, 0, len(ids))
+		for _, id := range ids {
+			if slices.Contains(authGroupIDs, id) {
+				kept = append(kept, id)
+			}
+		}
+		return &kept
+	}
+	permissions := &domain.NodePermissions{
+		Answerable: perms.Answerable,
+		Visitable:  perms.Visitable,
+		Visible:    perms.Visible,
+	}
+	for _, perm := range []*consts.NodeAccessPerm{&permissions.Answerable, &permissions.Visitable, &permissions.Visible} {
+		if *perm == "" {
+			*perm = consts.NodeAccessPermOpen
+		}
+	}
+	return &nodeV1.NodePermissionEditReq{
+		KbId:             kbID,
+		Permissions:      permissions,
+		AnswerableGroups: filter(perms.AnswerableGroups),
+		VisitableGroups:  filter(perms.VisitableGroups),
+		VisibleGroups:    filter(perms.VisibleGroups),
+	}
+}
+
+func isOpenPermission(edit *nodeV1.NodePermissionEditReq) bool {
+	return edit.Permissions.Answerable == consts.NodeAccessPermOpen &&
+		edit.Permissions.Visitable == consts.NodeAccessPermOpen &&
+		edit.Permissions.Visible == consts.NodeAccessPermOpen &&
+		len(*edit.AnswerableGroups) == 0 && len(*edit.VisitableGroups) == 0 && len(*edit.VisibleGroups) == 0
+}
+
+func importSiblingKey(parentID string, nodeType domain.NodeType, name string) string {
+	return fmt.Sprintf("%s/%d/%s", parentID, nodeType, name)
+}
+
+// importArchive reads the files of an uploaded archive, the decompressed sizes are capped against zip bombs
+type importArchive struct {
+	files  map[string]*zip.File
+	budget int64 // decompressed bytes the archive may still read
+}
+
+func (a *importArchive) read(name string) ([]byte, error) {
+	f, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s not found", domain.ErrInvalidImportArchive, name)
+	}
+	limit := max(0, min(importMaxFileSize, a.budget))
+	// the size in the header is only a hint, the limit is enforced on the data read
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%w: %s is too large", domain.ErrInvalidImportArchive, name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: open %s failed: %v", domain.ErrInvalidImportArchive, name, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	a.budget -= int64(len(data))
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: %s is too large", domain.ErrInvalidImportArchive, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %s failed: %v", domain.ErrInvalidImportArchive, name, err)
+	}
+	return data, nil
+}
+
+// parseExportedMarkdown splits an exported markdown file into front matter and body
+func parseExportedMarkdown(data []byte) (*domain.NodeExportFrontMatter, string, error) {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, "", errors.New("front matter not found")
+	}
+	header, body, ok := strings.Cut(content[len("---\n"):], "\n---\n")
+	if !ok {
+		return nil, "", errors.New("front matter is not closed")
+	}
+	var frontMatter domain.NodeExportFrontMatter
+	if err := yaml.Unmarshal([]byte(header), &frontMatter); err != nil {
+		return nil, "", fmt.Errorf("parse front matter failed: %w", err)
+	}
+	return &frontMatter, strings.TrimPrefix(body, "\n"), nil
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	kbV1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	nodeV1 "github.com/chaitin/panda-wiki/api/node/v1"
+	shareV1 "github.com/chaitin/panda-wiki/api/share/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+var nodeSlugRegexp = regexp.MustCompile(`^[\p{L}\p{N}]+(?:[-_][\p{L}\p{N}]+)*(?:/[\p{L}\p{N}]+(?:[-_][\p{L}\p{N}]+)*)*$`)
+
+type KBRedirectUsecase struct {
+	repo     *pg.KBRedirectRepository
+	nodeRepo *pg.NodeRepository
+	logger   *log.Logger
+}
+
+func NewKBRedirectUsecase(repo *pg.KBRedirectRepository, nodeRepo *pg.NodeRepository, logger *log.Logger) *KBRedirectUsecase {
+	return &KBRedirectUsecase{
+		repo:     repo,
+		nodeRepo: nodeRepo,
+		logger:   logger.WithModule("usecase.kb_redirect"),
+	}
+}
+
+func (u *KBRedirectUsecase) GetList(ctx context.Context, kbID string) ([]*kbV1.KBRedirectItem, error) {
+	return u.repo.GetList(ctx, kbID)
+}
+
+func (u *KBRedirectUsecase) Create(ctx context.Context, req *kbV1.KBRedirectCreateReq, userID string) (*kbV1.KBRedirectCreateResp, error) {
+	sourcePath, err := u.validateRedirect(ctx, req.KBId, 0, req.SourcePath, req.TargetNodeID, req.TargetURL)
+	if err != nil {
+		return nil, err
+	}
+	redirect := &domain.KBRedirect{
+		KBID:         req.KBId,
+		SourcePath:   sourcePath,
+		TargetNodeID: req.TargetNodeID,
+		TargetURL:    req.TargetURL,
+		Permanent:    req.Permanent == nil || *req.Permanent,
+		CreatedBy:    userID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := u.repo.Create(ctx, redirect); err != nil {
+		return nil, err
+	}
+	return &kbV1.KBRedirectCreateResp{RedirectID: redirect.ID}, nil
+}
+
+func (u *KBRedirectUsecase) Update(ctx context.Context, req *kbV1.KBRedirectUpdateReq) error {
+	sourcePath, err := u.validateRedirect(ctx, req.KBId, req.RedirectID, req.SourcePath, req.TargetNodeID, req.TargetURL)
+	if err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, req.KBId, req.RedirectID, map[string]any{
+		"source_path":    sourcePath,
+		"target_node_id": req.TargetNodeID,
+		"target_url":     req.TargetURL,
+		"permanent":      req.Permanent,
+	})
+}
+
+func (u *KBRedirectUsecase) Delete(ctx context.Context, kbID string, id int64) error {
+	return u.repo.Delete(ctx, kbID, id)
+}
+
+// validateRedirect returns the normalized source path, the redirect must have exactly one target and must not point to itself
+func (u *KBRedirectUsecase) validateRedirect(ctx context.Context, kbID string, id int64, sourcePath, targetNodeID, targetURL string) (string, error) {
+	sourcePath, ok := normalizeSitePath(sourcePath)
+	if !ok {
+		return "", domain.ErrInvalidRedirect
+	}
+	if (targetNodeID == "") == (targetURL == "") {
+		return "", domain.ErrInvalidRedirect
+	}
+	if targetURL != "" {
+		if target, err := url.Parse(targetURL); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return "", domain.ErrInvalidRedirect
+		}
+	}
+	if targetNodeID != "" {
+		nodes, err := u.nodeRepo.GetNodeBriefsByIDs(ctx, kbID, []string{targetNodeID})
+		if err != nil {
+			return "", err
+		}
+		if len(nodes) == 0 {
+			return "", domain.ErrInvalidRedirect
+		}
+		slug, err := u.repo.GetSlug(ctx, kbID, targetNodeID)
+		if err != nil {
+			return "", err
+		}
+		if sourcePath == domain.NodePath(targetNodeID, "") || sourcePath == domain.NodePath(targetNodeID, slug) {
+			return "", domain.ErrInvalidRedirect
+		}
+	}
+	existing, err := u.repo.GetBySourcePath(ctx, kbID, sourcePath)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && existing.ID != id {
+		return "", domain.ErrRedirectExists
+	}
+	return sourcePath, nil
+}
+
+func (u *KBRedirectUsecase) GetSlug(ctx context.Context, kbID, nodeID string) (*nodeV1.NodeSlugResp, error) {
+	slug, err := u.repo.GetSlug(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeV1.NodeSlugResp{Slug: slug, Path: domain.NodePath(nodeID, slug)}, nil
+}
+
+// SetSlug sets the alias of a node, an empty slug removes it. The replaced alias keeps working as a redirect
+func (u *KBRedirectUsecase) SetSlug(ctx context.Context, req *nodeV1.NodeSlugUpdateReq, userID string) error {
+	nodes, err := u.nodeRepo.GetNodeBriefsByIDs(ctx, req.KbId, []string{req.ID})
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return domain.ErrPermissionDenied
+	}
+	slug := strings.ToLower(strings.Trim(strings.TrimSpace(req.Slug), "/"))
+	if slug != "" {
+		if !nodeSlugRegexp.MatchString(slug) {
+			return domain.ErrInvalidNodeSlug
+		}
+		owner, err := u.repo.GetNodeIDBySlug(ctx, req.KbId, slug)
+		if err != nil {
+			return err
+		}
+		if owner != "" && owner != req.ID {
+			return domain.ErrNodeSlugExists
+		}
+	}
+
+	old, err := u.repo.GetSlug(ctx, req.KbId, req.ID)
+	if err != nil {
+		return err
+	}
+	if old == slug {
+		return nil
+	}
+	if old != "" {
+		if err := u.repo.Upsert(ctx, []*domain.KBRedirect{{
+			KBID:         req.KbId,
+			SourcePath:   domain.NodeSlugPath(old),
+			TargetNodeID: req.ID,
+			Permanent:    true,
+			CreatedBy:    userID,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}}); err != nil {
+			return err
+		}
+	}
+	if slug == "" {
+		return u.repo.DeleteSlug(ctx, req.KbId, req.ID)
+	}
+	// the slug path may have been redirected elsewhere before
+	if err := u.repo.DeleteBySourcePaths(ctx, req.KbId, []string{domain.NodeSlugPath(slug)}); err != nil {
+		return err
+	}
+	return u.repo.UpsertSlug(ctx, &domain.NodeSlug{
+		NodeID:    req.ID,
+		KBID:      req.KbId,
+		Slug:      slug,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// Resolve maps a request path of the site to a published node, or a redirect
+func (u *KBRedirectUsecase) Resolve(ctx context.Context, kbID, rawPath string) (*shareV1.ShareNodeResolveResp, error) {
+	sitePath, ok := normalizeSitePath(rawPath)
+	if ok {
+		nodeID := ""
+		switch {
+		case strings.HasPrefix(sitePath, domain.NodeSlugPathPrefix):
+			var err error
+			nodeID, err = u.repo.GetNodeIDBySlug(ctx, kbID, strings.TrimPrefix(sitePath, domain.NodeSlugPathPrefix))
+			if err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(sitePath, "/node/"):
+			nodeID = strings.TrimPrefix(sitePath, "/node/")
+		}
+		if nodeID != "" {
+			published, err := u.isPublished(ctx, kbID, nodeID)
+			if err != nil {
+				return nil, err
+			}
+			if published {
+				slug, err := u.repo.GetSlug(ctx, kbID, nodeID)
+				if err != nil {
+					return nil, err
+				}
+				return &shareV1.ShareNodeResolveResp{
+					Type:   domain.ShareResolveTypeNode,
+					NodeID: nodeID,
+					Path:   domain.NodePath(nodeID, slug),
+				}, nil
+			}
+		}
+
+		resp, err := u.resolveRedirect(ctx, kbID, sitePath)
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			return resp, nil
+		}
+	}
+
+	return &shareV1.ShareNodeResolveResp{Type: domain.ShareResolveTypeNotFound}, nil
+}
+
+// resolveRedirect returns nil when the path has no redirect or its target node is not published
+func (u *KBRedirectUsecase) resolveRedirect(ctx context.Context, kbID, sitePath string) (*shareV1.ShareNodeResolveResp, error) {
+	redirect, err := u.repo.GetBySourcePath(ctx, kbID, sitePath)
+	if err != nil || redirect == nil {
+		return nil, err
+	}
+	resp := &shareV1.ShareNodeResolveResp{
+		Type:        domain.ShareResolveTypeRedirect,
+		RedirectURL: redirect.TargetURL,
+		StatusCode:  http.StatusFound,
+	}
+	if redirect.Permanent {
+		resp.StatusCode = http.StatusMovedPermanently
+	}
+	if redirect.TargetNodeID != "" {
+		published, err := u.isPublished(ctx, kbID, redirect.TargetNodeID)
+		if err != nil || !published {
+			return nil, err
+		}
+		slug, err := u.repo.GetSlug(ctx, kbID, redirect.TargetNodeID)
+		if err != nil {
+			return nil, err
+		}
+		resp.NodeID = redirect.TargetNodeID
+		resp.RedirectURL = domain.NodePath(redirect.TargetNodeID, slug)
+	}
+	if err := u.repo.Hit(ctx, redirect.ID); err != nil {
+		u.logger.Warn("count redirect hit failed", log.Int64("redirect_id", redirect.ID), log.Error(err))
+	}
+	return resp, nil
+}
+
+func (u *KBRedirectUsecase) isPublished(ctx context.Context, kbID, nodeID string) (bool, error) {
+	releases, err := u.nodeRepo.GetLatestNodeReleaseByNodeIDs(ctx, kbID, []string{nodeID})
+	if err != nil {
+		return false, err
+	}
+	return len(releases) > 0, nil
+}
+
+// SetShareNodeListSlugs fills the aliases of the share node list
+func (u *KBRedirectUsecase) SetShareNodeListSlugs(ctx context.Context, kbID string, nodes []*domain.ShareNodeListItemResp) error {
+	slugs, err := u.repo.GetSlugs(ctx, kbID)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		node.Slug = slugs[node.ID]
+	}
+	return nil
+}
+
+// SetShareNodeSlugs fills the aliases of a share node and of its children
+func (u *KBRedirectUsecase) SetShareNodeSlugs(ctx context.Context, kbID string, node *shareV1.ShareNodeDetailResp) error {
+	slugs, err := u.repo.GetSlugs(ctx, kbID)
+	if err != nil {
+		return err
+	}
+	node.Slug = slugs[node.ID]
+	var setChildren func(items []*domain.ShareNodeDetailItem)
+	setChildren = func(items []*domain.ShareNodeDetailItem) {
+		for _, item := range items {
+			item.Slug = slugs[item.ID]
+			setChildren(item.Children)
+		}
+	}
+	setChildren(node.List)
+	return nil
+}
+
+// normalizeSitePath returns the decoded path without query and trailing slash, full urls are reduced to their path.
+// The alias part of /docs/ paths is lowercased like the stored slugs
+func normalizeSitePath(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	sitePath := path.Clean("/" + parsed.Path)
+	if sitePath == "/" {
+		return "", false
+	}
+	if strings.HasPrefix(sitePath, domain.NodeSlugPathPrefix) {
+		sitePath = strings.ToLower(sitePath)
+	}
+	return sitePath, true
+}
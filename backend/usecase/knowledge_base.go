@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
 	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
 	"github.com/chaitin/panda-wiki/config"
@@ -19,26 +20,32 @@ import (
 )
 
 type KnowledgeBaseUsecase struct {
-	repo     *pg.KnowledgeBaseRepository
-	nodeRepo *pg.NodeRepository
-	ragRepo  *mq.RAGRepository
-	userRepo *pg.UserRepository
-	rag      rag.RAGService
-	kbCache  *cache.KBRepo
-	logger   *log.Logger
-	config   *config.Config
+	repo           *pg.KnowledgeBaseRepository
+	nodeRepo       *pg.NodeRepository
+	linkRepo       *pg.NodeLinkRepository
+	ragRepo        *mq.RAGRepository
+	userRepo       *pg.UserRepository
+	rag            rag.RAGService
+	kbCache        *cache.KBRepo
+	webhookUsecase *WebhookUsecase
+	lintUsecase    *NodeLintUsecase
+	logger         *log.Logger
+	config         *config.Config
 }
 
-func NewKnowledgeBaseUsecase(repo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, ragRepo *mq.RAGRepository, userRepo *pg.UserRepository, rag rag.RAGService, kbCache *cache.KBRepo, logger *log.Logger, config *config.Config) (*KnowledgeBaseUsecase, error) {
+func NewKnowledgeBaseUsecase(repo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, linkRepo *pg.NodeLinkRepository, ragRepo *mq.RAGRepository, userRepo *pg.UserRepository, rag rag.RAGService, kbCache *cache.KBRepo, webhookUsecase *WebhookUsecase, lintUsecase *NodeLintUsecase, logger *log.Logger, config *config.Config) (*KnowledgeBaseUsecase, error) {
 	u := &KnowledgeBaseUsecase{
-		repo:     repo,
-		nodeRepo: nodeRepo,
-		ragRepo:  ragRepo,
-		userRepo: userRepo,
-		rag:      rag,
-		logger:   logger.WithModule("usecase.knowledge_base"),
-		config:   config,
-		kbCache:  kbCache,
+		repo:           repo,
+		nodeRepo:       nodeRepo,
+		linkRepo:       linkRepo,
+		ragRepo:        ragRepo,
+		userRepo:       userRepo,
+		rag:            rag,
+		logger:         logger.WithModule("usecase.knowledge_base"),
+		config:         config,
+		kbCache:        kbCache,
+		webhookUsecase: webhookUsecase,
+		lintUsecase:    lintUsecase,
 	}
 	return u, nil
 }
@@ -147,6 +154,7 @@ func (u *KnowledgeBaseUsecase) DeleteKnowledgeBase(ctx context.Context, kbID str
 }
 
 func (u *KnowledgeBaseUsecase) CreateKBRelease(ctx context.Context, req *domain.CreateKBReleaseReq, userId string) (string, error) {
+	var nodeReleases []*domain.NodeRelease
 	if len(req.NodeIDs) > 0 {
 		// create published nodes
 		releaseIDs, err := u.nodeRepo.CreateNodeReleases(ctx, req.KBID, userId, req.NodeIDs)
@@ -166,6 +174,12 @@ func (u *KnowledgeBaseUsecase) CreateKBRelease(ctx context.Context, req *domain.
 			if err := u.ragRepo.AsyncUpdateNodeReleaseVector(ctx, nodeContentVectorRequests); err != nil {
 				return "", err
 			}
+			nodeReleases, err = u.nodeRepo.GetNodeReleasesByIDs(ctx, releaseIDs)
+			if err != nil {
+				u.logger.Error("get new node releases failed", log.String("kb_id", req.KBID), log.Error(err))
+			}
+			u.updateNodeReleaseLinks(ctx, req.KBID, nodeReleases)
+			u.lintUsecase.LintNodeReleases(ctx, req.KBID, nodeReleases)
 		}
 	}
 
@@ -180,9 +194,45 @@ func (u *KnowledgeBaseUsecase) CreateKBRelease(ctx context.Context, req *domain.
 		return "", fmt.Errorf("failed to create kb release: %w", err)
 	}
 
+	published := lo.Map(nodeReleases, func(nodeRelease *domain.NodeRelease, _ int) any {
+		return &domain.WebhookNodeData{
+			ID:        nodeRelease.NodeID,
+			Name:      nodeRelease.Name,
+			Type:      nodeRelease.Type,
+			ParentID:  nodeRelease.ParentID,
+			ReleaseID: nodeRelease.ID,
+		}
+	})
+	u.webhookUsecase.Publish(ctx, req.KBID, domain.WebhookEventNodePublished, published...)
+	u.webhookUsecase.Publish(ctx, req.KBID, domain.WebhookEventKBReleaseCreated, &domain.WebhookKBReleaseData{
+		ID:      release.ID,
+		Tag:     release.Tag,
+		Message: release.Message,
+		NodeIDs: lo.Map(nodeReleases, func(nodeRelease *domain.NodeRelease, _ int) string {
+			return nodeRelease.NodeID
+		}),
+	})
+
 	return release.ID, nil
 }
 
+// updateNodeReleaseLinks indexes the links of the new node releases
+func (u *KnowledgeBaseUsecase) updateNodeReleaseLinks(ctx context.Context, kbID string, nodeReleases []*domain.NodeRelease) {
+	if len(nodeReleases) == 0 {
+		return
+	}
+	kb, err := u.repo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		u.logger.Error("get kb for node links failed", log.String("kb_id", kbID), log.Error(err))
+		return
+	}
+	for _, nodeRelease := range nodeReleases {
+		if err := replaceNodeLinks(ctx, u.linkRepo, kb, nodeRelease.NodeID, domain.NodeLinkScopeRelease, nodeRelease.Content); err != nil {
+			u.logger.Error("update node release links failed", log.String("node_id", nodeRelease.NodeID), log.Error(err))
+		}
+	}
+}
+
 func (u *KnowledgeBaseUsecase) GetKBReleaseList(ctx context.Context, req *domain.GetKBReleaseListReq) (*domain.GetKBReleaseListResp, error) {
 	total, releases, err := u.repo.GetKBReleaseList(ctx, req.KBID)
 	if err != nil {
@@ -31,6 +31,7 @@ type LLMUsecase struct {
 	nodeRepo         *pg.NodeRepository
 	modelRepo        *pg.ModelRepository
 	promptRepo       *pg.PromptRepo
+	translationRepo  *pg.NodeTranslationRepository
 	config           *config.Config
 	logger           *log.Logger
 	modelkit         *modelkit.ModelKit
@@ -41,7 +42,7 @@ const (
 	summaryMaxChunks       = 4     // max chunks to process for summary
 )
 
-func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *pg.ConversationRepository, kbRepo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, modelRepo *pg.ModelRepository, promptRepo *pg.PromptRepo, logger *log.Logger) *LLMUsecase {
+func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *pg.ConversationRepository, kbRepo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, modelRepo *pg.ModelRepository, promptRepo *pg.PromptRepo, translationRepo *pg.NodeTranslationRepository, logger *log.Logger) *LLMUsecase {
 	tiktoken.SetBpeLoader(&utils.Localloader{})
 	modelkit := modelkit.NewModelKit(logger.Logger)
 	return &LLMUsecase{
@@ -52,6 +53,7 @@ func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *
 		nodeRepo:         nodeRepo,
 		modelRepo:        modelRepo,
 		promptRepo:       promptRepo,
+		translationRepo:  translationRepo,
 		logger:           logger.WithModule("usecase.llm"),
 		modelkit:         modelkit,
 	}
@@ -63,6 +65,8 @@ func (u *LLMUsecase) FormatConversationMessages(
 	kbID string,
 	groupIDs []int,
 	systemPrompt string,
+	filter *domain.NodeMetaFilter,
+	locale string,
 ) ([]*schema.Message, []*domain.RankedNodeChunks, error) {
 	messages := make([]*schema.Message, 0)
 	rankedNodes := make([]*domain.RankedNodeChunks, 0)
@@ -106,7 +110,7 @@ func (u *LLMUsecase) FormatConversationMessages(
 			if err != nil {
 				return nil, nil, fmt.Errorf("get kb failed: %w", err)
 			}
-			rankedNodes, err = u.GetRankNodes(ctx, []string{kb.DatasetID}, question, groupIDs, 0, historyMessages[:len(historyMessages)-1])
+			rankedNodes, err = u.GetRankNodes(ctx, []string{kb.DatasetID}, question, groupIDs, 0, historyMessages[:len(historyMessages)-1], filter, locale)
 			if err != nil {
 				return nil, nil, fmt.Errorf("get rank nodes failed: %w", err)
 			}
@@ -310,10 +314,24 @@ func (u *LLMUsecase) GetRankNodes(
 	groupIDs []int,
 	similarityThreshold float64,
 	historyMessages []*schema.Message,
+	filter *domain.NodeMetaFilter,
+	locale string,
 ) ([]*domain.RankedNodeChunks, error) {
 	var rankedNodes []*domain.RankedNodeChunks
+	// restrict retrieval to documents matching tags and custom fields
+	var docIDs []string
+	if !filter.IsEmpty() {
+		var err error
+		docIDs, err = u.nodeRepo.GetNodeReleaseDocIDsByMeta(ctx, datasetIDs, filter)
+		if err != nil {
+			return nil, fmt.Errorf("get doc ids by node meta failed: %w", err)
+		}
+		if len(docIDs) == 0 {
+			return rankedNodes, nil
+		}
+	}
 	// get related documents from raglite
-	records, err := u.rag.QueryRecords(ctx, datasetIDs, question, groupIDs, similarityThreshold, historyMessages)
+	records, err := u.rag.QueryRecords(ctx, datasetIDs, question, groupIDs, docIDs, similarityThreshold, historyMessages)
 	if err != nil {
 		return nil, fmt.Errorf("get records from raglite failed: %w", err)
 	}
@@ -349,5 +367,54 @@ func (u *LLMUsecase) GetRankNodes(
 			}
 		}
 	}
-	return rankedNodes, nil
+	return u.preferLocale(ctx, rankedNodes, locale)
+}
+
+// preferLocale keeps one language variant of each retrieved document, the one in the asker's locale,
+// or the source when there is none. The variant takes the rank of the best ranked one.
+func (u *LLMUsecase) preferLocale(ctx context.Context, rankedNodes []*domain.RankedNodeChunks, locale string) ([]*domain.RankedNodeChunks, error) {
+	if len(rankedNodes) < 2 {
+		return rankedNodes, nil
+	}
+	translations, err := u.translationRepo.GetByNodeIDs(ctx, lo.Map(rankedNodes, func(node *domain.RankedNodeChunks, _ int) string {
+		return node.NodeID
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("get node translations failed: %w", err)
+	}
+	if len(translations) == 0 {
+		return rankedNodes, nil
+	}
+	translationMap := lo.SliceToMap(translations, func(t *domain.NodeTranslation) (string, *domain.NodeTranslation) {
+		return t.NodeID, t
+	})
+	sourceID := func(node *domain.RankedNodeChunks) string {
+		if t, ok := translationMap[node.NodeID]; ok {
+			return t.SourceNodeID
+		}
+		return node.NodeID
+	}
+	variants := lo.GroupBy(rankedNodes, sourceID)
+	result := make([]*domain.RankedNodeChunks, 0, len(variants))
+	for _, node := range rankedNodes {
+		group, ok := variants[sourceID(node)]
+		if !ok {
+			continue
+		}
+		delete(variants, sourceID(node))
+		locales := lo.Map(group, func(variant *domain.RankedNodeChunks, _ int) string {
+			if t, ok := translationMap[variant.NodeID]; ok {
+				return t.Locale
+			}
+			return ""
+		})
+		preferred := node
+		if i := utils.MatchLocale(locale, locales); i >= 0 {
+			preferred = group[i]
+		} else if i := slices.Index(locales, ""); i >= 0 {
+			preferred = group[i]
+		}
+		result = append(result, preferred)
+	}
+	return result, nil
 }
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/samber/lo"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/store/rag/ct"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const llmsContentChunkSize = 200
+
+// LLMsUsecase serves the published documents as markdown for AI agents, following https://llmstxt.org
+type LLMsUsecase struct {
+	nodeUsecase *NodeUsecase
+	nodeRepo    *pg.NodeRepository
+	kbRepo      *pg.KnowledgeBaseRepository
+	appRepo     *pg.AppRepository
+	mdConv      *converter.Converter
+	logger      *log.Logger
+}
+
+func NewLLMsUsecase(
+	nodeUsecase *NodeUsecase,
+	nodeRepo *pg.NodeRepository,
+	kbRepo *pg.KnowledgeBaseRepository,
+	appRepo *pg.AppRepository,
+	logger *log.Logger,
+) *LLMsUsecase {
+	return &LLMsUsecase{
+		nodeUsecase: nodeUsecase,
+		nodeRepo:    nodeRepo,
+		kbRepo:      kbRepo,
+		appRepo:     appRepo,
+		mdConv:      ct.NewHTML2MDConverter(),
+		logger:      logger.WithModule("usecase.llms"),
+	}
+}
+
+// llmsSite is the published tree the visitor can visit
+type llmsSite struct {
+	name     string
+	desc     string
+	baseURL  string
+	children map[string][]*domain.ShareNodeListItemResp // parent id -> nodes ordered by position
+}
+
+func (u *LLMsUsecase) getSite(ctx context.Context, kbID string, authId uint) (*llmsSite, error) {
+	app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return nil, err
+	}
+	if !app.Settings.LLMsTxtSettings.IsEnabled {
+		return nil, domain.ErrLLMsTxtDisabled
+	}
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	// visible nodes, documents also have to be visitable
+	nodes, err := u.nodeUsecase.GetNodeReleaseListByKBID(ctx, kbID, authId)
+	if err != nil {
+		return nil, err
+	}
+	visitableIDs, err := u.nodeUsecase.GetNodeIdsByAuthId(ctx, authId, consts.NodePermNameVisitable)
+	if err != nil {
+		return nil, err
+	}
+	nodeMap := lo.SliceToMap(nodes, func(node *domain.ShareNodeListItemResp) (string, *domain.ShareNodeListItemResp) {
+		return node.ID, node
+	})
+	site := &llmsSite{
+		name:     lo.CoalesceOrEmpty(app.Settings.Title, kb.Name),
+		desc:     app.Settings.Desc,
+		baseURL:  kb.AccessSettings.BaseURL,
+		children: make(map[string][]*domain.ShareNodeListItemResp),
+	}
+	for _, node := range nodes {
+		if node.Type == domain.NodeTypeDocument && !isNodeVisitable(node, visitableIDs) {
+			continue
+		}
+		parentID := node.ParentID
+		if _, ok := nodeMap[parentID]; !ok {
+			parentID = ""
+		}
+		site.children[parentID] = append(site.children[parentID], node)
+	}
+	for _, children := range site.children {
+		slices.SortStableFunc(children, func(a, b *domain.ShareNodeListItemResp) int {
+			return cmp.Compare(a.Position, b.Position)
+		})
+	}
+	return site, nil
+}
+
+func isNodeVisitable(node *domain.ShareNodeListItemResp, visitableIDs []string) bool {
+	switch node.Permissions.Visitable {
+	case consts.NodeAccessPermOpen:
+		return true
+	case consts.NodeAccessPermPartial:
+		return slices.Contains(visitableIDs, node.ID)
+	default:
+		return false
+	}
+}
+
+// walk visits the documents under parentID depth first, path is the names of the folders between
+func (s *llmsSite) walk(parentID string, path []string, fn func(node *domain.ShareNodeListItemResp, path []string)) {
+	for _, node := range s.children[parentID] {
+		if node.Type == domain.NodeTypeFolder {
+			s.walk(node.ID, append(slices.Clone(path), node.Name), fn)
+			continue
+		}
+		fn(node, path)
+	}
+}
+
+// GetLLMsTxt returns the index of the documents, every top level folder is a section
+func (u *LLMsUsecase) GetLLMsTxt(ctx context.Context, kbID string, authId uint) (string, error) {
+	site, err := u.getSite(ctx, kbID, authId)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", site.name)
+	if site.desc != "" {
+		fmt.Fprintf(&sb, "> %s\n\n", strings.Join(strings.Fields(site.desc), " "))
+	}
+	fmt.Fprintf(&sb, "The full content of all documents is available at %s/llms-full.txt\n", site.baseURL)
+
+	writeSection := func(title string, docs func(fn func(node *domain.ShareNodeListItemResp, path []string))) {
+		var section strings.Builder
+		docs(func(node *domain.ShareNodeListItemResp, path []string) {
+			name := strings.Join(append(slices.Clone(path), node.Name), " / ")
+			fmt.Fprintf(&section, "- [%s](%s/node/%s.md)", name, site.baseURL, node.ID)
+			if summary := strings.Join(strings.Fields(node.Meta.Summary), " "); summary != "" {
+				fmt.Fprintf(&section, ": %s", summary)
+			}
+			section.WriteString("\n")
+		})
+		// folders without visitable documents have no section
+		if section.Len() > 0 {
+			fmt.Fprintf(&sb, "\n## %s\n\n%s", title, section.String())
+		}
+	}
+	writeSection("Docs", func(fn func(node *domain.ShareNodeListItemResp, path []string)) {
+		for _, node := range site.children[""] {
+			if node.Type == domain.NodeTypeDocument {
+				fn(node, nil)
+			}
+		}
+	})
+	for _, folder := range site.children[""] {
+		if folder.Type == domain.NodeTypeFolder {
+			writeSection(folder.Name, func(fn func(node *domain.ShareNodeListItemResp, path []string)) {
+				site.walk(folder.ID, nil, fn)
+			})
+		}
+	}
+	return sb.String(), nil
+}
+
+// GetLLMsFullTxt returns the markdown of all documents in tree order
+func (u *LLMsUsecase) GetLLMsFullTxt(ctx context.Context, kbID string, authId uint) (string, error) {
+	site, err := u.getSite(ctx, kbID, authId)
+	if err != nil {
+		return "", err
+	}
+	docs := make([]*domain.ShareNodeListItemResp, 0)
+	site.walk("", nil, func(node *domain.ShareNodeListItemResp, _ []string) {
+		docs = append(docs, node)
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", site.name)
+	if site.desc != "" {
+		fmt.Fprintf(&sb, "\n> %s\n", strings.Join(strings.Fields(site.desc), " "))
+	}
+	for _, chunk := range lo.Chunk(docs, llmsContentChunkSize) {
+		contents, err := u.nodeRepo.GetLatestNodeReleaseContents(ctx, kbID, lo.Map(chunk, func(node *domain.ShareNodeListItemResp, _ int) string {
+			return node.ID
+		}))
+		if err != nil {
+			return "", err
+		}
+		for _, node := range chunk {
+			content, ok := contents[node.ID]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n---\n\n# %s\n\nSource: %s/node/%s\n\n%s\n", node.Name, site.baseURL, node.ID, u.toMarkdown(node.ID, content, node.Meta.ContentType))
+		}
+	}
+	return sb.String(), nil
+}
+
+// GetNodeMarkdown returns a published document as markdown, or the list of its children for a folder.
+// The caller validates that the visitor can visit the node.
+func (u *LLMsUsecase) GetNodeMarkdown(ctx context.Context, kbID, nodeID string, authId uint) (string, error) {
+	site, err := u.getSite(ctx, kbID, authId)
+	if err != nil {
+		return "", err
+	}
+	node, err := u.nodeRepo.GetNodeReleaseDetailByKBIDAndID(ctx, kbID, nodeID)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", node.Name)
+	if node.Type == domain.NodeTypeFolder {
+		for _, child := range site.children[node.ID] {
+			fmt.Fprintf(&sb, "- [%s](%s/node/%s.md)\n", child.Name, site.baseURL, child.ID)
+		}
+		return sb.String(), nil
+	}
+	if node.Meta.Summary != "" {
+		fmt.Fprintf(&sb, "> %s\n\n", strings.Join(strings.Fields(node.Meta.Summary), " "))
+	}
+	sb.WriteString(u.toMarkdown(node.ID, node.Content, node.Meta.ContentType))
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+func (u *LLMsUsecase) toMarkdown(nodeID, content, contentType string) string {
+	if contentType == domain.ContentTypeMD || (contentType == "" && !utils.IsLikelyHTML(content)) {
+		return strings.TrimSpace(content)
+	}
+	md, err := u.mdConv.ConvertString(content)
+	if err != nil {
+		u.logger.Warn("convert html to markdown failed, use plain text", log.String("node_id", nodeID), log.Error(err))
+		return utils.ContentToText(content)
+	}
+	return strings.TrimSpace(md)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/html"
@@ -16,6 +17,7 @@ import (
 
 	v1 "github.com/chaitin/panda-wiki/api/node/v1"
 	shareV1 "github.com/chaitin/panda-wiki/api/share/v1"
+	"github.com/chaitin/panda-wiki/config"
 	"github.com/chaitin/panda-wiki/consts"
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
@@ -27,22 +29,33 @@ import (
 )
 
 type NodeUsecase struct {
-	nodeRepo     *pg.NodeRepository
-	appRepo      *pg.AppRepository
-	ragRepo      *mq.RAGRepository
-	kbRepo       *pg.KnowledgeBaseRepository
-	modelRepo    *pg.ModelRepository
-	userRepo     *pg.UserRepository
-	authRepo     *pg.AuthRepo
-	llmUsecase   *LLMUsecase
-	logger       *log.Logger
-	s3Client     *s3.MinioClient
-	rAGService   rag.RAGService
-	modelUsecase *ModelUsecase
+	nodeRepo         *pg.NodeRepository
+	nodeTemplateRepo *pg.NodeTemplateRepository
+	nodeLinkRepo     *pg.NodeLinkRepository
+	nodeFieldRepo    *pg.NodeFieldRepository
+	redirectRepo     *pg.KBRedirectRepository
+	appRepo          *pg.AppRepository
+	ragRepo          *mq.RAGRepository
+	kbRepo           *pg.KnowledgeBaseRepository
+	modelRepo        *pg.ModelRepository
+	userRepo         *pg.UserRepository
+	authRepo         *pg.AuthRepo
+	llmUsecase       *LLMUsecase
+	logger           *log.Logger
+	s3Client         *s3.MinioClient
+	rAGService       rag.RAGService
+	modelUsecase     *ModelUsecase
+	webhookUsecase   *WebhookUsecase
+	lintUsecase      *NodeLintUsecase
+	config           *config.Config
 }
 
 func NewNodeUsecase(
 	nodeRepo *pg.NodeRepository,
+	nodeTemplateRepo *pg.NodeTemplateRepository,
+	nodeLinkRepo *pg.NodeLinkRepository,
+	nodeFieldRepo *pg.NodeFieldRepository,
+	redirectRepo *pg.KBRedirectRepository,
 	appRepo *pg.AppRepository,
 	ragRepo *mq.RAGRepository,
 	userRepo *pg.UserRepository,
@@ -54,30 +67,62 @@ func NewNodeUsecase(
 	modelRepo *pg.ModelRepository,
 	authRepo *pg.AuthRepo,
 	modelUsecase *ModelUsecase,
+	webhookUsecase *WebhookUsecase,
+	lintUsecase *NodeLintUsecase,
+	config *config.Config,
 ) *NodeUsecase {
 	return &NodeUsecase{
-		nodeRepo:     nodeRepo,
-		rAGService:   ragService,
-		appRepo:      appRepo,
-		ragRepo:      ragRepo,
-		kbRepo:       kbRepo,
-		authRepo:     authRepo,
-		userRepo:     userRepo,
-		llmUsecase:   llmUsecase,
-		modelRepo:    modelRepo,
-		logger:       logger.WithModule("usecase.node"),
-		s3Client:     s3Client,
-		modelUsecase: modelUsecase,
+		nodeRepo:         nodeRepo,
+		nodeTemplateRepo: nodeTemplateRepo,
+		nodeLinkRepo:     nodeLinkRepo,
+		nodeFieldRepo:    nodeFieldRepo,
+		redirectRepo:     redirectRepo,
+		rAGService:       ragService,
+		appRepo:          appRepo,
+		ragRepo:          ragRepo,
+		kbRepo:           kbRepo,
+		authRepo:         authRepo,
+		userRepo:         userRepo,
+		llmUsecase:       llmUsecase,
+		modelRepo:        modelRepo,
+		logger:           logger.WithModule("usecase.node"),
+		s3Client:         s3Client,
+		modelUsecase:     modelUsecase,
+		webhookUsecase:   webhookUsecase,
+		lintUsecase:      lintUsecase,
+		config:           config,
 	}
 }
 
 const ragSyncChunkSize = 100
 
 func (u *NodeUsecase) Create(ctx context.Context, req *domain.CreateNodeReq, userId string) (string, error) {
+	if req.TemplateID != "" {
+		if err := u.applyNodeTemplate(ctx, req, userId); err != nil {
+			return "", err
+		}
+	}
+	if len(req.Tags) > 0 || len(req.Fields) > 0 {
+		tags, fields, err := u.normalizeNodeMeta(ctx, req.KBID, req.Tags, req.Fields)
+		if err != nil {
+			return "", err
+		}
+		req.Tags, req.Fields = tags, fields
+	}
 	nodeID, err := u.nodeRepo.Create(ctx, req, userId)
 	if err != nil {
 		return "", err
 	}
+	if req.Content != "" {
+		u.updateNodeDraftLinks(ctx, req.KBID, nodeID, req.Content)
+		u.lintUsecase.LintNode(ctx, req.KBID, nodeID, req.Content, lo.FromPtr(req.ContentType))
+	}
+	u.webhookUsecase.Publish(ctx, req.KBID, domain.WebhookEventNodeCreated, &domain.WebhookNodeData{
+		ID:       nodeID,
+		Name:     req.Name,
+		Type:     req.Type,
+		ParentID: req.ParentID,
+	})
 	return nodeID, nil
 }
 
@@ -89,6 +134,7 @@ func (u *NodeUsecase) GetList(ctx context.Context, req *domain.GetNodeListReq) (
 	if len(nodes) == 0 {
 		return nodes, nil
 	}
+	setNodeSearchSnippets(nodes, req.Search)
 
 	publisherMap, err := u.nodeRepo.GetNodeReleasePublisherMap(ctx, req.KBID)
 	if err != nil {
@@ -136,34 +182,166 @@ func (u *NodeUsecase) GetNodeByKBID(ctx context.Context, id, kbId, format string
 	return node, nil
 }
 
-func (u *NodeUsecase) NodeAction(ctx context.Context, req *domain.NodeActionReq) error {
+func (u *NodeUsecase) NodeAction(ctx context.Context, req *domain.NodeActionReq, userId string) (*v1.NodeActionResp, error) {
+	resp := &v1.NodeActionResp{}
 	switch req.Action {
 	case "delete":
-		docIDs, err := u.nodeRepo.Delete(ctx, req.KBID, req.IDs)
+		// links from other nodes which will be broken after deletion
+		inboundLinks, err := u.GetNodeInboundLinks(ctx, &v1.NodeInboundLinksReq{KbId: req.KBID, IDs: req.IDs})
 		if err != nil {
-			return err
+			return nil, err
 		}
-		nodeVectorContentRequests := make([]*domain.NodeReleaseVectorRequest, 0)
-		for _, docID := range docIDs {
-			nodeVectorContentRequests = append(nodeVectorContentRequests, &domain.NodeReleaseVectorRequest{
-				KBID:   req.KBID,
-				DocID:  docID,
-				Action: "delete",
-			})
+		resp.InboundLinks = inboundLinks
+
+		// children are moved to trash as well
+		deleted, err := u.nodeRepo.GetNodeBriefsByIDs(ctx, req.KBID, u.nodeRepo.GetAllChildNodeIDs(ctx, req.KBID, req.IDs))
+		if err != nil {
+			return nil, err
 		}
-		if err := u.ragRepo.AsyncUpdateNodeReleaseVector(ctx, nodeVectorContentRequests); err != nil {
-			return err
+
+		if req.ReplacementNodeID != "" {
+			if lo.ContainsBy(deleted, func(node *domain.Node) bool { return node.ID == req.ReplacementNodeID }) {
+				return nil, domain.ErrInvalidRedirect
+			}
+			replacement, err := u.nodeRepo.GetNodeBriefsByIDs(ctx, req.KBID, []string{req.ReplacementNodeID})
+			if err != nil {
+				return nil, err
+			}
+			if len(replacement) == 0 {
+				return nil, domain.ErrInvalidRedirect
+			}
+		}
+
+		// move to trash, rag documents are deleted when the trash is purged
+		if err := u.nodeRepo.Delete(ctx, req.KBID, req.IDs, userId); err != nil {
+			return nil, err
+		}
+		u.publishNodeEvent(ctx, req.KBID, domain.WebhookEventNodeDeleted, deleted)
+
+		if req.ReplacementNodeID != "" {
+			if err := u.redirectDeletedNodes(ctx, req.KBID, deleted, req.ReplacementNodeID, userId); err != nil {
+				return nil, err
+			}
 		}
 	}
-	return nil
+	return resp, nil
 }
 
-func (u *NodeUsecase) Update(ctx context.Context, req *domain.UpdateNodeReq, userId string) error {
-	err := u.nodeRepo.UpdateNodeContent(ctx, req, userId)
+// redirectDeletedNodes redirects the urls and aliases of the deleted nodes to the replacement.
+// NodeRepository.RestoreTrash removes the redirects of the restored nodes' urls and aliases again,
+// PurgeTrash removes the aliases of the purged nodes and the redirects to them
+func (u *NodeUsecase) redirectDeletedNodes(ctx context.Context, kbID string, deleted []*domain.Node, replacementID, userId string) error {
+	slugs, err := u.redirectRepo.GetSlugs(ctx, kbID)
 	if err != nil {
 		return err
 	}
-	return nil
+	now := time.Now()
+	redirects := make([]*domain.KBRedirect, 0, len(deleted))
+	addRedirect := func(sourcePath string) {
+		redirects = append(redirects, &domain.KBRedirect{
+			KBID:         kbID,
+			SourcePath:   sourcePath,
+			TargetNodeID: replacementID,
+			Permanent:    true,
+			CreatedBy:    userId,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+	for _, node := range deleted {
+		addRedirect(domain.NodePath(node.ID, ""))
+		if slug := slugs[node.ID]; slug != "" {
+			addRedirect(domain.NodeSlugPath(slug))
+		}
+	}
+	return u.redirectRepo.Upsert(ctx, redirects)
+}
+
+func (u *NodeUsecase) Update(ctx context.Context, req *domain.UpdateNodeReq, userId string) (*v1.NodeUpdateResp, error) {
+	if req.Tags != nil || req.Fields != nil {
+		var tags []string
+		var fields map[string]string
+		if req.Tags != nil {
+			tags = *req.Tags
+		}
+		if req.Fields != nil {
+			fields = *req.Fields
+		}
+		tags, fields, err := u.normalizeNodeMeta(ctx, req.KBID, tags, fields)
+		if err != nil {
+			return nil, err
+		}
+		if req.Tags != nil {
+			req.Tags = &tags
+		}
+		if req.Fields != nil {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			req.Fields = &fields
+		}
+	}
+	version, err := u.nodeRepo.UpdateNodeContent(ctx, req, userId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.NodeUpdateResp{Version: version}
+	if req.Content != nil {
+		u.updateNodeDraftLinks(ctx, req.KBID, req.ID, *req.Content)
+		resp.LintIssues = u.lintUsecase.LintNode(ctx, req.KBID, req.ID, *req.Content, lo.FromPtr(req.ContentType))
+	}
+	if nodes, err := u.nodeRepo.GetNodeBriefsByIDs(ctx, req.KBID, []string{req.ID}); err != nil {
+		u.logger.Error("get node for webhook failed", log.String("node_id", req.ID), log.Error(err))
+	} else {
+		u.publishNodeEvent(ctx, req.KBID, domain.WebhookEventNodeUpdated, nodes)
+	}
+	return resp, nil
+}
+
+func (u *NodeUsecase) publishNodeEvent(ctx context.Context, kbID string, event domain.WebhookEvent, nodes []*domain.Node) {
+	data := lo.Map(nodes, func(node *domain.Node, _ int) any {
+		return &domain.WebhookNodeData{
+			ID:       node.ID,
+			Name:     node.Name,
+			Type:     node.Type,
+			ParentID: node.ParentID,
+		}
+	})
+	u.webhookUsecase.Publish(ctx, kbID, event, data...)
+}
+
+// GetUpdateConflict builds the latest node state and a three-way merge of the rejected update
+func (u *NodeUsecase) GetUpdateConflict(ctx context.Context, req *domain.UpdateNodeReq) (*v1.NodeUpdateConflictResp, error) {
+	node, err := u.nodeRepo.GetByID(ctx, req.ID, req.KBID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.NodeUpdateConflictResp{
+		ID:            node.ID,
+		Version:       node.Version,
+		Name:          node.Name,
+		Content:       node.Content,
+		EditorId:      node.EditorId,
+		EditorAccount: node.EditorAccount,
+		EditTime:      node.UpdatedAt,
+		MergedContent: node.Content,
+	}
+	if req.Content == nil || req.Version == nil {
+		return resp, nil
+	}
+
+	base := ""
+	baseVersion, err := u.nodeRepo.GetNodeVersionContent(ctx, node.ID, *req.Version)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	} else {
+		base = baseVersion.Content
+	}
+	isHTML := node.Meta.ContentType != domain.ContentTypeMD && utils.IsLikelyHTML(node.Content)
+	resp.MergedContent, resp.HasConflict = utils.ThreeWayMerge(base, *req.Content, node.Content, isHTML)
+	return resp, nil
 }
 
 func (u *NodeUsecase) ValidateNodePerm(ctx context.Context, kbID, nodeId string, authId uint) *domain.PWResponseErrCode {
@@ -523,20 +701,30 @@ func (u *NodeUsecase) ValidateNodePermissionsEdit(req v1.NodePermissionEditReq,
 	return nil
 }
 
-func (u *NodeUsecase) NodePermissionsEdit(ctx context.Context, req v1.NodePermissionEditReq) error {
+func (u *NodeUsecase) NodePermissionsEdit(ctx context.Context, req v1.NodePermissionEditReq) (*v1.NodePermissionEditResp, error) {
+	resp := &v1.NodePermissionEditResp{}
+	if req.Permissions != nil && req.Permissions.Visitable == consts.NodeAccessPermClosed {
+		// links from other nodes which can no longer be visited
+		inboundLinks, err := u.nodeLinkRepo.GetInboundLinks(ctx, req.KbId, req.IDs, req.IDs)
+		if err != nil {
+			return nil, err
+		}
+		resp.InboundLinks = inboundLinks
+	}
+
 	if req.Permissions != nil {
 		updateMap := map[string]interface{}{
 			"permissions": req.Permissions,
 		}
 
 		if err := u.nodeRepo.UpdateNodesByKbID(ctx, req.IDs, req.KbId, updateMap); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	nodeReleases, err := u.nodeRepo.GetLatestNodeReleaseByNodeIDs(ctx, req.KbId, req.IDs)
 	if err != nil {
-		return fmt.Errorf("get latest node release failed: %w", err)
+		return nil, fmt.Errorf("get latest node release failed: %w", err)
 	}
 
 	if len(nodeReleases) > 0 {
@@ -565,30 +753,30 @@ func (u *NodeUsecase) NodePermissionsEdit(ctx context.Context, req v1.NodePermis
 
 		if len(nodeVectorContentRequests) != 0 {
 			if err := u.ragRepo.AsyncUpdateNodeReleaseVector(ctx, nodeVectorContentRequests); err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
 
 	if req.AnswerableGroups != nil {
 		if err := u.nodeRepo.UpdateNodeGroupByKbIDAndNodeIds(ctx, req.IDs, *req.AnswerableGroups, consts.NodePermNameAnswerable); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if req.VisibleGroups != nil {
 		if err := u.nodeRepo.UpdateNodeGroupByKbIDAndNodeIds(ctx, req.IDs, *req.VisibleGroups, consts.NodePermNameVisible); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if req.VisitableGroups != nil {
 		if err := u.nodeRepo.UpdateNodeGroupByKbIDAndNodeIds(ctx, req.IDs, *req.VisitableGroups, consts.NodePermNameVisitable); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return resp, nil
 }
 
 func (u *NodeUsecase) SyncRagNodeStatus(ctx context.Context) error {
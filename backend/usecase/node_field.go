@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+const maxNodeTags = 20
+
+var nodeFieldKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func (u *NodeUsecase) GetNodeFieldList(ctx context.Context, req *v1.NodeFieldListReq) ([]*domain.NodeField, error) {
+	return u.nodeFieldRepo.GetListByKBID(ctx, req.KbId)
+}
+
+func (u *NodeUsecase) CreateNodeField(ctx context.Context, req *v1.NodeFieldCreateReq) (string, error) {
+	if !nodeFieldKeyRegex.MatchString(req.Key) {
+		return "", fmt.Errorf("%w: key must start with a-z and contain only a-z, 0-9 and _", domain.ErrInvalidNodeField)
+	}
+	options := normalizeNodeTags(req.Options)
+	if req.Type == domain.NodeFieldTypeSelect && len(options) == 0 {
+		return "", fmt.Errorf("%w: select field requires options", domain.ErrInvalidNodeField)
+	}
+	now := time.Now()
+	field := &domain.NodeField{
+		ID:        uuid.New().String(),
+		KBID:      req.KbId,
+		Key:       req.Key,
+		Name:      req.Name,
+		Type:      req.Type,
+		Options:   pq.StringArray(options),
+		Position:  req.Position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.nodeFieldRepo.Create(ctx, field); err != nil {
+		return "", err
+	}
+	return field.ID, nil
+}
+
+func (u *NodeUsecase) UpdateNodeField(ctx context.Context, req *v1.NodeFieldUpdateReq) error {
+	field, err := u.nodeFieldRepo.GetByID(ctx, req.KbId, req.ID)
+	if err != nil {
+		return err
+	}
+	updateMap := map[string]any{
+		"updated_at": time.Now(),
+	}
+	if req.Name != nil {
+		updateMap["name"] = *req.Name
+	}
+	if req.Options != nil {
+		options := normalizeNodeTags(*req.Options)
+		if field.Type == domain.NodeFieldTypeSelect && len(options) == 0 {
+			return fmt.Errorf("%w: select field requires options", domain.ErrInvalidNodeField)
+		}
+		updateMap["options"] = pq.StringArray(options)
+	}
+	if req.Position != nil {
+		updateMap["position"] = *req.Position
+	}
+	return u.nodeFieldRepo.Update(ctx, req.KbId, req.ID, updateMap)
+}
+
+func (u *NodeUsecase) DeleteNodeField(ctx context.Context, req *v1.NodeFieldDeleteReq) error {
+	return u.nodeFieldRepo.Delete(ctx, req.KbId, req.ID)
+}
+
+func (u *NodeUsecase) GetNodeTagList(ctx context.Context, req *v1.NodeTagListReq) ([]*domain.NodeTagCount, error) {
+	return u.nodeRepo.GetTagsByKBID(ctx, req.KbId)
+}
+
+// normalizeNodeTags trims, de-duplicates and drops empty values
+func normalizeNodeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// normalizeNodeMeta validates tags and custom field values against the field definitions of the kb,
+// empty field values are removed
+func (u *NodeUsecase) normalizeNodeMeta(ctx context.Context, kbID string, tags []string, fields map[string]string) ([]string, map[string]string, error) {
+	tags = normalizeNodeTags(tags)
+	if len(tags) > maxNodeTags {
+		return nil, nil, fmt.Errorf("%w: at most %d tags", domain.ErrInvalidNodeField, maxNodeTags)
+	}
+	if len(fields) == 0 {
+		return tags, nil, nil
+	}
+
+	defs, err := u.nodeFieldRepo.GetListByKBID(ctx, kbID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defMap := lo.SliceToMap(defs, func(def *domain.NodeField) (string, *domain.NodeField) {
+		return def.Key, def
+	})
+	result := make(map[string]string, len(fields))
+	for key, value := range fields {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		def, ok := defMap[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: unknown field %s", domain.ErrInvalidNodeField, key)
+		}
+		switch def.Type {
+		case domain.NodeFieldTypeSelect:
+			if !slices.Contains(def.Options, value) {
+				return nil, nil, fmt.Errorf("%w: %s is not an option of %s", domain.ErrInvalidNodeField, value, key)
+			}
+		case domain.NodeFieldTypeDate:
+			if _, err := time.Parse(domain.NodeFieldDateLayout, value); err != nil {
+				return nil, nil, fmt.Errorf("%w: %s must be a date like 2006-01-02", domain.ErrInvalidNodeField, key)
+			}
+		}
+		result[key] = value
+	}
+	return tags, result, nil
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	externalLinkCheckInterval = 24 * time.Hour
+	externalLinkCheckBatch    = 500
+	externalLinkCheckWorkers  = 8
+	externalLinkCheckTimeout  = 10 * time.Second
+)
+
+// kbLinkHosts returns the hosts under which absolute /node/<id> urls are treated as internal links
+func kbLinkHosts(kb *domain.KnowledgeBase) []string {
+	hosts := append([]string{}, kb.AccessSettings.Hosts...)
+	if kb.AccessSettings.BaseURL != "" {
+		if u, err := url.Parse(kb.AccessSettings.BaseURL); err == nil && u.Host != "" {
+			hosts = append(hosts, u.Host)
+		}
+	}
+	return hosts
+}
+
+// replaceNodeLinks parses the links in content and stores them as the node's links of the given scope
+func replaceNodeLinks(ctx context.Context, nodeLinkRepo *pg.NodeLinkRepository, kb *domain.KnowledgeBase, nodeID string, scope domain.NodeLinkScope, content string) error {
+	hosts := kbLinkHosts(kb)
+	links := make([]*domain.NodeLink, 0)
+	for _, link := range utils.ExtractLinks(content) {
+		if targetID, ok := utils.ParseNodeLink(link, hosts); ok {
+			links = append(links, &domain.NodeLink{
+				KBID:         kb.ID,
+				NodeID:       nodeID,
+				Scope:        scope,
+				Type:         domain.NodeLinkTypeInternal,
+				URL:          link,
+				TargetNodeID: targetID,
+			})
+			continue
+		}
+		if utils.IsExternalLink(link) {
+			links = append(links, &domain.NodeLink{
+				KBID:   kb.ID,
+				NodeID: nodeID,
+				Scope:  scope,
+				Type:   domain.NodeLinkTypeExternal,
+				URL:    link,
+			})
+		}
+	}
+	return nodeLinkRepo.ReplaceNodeLinks(ctx, kb.ID, nodeID, scope, links)
+}
+
+func (u *NodeUsecase) updateNodeDraftLinks(ctx context.Context, kbID, nodeID, content string) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		u.logger.Error("get kb for node links failed", log.String("kb_id", kbID), log.Error(err))
+		return
+	}
+	if err := replaceNodeLinks(ctx, u.nodeLinkRepo, kb, nodeID, domain.NodeLinkScopeDraft, content); err != nil {
+		u.logger.Error("update node links failed", log.String("node_id", nodeID), log.Error(err))
+	}
+}
+
+// GetNodeBacklinks returns the nodes linking to the given node
+func (u *NodeUsecase) GetNodeBacklinks(ctx context.Context, req *v1.NodeBacklinksReq) ([]*v1.NodeLinkItem, error) {
+	return u.nodeLinkRepo.GetInboundLinks(ctx, req.KbId, []string{req.ID}, []string{req.ID})
+}
+
+// GetNodeInboundLinks returns the links that break when the given nodes and their children are removed
+func (u *NodeUsecase) GetNodeInboundLinks(ctx context.Context, req *v1.NodeInboundLinksReq) ([]*v1.NodeLinkItem, error) {
+	ids := u.nodeRepo.GetAllChildNodeIDs(ctx, req.KbId, req.IDs)
+	return u.nodeLinkRepo.GetInboundLinks(ctx, req.KbId, ids, ids)
+}
+
+func (u *NodeUsecase) GetNodeBrokenLinks(ctx context.Context, req *v1.NodeBrokenLinksReq) ([]*v1.NodeBrokenLinkItem, error) {
+	return u.nodeLinkRepo.GetBrokenLinks(ctx, req.KbId)
+}
+
+// CheckExternalLinks checks the external urls which are not checked in the last interval
+func (u *NodeUsecase) CheckExternalLinks(ctx context.Context) error {
+	urls, err := u.nodeLinkRepo.GetExternalURLsToCheck(ctx, time.Now().Add(-externalLinkCheckInterval), externalLinkCheckBatch)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	// the status codes are shown to editors, internal addresses must not be probed
+	client := utils.NewPublicHTTPClient(externalLinkCheckTimeout)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	urlCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < externalLinkCheckWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range urlCh {
+				status, statusCode, errMsg := checkExternalLink(ctx, client, link)
+				if err := u.nodeLinkRepo.UpdateExternalLinkStatus(ctx, link, status, statusCode, errMsg); err != nil {
+					u.logger.Error("update external link status failed", log.String("url", link), log.Error(err))
+				}
+			}
+		}()
+	}
+	for _, link := range urls {
+		urlCh <- link
+	}
+	close(urlCh)
+	wg.Wait()
+
+	u.logger.Info("check external links done", log.Int("count", len(urls)))
+	return nil
+}
+
+func checkExternalLink(ctx context.Context, client *http.Client, link string) (domain.NodeLinkStatus, int, string) {
+	statusCode, err := requestExternalLink(ctx, client, http.MethodHead, link)
+	// some sites do not support HEAD
+	if err != nil || statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusForbidden || statusCode == http.StatusNotImplemented {
+		statusCode, err = requestExternalLink(ctx, client, http.MethodGet, link)
+	}
+	if errors.Is(err, utils.ErrPrivateAddress) {
+		return domain.NodeLinkStatusUnchecked, 0, utils.ErrPrivateAddress.Error()
+	}
+	if err != nil {
+		return domain.NodeLinkStatusBroken, 0, err.Error()
+	}
+	if statusCode >= http.StatusBadRequest {
+		return domain.NodeLinkStatusBroken, statusCode, fmt.Sprintf("unexpected status code: %d", statusCode)
+	}
+	return domain.NodeLinkStatusOK, statusCode, ""
+}
+
+func requestExternalLink(ctx context.Context, client *http.Client, method, link string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "PandaWiki-LinkChecker/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
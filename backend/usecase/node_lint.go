@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/config"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	nodeLintMaxTableRows = 100
+	nodeLintMaxTableCols = 12
+)
+
+// NodeLintUsecase checks node content for quality issues when it is saved or published
+type NodeLintUsecase struct {
+	lintRepo      *pg.NodeLintRepository
+	nodeRepo      *pg.NodeRepository
+	kbRepo        *pg.KnowledgeBaseRepository
+	blockWordRepo *pg.BlockWordRepo
+	config        *config.Config
+	logger        *log.Logger
+}
+
+func NewNodeLintUsecase(
+	lintRepo *pg.NodeLintRepository,
+	nodeRepo *pg.NodeRepository,
+	kbRepo *pg.KnowledgeBaseRepository,
+	blockWordRepo *pg.BlockWordRepo,
+	config *config.Config,
+	logger *log.Logger,
+) *NodeLintUsecase {
+	return &NodeLintUsecase{
+		lintRepo:      lintRepo,
+		nodeRepo:      nodeRepo,
+		kbRepo:        kbRepo,
+		blockWordRepo: blockWordRepo,
+		config:        config,
+		logger:        logger.WithModule("usecase.node_lint"),
+	}
+}
+
+// nodeLintContent is the content of a node to lint
+type nodeLintContent struct {
+	NodeID      string
+	Content     string
+	ContentType string // detected from the content when empty
+}
+
+// LintNode lints and stores the issues of a node, failures are logged only so saving is never blocked
+func (u *NodeLintUsecase) LintNode(ctx context.Context, kbID, nodeID, content, contentType string) []*domain.NodeLintIssue {
+	issues, err := u.lintNodes(ctx, kbID, []*nodeLintContent{{NodeID: nodeID, Content: content, ContentType: contentType}})
+	if err != nil {
+		u.logger.Error("lint node failed", log.String("node_id", nodeID), log.Error(err))
+		return make([]*domain.NodeLintIssue, 0)
+	}
+	return issues[nodeID]
+}
+
+// LintNodeReleases lints the published content, and relints the nodes whose link issues point to the published nodes
+func (u *NodeLintUsecase) LintNodeReleases(ctx context.Context, kbID string, nodeReleases []*domain.NodeRelease) {
+	if len(nodeReleases) == 0 {
+		return
+	}
+	contents := lo.Map(nodeReleases, func(release *domain.NodeRelease, _ int) *nodeLintContent {
+		return &nodeLintContent{NodeID: release.NodeID, Content: release.Content, ContentType: release.Meta.ContentType}
+	})
+	publishedIDs := lo.Map(nodeReleases, func(release *domain.NodeRelease, _ int) string {
+		return release.NodeID
+	})
+	linkingIDs, err := u.lintRepo.GetNodeIDsByTargets(ctx, kbID, publishedIDs)
+	if err != nil {
+		u.logger.Error("get nodes linking to published nodes failed", log.String("kb_id", kbID), log.Error(err))
+	}
+	linkingIDs = lo.Without(linkingIDs, publishedIDs...)
+	for _, chunk := range lo.Chunk(linkingIDs, 100) {
+		nodes, err := u.nodeRepo.GetNodesByIDs(ctx, kbID, chunk)
+		if err != nil {
+			u.logger.Error("get nodes to relint failed", log.String("kb_id", kbID), log.Error(err))
+			break
+		}
+		for _, node := range nodes {
+			contents = append(contents, &nodeLintContent{NodeID: node.ID, Content: node.Content, ContentType: node.Meta.ContentType})
+		}
+	}
+	if _, err := u.lintNodes(ctx, kbID, contents); err != nil {
+		u.logger.Error("lint node releases failed", log.String("kb_id", kbID), log.Error(err))
+	}
+}
+
+// RefreshKB lints all documents of the kb
+func (u *NodeLintUsecase) RefreshKB(ctx context.Context, kbID string) (*v1.NodeLintRefreshResp, error) {
+	nodes, err := u.nodeRepo.GetNodesByKBID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	contents := make([]*nodeLintContent, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Type == domain.NodeTypeFolder {
+			continue
+		}
+		contents = append(contents, &nodeLintContent{NodeID: node.ID, Content: node.Content, ContentType: node.Meta.ContentType})
+	}
+	if _, err := u.lintNodes(ctx, kbID, contents); err != nil {
+		return nil, err
+	}
+	return &v1.NodeLintRefreshResp{NodeCount: len(contents)}, nil
+}
+
+func (u *NodeLintUsecase) GetReport(ctx context.Context, kbID string) (*v1.NodeLintReportResp, error) {
+	issues, err := u.lintRepo.GetIssuesByKBID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.NodeLintReportResp{
+		IssueCount: len(issues),
+		RuleCounts: make(map[domain.NodeLintRule]int),
+		Nodes:      make([]*v1.NodeLintReportItem, 0),
+	}
+	if len(issues) == 0 {
+		return resp, nil
+	}
+	nodeIssues := lo.GroupBy(issues, func(issue *domain.NodeLintIssue) string {
+		return issue.NodeID
+	})
+	names, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, lo.Keys(nodeIssues))
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		resp.RuleCounts[issue.Rule]++
+		if len(resp.Nodes) == 0 || resp.Nodes[len(resp.Nodes)-1].NodeID != issue.NodeID {
+			resp.Nodes = append(resp.Nodes, &v1.NodeLintReportItem{
+				NodeID:   issue.NodeID,
+				NodeName: names[issue.NodeID],
+				Issues:   nodeIssues[issue.NodeID],
+			})
+		}
+	}
+	// most issues first
+	slices.SortStableFunc(resp.Nodes, func(a, b *v1.NodeLintReportItem) int {
+		return len(b.Issues) - len(a.Issues)
+	})
+	resp.NodeCount = len(resp.Nodes)
+	return resp, nil
+}
+
+// lintNodes lints and stores the issues of the nodes, returns the issues by node id
+func (u *NodeLintUsecase) lintNodes(ctx context.Context, kbID string, contents []*nodeLintContent) (map[string][]*domain.NodeLintIssue, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	words, err := u.blockWordRepo.GetBlockWords(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	// uploaded images are served under the kb hosts, or referenced by the s3 endpoint inside the deployment
+	staticHosts := append(kbLinkHosts(kb), s3EndpointHost(u.config.S3.Endpoint))
+	linter := &nodeLinter{
+		u:           u,
+		kb:          kb,
+		hosts:       kbLinkHosts(kb),
+		staticHosts: staticHosts,
+		words: lo.Filter(words, func(word string, _ int) bool {
+			return strings.TrimSpace(word) != ""
+		}),
+	}
+	result := make(map[string][]*domain.NodeLintIssue, len(contents))
+	for _, content := range contents {
+		issues, err := linter.lint(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.lintRepo.ReplaceNodeIssues(ctx, kbID, content.NodeID, issues); err != nil {
+			return nil, err
+		}
+		result[content.NodeID] = issues
+	}
+	return result, nil
+}
+
+type nodeLinter struct {
+	u           *NodeLintUsecase
+	kb          *domain.KnowledgeBase
+	hosts       []string
+	staticHosts []string
+	words       []string
+}
+
+func (l *nodeLinter) lint(ctx context.Context, content *nodeLintContent) ([]*domain.NodeLintIssue, error) {
+	issues := make([]*domain.NodeLintIssue, 0)
+	add := func(rule domain.NodeLintRule, line int, text, message string) *domain.NodeLintIssue {
+		issue := &domain.NodeLintIssue{
+			KBID:    l.kb.ID,
+			NodeID:  content.NodeID,
+			Rule:    rule,
+			Line:    line,
+			Text:    text,
+			Message: message,
+		}
+		issues = append(issues, issue)
+		return issue
+	}
+
+	isHTML := content.ContentType == domain.ContentTypeHTML
+	if content.ContentType == "" {
+		isHTML = utils.IsLikelyHTML(content.Content)
+	}
+	elements := utils.ParseContentElements(content.Content, isHTML)
+
+	prevLevel := 0
+	for _, heading := range elements.Headings {
+		if heading.Text == "" {
+			add(domain.NodeLintRuleEmptyHeading, heading.Line, "", fmt.Sprintf("h%d heading is empty", heading.Level))
+			continue
+		}
+		if prevLevel > 0 && heading.Level > prevLevel+1 {
+			add(domain.NodeLintRuleSkippedHeadingLevel, heading.Line, heading.Text,
+				fmt.Sprintf("h%d follows h%d, heading levels should not be skipped", heading.Level, prevLevel))
+		}
+		prevLevel = heading.Level
+	}
+
+	for _, image := range elements.Images {
+		if image.Alt == "" {
+			add(domain.NodeLintRuleImageMissingAlt, image.Line, image.Src, "image has no alt text")
+		}
+		if l.isExternalImage(image.Src) {
+			add(domain.NodeLintRuleExternalImage, image.Line, image.Src, "image is not uploaded to the knowledge base")
+		}
+	}
+
+	targets := make(map[string]string) // node id -> link
+	for _, link := range utils.ExtractLinks(content.Content) {
+		if targetID, ok := utils.ParseNodeLink(link, l.hosts); ok {
+			if _, ok := targets[targetID]; !ok {
+				targets[targetID] = link
+			}
+		}
+	}
+	if len(targets) > 0 {
+		states, err := l.u.nodeRepo.GetNodePublishedStates(ctx, l.kb.ID, lo.Keys(targets))
+		if err != nil {
+			return nil, err
+		}
+		for targetID, link := range targets {
+			line := utils.LineAt(content.Content, max(strings.Index(content.Content, link), 0))
+			published, ok := states[targetID]
+			switch {
+			case !ok:
+				add(domain.NodeLintRuleMissingNodeLink, line, link, "linked document does not exist").TargetNodeID = targetID
+			case !published:
+				add(domain.NodeLintRuleUnpublishedNodeLink, line, link, "linked document is not published").TargetNodeID = targetID
+			}
+		}
+	}
+
+	for _, table := range elements.Tables {
+		if table.Rows > nodeLintMaxTableRows || table.Cols > nodeLintMaxTableCols {
+			add(domain.NodeLintRuleOversizedTable, table.Line, fmt.Sprintf("%d x %d", table.Rows, table.Cols),
+				fmt.Sprintf("table exceeds %d rows or %d columns", nodeLintMaxTableRows, nodeLintMaxTableCols))
+		}
+	}
+
+	lowerContent := strings.ToLower(content.Content)
+	for _, word := range l.words {
+		if index := strings.Index(lowerContent, strings.ToLower(word)); index >= 0 {
+			add(domain.NodeLintRuleBlockWord, utils.LineAt(lowerContent, index), word, "content contains a blocked word")
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b *domain.NodeLintIssue) int {
+		return a.Line - b.Line
+	})
+	return issues, nil
+}
+
+// s3EndpointHost returns the host of the configured s3 endpoint, which may be given with or without scheme
+func s3EndpointHost(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpoint
+}
+
+// isExternalImage reports whether src is an absolute url outside of the static file bucket
+func (l *nodeLinter) isExternalImage(src string) bool {
+	if strings.HasPrefix(src, "//") {
+		src = "https:" + src
+	}
+	if !utils.IsExternalLink(src) {
+		return false
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	if !strings.HasPrefix(u.Path, "/"+domain.Bucket+"/") {
+		return true
+	}
+	for _, host := range l.staticHosts {
+		if strings.EqualFold(u.Hostname(), host) || strings.EqualFold(u.Host, host) {
+			return false
+		}
+	}
+	return true
+}
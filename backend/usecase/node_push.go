@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+const nodePushFolderPrefix = "folder:"
+
+var errInvalidNodePushPath = errors.New("invalid path")
+
+// NodePushUsecase creates and updates nodes from documents pushed by build pipelines
+type NodePushUsecase struct {
+	pushRepo    *pg.NodePushRepository
+	nodeRepo    *pg.NodeRepository
+	nodeUsecase *NodeUsecase
+	kbUsecase   *KnowledgeBaseUsecase
+	logger      *log.Logger
+
+	// pushes of the same source are serialized, so concurrent jobs do not create the same folders twice
+	locks sync.Map
+}
+
+func NewNodePushUsecase(
+	pushRepo *pg.NodePushRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeUsecase *NodeUsecase,
+	kbUsecase *KnowledgeBaseUsecase,
+	logger *log.Logger,
+) *NodePushUsecase {
+	return &NodePushUsecase{
+		pushRepo:    pushRepo,
+		nodeRepo:    nodeRepo,
+		nodeUsecase: nodeUsecase,
+		kbUsecase:   kbUsecase,
+		logger:      logger.WithModule("usecase.node_push"),
+	}
+}
+
+// Push writes the documents of the batch, failed documents are reported in their result and do not stop the batch
+func (u *NodePushUsecase) Push(ctx context.Context, req *v1.NodePushReq, userID string, maxNode int) (*v1.NodePushResp, error) {
+	lock, _ := u.locks.LoadOrStore(req.KbId+"/"+req.Source, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if req.ParentID != "" {
+		parent, err := u.nodeRepo.GetNodeByID(ctx, req.ParentID)
+		if err != nil || parent.KBID != req.KbId || parent.Type != domain.NodeTypeFolder {
+			return nil, fmt.Errorf("%w: parent folder not found", domain.ErrInvalidNodePush)
+		}
+	}
+
+	pushes, err := u.pushRepo.GetListBySource(ctx, req.KbId, req.Source)
+	if err != nil {
+		return nil, err
+	}
+	nodeIDs := make([]string, 0, len(pushes))
+	for _, push := range pushes {
+		nodeIDs = append(nodeIDs, push.NodeID)
+	}
+	// nodes deleted in the kb are created again
+	existing, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	p := &nodePusher{
+		u:       u,
+		req:     req,
+		userID:  userID,
+		maxNode: maxNode,
+		mapped:  make(map[string]*domain.KBNodePush, len(pushes)),
+		publish: make([]string, 0),
+	}
+	for _, push := range pushes {
+		if _, ok := existing[push.NodeID]; ok {
+			p.mapped[push.ExternalID] = push
+		}
+	}
+
+	resp := &v1.NodePushResp{Results: make([]*v1.NodePushResult, 0, len(req.Docs))}
+	seen := make(map[string]bool, len(req.Docs))
+	for _, doc := range req.Docs {
+		result := &v1.NodePushResult{ExternalID: doc.ExternalID}
+		switch {
+		case seen[doc.ExternalID]:
+			result.Status = domain.NodePushStatusFailed
+			result.Error = "duplicated external id in the batch"
+		case strings.HasPrefix(doc.ExternalID, nodePushFolderPrefix):
+			result.Status = domain.NodePushStatusFailed
+			result.Error = fmt.Sprintf("external id must not start with %q", nodePushFolderPrefix)
+		default:
+			if err := p.writeDocument(ctx, doc, result); err != nil {
+				result.Status = domain.NodePushStatusFailed
+				result.Error = err.Error()
+			}
+		}
+		seen[doc.ExternalID] = true
+		resp.Results = append(resp.Results, result)
+	}
+
+	if req.Publish && len(p.publish) > 0 {
+		message := req.ReleaseMessage
+		if message == "" {
+			message = fmt.Sprintf("push from %s", req.Source)
+		}
+		tag := req.ReleaseTag
+		if tag == "" {
+			tag = fmt.Sprintf("push-%s", time.Now().Format("20060102150405"))
+		}
+		releaseID, err := u.kbUsecase.CreateKBRelease(ctx, &domain.CreateKBReleaseReq{
+			KBID:    req.KbId,
+			Message: message,
+			Tag:     tag,
+			NodeIDs: p.publish,
+		}, userID)
+		if err != nil {
+			return nil, fmt.Errorf("publish pushed docs failed: %w", err)
+		}
+		resp.ReleaseID = releaseID
+	}
+	return resp, nil
+}
+
+type nodePusher struct {
+	u       *NodePushUsecase
+	req     *v1.NodePushReq
+	userID  string
+	maxNode int
+	mapped  map[string]*domain.KBNodePush // external id -> node
+	publish []string                      // created and changed nodes, and docs which are still drafts
+}
+
+func (p *nodePusher) writeDocument(ctx context.Context, doc *v1.NodePushDoc, result *v1.NodePushResult) error {
+	docPath, ok := cleanNodePushPath(doc.Path)
+	if !ok {
+		return errInvalidNodePushPath
+	}
+	parentID, err := p.ensureFolder(ctx, path.Dir(docPath))
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(doc.Title)
+	if name == "" {
+		base := path.Base(docPath)
+		name = strings.TrimSuffix(base, path.Ext(base))
+	}
+	contentType := doc.ContentType
+	if contentType == "" {
+		contentType = domain.ContentTypeMD
+	}
+
+	push, ok := p.mapped[doc.ExternalID]
+	if !ok {
+		nodeID, err := p.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+			KBID:        p.req.KbId,
+			ParentID:    parentID,
+			Type:        domain.NodeTypeDocument,
+			Name:        name,
+			Content:     doc.Content,
+			ContentType: &contentType,
+			MaxNode:     p.maxNode,
+		}, p.userID)
+		if err != nil {
+			return err
+		}
+		push = &domain.KBNodePush{KBID: p.req.KbId, Source: p.req.Source, ExternalID: doc.ExternalID, NodeID: nodeID, Type: domain.NodeTypeDocument, Path: docPath}
+		if err := p.u.pushRepo.Save(ctx, push); err != nil {
+			return err
+		}
+		p.mapped[doc.ExternalID] = push
+		p.publish = append(p.publish, nodeID)
+		result.NodeID, result.Status = nodeID, domain.NodePushStatusCreated
+		return nil
+	}
+
+	result.NodeID = push.NodeID
+	current, err := p.u.nodeRepo.GetNodeByID(ctx, push.NodeID)
+	if err != nil {
+		return err
+	}
+	moved := current.ParentID != parentID
+	if moved {
+		if err := p.u.nodeUsecase.MoveNode(ctx, &domain.MoveNodeReq{ID: push.NodeID, KbID: p.req.KbId, ParentID: parentID}); err != nil {
+			return fmt.Errorf("move to %s failed: %w", path.Dir(docPath), err)
+		}
+	}
+	changed := current.Name != name || current.Content != doc.Content
+	if changed {
+		if _, err := p.u.nodeUsecase.Update(ctx, &domain.UpdateNodeReq{
+			ID:          push.NodeID,
+			KBID:        p.req.KbId,
+			Name:        &name,
+			Content:     &doc.Content,
+			ContentType: &contentType,
+		}, p.userID); err != nil {
+			return err
+		}
+	}
+	if push.Path != docPath {
+		push.Path = docPath
+		if err := p.u.pushRepo.Save(ctx, push); err != nil {
+			return err
+		}
+	}
+	if moved || changed || current.Status != domain.NodeStatusReleased {
+		p.publish = append(p.publish, push.NodeID)
+	}
+	result.Status = domain.NodePushStatusUnchanged
+	if moved || changed {
+		result.Status = domain.NodePushStatusUpdated
+	}
+	return nil
+}
+
+// ensureFolder returns the folder node of the directory, missing folders are created
+func (p *nodePusher) ensureFolder(ctx context.Context, dir string) (string, error) {
+	if dir == "." || dir == "" {
+		return p.req.ParentID, nil
+	}
+	key := nodePushFolderPrefix + dir
+	if push, ok := p.mapped[key]; ok {
+		return push.NodeID, nil
+	}
+	parentID, err := p.ensureFolder(ctx, path.Dir(dir))
+	if err != nil {
+		return "", err
+	}
+	nodeID, err := p.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+		KBID:     p.req.KbId,
+		ParentID: parentID,
+		Type:     domain.NodeTypeFolder,
+		Name:     path.Base(dir),
+		MaxNode:  p.maxNode,
+	}, p.userID)
+	if err != nil {
+		return "", fmt.Errorf("create folder %s failed: %w", dir, err)
+	}
+	push := &domain.KBNodePush{KBID: p.req.KbId, Source: p.req.Source, ExternalID: key, NodeID: nodeID, Type: domain.NodeTypeFolder, Path: dir}
+	if err := p.u.pushRepo.Save(ctx, push); err != nil {
+		return "", err
+	}
+	p.mapped[key] = push
+	p.publish = append(p.publish, nodeID)
+	return nodeID, nil
+}
+
+// cleanNodePushPath normalizes the slash separated path, it is rooted first so ".." can not leave the parent folder
+func cleanNodePushPath(p string) (string, bool) {
+	p = path.Clean("/" + strings.ReplaceAll(strings.TrimSpace(p), "\\", "/"))
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return "", false
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if strings.TrimSpace(segment) == "" {
+			return "", false
+		}
+	}
+	return p, true
+}
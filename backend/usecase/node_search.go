@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/samber/lo"
+
+	shareV1 "github.com/chaitin/panda-wiki/api/share/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	searchSnippetCount  = 3
+	searchSnippetRadius = 60 // runes of context around a match
+)
+
+// SearchNodeReleases searches the published documents the user can visit
+func (u *NodeUsecase) SearchNodeReleases(ctx context.Context, kbID string, req *shareV1.ShareNodeSearchReq, authId uint) (*shareV1.ShareNodeSearchResp, error) {
+	terms := utils.ParseSearchTerms(req.Query)
+	partialIDs, err := u.GetNodeIdsByAuthId(ctx, authId, consts.NodePermNameVisitable)
+	if err != nil {
+		return nil, err
+	}
+	// the release tree is used for folder paths and the subtree filter
+	releaseNodes, err := u.nodeRepo.GetNodeReleaseListByKBID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	nodeMap := lo.SliceToMap(releaseNodes, func(node *domain.ShareNodeListItemResp) (string, *domain.ShareNodeListItemResp) {
+		return node.ID, node
+	})
+
+	params := &pg.NodeReleaseSearchParams{
+		KBID:       kbID,
+		Terms:      terms,
+		Tags:       req.Tags,
+		PartialIDs: partialIDs,
+		Offset:     req.Offset(),
+		Limit:      req.Limit(),
+	}
+	if req.ParentID != "" {
+		params.NodeIDs = make([]string, 0)
+		for _, node := range releaseNodes {
+			for parent, ok := nodeMap[node.ParentID]; ok; parent, ok = nodeMap[parent.ParentID] {
+				if parent.ID == req.ParentID {
+					params.NodeIDs = append(params.NodeIDs, node.ID)
+					break
+				}
+			}
+		}
+	}
+	total, hits, err := u.nodeRepo.SearchNodeReleases(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*shareV1.ShareNodeSearchItem, 0, len(hits))
+	for _, hit := range hits {
+		path := make([]string, 0)
+		for parent, ok := nodeMap[hit.ParentID]; ok; parent, ok = nodeMap[parent.ParentID] {
+			path = append(path, parent.Name)
+		}
+		slices.Reverse(path)
+		tags := hit.Meta.Tags
+		if tags == nil {
+			tags = make(domain.NodeTags, 0)
+		}
+		items = append(items, &shareV1.ShareNodeSearchItem{
+			ID:        hit.ID,
+			Name:      utils.HighlightText(hit.Name, terms),
+			Emoji:     hit.Meta.Emoji,
+			Path:      path,
+			Tags:      tags,
+			Snippets:  utils.HighlightSnippets(utils.ContentToText(hit.Content), terms, searchSnippetCount, searchSnippetRadius),
+			UpdatedAt: hit.UpdatedAt,
+		})
+	}
+	return domain.NewPaginatedResult(items, uint64(total)), nil
+}
+
+// setNodeSearchSnippets fills the snippets of the matched drafts, content is only loaded for them
+func setNodeSearchSnippets(nodes []*domain.NodeListItemResp, search string) {
+	terms := utils.ParseSearchTerms(search)
+	if len(terms) == 0 {
+		return
+	}
+	for _, node := range nodes {
+		if node.Type == domain.NodeTypeDocument {
+			node.Snippets = utils.HighlightSnippets(utils.ContentToText(node.Content), terms, searchSnippetCount, searchSnippetRadius)
+		}
+		node.Content = ""
+	}
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	shareV1 "github.com/chaitin/panda-wiki/api/share/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const seoDescriptionMaxRunes = 160
+
+// NodeSEOUsecase generates the seo metadata and structured data of the public pages, admins can override it per node
+type NodeSEOUsecase struct {
+	seoRepo  *pg.NodeSEORepository
+	nodeRepo *pg.NodeRepository
+	kbRepo   *pg.KnowledgeBaseRepository
+	appRepo  *pg.AppRepository
+	slugRepo *pg.KBRedirectRepository
+	logger   *log.Logger
+}
+
+func NewNodeSEOUsecase(
+	seoRepo *pg.NodeSEORepository,
+	nodeRepo *pg.NodeRepository,
+	kbRepo *pg.KnowledgeBaseRepository,
+	appRepo *pg.AppRepository,
+	slugRepo *pg.KBRedirectRepository,
+	logger *log.Logger,
+) *NodeSEOUsecase {
+	return &NodeSEOUsecase{
+		seoRepo:  seoRepo,
+		nodeRepo: nodeRepo,
+		kbRepo:   kbRepo,
+		appRepo:  appRepo,
+		slugRepo: slugRepo,
+		logger:   logger.WithModule("usecase.node_seo"),
+	}
+}
+
+// GetOverrides returns the seo overrides of a node, empty fields are generated
+func (u *NodeSEOUsecase) GetOverrides(ctx context.Context, kbID, nodeID string) (*domain.NodeSEO, error) {
+	node, err := u.nodeRepo.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node.KBID != kbID {
+		return nil, domain.ErrPermissionDenied
+	}
+	seo, err := u.seoRepo.GetByNodeID(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if seo == nil {
+		seo = &domain.NodeSEO{NodeID: nodeID, KBID: kbID}
+	}
+	return seo, nil
+}
+
+func (u *NodeSEOUsecase) UpdateOverrides(ctx context.Context, req *v1.NodeSEOUpdateReq) error {
+	node, err := u.nodeRepo.GetNodeByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if node.KBID != req.KbId {
+		return domain.ErrPermissionDenied
+	}
+	return u.seoRepo.Upsert(ctx, &domain.NodeSEO{
+		NodeID:       req.ID,
+		KBID:         req.KbId,
+		Title:        strings.TrimSpace(req.Title),
+		Description:  strings.TrimSpace(req.Description),
+		Keywords:     strings.TrimSpace(req.Keywords),
+		Image:        strings.TrimSpace(req.Image),
+		CanonicalURL: strings.TrimSpace(req.CanonicalURL),
+		NoIndex:      req.NoIndex,
+		UpdatedAt:    time.Now(),
+	})
+}
+
+// seoSite is the kb level data shared by all pages
+type seoSite struct {
+	kb       *domain.KnowledgeBase
+	settings *domain.AppSettings
+	baseURL  string
+	name     string
+	public   bool // visitors do not have to log in
+}
+
+func (u *NodeSEOUsecase) getSite(ctx context.Context, kbID string) (*seoSite, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, kbID, domain.AppTypeWeb)
+	if err != nil {
+		return nil, err
+	}
+	site := &seoSite{
+		kb:       kb,
+		settings: &app.Settings,
+		baseURL:  kb.AccessSettings.BaseURL,
+		name:     kb.Name,
+		public:   kb.AccessSettings.GetAuthType() == consts.AuthTypeNull,
+	}
+	if app.Settings.Title != "" {
+		site.name = app.Settings.Title
+	}
+	return site, nil
+}
+
+// GetShareSEO returns the seo data of a published node, or of the landing page when nodeID is empty
+func (u *NodeSEOUsecase) GetShareSEO(ctx context.Context, kbID, nodeID string) (*shareV1.ShareNodeSEOResp, error) {
+	site, err := u.getSite(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if nodeID == "" {
+		return u.getLandingSEO(ctx, site)
+	}
+
+	node, err := u.nodeRepo.GetNodeReleaseDetailByKBIDAndID(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	override, err := u.seoRepo.GetByNodeID(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		override = &domain.NodeSEO{}
+	}
+
+	slug, err := u.slugRepo.GetSlug(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	url := site.baseURL + domain.NodePath(node.ID, slug)
+	title := lo.CoalesceOrEmpty(override.Title, node.Name)
+	description := lo.CoalesceOrEmpty(override.Description, node.Meta.Summary)
+	if description == "" {
+		description = utils.TruncateText(utils.ContentToText(node.Content), seoDescriptionMaxRunes)
+	}
+	keywords := lo.CoalesceOrEmpty(override.Keywords, strings.Join(node.Meta.Tags, ", "), site.settings.Keyword)
+	image := sitemapImageURL(site.baseURL, override.Image)
+	if image == "" {
+		image = firstContentImage(site.baseURL, node.Content)
+	}
+	if image == "" {
+		image = sitemapImageURL(site.baseURL, site.settings.Icon)
+	}
+	index := site.public && node.Permissions.Visitable == consts.NodeAccessPermOpen && !override.NoIndex
+
+	resp := newShareSEO(site, title, description, keywords, lo.CoalesceOrEmpty(override.CanonicalURL, url), image, index)
+	resp.OpenGraph.Type = "article"
+	resp.OpenGraph.PublishedTime = node.CreatedAt.Format(time.RFC3339)
+	resp.OpenGraph.ModifiedTime = node.UpdatedAt.Format(time.RFC3339)
+
+	if node.Type == domain.NodeTypeDocument {
+		article := map[string]any{
+			"@context":         "https://schema.org",
+			"@type":            "TechArticle",
+			"headline":         title,
+			"description":      description,
+			"url":              resp.CanonicalURL,
+			"mainEntityOfPage": resp.CanonicalURL,
+			"datePublished":    resp.OpenGraph.PublishedTime,
+			"dateModified":     resp.OpenGraph.ModifiedTime,
+			"publisher": map[string]any{
+				"@type": "Organization",
+				"name":  site.name,
+			},
+		}
+		if image != "" {
+			article["image"] = image
+		}
+		if keywords != "" {
+			article["keywords"] = keywords
+		}
+		resp.JSONLD = append(resp.JSONLD, article)
+	}
+
+	breadcrumb, err := u.getBreadcrumb(ctx, site, url, node.Name, node.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	resp.JSONLD = append(resp.JSONLD, breadcrumb)
+	return resp, nil
+}
+
+// getBreadcrumb builds the BreadcrumbList of the node from the folders of the release tree
+func (u *NodeSEOUsecase) getBreadcrumb(ctx context.Context, site *seoSite, url, name, parentID string) (map[string]any, error) {
+	releaseNodes, err := u.nodeRepo.GetNodeReleaseListByKBID(ctx, site.kb.ID)
+	if err != nil {
+		return nil, err
+	}
+	nodeMap := lo.SliceToMap(releaseNodes, func(node *domain.ShareNodeListItemResp) (string, *domain.ShareNodeListItemResp) {
+		return node.ID, node
+	})
+	type crumb struct{ name, url string }
+	crumbs := []crumb{{name: name, url: url}}
+	for parent, ok := nodeMap[parentID]; ok; parent, ok = nodeMap[parent.ParentID] {
+		crumbs = append(crumbs, crumb{name: parent.Name, url: parent.GetURL(site.baseURL)})
+	}
+	crumbs = append(crumbs, crumb{name: site.name, url: site.baseURL + "/welcome"})
+	slices.Reverse(crumbs)
+
+	items := make([]map[string]any, 0, len(crumbs))
+	for i, c := range crumbs {
+		items = append(items, map[string]any{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"name":     c.name,
+			"item":     c.url,
+		})
+	}
+	return map[string]any{
+		"@context":        "https://schema.org",
+		"@type":           "BreadcrumbList",
+		"itemListElement": items,
+	}, nil
+}
+
+// getLandingSEO returns the seo data of the landing page, FAQ sections linking to published documents become a FAQPage
+func (u *NodeSEOUsecase) getLandingSEO(ctx context.Context, site *seoSite) (*shareV1.ShareNodeSEOResp, error) {
+	url := site.baseURL + "/welcome"
+	resp := newShareSEO(site, site.name, site.settings.Desc, site.settings.Keyword, url, sitemapImageURL(site.baseURL, site.settings.Icon), site.public)
+	resp.OpenGraph.Type = "website"
+	website := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "WebSite",
+		"name":     site.name,
+		"url":      url,
+	}
+	if site.settings.Desc != "" {
+		website["description"] = site.settings.Desc
+	}
+	resp.JSONLD = append(resp.JSONLD, website)
+
+	hosts := kbLinkHosts(site.kb)
+	questions := make([]map[string]any, 0)
+	for _, config := range site.settings.WebAppLandingConfigs {
+		if config.FaqConfig == nil {
+			continue
+		}
+		for _, item := range config.FaqConfig.List {
+			nodeID, ok := utils.ParseNodeLink(item.Link, hosts)
+			if !ok || strings.TrimSpace(item.Question) == "" {
+				continue
+			}
+			node, err := u.nodeRepo.GetNodeReleaseDetailByKBIDAndID(ctx, site.kb.ID, nodeID)
+			if err != nil {
+				// not published, the question has no answer
+				u.logger.Debug("skip faq without published answer", log.String("node_id", nodeID), log.Error(err))
+				continue
+			}
+			if node.Permissions.Visitable != consts.NodeAccessPermOpen {
+				continue
+			}
+			answer := node.Meta.Summary
+			if answer == "" {
+				answer = utils.TruncateText(utils.ContentToText(node.Content), seoDescriptionMaxRunes*3)
+			}
+			questions = append(questions, map[string]any{
+				"@type": "Question",
+				"name":  strings.TrimSpace(item.Question),
+				"acceptedAnswer": map[string]any{
+					"@type": "Answer",
+					"text":  answer,
+					"url":   fmt.Sprintf("%s/node/%s", site.baseURL, node.ID),
+				},
+			})
+		}
+	}
+	if len(questions) > 0 {
+		resp.JSONLD = append(resp.JSONLD, map[string]any{
+			"@context":   "https://schema.org",
+			"@type":      "FAQPage",
+			"mainEntity": questions,
+		})
+	}
+	return resp, nil
+}
+
+func newShareSEO(site *seoSite, title, description, keywords, canonicalURL, image string, index bool) *shareV1.ShareNodeSEOResp {
+	robots := "index, follow"
+	if !index {
+		robots = "noindex, nofollow"
+	}
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+	return &shareV1.ShareNodeSEOResp{
+		Title:        title,
+		Description:  description,
+		Keywords:     keywords,
+		CanonicalURL: canonicalURL,
+		Robots:       robots,
+		OpenGraph: shareV1.ShareOpenGraph{
+			Title:       title,
+			Description: description,
+			URL:         canonicalURL,
+			Image:       image,
+			SiteName:    site.name,
+		},
+		Twitter: shareV1.ShareTwitterCard{
+			Card:        card,
+			Title:       title,
+			Description: description,
+			Image:       image,
+		},
+		JSONLD: make([]map[string]any, 0),
+	}
+}
+
+// firstContentImage returns the absolute url of the first image in the content
+func firstContentImage(baseURL, content string) string {
+	for _, image := range utils.ParseContentElements(content, utils.IsLikelyHTML(content)).Images {
+		if src := sitemapImageURL(baseURL, image.Src); src != "" {
+			return src
+		}
+	}
+	return ""
+}
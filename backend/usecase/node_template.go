@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+var nodeTemplateVarRegex = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+func (u *NodeUsecase) GetNodeTemplateList(ctx context.Context, req *v1.NodeTemplateListReq) ([]*domain.NodeTemplate, error) {
+	return u.nodeTemplateRepo.GetListByKBID(ctx, req.KbId)
+}
+
+func (u *NodeUsecase) GetNodeTemplate(ctx context.Context, req *v1.NodeTemplateDetailReq) (*domain.NodeTemplate, error) {
+	return u.nodeTemplateRepo.GetByID(ctx, req.KbId, req.ID)
+}
+
+func (u *NodeUsecase) CreateNodeTemplate(ctx context.Context, req *v1.NodeTemplateCreateReq, userId string) (string, error) {
+	now := time.Now()
+	template := &domain.NodeTemplate{
+		ID:          uuid.New().String(),
+		KBID:        req.KbId,
+		Name:        req.Name,
+		Emoji:       req.Emoji,
+		ContentType: req.ContentType,
+		Content:     req.Content,
+		Summary:     req.Summary,
+		CreatorId:   userId,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.nodeTemplateRepo.Create(ctx, template); err != nil {
+		return "", err
+	}
+	return template.ID, nil
+}
+
+func (u *NodeUsecase) UpdateNodeTemplate(ctx context.Context, req *v1.NodeTemplateUpdateReq) error {
+	updateMap := map[string]any{
+		"updated_at": time.Now(),
+	}
+	if req.Name != nil {
+		updateMap["name"] = *req.Name
+	}
+	if req.Emoji != nil {
+		updateMap["emoji"] = *req.Emoji
+	}
+	if req.ContentType != nil {
+		updateMap["content_type"] = *req.ContentType
+	}
+	if req.Content != nil {
+		updateMap["content"] = *req.Content
+	}
+	if req.Summary != nil {
+		updateMap["summary"] = *req.Summary
+	}
+	return u.nodeTemplateRepo.Update(ctx, req.KbId, req.ID, updateMap)
+}
+
+func (u *NodeUsecase) DeleteNodeTemplate(ctx context.Context, req *v1.NodeTemplateDeleteReq) error {
+	return u.nodeTemplateRepo.Delete(ctx, req.KbId, req.ID)
+}
+
+// CreateNodeTemplateFromNode saves an existing document as a template
+func (u *NodeUsecase) CreateNodeTemplateFromNode(ctx context.Context, req *v1.NodeTemplateFromNodeReq, userId string) (string, error) {
+	node, err := u.nodeRepo.GetByID(ctx, req.NodeId, req.KbId)
+	if err != nil {
+		return "", err
+	}
+	if node.Type != domain.NodeTypeDocument {
+		return "", fmt.Errorf("only document can be saved as template")
+	}
+	name := req.Name
+	if name == "" {
+		name = node.Name
+	}
+	return u.CreateNodeTemplate(ctx, &v1.NodeTemplateCreateReq{
+		KbId:        req.KbId,
+		Name:        name,
+		Emoji:       node.Meta.Emoji,
+		ContentType: node.Meta.ContentType,
+		Content:     node.Content,
+		Summary:     node.Meta.Summary,
+	}, userId)
+}
+
+// applyNodeTemplate fills the create request from the template, fields given by the user take precedence
+func (u *NodeUsecase) applyNodeTemplate(ctx context.Context, req *domain.CreateNodeReq, userId string) error {
+	template, err := u.nodeTemplateRepo.GetByID(ctx, req.KBID, req.TemplateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("node template %s not found", req.TemplateID)
+		}
+		return err
+	}
+
+	vars, err := u.getNodeTemplateVars(ctx, req, userId)
+	if err != nil {
+		return err
+	}
+
+	if req.Content == "" {
+		contentType := template.ContentType
+		if req.ContentType != nil {
+			contentType = *req.ContentType
+		}
+		contentVars := vars
+		if contentType == domain.ContentTypeHTML || (contentType == "" && utils.IsLikelyHTML(template.Content)) {
+			contentVars = make(map[string]string, len(vars))
+			for name, value := range vars {
+				contentVars[name] = html.EscapeString(value)
+			}
+		}
+		req.Content = renderNodeTemplate(template.Content, contentVars)
+	}
+	if req.Emoji == "" {
+		req.Emoji = template.Emoji
+	}
+	if req.Summary == nil && template.Summary != "" {
+		summary := renderNodeTemplate(template.Summary, vars)
+		req.Summary = &summary
+	}
+	if req.ContentType == nil && template.ContentType != "" {
+		contentType := template.ContentType
+		req.ContentType = &contentType
+	}
+	return nil
+}
+
+func (u *NodeUsecase) getNodeTemplateVars(ctx context.Context, req *domain.CreateNodeReq, userId string) (map[string]string, error) {
+	now := time.Now()
+	vars := map[string]string{
+		domain.NodeTemplateVarDate:     now.Format("2006-01-02"),
+		domain.NodeTemplateVarTime:     now.Format("15:04"),
+		domain.NodeTemplateVarDatetime: now.Format("2006-01-02 15:04"),
+		domain.NodeTemplateVarTitle:    req.Name,
+	}
+	if userId != "" {
+		user, err := u.userRepo.GetUser(ctx, userId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if user != nil {
+			vars[domain.NodeTemplateVarAuthor] = user.Account
+		}
+	}
+	if req.ParentID != "" {
+		parent, err := u.nodeRepo.GetByID(ctx, req.ParentID, req.KBID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if parent != nil {
+			vars[domain.NodeTemplateVarParentName] = parent.Name
+		}
+	}
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KBID)
+	if err != nil {
+		return nil, err
+	}
+	vars[domain.NodeTemplateVarKBName] = kb.Name
+	return vars, nil
+}
+
+// renderNodeTemplate replaces known {{var}} placeholders, unknown ones are kept as is
+func renderNodeTemplate(content string, vars map[string]string) string {
+	return nodeTemplateVarRegex.ReplaceAllStringFunc(content, func(match string) string {
+		name := nodeTemplateVarRegex.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
@@ -0,0 +1,506 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	modelkit "github.com/chaitin/ModelKit/v2/usecase"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	shareV1 "github.com/chaitin/panda-wiki/api/share/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	translateChunkMaxRunes   = 4000 // lines are sent together up to this size
+	translateChunkTokenLimit = 2000 // longer lines are split by tokens
+	translateTimeout         = 30 * time.Minute
+	// a translation still translating after this was interrupted, e.g. by a restart of the server running it
+	translateStaleAfter = translateTimeout + 5*time.Minute
+)
+
+// NodeTranslationUsecase manages the language variants of nodes, a translation is a node of its own
+// with its own release, linked to the source node
+type NodeTranslationUsecase struct {
+	translationRepo *pg.NodeTranslationRepository
+	nodeRepo        *pg.NodeRepository
+	nodeUsecase     *NodeUsecase
+	llm             *LLMUsecase
+	model           *ModelUsecase
+	logger          *log.Logger
+	modelkit        *modelkit.ModelKit
+}
+
+func NewNodeTranslationUsecase(
+	translationRepo *pg.NodeTranslationRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeUsecase *NodeUsecase,
+	llm *LLMUsecase,
+	model *ModelUsecase,
+	logger *log.Logger,
+) *NodeTranslationUsecase {
+	return &NodeTranslationUsecase{
+		translationRepo: translationRepo,
+		nodeRepo:        nodeRepo,
+		nodeUsecase:     nodeUsecase,
+		llm:             llm,
+		model:           model,
+		logger:          logger.WithModule("usecase.node_translation"),
+		modelkit:        modelkit.NewModelKit(logger.Logger),
+	}
+}
+
+// FailStale marks the translations which are still translating after the timeout as failed,
+// they run in the process of an api server and are lost when it restarts
+func (u *NodeTranslationUsecase) FailStale(ctx context.Context) error {
+	return u.translationRepo.FailStale(ctx, time.Now().Add(-translateStaleAfter), "translation interrupted or timed out")
+}
+
+func (u *NodeTranslationUsecase) GetList(ctx context.Context, kbID, sourceNodeID string) ([]*v1.NodeTranslationItem, error) {
+	return u.translationRepo.GetListBySourceNodeID(ctx, kbID, sourceNodeID)
+}
+
+// getSource returns the node to translate, translations can not be translated again
+func (u *NodeTranslationUsecase) getSource(ctx context.Context, kbID, id string) (*domain.Node, error) {
+	node, err := u.nodeRepo.GetNodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if node.KBID != kbID {
+		return nil, domain.ErrPermissionDenied
+	}
+	translation, err := u.translationRepo.GetByNodeID(ctx, kbID, id)
+	if err != nil {
+		return nil, err
+	}
+	if translation != nil {
+		return nil, fmt.Errorf("%w: node is a translation of another node", domain.ErrInvalidNodeTranslation)
+	}
+	return node, nil
+}
+
+// Translate translates the source node into the locale with the chat model in the background,
+// the translation node is created on first use and overwritten afterwards
+func (u *NodeTranslationUsecase) Translate(ctx context.Context, req *v1.NodeTranslateReq, userID string, maxNode int) (string, error) {
+	locale := utils.NormalizeLocale(req.Locale)
+	if locale == "" {
+		return "", fmt.Errorf("%w: invalid locale %q", domain.ErrInvalidNodeTranslation, req.Locale)
+	}
+	source, err := u.getSource(ctx, req.KbId, req.ID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := u.model.GetChatModel(ctx); err != nil {
+		u.logger.Error("get chat model failed", log.Error(err))
+		return "", domain.ErrModelNotConfigured
+	}
+
+	translation, err := u.translationRepo.GetBySourceAndLocale(ctx, req.KbId, source.ID, locale)
+	if err != nil {
+		return "", err
+	}
+	if translation != nil {
+		// a stale translation is taken over
+		started, err := u.translationRepo.StartTranslate(ctx, translation.NodeID, time.Now().Add(-translateStaleAfter))
+		if err != nil {
+			return "", err
+		}
+		if !started {
+			return "", domain.ErrNodeTranslationRunning
+		}
+	} else {
+		createReq := &domain.CreateNodeReq{
+			KBID:     req.KbId,
+			ParentID: source.ParentID,
+			Type:     source.Type,
+			Name:     source.Name,
+			Emoji:    source.Meta.Emoji,
+			MaxNode:  maxNode,
+		}
+		if source.Meta.ContentType != "" {
+			createReq.ContentType = &source.Meta.ContentType
+		}
+		nodeID, err := u.nodeUsecase.Create(ctx, createReq, userID)
+		if err != nil {
+			return "", err
+		}
+		translation = &domain.NodeTranslation{
+			NodeID:       nodeID,
+			KBID:         req.KbId,
+			SourceNodeID: source.ID,
+			Locale:       locale,
+			Status:       domain.NodeTranslationStatusTranslating,
+		}
+		if err := u.translationRepo.Create(ctx, translation); err != nil {
+			return "", err
+		}
+	}
+	go u.runTranslate(translation, userID)
+	return translation.NodeID, nil
+}
+
+func (u *NodeTranslationUsecase) runTranslate(translation *domain.NodeTranslation, userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), translateTimeout)
+	defer cancel()
+	logger := u.logger.With(log.String("kb_id", translation.KBID), log.String("node_id", translation.NodeID), log.String("locale", translation.Locale))
+
+	sourceVersion, err := u.translate(ctx, translation, userID)
+	updateMap := map[string]any{
+		"status":         domain.NodeTranslationStatusReady,
+		"source_version": sourceVersion,
+	}
+	if err != nil {
+		logger.Error("node translation failed", log.Error(err))
+		updateMap = map[string]any{
+			"status": domain.NodeTranslationStatusFailed,
+			"error":  err.Error(),
+		}
+	}
+	if err := u.translationRepo.Update(ctx, translation.NodeID, updateMap); err != nil {
+		logger.Error("update node translation status failed", log.Error(err))
+	}
+}
+
+// translate writes the translation of the current source into the translation node and returns the translated source version
+func (u *NodeTranslationUsecase) translate(ctx context.Context, translation *domain.NodeTranslation, userID string) (int64, error) {
+	source, err := u.nodeRepo.GetNodeByID(ctx, translation.SourceNodeID)
+	if err != nil {
+		return 0, fmt.Errorf("get source node failed: %w", err)
+	}
+	model, err := u.model.GetChatModel(ctx)
+	if err != nil {
+		return 0, domain.ErrModelNotConfigured
+	}
+	modelkitModel, err := model.ToModelkitModel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert model to modelkit model: %w", err)
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return 0, fmt.Errorf("get chat model failed: %w", err)
+	}
+
+	name, err := u.translateText(ctx, chatModel, translation.Locale, source.Name)
+	if err != nil {
+		return 0, err
+	}
+	updateReq := &domain.UpdateNodeReq{
+		ID:   translation.NodeID,
+		KBID: translation.KBID,
+		Name: &name,
+	}
+	if source.Meta.Summary != "" {
+		summary, err := u.translateText(ctx, chatModel, translation.Locale, source.Meta.Summary)
+		if err != nil {
+			return 0, err
+		}
+		updateReq.Summary = &summary
+	}
+	if source.Type == domain.NodeTypeDocument {
+		content, err := u.translateText(ctx, chatModel, translation.Locale, source.Content)
+		if err != nil {
+			return 0, err
+		}
+		updateReq.Content = &content
+		if source.Meta.ContentType != "" {
+			updateReq.ContentType = &source.Meta.ContentType
+		}
+	}
+	if _, err := u.nodeUsecase.Update(ctx, updateReq, userID); err != nil {
+		return 0, fmt.Errorf("update translation node failed: %w", err)
+	}
+	return source.Version, nil
+}
+
+// translateText translates the text chunk by chunk, chunks end at line breaks so the markup of a line stays together
+func (u *NodeTranslationUsecase) translateText(ctx context.Context, chatModel model.BaseChatModel, locale, text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+	chunks, err := u.splitText(text)
+	if err != nil {
+		return "", err
+	}
+	template := prompt.FromMessages(schema.GoTemplate,
+		schema.SystemMessage(domain.NodeTranslateSystemPrompt),
+		schema.UserMessage(domain.NodeTranslateFormatter),
+	)
+	translated := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		if strings.TrimSpace(chunk) == "" {
+			translated = append(translated, chunk)
+			continue
+		}
+		messages, err := template.Format(ctx, map[string]any{
+			"Locale":  locale,
+			"Content": chunk,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to format message: %w", err)
+		}
+		result, err := u.llm.Generate(ctx, chatModel, messages)
+		if err != nil {
+			return "", fmt.Errorf("translate chunk %d failed: %w", i, err)
+		}
+		result = u.llm.trimThinking(strings.TrimSpace(result))
+		result = strings.TrimSuffix(strings.TrimPrefix(result, "<DOCUMENT>"), "</DOCUMENT>")
+		translated = append(translated, strings.Trim(result, "\n"))
+	}
+	return strings.Join(translated, "\n"), nil
+}
+
+func (u *NodeTranslationUsecase) splitText(text string) ([]string, error) {
+	chunks := make([]string, 0)
+	var chunk strings.Builder
+	flush := func() {
+		if chunk.Len() > 0 {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if utf8.RuneCountInString(line) > translateChunkMaxRunes {
+			flush()
+			parts, err := u.llm.SplitByTokenLimit(line, translateChunkTokenLimit)
+			if err != nil {
+				return nil, err
+			}
+			chunks = append(chunks, parts...)
+			continue
+		}
+		if chunk.Len() > 0 && utf8.RuneCountInString(chunk.String())+utf8.RuneCountInString(line) >= translateChunkMaxRunes {
+			flush()
+		}
+		if chunk.Len() > 0 {
+			chunk.WriteString("\n")
+		}
+		chunk.WriteString(line)
+	}
+	flush()
+	return chunks, nil
+}
+
+// Link links an existing node as the translation of the source node, e.g. a document translated by hand
+func (u *NodeTranslationUsecase) Link(ctx context.Context, req *v1.NodeTranslationLinkReq) error {
+	locale := utils.NormalizeLocale(req.Locale)
+	if locale == "" {
+		return fmt.Errorf("%w: invalid locale %q", domain.ErrInvalidNodeTranslation, req.Locale)
+	}
+	source, err := u.getSource(ctx, req.KbId, req.ID)
+	if err != nil {
+		return err
+	}
+	node, err := u.nodeRepo.GetNodeByID(ctx, req.NodeID)
+	if err != nil {
+		return err
+	}
+	if node.KBID != req.KbId {
+		return domain.ErrPermissionDenied
+	}
+	if node.ID == source.ID || node.Type != source.Type {
+		return fmt.Errorf("%w: translation must be another node of the same type", domain.ErrInvalidNodeTranslation)
+	}
+	links, err := u.translationRepo.GetByNodeIDs(ctx, []string{node.ID})
+	if err != nil {
+		return err
+	}
+	if len(links) > 0 {
+		return fmt.Errorf("%w: node is already a translation or has translations", domain.ErrInvalidNodeTranslation)
+	}
+	existing, err := u.translationRepo.GetBySourceAndLocale(ctx, req.KbId, source.ID, locale)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: node already has a %s translation", domain.ErrInvalidNodeTranslation, locale)
+	}
+	return u.translationRepo.Create(ctx, &domain.NodeTranslation{
+		NodeID:        node.ID,
+		KBID:          req.KbId,
+		SourceNodeID:  source.ID,
+		Locale:        locale,
+		SourceVersion: source.Version,
+		Status:        domain.NodeTranslationStatusReady,
+	})
+}
+
+func (u *NodeTranslationUsecase) getTranslation(ctx context.Context, kbID, nodeID string) (*domain.NodeTranslation, error) {
+	translation, err := u.translationRepo.GetByNodeID(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if translation == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if translation.Status == domain.NodeTranslationStatusTranslating {
+		return nil, domain.ErrNodeTranslationRunning
+	}
+	return translation, nil
+}
+
+// MarkSynced marks the translation as up to date with the current source, after it is reviewed or updated by hand
+func (u *NodeTranslationUsecase) MarkSynced(ctx context.Context, kbID, nodeID string) error {
+	translation, err := u.getTranslation(ctx, kbID, nodeID)
+	if err != nil {
+		return err
+	}
+	source, err := u.nodeRepo.GetNodeByID(ctx, translation.SourceNodeID)
+	if err != nil {
+		return err
+	}
+	return u.translationRepo.Update(ctx, nodeID, map[string]any{
+		"status":         domain.NodeTranslationStatusReady,
+		"error":          "",
+		"source_version": source.Version,
+	})
+}
+
+// Unlink removes the translation link, the translated node is kept as a plain node
+func (u *NodeTranslationUsecase) Unlink(ctx context.Context, kbID, nodeID string) error {
+	if _, err := u.getTranslation(ctx, kbID, nodeID); err != nil {
+		return err
+	}
+	return u.translationRepo.Delete(ctx, kbID, nodeID)
+}
+
+// GetSourceNodeID returns the source of a translated node, the node itself when it is not a translation
+func (u *NodeTranslationUsecase) GetSourceNodeID(ctx context.Context, kbID, nodeID string) (string, error) {
+	translation, err := u.translationRepo.GetByNodeID(ctx, kbID, nodeID)
+	if err != nil {
+		return "", err
+	}
+	if translation == nil {
+		return nodeID, nil
+	}
+	return translation.SourceNodeID, nil
+}
+
+// LocalizeNodeList replaces the published nodes by their published translation in the locale,
+// nodes without one are kept in the source language
+func (u *NodeTranslationUsecase) LocalizeNodeList(ctx context.Context, kbID, locale string, nodes []*domain.ShareNodeListItemResp) ([]*domain.ShareNodeListItemResp, error) {
+	if utils.NormalizeLocale(locale) == "" || len(nodes) == 0 {
+		return nodes, nil
+	}
+	published, err := u.translationRepo.GetPublished(ctx, kbID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(published) == 0 {
+		return nodes, nil
+	}
+	bySource := lo.GroupBy(published, func(t *pg.PublishedNodeTranslation) string {
+		return t.SourceNodeID
+	})
+	localizedIDs := make(map[string]string) // source id -> translation id
+	items := make([]*domain.ShareNodeListItemResp, 0, len(nodes))
+	for _, node := range nodes {
+		translations := bySource[node.ID]
+		i := utils.MatchLocale(locale, lo.Map(translations, func(t *pg.PublishedNodeTranslation, _ int) string {
+			return t.Locale
+		}))
+		// the visitor may see the source, the translation has to be as visible
+		if i < 0 || (translations[i].Permissions.Visible != consts.NodeAccessPermOpen && translations[i].Permissions.Visible != node.Permissions.Visible) {
+			items = append(items, node)
+			continue
+		}
+		t := translations[i]
+		localized := *node
+		localized.ID = t.NodeID
+		localized.Name = t.Name
+		localized.Emoji = t.Meta.Emoji
+		localized.Meta = t.Meta
+		localized.UpdatedAt = t.UpdatedAt
+		localized.Permissions = t.Permissions
+		localizedIDs[node.ID] = t.NodeID
+		items = append(items, &localized)
+	}
+	// children follow their translated folders
+	for _, item := range items {
+		if id, ok := localizedIDs[item.ParentID]; ok {
+			item.ParentID = id
+		}
+	}
+	return items, nil
+}
+
+// ResolveNodeID returns the published variant of the node in the locale, the source when there is none
+func (u *NodeTranslationUsecase) ResolveNodeID(ctx context.Context, kbID, nodeID, locale string) (string, error) {
+	if utils.NormalizeLocale(locale) == "" {
+		return nodeID, nil
+	}
+	sourceID, translations, err := u.getPublishedVariants(ctx, kbID, nodeID)
+	if err != nil {
+		return "", err
+	}
+	if len(translations) == 0 {
+		return nodeID, nil
+	}
+	i := utils.MatchLocale(locale, lo.Map(translations, func(t *pg.PublishedNodeTranslation, _ int) string {
+		return t.Locale
+	}))
+	if i < 0 {
+		return sourceID, nil
+	}
+	return translations[i].NodeID, nil
+}
+
+// getPublishedVariants returns the source id of the node and the published translations of the source
+func (u *NodeTranslationUsecase) getPublishedVariants(ctx context.Context, kbID, nodeID string) (string, []*pg.PublishedNodeTranslation, error) {
+	translations, err := u.translationRepo.GetPublished(ctx, kbID, []string{nodeID})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, t := range translations {
+		if t.NodeID == nodeID {
+			translations, err = u.translationRepo.GetPublished(ctx, kbID, []string{t.SourceNodeID})
+			return t.SourceNodeID, translations, err
+		}
+	}
+	return nodeID, translations, nil
+}
+
+// SetShareNodeTranslations sets the locale and the visitable language variants of a published node
+func (u *NodeTranslationUsecase) SetShareNodeTranslations(ctx context.Context, kbID string, node *shareV1.ShareNodeDetailResp) error {
+	sourceID, translations, err := u.getPublishedVariants(ctx, kbID, node.ID)
+	if err != nil {
+		return err
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	if sourceID == node.ID {
+		node.Translations = append(node.Translations, &domain.ShareNodeTranslation{ID: node.ID, Name: node.Name})
+	} else {
+		source, err := u.nodeRepo.GetNodeReleaseDetailByKBIDAndID(ctx, kbID, sourceID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		// sources which can not be visited are left out
+		if source != nil {
+			node.Translations = append(node.Translations, &domain.ShareNodeTranslation{ID: source.ID, Name: source.Name})
+		}
+	}
+	for _, t := range translations {
+		if t.NodeID == node.ID {
+			node.Locale = t.Locale
+		}
+		if t.Permissions.Visitable == consts.NodeAccessPermClosed {
+			continue
+		}
+		node.Translations = append(node.Translations, &domain.ShareNodeTranslation{ID: t.NodeID, Locale: t.Locale, Name: t.Name})
+	}
+	return nil
+}
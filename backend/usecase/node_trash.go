@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/node/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+)
+
+func (u *NodeUsecase) trashRetention() time.Duration {
+	return time.Duration(u.config.Trash.RetentionDays) * 24 * time.Hour
+}
+
+func (u *NodeUsecase) GetNodeTrashList(ctx context.Context, req *v1.NodeTrashListReq) ([]*v1.NodeTrashListItem, error) {
+	items, err := u.nodeRepo.GetTrashList(ctx, req.KbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.ExpireAt = item.DeletedAt.Add(u.trashRetention())
+	}
+	return items, nil
+}
+
+// RestoreNodeTrash restores trashed nodes with their children and releases
+func (u *NodeUsecase) RestoreNodeTrash(ctx context.Context, req *v1.NodeTrashRestoreReq, maxNode int) error {
+	nodes, nodeReleases, err := u.nodeRepo.RestoreTrash(ctx, req.KbId, req.IDs, maxNode)
+	if err != nil {
+		return err
+	}
+
+	// rebuild links removed on deletion
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KbId)
+	if err != nil {
+		u.logger.Error("get kb for node links failed", log.String("kb_id", req.KbId), log.Error(err))
+		return nil
+	}
+	for _, node := range nodes {
+		if err := replaceNodeLinks(ctx, u.nodeLinkRepo, kb, node.ID, domain.NodeLinkScopeDraft, node.Content); err != nil {
+			u.logger.Error("update node links failed", log.String("node_id", node.ID), log.Error(err))
+		}
+		if node.Type != domain.NodeTypeFolder {
+			u.lintUsecase.LintNode(ctx, req.KbId, node.ID, node.Content, node.Meta.ContentType)
+		}
+	}
+	latestReleases := make(map[string]*domain.NodeRelease)
+	for _, release := range nodeReleases {
+		if latest, ok := latestReleases[release.NodeID]; !ok || release.UpdatedAt.After(latest.UpdatedAt) {
+			latestReleases[release.NodeID] = release
+		}
+	}
+	for _, release := range latestReleases {
+		if err := replaceNodeLinks(ctx, u.nodeLinkRepo, kb, release.NodeID, domain.NodeLinkScopeRelease, release.Content); err != nil {
+			u.logger.Error("update node release links failed", log.String("node_id", release.NodeID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// DeleteNodeTrash permanently deletes trashed nodes
+func (u *NodeUsecase) DeleteNodeTrash(ctx context.Context, req *v1.NodeTrashDeleteReq) error {
+	rootIDs := req.IDs
+	if req.All {
+		items, err := u.nodeRepo.GetTrashList(ctx, req.KbId)
+		if err != nil {
+			return err
+		}
+		rootIDs = lo.Map(items, func(item *v1.NodeTrashListItem, _ int) string {
+			return item.ID
+		})
+	}
+	if len(rootIDs) == 0 {
+		return nil
+	}
+	return u.purgeNodeTrash(ctx, req.KbId, rootIDs)
+}
+
+// PurgeExpiredNodeTrash permanently deletes nodes which stay in the trash longer than the retention period
+func (u *NodeUsecase) PurgeExpiredNodeTrash(ctx context.Context) error {
+	expired, err := u.nodeRepo.GetExpiredTrashRootIDs(ctx, time.Now().Add(-u.trashRetention()))
+	if err != nil {
+		return err
+	}
+	for kbID, rootIDs := range expired {
+		if err := u.purgeNodeTrash(ctx, kbID, rootIDs); err != nil {
+			u.logger.Error("purge node trash failed", log.String("kb_id", kbID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (u *NodeUsecase) purgeNodeTrash(ctx context.Context, kbID string, rootIDs []string) error {
+	docIDs, err := u.nodeRepo.PurgeTrash(ctx, kbID, rootIDs)
+	if err != nil {
+		return err
+	}
+	nodeVectorContentRequests := make([]*domain.NodeReleaseVectorRequest, 0)
+	for _, docID := range docIDs {
+		nodeVectorContentRequests = append(nodeVectorContentRequests, &domain.NodeReleaseVectorRequest{
+			KBID:   kbID,
+			DocID:  docID,
+			Action: "delete",
+		})
+	}
+	if len(nodeVectorContentRequests) == 0 {
+		return nil
+	}
+	return u.ragRepo.AsyncUpdateNodeReleaseVector(ctx, nodeVectorContentRequests)
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+
+	v1 "github.com/chaitin/panda-wiki/api/crawler/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/openapi"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/store/s3"
+)
+
+const (
+	openAPIMaxSpecSize    = 20 << 20
+	openAPITagKeyPrefix   = "tag:"
+	openAPIRemovedPrefix  = "[Removed] "
+	openAPIRemovedWarning = "> **Removed**: this operation is no longer part of the API spec"
+)
+
+type OpenAPIImportUsecase struct {
+	specRepo    *pg.OpenAPISpecRepository
+	nodeRepo    *pg.NodeRepository
+	nodeUsecase *NodeUsecase
+	s3Client    *s3.MinioClient
+	logger      *log.Logger
+}
+
+func NewOpenAPIImportUsecase(
+	specRepo *pg.OpenAPISpecRepository,
+	nodeRepo *pg.NodeRepository,
+	nodeUsecase *NodeUsecase,
+	s3Client *s3.MinioClient,
+	logger *log.Logger,
+) *OpenAPIImportUsecase {
+	return &OpenAPIImportUsecase{
+		specRepo:    specRepo,
+		nodeRepo:    nodeRepo,
+		nodeUsecase: nodeUsecase,
+		s3Client:    s3Client,
+		logger:      logger.WithModule("usecase.openapi_import"),
+	}
+}
+
+// Import creates a folder per tag and a document per operation, importing into an existing spec
+// updates the documents matched by operation id and marks the ones of removed operations
+func (u *OpenAPIImportUsecase) Import(ctx context.Context, req *v1.OpenAPIImportReq, userID string, maxNode int) (*v1.OpenAPIImportResp, error) {
+	data, err := u.readSpec(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := openapi.Parse(data)
+	if err != nil {
+		if errors.Is(err, openapi.ErrInvalidSpec) {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidOpenAPISpec, err)
+		}
+		return nil, err
+	}
+	if len(spec.Operations) == 0 {
+		return nil, fmt.Errorf("%w: spec has no operations", domain.ErrInvalidOpenAPISpec)
+	}
+
+	var record *domain.KBOpenAPISpec
+	if req.SpecID != "" {
+		record, err = u.specRepo.GetByID(ctx, req.KbID, req.SpecID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if req.ParentID != "" {
+			parent, err := u.nodeRepo.GetNodeByID(ctx, req.ParentID)
+			if err != nil || parent.KBID != req.KbID || parent.Type != domain.NodeTypeFolder {
+				return nil, fmt.Errorf("%w: parent folder not found", domain.ErrInvalidOpenAPISpec)
+			}
+		}
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			name = spec.Title
+		}
+		record = &domain.KBOpenAPISpec{
+			ID:        uuid.New().String(),
+			KBID:      req.KbID,
+			Name:      name,
+			ParentID:  req.ParentID,
+			CreatorID: userID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := u.specRepo.Create(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+
+	im := &openAPIImporter{
+		u:       u,
+		record:  record,
+		spec:    spec,
+		userID:  userID,
+		maxNode: maxNode,
+		result:  &domain.OpenAPIImportResult{},
+		mapped:  make(map[string]*domain.KBOpenAPISpecNode),
+		folders: make(map[string]string),
+	}
+	if err := im.run(ctx); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := u.specRepo.Update(ctx, record.ID, map[string]any{
+		"title":            spec.Title,
+		"version":          spec.Version,
+		"last_result":      im.result,
+		"last_imported_at": now,
+	}); err != nil {
+		return nil, err
+	}
+	u.logger.Info("openapi spec imported", log.String("kb_id", record.KBID), log.String("spec_id", record.ID),
+		log.String("version", spec.Version), log.Int("created", im.result.Created), log.Int("updated", im.result.Updated),
+		log.Int("removed", im.result.Removed))
+	return &v1.OpenAPIImportResp{
+		SpecID:  record.ID,
+		Title:   spec.Title,
+		Version: spec.Version,
+		Result:  *im.result,
+	}, nil
+}
+
+func (u *OpenAPIImportUsecase) readSpec(ctx context.Context, req *v1.OpenAPIImportReq) ([]byte, error) {
+	if req.Key == "" {
+		if strings.TrimSpace(req.Content) == "" {
+			return nil, fmt.Errorf("%w: key or content is required", domain.ErrInvalidOpenAPISpec)
+		}
+		return []byte(req.Content), nil
+	}
+	if !strings.HasPrefix(req.Key, req.KbID+"/") {
+		return nil, fmt.Errorf("%w: file does not belong to the knowledge base", domain.ErrInvalidOpenAPISpec)
+	}
+	object, err := u.s3Client.GetObject(ctx, domain.Bucket, req.Key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get spec file failed: %w", err)
+	}
+	defer object.Close()
+	data, err := io.ReadAll(io.LimitReader(object, openAPIMaxSpecSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read spec file failed: %w", err)
+	}
+	if len(data) > openAPIMaxSpecSize {
+		return nil, fmt.Errorf("%w: spec file is larger than %d bytes", domain.ErrInvalidOpenAPISpec, openAPIMaxSpecSize)
+	}
+	return data, nil
+}
+
+func (u *OpenAPIImportUsecase) GetSpecList(ctx context.Context, req *v1.OpenAPISpecListReq) ([]*domain.KBOpenAPISpec, error) {
+	return u.specRepo.GetListByKBID(ctx, req.KbID)
+}
+
+// DeleteSpec forgets the spec, generated nodes are kept
+func (u *OpenAPIImportUsecase) DeleteSpec(ctx context.Context, req *v1.OpenAPISpecDeleteReq) error {
+	if _, err := u.specRepo.GetByID(ctx, req.KbID, req.SpecID); err != nil {
+		return err
+	}
+	return u.specRepo.Delete(ctx, req.KbID, req.SpecID)
+}
+
+type openAPIImporter struct {
+	u       *OpenAPIImportUsecase
+	record  *domain.KBOpenAPISpec
+	spec    *openapi.Spec
+	userID  string
+	maxNode int
+	result  *domain.OpenAPIImportResult
+	mapped  map[string]*domain.KBOpenAPISpecNode // operation key or tag key -> node
+	folders map[string]string                    // tag name -> folder node id
+}
+
+func (im *openAPIImporter) run(ctx context.Context) error {
+	specNodes, err := im.u.specRepo.GetSpecNodes(ctx, im.record.ID)
+	if err != nil {
+		return err
+	}
+	nodeIDs := make([]string, 0, len(specNodes))
+	for _, node := range specNodes {
+		nodeIDs = append(nodeIDs, node.NodeID)
+	}
+	// nodes deleted in the kb are created again
+	existing, err := im.u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return err
+	}
+	for _, node := range specNodes {
+		if _, ok := existing[node.NodeID]; ok {
+			im.mapped[node.Key] = node
+		}
+	}
+
+	for _, tag := range im.spec.Tags {
+		if err := im.ensureFolder(ctx, tag.Name); err != nil {
+			return err
+		}
+	}
+	present := make(map[string]bool, len(im.spec.Operations))
+	for _, op := range im.spec.Operations {
+		present[op.Key] = true
+		if err := im.writeOperation(ctx, op); err != nil {
+			return err
+		}
+	}
+	for key, node := range im.mapped {
+		if node.Type != domain.NodeTypeDocument || node.Removed || present[key] {
+			continue
+		}
+		if err := im.markRemoved(ctx, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (im *openAPIImporter) ensureFolder(ctx context.Context, tag string) error {
+	key := openAPITagKeyPrefix + tag
+	if node, ok := im.mapped[key]; ok {
+		im.folders[tag] = node.NodeID
+		return nil
+	}
+	nodeID, err := im.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+		KBID:     im.record.KBID,
+		ParentID: im.record.ParentID,
+		Type:     domain.NodeTypeFolder,
+		Name:     tag,
+		MaxNode:  im.maxNode,
+	}, im.userID)
+	if err != nil {
+		return fmt.Errorf("create folder of tag %s failed: %w", tag, err)
+	}
+	node := &domain.KBOpenAPISpecNode{SpecID: im.record.ID, Key: key, KBID: im.record.KBID, NodeID: nodeID, Type: domain.NodeTypeFolder}
+	if err := im.u.specRepo.SaveSpecNode(ctx, node); err != nil {
+		return err
+	}
+	im.mapped[key] = node
+	im.folders[tag] = nodeID
+	im.result.Created++
+	return nil
+}
+
+func (im *openAPIImporter) writeOperation(ctx context.Context, op *openapi.Operation) error {
+	folderID := im.folders[op.Tag]
+	name, content := op.Name(), op.Markdown()
+	contentType := domain.ContentTypeMD
+
+	node, ok := im.mapped[op.Key]
+	if !ok {
+		nodeID, err := im.u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+			KBID:        im.record.KBID,
+			ParentID:    folderID,
+			Type:        domain.NodeTypeDocument,
+			Name:        name,
+			Content:     content,
+			ContentType: &contentType,
+			MaxNode:     im.maxNode,
+		}, im.userID)
+		if err != nil {
+			return fmt.Errorf("create document of operation %s failed: %w", op.Key, err)
+		}
+		node = &domain.KBOpenAPISpecNode{SpecID: im.record.ID, Key: op.Key, KBID: im.record.KBID, NodeID: nodeID, Type: domain.NodeTypeDocument}
+		if err := im.u.specRepo.SaveSpecNode(ctx, node); err != nil {
+			return err
+		}
+		im.mapped[op.Key] = node
+		im.result.Created++
+		return nil
+	}
+
+	current, err := im.u.nodeRepo.GetNodeByID(ctx, node.NodeID)
+	if err != nil {
+		return err
+	}
+	// operations follow their first tag
+	if current.ParentID != folderID {
+		if err := im.u.nodeUsecase.MoveNode(ctx, &domain.MoveNodeReq{ID: node.NodeID, KbID: im.record.KBID, ParentID: folderID}); err != nil {
+			im.result.Warnings = append(im.result.Warnings, fmt.Sprintf("%s: move to tag %s failed: %v", op.Key, op.Tag, err))
+		}
+	}
+	changed := current.Name != name || current.Content != content
+	if changed {
+		if _, err := im.u.nodeUsecase.Update(ctx, &domain.UpdateNodeReq{
+			ID:          node.NodeID,
+			KBID:        im.record.KBID,
+			Name:        &name,
+			Content:     &content,
+			ContentType: &contentType,
+		}, im.userID); err != nil {
+			return fmt.Errorf("update document of operation %s failed: %w", op.Key, err)
+		}
+	}
+	switch {
+	case node.Removed:
+		node.Removed = false
+		if err := im.u.specRepo.SaveSpecNode(ctx, node); err != nil {
+			return err
+		}
+		im.result.Restored++
+	case changed:
+		im.result.Updated++
+	default:
+		im.result.Unchanged++
+	}
+	return nil
+}
+
+// markRemoved keeps the document of a removed operation, its name and a notice tell readers it is gone
+func (im *openAPIImporter) markRemoved(ctx context.Context, node *domain.KBOpenAPISpecNode) error {
+	current, err := im.u.nodeRepo.GetNodeByID(ctx, node.NodeID)
+	if err != nil {
+		return err
+	}
+	name := openAPIRemovedPrefix + strings.TrimPrefix(current.Name, openAPIRemovedPrefix)
+	notice := openAPIRemovedWarning + "."
+	if im.spec.Version != "" {
+		notice = fmt.Sprintf("%s since version %s.", openAPIRemovedWarning, im.spec.Version)
+	}
+	content := notice + "\n\n" + current.Content
+	contentType := domain.ContentTypeMD
+	if _, err := im.u.nodeUsecase.Update(ctx, &domain.UpdateNodeReq{
+		ID:          node.NodeID,
+		KBID:        im.record.KBID,
+		Name:        &name,
+		Content:     &content,
+		ContentType: &contentType,
+	}, im.userID); err != nil {
+		return fmt.Errorf("mark document of operation %s as removed failed: %w", node.Key, err)
+	}
+	node.Removed = true
+	if err := im.u.specRepo.SaveSpecNode(ctx, node); err != nil {
+		return err
+	}
+	im.result.Removed++
+	return nil
+}
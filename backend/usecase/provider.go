@@ -29,10 +29,23 @@ var ProviderSet = wire.NewSet(
 	NewCreationUsecase,
 	NewFileUsecase,
 	NewSitemapUsecase,
+	NewFeedUsecase,
+	NewLLMsUsecase,
 	NewStatUseCase,
 	NewCommentUsecase,
 	NewWechatUsecase,
 	NewWecomUsecase,
 	NewWechatAppUsecase,
 	NewAuthUsecase,
+	NewKBExportUsecase,
+	NewGitSyncUsecase,
+	NewCrawlerSyncUsecase,
+	NewOpenAPIImportUsecase,
+	NewWebhookUsecase,
+	NewNodePushUsecase,
+	NewNodeLintUsecase,
+	NewNodeSEOUsecase,
+	NewNodeTranslationUsecase,
+	NewDocumentFeedbackUsecase,
+	NewKBRedirectUsecase,
 )
@@ -2,51 +2,232 @@ package usecase
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	sitemapMaxURLs          = 50000 // limit of the sitemap protocol
+	sitemapMaxImagesPerURL  = 1000
+	sitemapContentChunkSize = 500
 )
 
 type SitemapUsecase struct {
-	nodeUsecase *pg.NodeRepository
-	appUsecase  *pg.KnowledgeBaseRepository
-	logger      *log.Logger
+	nodeRepo        *pg.NodeRepository
+	kbRepo          *pg.KnowledgeBaseRepository
+	translationRepo *pg.NodeTranslationRepository
+	redirectRepo    *pg.KBRedirectRepository
+	logger          *log.Logger
+}
+
+func NewSitemapUsecase(nodeRepo *pg.NodeRepository, kbRepo *pg.KnowledgeBaseRepository, translationRepo *pg.NodeTranslationRepository, redirectRepo *pg.KBRedirectRepository, logger *log.Logger) *SitemapUsecase {
+	return &SitemapUsecase{nodeRepo: nodeRepo, kbRepo: kbRepo, translationRepo: translationRepo, redirectRepo: redirectRepo, logger: logger.WithModule("usecase.sitemap")}
+}
+
+type sitemapURLSet struct {
+	XMLName    xml.Name      `xml:"urlset"`
+	Xmlns      string        `xml:"xmlns,attr"`
+	XmlnsImage string        `xml:"xmlns:image,attr"`
+	XmlnsXhtml string        `xml:"xmlns:xhtml,attr"`
+	URLs       []*sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string              `xml:"loc"`
+	LastMod    string              `xml:"lastmod,omitempty"`
+	Alternates []*sitemapAlternate `xml:"xhtml:link"`
+	Images     []*sitemapImage     `xml:"image:image"`
+}
+
+// sitemapAlternate is a language variant of the url
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type sitemapImage struct {
+	Loc string `xml:"image:loc"`
 }
 
-func NewSitemapUsecase(nodeUsecase *pg.NodeRepository, appUsecase *pg.KnowledgeBaseRepository, logger *log.Logger) *SitemapUsecase {
-	return &SitemapUsecase{nodeUsecase: nodeUsecase, appUsecase: appUsecase, logger: logger.WithModule("usecase.sitemap")}
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []*sitemapPointer `xml:"sitemap"`
 }
 
-func (u *SitemapUsecase) GetSitemap(ctx context.Context, kbID string) (string, error) {
-	nodes, err := u.nodeUsecase.GetNodeReleaseListByKBID(ctx, kbID)
+type sitemapPointer struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// GetSitemap returns the sitemap of the publicly visitable documents.
+// Page 0 is the whole sitemap, or an index of the pages when there are more than 50k urls.
+func (u *SitemapUsecase) GetSitemap(ctx context.Context, kbID string, page int) ([]byte, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge base: %w", err)
+	}
+	settings := kb.AccessSettings
+	if settings.IsForbidden || settings.SimpleAuth.Enabled || settings.EnterpriseAuth.Enabled {
+		return nil, domain.ErrSitemapNotAvailable
+	}
+	release, err := u.kbRepo.GetLatestRelease(ctx, kbID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSitemapNotAvailable
+		}
+		return nil, fmt.Errorf("failed to get latest release: %w", err)
+	}
+	nodes, err := u.nodeRepo.GetSitemapNodeReleases(ctx, kbID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get node release list: %w", err)
+		return nil, fmt.Errorf("failed to get node release list: %w", err)
 	}
 
-	kb, err := u.appUsecase.GetKnowledgeBaseByID(ctx, kbID)
+	slugs, err := u.redirectRepo.GetSlugs(ctx, kbID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get knowledge base: %w", err)
+		return nil, fmt.Errorf("failed to get node slugs: %w", err)
 	}
 
-	sb := strings.Builder{}
-	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
-	sb.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	baseURL := kb.AccessSettings.BaseURL
+	urls := make([]*sitemapURL, 0, len(nodes)+1)
+	urls = append(urls, &sitemapURL{Loc: baseURL + "/welcome", LastMod: release.CreatedAt.Format(time.RFC3339)})
+	nodeIDs := make(map[*sitemapURL]string, len(nodes))
+	for _, node := range nodes {
+		// nodes with an alias are listed at the alias
+		url := &sitemapURL{Loc: baseURL + domain.NodePath(node.ID, slugs[node.ID]), LastMod: node.UpdatedAt.Format(time.RFC3339)}
+		urls = append(urls, url)
+		nodeIDs[url] = node.ID
+	}
+	pages := lo.Chunk(urls, sitemapMaxURLs)
 
-	// add welcome
-	sb.WriteString(fmt.Sprintf(`<url><loc>%s/welcome</loc><lastmod>%s</lastmod></url>`, kb.AccessSettings.BaseURL, time.Now().Format(time.DateOnly)))
+	if page == 0 && len(pages) > 1 {
+		index := &sitemapIndex{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+		for i, pageURLs := range pages {
+			index.Sitemaps = append(index.Sitemaps, &sitemapPointer{
+				Loc:     fmt.Sprintf("%s/sitemap.xml?page=%d", baseURL, i+1),
+				LastMod: lo.MaxBy(pageURLs, func(a, b *sitemapURL) bool { return a.LastMod > b.LastMod }).LastMod,
+			})
+		}
+		return marshalSitemap(index)
+	}
+	page = max(page, 1)
+	if page > len(pages) {
+		return nil, domain.ErrSitemapNotAvailable
+	}
+	pageURLs := pages[page-1]
+	if err := u.setAlternates(ctx, kbID, pageURLs, nodeIDs); err != nil {
+		return nil, err
+	}
+	if err := u.setImages(ctx, kbID, baseURL, pageURLs, nodeIDs); err != nil {
+		return nil, err
+	}
+	return marshalSitemap(&sitemapURLSet{
+		Xmlns:      "http://www.sitemaps.org/schemas/sitemap/0.9",
+		XmlnsImage: "http://www.google.com/schemas/sitemap-image/1.1",
+		XmlnsXhtml: "http://www.w3.org/1999/xhtml",
+		URLs:       pageURLs,
+	})
+}
 
-	// add nodes
-	for _, node := range nodes {
-		if node.Type == domain.NodeTypeDocument {
-			sb.WriteString(fmt.Sprintf(`<url><loc>%s</loc><lastmod>%s</lastmod></url>`, node.GetURL(kb.AccessSettings.BaseURL), node.UpdatedAt.Format(time.DateOnly)))
+// setAlternates links the language variants of the documents on the page which are in the sitemap,
+// the source document is the x-default
+func (u *SitemapUsecase) setAlternates(ctx context.Context, kbID string, urls []*sitemapURL, nodeIDs map[*sitemapURL]string) error {
+	translations, err := u.translationRepo.GetPublished(ctx, kbID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get node translations: %w", err)
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	locs := make(map[string]string, len(nodeIDs)) // node id -> url in the sitemap
+	for url, id := range nodeIDs {
+		locs[id] = url.Loc
+	}
+	sourceIDs := make(map[string]string) // node id -> source id
+	variants := make(map[string][]*sitemapAlternate)
+	for _, t := range translations {
+		if locs[t.NodeID] == "" || locs[t.SourceNodeID] == "" {
+			continue
+		}
+		if _, ok := variants[t.SourceNodeID]; !ok {
+			variants[t.SourceNodeID] = []*sitemapAlternate{{Rel: "alternate", Hreflang: "x-default", Href: locs[t.SourceNodeID]}}
+			sourceIDs[t.SourceNodeID] = t.SourceNodeID
+		}
+		variants[t.SourceNodeID] = append(variants[t.SourceNodeID], &sitemapAlternate{Rel: "alternate", Hreflang: t.Locale, Href: locs[t.NodeID]})
+		sourceIDs[t.NodeID] = t.SourceNodeID
+	}
+	for _, url := range urls {
+		if sourceID, ok := sourceIDs[nodeIDs[url]]; ok {
+			url.Alternates = variants[sourceID]
 		}
 	}
+	return nil
+}
+
+// setImages adds the images in the published content of the documents on the page
+func (u *SitemapUsecase) setImages(ctx context.Context, kbID, baseURL string, urls []*sitemapURL, nodeIDs map[*sitemapURL]string) error {
+	ids := make([]string, 0, len(urls))
+	urlByID := make(map[string]*sitemapURL, len(urls))
+	for _, url := range urls {
+		if id, ok := nodeIDs[url]; ok {
+			ids = append(ids, id)
+			urlByID[id] = url
+		}
+	}
+	for _, chunk := range lo.Chunk(ids, sitemapContentChunkSize) {
+		contents, err := u.nodeRepo.GetLatestNodeReleaseContents(ctx, kbID, chunk)
+		if err != nil {
+			return fmt.Errorf("failed to get node release contents: %w", err)
+		}
+		for id, content := range contents {
+			url := urlByID[id]
+			seen := make(map[string]bool)
+			for _, image := range utils.ParseContentElements(content, utils.IsLikelyHTML(content)).Images {
+				loc := sitemapImageURL(baseURL, image.Src)
+				if loc == "" || seen[loc] {
+					continue
+				}
+				seen[loc] = true
+				url.Images = append(url.Images, &sitemapImage{Loc: loc})
+				if len(url.Images) == sitemapMaxImagesPerURL {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
 
-	sb.WriteString(`</urlset>`)
+// sitemapImageURL returns the absolute url of an image src, data urls and other schemes are skipped
+func sitemapImageURL(baseURL, src string) string {
+	switch {
+	case strings.HasPrefix(src, "//"):
+		return "https:" + src
+	case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"):
+		return src
+	case strings.HasPrefix(src, "/"):
+		return baseURL + src
+	default:
+		return ""
+	}
+}
 
-	return sb.String(), nil
+func marshalSitemap(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
 }
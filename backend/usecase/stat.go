@@ -30,9 +30,12 @@ type StatUseCase struct {
 	logger           *log.Logger
 	geoCacheRepo     *cache.GeoRepo
 	authRepo         *pg.AuthRepo
+	searchQueryRepo  *pg.SearchQueryRepository
+	feedbackRepo     *pg.DocumentFeedbackRepository
+	nodeUsecase      *NodeUsecase
 }
 
-func NewStatUseCase(repo *pg.StatRepository, nodeRepo *pg.NodeRepository, conversationRepo *pg.ConversationRepository, appRepo *pg.AppRepository, ipRepo *ipdb.IPAddressRepo, geoCacheRepo *cache.GeoRepo, authRepo *pg.AuthRepo, kbRepo *pg.KnowledgeBaseRepository, logger *log.Logger) *StatUseCase {
+func NewStatUseCase(repo *pg.StatRepository, nodeRepo *pg.NodeRepository, conversationRepo *pg.ConversationRepository, appRepo *pg.AppRepository, ipRepo *ipdb.IPAddressRepo, geoCacheRepo *cache.GeoRepo, authRepo *pg.AuthRepo, kbRepo *pg.KnowledgeBaseRepository, searchQueryRepo *pg.SearchQueryRepository, feedbackRepo *pg.DocumentFeedbackRepository, nodeUsecase *NodeUsecase, logger *log.Logger) *StatUseCase {
 	return &StatUseCase{
 		repo:             repo,
 		nodeRepo:         nodeRepo,
@@ -42,6 +45,9 @@ func NewStatUseCase(repo *pg.StatRepository, nodeRepo *pg.NodeRepository, conver
 		geoCacheRepo:     geoCacheRepo,
 		authRepo:         authRepo,
 		kbRepo:           kbRepo,
+		searchQueryRepo:  searchQueryRepo,
+		feedbackRepo:     feedbackRepo,
+		nodeUsecase:      nodeUsecase,
 		logger:           logger.WithModule("usecase.stats"),
 	}
 }
@@ -438,6 +444,11 @@ func (u *StatUseCase) AggregateHourlyStats(ctx context.Context) error {
 			return err
 		}
 
+		nodeEngagement, err := u.repo.GetNodeEngagementOneHour(ctx, kbId)
+		if err != nil {
+			return err
+		}
+
 		statPageHour.KbID = kbId
 		statPageHour.Hour = lastHour
 		statPageHour.ConversationCount = conversationCount
@@ -448,6 +459,7 @@ func (u *StatUseCase) AggregateHourlyStats(ctx context.Context) error {
 		statPageHour.HotPage = hotPages
 		statPageHour.HotBrowser = hotBrowsers
 		statPageHour.HotOS = hotOS
+		statPageHour.NodeEngagement = nodeEngagement
 
 		if err := u.repo.CreateStatPageHour(ctx, statPageHour); err != nil {
 			return err
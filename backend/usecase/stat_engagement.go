@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+const nodeEngagementReportLimit = 100
+
+func (u *StatUseCase) RecordPageEngagement(ctx context.Context, engagement *domain.StatPageEngagement) error {
+	// the beacon is public, only published docs of the kb are recorded
+	published, err := u.nodeRepo.GetNodePublishedStates(ctx, engagement.KBID, []string{engagement.NodeID})
+	if err != nil {
+		return err
+	}
+	if !published[engagement.NodeID] {
+		return domain.ErrStatEngagementNodeNotPublished
+	}
+	engagement.DwellSeconds = min(engagement.DwellSeconds, domain.StatEngagementMaxDwellSeconds)
+	return u.repo.CreateStatPageEngagement(ctx, engagement)
+}
+
+// GetNodeEngagements returns how the most visited docs of the period are read, the realtime data of
+// the last 24 hours is merged with the hourly stats for longer periods
+func (u *StatUseCase) GetNodeEngagements(ctx context.Context, kbID string, day consts.StatDay) ([]*v1.StatNodeEngagementItem, error) {
+	engagements, err := u.repo.GetNodeEngagements(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if day > consts.StatDay1 {
+		engagementsByHour, err := u.repo.GetNodeEngagementsByHour(ctx, kbID, int64(day)*24)
+		if err != nil {
+			return nil, err
+		}
+		engagements.Merge(engagementsByHour)
+	}
+
+	items := make([]*v1.StatNodeEngagementItem, 0, len(engagements))
+	for nodeID, e := range engagements {
+		if e.Views == 0 {
+			continue
+		}
+		views := float64(e.Views)
+		items = append(items, &v1.StatNodeEngagementItem{
+			NodeID:          nodeID,
+			Views:           e.Views,
+			AvgDwellSeconds: float64(e.DwellSeconds) / views,
+			AvgScrollDepth:  float64(e.ScrollDepth) / views,
+			ReadRate:        float64(e.Reads) / views,
+			BounceRate:      float64(e.Bounces) / views,
+			ExitRate:        float64(e.Exits) / views,
+			Copies:          e.Copies,
+			OutboundClicks:  e.OutboundClicks,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Views != items[j].Views {
+			return items[i].Views > items[j].Views
+		}
+		return items[i].NodeID < items[j].NodeID
+	})
+	if len(items) > nodeEngagementReportLimit {
+		items = items[:nodeEngagementReportLimit]
+	}
+
+	nodeIDs := lo.Map(items, func(item *v1.StatNodeEngagementItem, _ int) string {
+		return item.NodeID
+	})
+	docNames, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.NodeName = docNames[item.NodeID]
+	}
+	return items, nil
+}
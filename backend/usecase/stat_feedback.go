@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+)
+
+const nodeFeedbackReportLimit = 100
+
+// GetNodeFeedbackStats returns the page feedback of the period by node, the docs with most negative feedback first
+func (u *StatUseCase) GetNodeFeedbackStats(ctx context.Context, kbID string, day consts.StatDay) ([]*v1.StatNodeFeedbackItem, error) {
+	since := time.Now().Add(-time.Duration(max(day, consts.StatDay1)) * 24 * time.Hour)
+	items, err := u.feedbackRepo.GetNodeStats(ctx, kbID, since, nodeFeedbackReportLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := lo.Map(items, func(item *v1.StatNodeFeedbackItem, _ int) string {
+		return item.NodeID
+	})
+	docNames, err := u.nodeRepo.GetNodeNameByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.NodeName = docNames[item.NodeID]
+		if total := item.Helpful + item.NotHelpful; total > 0 {
+			item.HelpfulRate = float64(item.Helpful) / float64(total)
+		}
+	}
+	return items, nil
+}
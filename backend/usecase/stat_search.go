@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/chaitin/panda-wiki/api/stat/v1"
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	searchQueryReportLimit   = 50
+	searchQueryGapDocStatDay = 90
+	searchQueryRetentionDay  = 180
+)
+
+// GetTopSearchQueries returns the most frequent queries of the period, zeroResultOnly keeps the queries without results
+func (u *StatUseCase) GetTopSearchQueries(ctx context.Context, kbID string, day consts.StatDay, source domain.SearchQuerySource, zeroResultOnly bool) ([]*v1.StatSearchQueryItem, error) {
+	since := time.Now().Add(-time.Duration(day) * 24 * time.Hour)
+	return u.searchQueryRepo.GetTopQueries(ctx, kbID, since, source, zeroResultOnly, searchQueryReportLimit)
+}
+
+// GetNegativeFeedbackQueries returns the chat questions whose answers were disliked most in the period
+func (u *StatUseCase) GetNegativeFeedbackQueries(ctx context.Context, kbID string, day consts.StatDay) ([]*v1.StatNegativeFeedbackQueryItem, error) {
+	since := time.Now().Add(-time.Duration(day) * 24 * time.Hour)
+	return u.searchQueryRepo.GetNegativeFeedbackQueries(ctx, kbID, since, searchQueryReportLimit)
+}
+
+// CreateSearchGapDoc creates a draft document for a query the kb can not answer well, prefilled with the query stats
+func (u *StatUseCase) CreateSearchGapDoc(ctx context.Context, req *v1.StatSearchGapDocReq, userID string, maxNode int) (*v1.StatSearchGapDocResp, error) {
+	query := strings.TrimSpace(req.Query)
+	since := time.Now().AddDate(0, 0, -searchQueryGapDocStatDay)
+	stats, err := u.searchQueryRepo.GetQueryStats(ctx, req.KbID, utils.NormalizeSearchQuery(query), since)
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	content.WriteString("> 此文档由搜索分析创建，用于补充知识库中缺失的内容。\n>\n")
+	fmt.Fprintf(&content, "> - 查询：%s\n", query)
+	fmt.Fprintf(&content, "> - 近 %d 天查询次数：%d，无结果次数：%d\n", searchQueryGapDocStatDay, stats.Count, stats.ZeroCount)
+	fmt.Fprintf(&content, "> - 平均召回文档数：%.1f，平均最高相似度：%.2f\n\n", stats.AvgResultCount, stats.AvgTopScore)
+	content.WriteString("## 问题\n\n## 解答\n")
+
+	contentType := domain.ContentTypeMD
+	nodeID, err := u.nodeUsecase.Create(ctx, &domain.CreateNodeReq{
+		KBID:        req.KbID,
+		ParentID:    req.ParentID,
+		Type:        domain.NodeTypeDocument,
+		Name:        query,
+		Content:     content.String(),
+		ContentType: &contentType,
+		MaxNode:     maxNode,
+	}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.StatSearchGapDocResp{NodeID: nodeID}, nil
+}
+
+// CleanupOldSearchQueries 清理180天前的查询记录
+func (u *StatUseCase) CleanupOldSearchQueries(ctx context.Context) error {
+	return u.searchQueryRepo.DeleteBefore(ctx, time.Now().AddDate(0, 0, -searchQueryRetentionDay))
+}
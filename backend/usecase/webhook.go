@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	v1 "github.com/chaitin/panda-wiki/api/kb/v1"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const (
+	webhookTimeout         = 10 * time.Second
+	webhookMaxAttempts     = 6
+	webhookClaimTimeout    = 10 * time.Minute // a queued attempt not made within this time is queued again
+	webhookRetryBatch      = 100
+	webhookMaxResponseBody = 2 << 10
+	webhookKeepDeliveries  = 30 * 24 * time.Hour
+)
+
+// webhookRetryBackoff is the delay after the n-th failed attempt
+var webhookRetryBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+}
+
+type WebhookUsecase struct {
+	webhookRepo *pg.WebhookRepository
+	mqRepo      *mq.WebhookRepository
+	httpClient  *http.Client
+	logger      *log.Logger
+}
+
+func NewWebhookUsecase(webhookRepo *pg.WebhookRepository, mqRepo *mq.WebhookRepository, logger *log.Logger) *WebhookUsecase {
+	httpClient := utils.NewPublicHTTPClient(webhookTimeout)
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &WebhookUsecase{
+		webhookRepo: webhookRepo,
+		mqRepo:      mqRepo,
+		httpClient:  httpClient,
+		logger:      logger.WithModule("usecase.webhook"),
+	}
+}
+
+func (u *WebhookUsecase) CreateWebhook(ctx context.Context, req *v1.KBWebhookCreateReq, userID string) (*v1.KBWebhookCreateResp, error) {
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+	webhook := &domain.KBWebhook{
+		ID:        uuid.New().String(),
+		KBID:      req.KBId,
+		Name:      req.Name,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    webhookEvents(req.Events),
+		Enabled:   req.Enabled == nil || *req.Enabled,
+		CreatorID: userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := u.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return &v1.KBWebhookCreateResp{WebhookID: webhook.ID, Secret: secret}, nil
+}
+
+func (u *WebhookUsecase) UpdateWebhook(ctx context.Context, req *v1.KBWebhookUpdateReq) error {
+	if _, err := u.webhookRepo.GetByID(ctx, req.KBId, req.WebhookID); err != nil {
+		return err
+	}
+	updateMap := make(map[string]any)
+	if req.Name != nil {
+		updateMap["name"] = *req.Name
+	}
+	if req.URL != nil {
+		if err := validateWebhookURL(ctx, *req.URL); err != nil {
+			return err
+		}
+		updateMap["url"] = *req.URL
+	}
+	if req.Secret != nil && *req.Secret != "" {
+		updateMap["secret"] = *req.Secret
+	}
+	if req.Events != nil {
+		updateMap["events"] = webhookEvents(*req.Events)
+	}
+	if req.Enabled != nil {
+		updateMap["enabled"] = *req.Enabled
+	}
+	return u.webhookRepo.Update(ctx, req.KBId, req.WebhookID, updateMap)
+}
+
+func (u *WebhookUsecase) GetWebhookList(ctx context.Context, kbID string) ([]*domain.KBWebhook, error) {
+	return u.webhookRepo.GetListByKBID(ctx, kbID)
+}
+
+func (u *WebhookUsecase) DeleteWebhook(ctx context.Context, kbID, id string) error {
+	return u.webhookRepo.Delete(ctx, kbID, id)
+}
+
+func (u *WebhookUsecase) GetDeliveryList(ctx context.Context, req *v1.KBWebhookDeliveryListReq) (*v1.KBWebhookDeliveryListResp, error) {
+	total, deliveries, err := u.webhookRepo.GetDeliveryList(ctx, req.KBId, req.WebhookID, req.Offset(), req.Limit())
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(deliveries, uint64(total)), nil
+}
+
+// TestWebhook sends a ping event right away, the attempt is recorded in the delivery log but never retried
+func (u *WebhookUsecase) TestWebhook(ctx context.Context, kbID, id string) (*domain.KBWebhookDelivery, error) {
+	webhook, err := u.webhookRepo.GetByID(ctx, kbID, id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := newWebhookDeliveries([]*domain.KBWebhook{webhook}, kbID, domain.WebhookEventPing, &domain.WebhookPingData{
+		WebhookID: webhook.ID,
+		Message:   "This is a test event from PandaWiki.",
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery := deliveries[0]
+	delivery.NextRetryAt = nil
+	u.send(ctx, webhook, delivery)
+	if delivery.Status != domain.WebhookDeliveryStatusSuccess {
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+	}
+	if err := u.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Redeliver queues the delivery again with a full set of attempts
+func (u *WebhookUsecase) Redeliver(ctx context.Context, kbID, deliveryID string) error {
+	delivery, err := u.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.KBID != kbID {
+		return gorm.ErrRecordNotFound
+	}
+	if err := u.webhookRepo.UpdateDelivery(ctx, delivery.ID, map[string]any{
+		"status":        domain.WebhookDeliveryStatusPending,
+		"attempts":      0,
+		"next_retry_at": time.Now().Add(webhookClaimTimeout),
+	}); err != nil {
+		return err
+	}
+	return u.mqRepo.AsyncDeliver(ctx, delivery.ID)
+}
+
+// Publish notifies the webhooks of the kb subscribed to the event, one event is sent per data item.
+// Errors are only logged, webhooks never fail the operation which triggered the event
+func (u *WebhookUsecase) Publish(ctx context.Context, kbID string, event domain.WebhookEvent, data ...any) {
+	if len(data) == 0 {
+		return
+	}
+	webhooks, err := u.webhookRepo.GetEnabledListByKBID(ctx, kbID)
+	if err != nil {
+		u.logger.Error("get webhooks failed", log.String("kb_id", kbID), log.String("event", string(event)), log.Error(err))
+		return
+	}
+	webhooks = lo.Filter(webhooks, func(webhook *domain.KBWebhook, _ int) bool {
+		return webhook.Subscribed(event)
+	})
+	if len(webhooks) == 0 {
+		return
+	}
+	deliveries, err := newWebhookDeliveries(webhooks, kbID, event, data...)
+	if err != nil {
+		u.logger.Error("build webhook deliveries failed", log.String("kb_id", kbID), log.String("event", string(event)), log.Error(err))
+		return
+	}
+	if err := u.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		u.logger.Error("create webhook deliveries failed", log.String("kb_id", kbID), log.String("event", string(event)), log.Error(err))
+		return
+	}
+	ids := lo.Map(deliveries, func(delivery *domain.KBWebhookDelivery, _ int) string {
+		return delivery.ID
+	})
+	// deliveries which are not queued are picked up by the retry job
+	if err := u.mqRepo.AsyncDeliver(ctx, ids...); err != nil {
+		u.logger.Warn("queue webhook deliveries failed", log.String("kb_id", kbID), log.String("event", string(event)), log.Error(err))
+	}
+}
+
+// Deliver makes a queued attempt of the delivery, failed attempts are retried with backoff
+func (u *WebhookUsecase) Deliver(ctx context.Context, deliveryID string) error {
+	delivery, err := u.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		// the webhook is deleted with its deliveries
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if delivery.Status != domain.WebhookDeliveryStatusPending && delivery.Status != domain.WebhookDeliveryStatusRetrying {
+		return nil
+	}
+	webhook, err := u.webhookRepo.GetByID(ctx, delivery.KBID, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !webhook.Enabled {
+		return u.webhookRepo.UpdateDelivery(ctx, delivery.ID, map[string]any{
+			"status":        domain.WebhookDeliveryStatusFailed,
+			"error":         "webhook is disabled",
+			"next_retry_at": nil,
+		})
+	}
+
+	u.send(ctx, webhook, delivery)
+	if delivery.Status != domain.WebhookDeliveryStatusSuccess {
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = domain.WebhookDeliveryStatusFailed
+		} else {
+			delivery.Status = domain.WebhookDeliveryStatusRetrying
+			delivery.NextRetryAt = lo.ToPtr(time.Now().Add(webhookRetryBackoff[min(delivery.Attempts, len(webhookRetryBackoff))-1]))
+		}
+		u.logger.Warn("webhook delivery failed",
+			log.String("delivery_id", delivery.ID),
+			log.Int("attempts", delivery.Attempts),
+			log.Int("response_code", delivery.ResponseCode),
+			log.String("error", delivery.Error))
+	}
+	return u.webhookRepo.UpdateDelivery(ctx, delivery.ID, map[string]any{
+		"status":        delivery.Status,
+		"attempts":      delivery.Attempts,
+		"response_code": delivery.ResponseCode,
+		"response_body": delivery.ResponseBody,
+		"error":         delivery.Error,
+		"duration":      delivery.Duration,
+		"next_retry_at": delivery.NextRetryAt,
+	})
+}
+
+// RetryDueDeliveries queues the deliveries which are due for a retry or whose queued attempt got lost
+func (u *WebhookUsecase) RetryDueDeliveries(ctx context.Context) error {
+	now := time.Now()
+	ids, err := u.webhookRepo.ClaimDueDeliveries(ctx, now, now.Add(webhookClaimTimeout), webhookRetryBatch)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	u.logger.Info("queue due webhook deliveries", log.Int("count", len(ids)))
+	return u.mqRepo.AsyncDeliver(ctx, ids...)
+}
+
+func (u *WebhookUsecase) CleanupOldDeliveries(ctx context.Context) error {
+	return u.webhookRepo.DeleteDeliveriesBefore(ctx, time.Now().Add(-webhookKeepDeliveries))
+}
+
+// send makes one attempt and records the result in the delivery, the status is success or left unchanged
+func (u *WebhookUsecase) send(ctx context.Context, webhook *domain.KBWebhook, delivery *domain.KBWebhookDelivery) {
+	delivery.Attempts++
+	delivery.ResponseCode = 0
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+	delivery.NextRetryAt = nil
+
+	start := time.Now()
+	defer func() {
+		delivery.Duration = time.Since(start).Milliseconds()
+	}()
+
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PandaWiki-Webhook")
+	req.Header.Set(domain.WebhookHeaderEvent, string(delivery.Event))
+	req.Header.Set(domain.WebhookHeaderDelivery, delivery.ID)
+	req.Header.Set(domain.WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(domain.WebhookHeaderSignature, "sha256="+signWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		// the url resolves to a private address now, nothing was sent
+		if errors.Is(err, utils.ErrPrivateAddress) {
+			err = domain.ErrWebhookURLNotPublic
+		}
+		delivery.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	delivery.ResponseCode = resp.StatusCode
+	// postgres text does not accept invalid utf8 and NUL
+	delivery.ResponseBody = strings.ReplaceAll(string(bytes.ToValidUTF8(body, nil)), "\x00", "")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+		return
+	}
+	delivery.Status = domain.WebhookDeliveryStatusSuccess
+}
+
+// signWebhookPayload signs "<timestamp>.<body>", the timestamp lets receivers reject replayed requests
+func signWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookDeliveries(webhooks []*domain.KBWebhook, kbID string, event domain.WebhookEvent, data ...any) ([]*domain.KBWebhookDelivery, error) {
+	now := time.Now()
+	deliveries := make([]*domain.KBWebhookDelivery, 0, len(webhooks)*len(data))
+	for _, item := range data {
+		payload, err := json.Marshal(&domain.WebhookPayload{
+			ID:        uuid.New().String(),
+			Event:     event,
+			KBID:      kbID,
+			CreatedAt: now,
+			Data:      item,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, webhook := range webhooks {
+			deliveries = append(deliveries, &domain.KBWebhookDelivery{
+				ID:          uuid.New().String(),
+				WebhookID:   webhook.ID,
+				KBID:        kbID,
+				Event:       event,
+				Payload:     string(payload),
+				Status:      domain.WebhookDeliveryStatusPending,
+				NextRetryAt: lo.ToPtr(now.Add(webhookClaimTimeout)),
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+// validateWebhookURL rejects urls of private addresses, the response of a delivery is shown to the admin.
+// The address is checked again when the request is made, see utils.NewPublicHTTPClient
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.ErrInvalidWebhookURL
+	}
+	if err := utils.ValidatePublicURL(ctx, rawURL); err != nil {
+		return domain.ErrWebhookURLNotPublic
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func webhookEvents(events []domain.WebhookEvent) pq.StringArray {
+	return lo.Uniq(lo.Map(events, func(event domain.WebhookEvent, _ int) string {
+		return string(event)
+	}))
+}
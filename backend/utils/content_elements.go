@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeadingRegex        = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdImageRegex          = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)`)
+	mdTableDelimiterRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdFenceRegex          = regexp.MustCompile("^ {0,3}(```|~~~)")
+	htmlHeadingRegex      = regexp.MustCompile(`(?is)<h([1-6])\b[^>]*>(.*?)</h[1-6]\s*>`)
+	htmlImageRegex        = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	htmlSrcAttrRegex      = regexp.MustCompile(`(?is)\bsrc\s*=\s*["']([^"']*)["']`)
+	htmlAltAttrRegex      = regexp.MustCompile(`(?is)\balt\s*=\s*["']([^"']*)["']`)
+	htmlTableRegex        = regexp.MustCompile(`(?is)<table\b.*?</table\s*>`)
+	htmlRowRegex          = regexp.MustCompile(`(?i)<tr\b`)
+	htmlCellRegex         = regexp.MustCompile(`(?i)<t[dh]\b`)
+	htmlTagRegex          = regexp.MustCompile(`<[^>]*>`)
+)
+
+type ContentHeading struct {
+	Level int
+	Text  string
+	Line  int
+}
+
+type ContentImage struct {
+	Src  string
+	Alt  string
+	Line int
+}
+
+type ContentTable struct {
+	Rows int // the markdown header row is not counted
+	Cols int
+	Line int
+}
+
+// ContentElements are the headings, images and tables of markdown or html content, lines are 1-based
+type ContentElements struct {
+	Headings []*ContentHeading
+	Images   []*ContentImage
+	Tables   []*ContentTable
+}
+
+// ParseContentElements parses markdown or html content, html images are also parsed in markdown
+func ParseContentElements(content string, isHTML bool) *ContentElements {
+	elements := &ContentElements{
+		Headings: make([]*ContentHeading, 0),
+		Images:   make([]*ContentImage, 0),
+		Tables:   make([]*ContentTable, 0),
+	}
+	if isHTML {
+		parseHTMLElements(content, elements)
+	} else {
+		parseMarkdownElements(content, elements)
+	}
+	for _, loc := range htmlImageRegex.FindAllStringIndex(content, -1) {
+		tag := content[loc[0]:loc[1]]
+		image := &ContentImage{Line: LineAt(content, loc[0])}
+		if m := htmlSrcAttrRegex.FindStringSubmatch(tag); m != nil {
+			image.Src = strings.TrimSpace(html.UnescapeString(m[1]))
+		}
+		if m := htmlAltAttrRegex.FindStringSubmatch(tag); m != nil {
+			image.Alt = strings.TrimSpace(html.UnescapeString(m[1]))
+		}
+		elements.Images = append(elements.Images, image)
+	}
+	return elements
+}
+
+func parseMarkdownElements(content string, elements *ContentElements) {
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if m := mdFenceRegex.FindStringSubmatch(line); m != nil {
+			if fence == "" {
+				fence = m[1]
+			} else if fence == m[1] {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		if m := mdHeadingRegex.FindStringSubmatch(line); m != nil {
+			elements.Headings = append(elements.Headings, &ContentHeading{
+				Level: len(m[1]),
+				Text:  strings.TrimSpace(m[2]),
+				Line:  i + 1,
+			})
+			continue
+		}
+		for _, m := range mdImageRegex.FindAllStringSubmatch(line, -1) {
+			elements.Images = append(elements.Images, &ContentImage{
+				Src:  m[2],
+				Alt:  strings.TrimSpace(m[1]),
+				Line: i + 1,
+			})
+		}
+		// a table is a header row followed by a delimiter row
+		if strings.Contains(line, "|") && i+1 < len(lines) && strings.Contains(lines[i+1], "-") && mdTableDelimiterRegex.MatchString(strings.TrimRight(lines[i+1], "\r")) {
+			table := &ContentTable{Cols: len(splitMarkdownTableRow(line)), Line: i + 1}
+			i += 2
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+				table.Rows++
+			}
+			i--
+			elements.Tables = append(elements.Tables, table)
+		}
+	}
+}
+
+func splitMarkdownTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	return strings.Split(row, "|")
+}
+
+func parseHTMLElements(content string, elements *ContentElements) {
+	for _, loc := range htmlHeadingRegex.FindAllStringSubmatchIndex(content, -1) {
+		text := htmlTagRegex.ReplaceAllString(content[loc[4]:loc[5]], "")
+		elements.Headings = append(elements.Headings, &ContentHeading{
+			Level: int(content[loc[2]] - '0'),
+			Text:  strings.TrimSpace(html.UnescapeString(text)),
+			Line:  LineAt(content, loc[0]),
+		})
+	}
+	for _, loc := range htmlTableRegex.FindAllStringIndex(content, -1) {
+		table := &ContentTable{Line: LineAt(content, loc[0])}
+		rows := htmlRowRegex.Split(content[loc[0]:loc[1]], -1)
+		for _, row := range rows[1:] {
+			table.Rows++
+			table.Cols = max(table.Cols, len(htmlCellRegex.FindAllStringIndex(row, -1)))
+		}
+		elements.Tables = append(elements.Tables, table)
+	}
+}
+
+// LineAt returns the 1-based line of the byte offset in content
+func LineAt(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
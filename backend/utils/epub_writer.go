@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// EpubBook describes an epub 3 book, a nav document and a toc.ncx for epub 2 readers are generated from the chapter tree
+type EpubBook struct {
+	Identifier string
+	Title      string
+	Subtitle   string
+	Language   string
+	Modified   time.Time
+	// Cover is shown on the generated title page, optional
+	Cover    *EpubResource
+	Chapters []*EpubChapter
+	// Resources are the images referenced by chapters, paths are relative to the book root
+	Resources []*EpubResource
+}
+
+type EpubChapter struct {
+	// FileName is relative to the book root, e.g. text/<id>.xhtml
+	FileName string
+	Title    string
+	// Body is the xhtml content of the chapter, see RenderXHTML
+	Body     string
+	Children []*EpubChapter
+}
+
+type EpubResource struct {
+	FileName  string
+	MediaType string
+	Data      []byte
+}
+
+const (
+	epubRoot      = "OEBPS"
+	epubStyleFile = "style.css"
+	epubCoverFile = "cover.xhtml"
+	epubStyle     = `body { font-family: serif; line-height: 1.6; }
+h1, h2, h3, h4, h5, h6 { line-height: 1.3; }
+img { max-width: 100%; }
+pre { white-space: pre-wrap; word-wrap: break-word; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #999; padding: 0.2em 0.4em; }
+.cover { text-align: center; margin-top: 20%; }
+.cover img { max-width: 40%; }
+`
+)
+
+// WriteEpub writes the book as an epub 3 container
+func WriteEpub(w io.Writer, book *EpubBook) error {
+	zw := zip.NewWriter(w)
+	// the mimetype must be the first entry and stored uncompressed
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	language := book.Language
+	if language == "" {
+		language = "zh-CN"
+	}
+	modified := book.Modified
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+	chapters := flattenEpubChapters(book.Chapters)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="` + epubRoot + `/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`},
+		{epubRoot + "/content.opf", epubPackage(book, chapters, language, modified)},
+		{epubRoot + "/nav.xhtml", epubNav(book, language)},
+		{epubRoot + "/toc.ncx", epubNCX(book)},
+		{epubRoot + "/" + epubStyleFile, epubStyle},
+		{epubRoot + "/" + epubCoverFile, epubCover(book, language)},
+	}
+	for _, chapter := range chapters {
+		files = append(files, struct {
+			name    string
+			content string
+		}{epubRoot + "/" + chapter.FileName, epubChapterDocument(chapter, language)})
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+
+	resources := book.Resources
+	if book.Cover != nil {
+		resources = append([]*EpubResource{book.Cover}, resources...)
+	}
+	for _, res := range resources {
+		fw, err := zw.Create(epubRoot + "/" + res.FileName)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(res.Data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func flattenEpubChapters(chapters []*EpubChapter) []*EpubChapter {
+	var result []*EpubChapter
+	for _, chapter := range chapters {
+		result = append(result, chapter)
+		result = append(result, flattenEpubChapters(chapter.Children)...)
+	}
+	return result
+}
+
+func epubPackage(book *EpubBook, chapters []*EpubChapter, language string, modified time.Time) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&b, "    <dc:identifier id=\"book-id\">urn:uuid:%s</dc:identifier>\n", html.EscapeString(book.Identifier))
+	fmt.Fprintf(&b, "    <dc:title>%s</dc:title>\n", html.EscapeString(book.Title))
+	fmt.Fprintf(&b, "    <dc:language>%s</dc:language>\n", html.EscapeString(language))
+	fmt.Fprintf(&b, "    <meta property=\"dcterms:modified\">%s</meta>\n", modified.UTC().Format("2006-01-02T15:04:05Z"))
+	if book.Cover != nil {
+		b.WriteString("    <meta name=\"cover\" content=\"cover-image\"/>\n")
+	}
+	b.WriteString("  </metadata>\n  <manifest>\n")
+	b.WriteString("    <item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	b.WriteString("    <item id=\"ncx\" href=\"toc.ncx\" media-type=\"application/x-dtbncx+xml\"/>\n")
+	b.WriteString("    <item id=\"style\" href=\"" + epubStyleFile + "\" media-type=\"text/css\"/>\n")
+	b.WriteString("    <item id=\"cover\" href=\"" + epubCoverFile + "\" media-type=\"application/xhtml+xml\"/>\n")
+	if book.Cover != nil {
+		fmt.Fprintf(&b, "    <item id=\"cover-image\" href=\"%s\" media-type=\"%s\" properties=\"cover-image\"/>\n",
+			html.EscapeString(book.Cover.FileName), html.EscapeString(book.Cover.MediaType))
+	}
+	for i, chapter := range chapters {
+		fmt.Fprintf(&b, "    <item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i, html.EscapeString(chapter.FileName))
+	}
+	for i, res := range book.Resources {
+		fmt.Fprintf(&b, "    <item id=\"res-%d\" href=\"%s\" media-type=\"%s\"/>\n", i, html.EscapeString(res.FileName), html.EscapeString(res.MediaType))
+	}
+	b.WriteString("  </manifest>\n  <spine toc=\"ncx\">\n    <itemref idref=\"cover\"/>\n    <itemref idref=\"nav\"/>\n")
+	for i := range chapters {
+		fmt.Fprintf(&b, "    <itemref idref=\"chapter-%d\"/>\n", i)
+	}
+	b.WriteString("  </spine>\n</package>\n")
+	return b.String()
+}
+
+func epubNav(book *EpubBook, language string) string {
+	var b strings.Builder
+	b.WriteString(epubDocumentHead(language, "目录", ""))
+	b.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h1>目录</h1>\n")
+	writeEpubNavList(&b, book.Chapters)
+	b.WriteString("</nav>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func writeEpubNavList(b *strings.Builder, chapters []*EpubChapter) {
+	if len(chapters) == 0 {
+		return
+	}
+	b.WriteString("<ol>\n")
+	for _, chapter := range chapters {
+		fmt.Fprintf(b, "<li><a href=\"%s\">%s</a>", html.EscapeString(chapter.FileName), html.EscapeString(chapter.Title))
+		writeEpubNavList(b, chapter.Children)
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ol>\n")
+}
+
+func epubNCX(book *EpubBook) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+`)
+	fmt.Fprintf(&b, "    <meta name=\"dtb:uid\" content=\"urn:uuid:%s\"/>\n", html.EscapeString(book.Identifier))
+	b.WriteString("  </head>\n")
+	fmt.Fprintf(&b, "  <docTitle><text>%s</text></docTitle>\n  <navMap>\n", html.EscapeString(book.Title))
+	order := 0
+	writeEpubNavPoints(&b, book.Chapters, &order)
+	b.WriteString("  </navMap>\n</ncx>\n")
+	return b.String()
+}
+
+func writeEpubNavPoints(b *strings.Builder, chapters []*EpubChapter, order *int) {
+	for _, chapter := range chapters {
+		*order++
+		fmt.Fprintf(b, "<navPoint id=\"nav-%d\" playOrder=\"%d\"><navLabel><text>%s</text></navLabel><content src=\"%s\"/>",
+			*order, *order, html.EscapeString(chapter.Title), html.EscapeString(chapter.FileName))
+		writeEpubNavPoints(b, chapter.Children, order)
+		b.WriteString("</navPoint>\n")
+	}
+}
+
+func epubCover(book *EpubBook, language string) string {
+	var b strings.Builder
+	b.WriteString(epubDocumentHead(language, book.Title, ""))
+	b.WriteString("<div class=\"cover\">\n")
+	if book.Cover != nil {
+		fmt.Fprintf(&b, "<img src=\"%s\" alt=\"\"/>\n", html.EscapeString(book.Cover.FileName))
+	}
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(book.Title))
+	if book.Subtitle != "" {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(book.Subtitle))
+	}
+	b.WriteString("</div>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func epubChapterDocument(chapter *EpubChapter, language string) string {
+	// links to the stylesheet are relative to the chapter file
+	prefix := strings.Repeat("../", strings.Count(path.Clean(chapter.FileName), "/"))
+	var b strings.Builder
+	b.WriteString(epubDocumentHead(language, chapter.Title, prefix))
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(chapter.Title))
+	b.WriteString(chapter.Body)
+	b.WriteString("\n</body>\n</html>\n")
+	return b.String()
+}
+
+func epubDocumentHead(language, title, prefix string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="` + html.EscapeString(language) + `" lang="` + html.EscapeString(language) + `">
+<head>
+<meta charset="UTF-8"/>
+<title>` + html.EscapeString(title) + `</title>
+<link rel="stylesheet" type="text/css" href="` + prefix + epubStyleFile + `"/>
+</head>
+<body>
+`
+}
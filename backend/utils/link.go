@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlLinkRegex     = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"']+)["']`)
+	markdownLinkRegex = regexp.MustCompile(`(?:^|[^!\\])\[[^\]]*\]\(\s*<?([^)\s>]+)`)
+	autoLinkRegex     = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	nodePathRegex     = regexp.MustCompile(`^/node/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})/?$`)
+)
+
+// ExtractLinks returns the de-duplicated link targets found in markdown or html content, images are skipped
+func ExtractLinks(content string) []string {
+	links := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, re := range []*regexp.Regexp{htmlLinkRegex, markdownLinkRegex, autoLinkRegex} {
+		for _, match := range re.FindAllStringSubmatch(content, -1) {
+			link := strings.TrimSpace(html.UnescapeString(match[1]))
+			if link == "" || strings.HasPrefix(link, "#") {
+				continue
+			}
+			if _, ok := seen[link]; ok {
+				continue
+			}
+			seen[link] = struct{}{}
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// ParseNodeLink reports whether link points to a wiki node (/node/<id>) and returns the node id.
+// Absolute urls are only treated as node links when their host is one of hosts.
+func ParseNodeLink(link string, hosts []string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+	if u.Host != "" {
+		matched := false
+		for _, host := range hosts {
+			if strings.EqualFold(u.Hostname(), host) || strings.EqualFold(u.Host, host) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+	} else if u.Scheme != "" {
+		return "", false
+	}
+	m := nodePathRegex.FindStringSubmatch(u.Path)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
+
+// IsExternalLink reports whether link is an absolute http(s) url
+func IsExternalLink(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale returns the canonical BCP 47 tag of a locale, e.g. en_us -> en-US, empty if it is invalid
+func NormalizeLocale(locale string) string {
+	if locale == "" {
+		return ""
+	}
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return ""
+	}
+	return tag.String()
+}
+
+// PreferredLocale returns the first locale of an Accept-Language header
+func PreferredLocale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 || tags[0] == language.Und {
+		return ""
+	}
+	return tags[0].String()
+}
+
+// MatchLocale returns the index of the locale that matches want best, an exact match ranks before
+// a match of the base language (en-GB for en-US). It returns -1 when nothing matches.
+func MatchLocale(want string, locales []string) int {
+	wantTag, err := language.Parse(want)
+	if err != nil || wantTag == language.Und {
+		return -1
+	}
+	wantBase, _ := wantTag.Base()
+	match := -1
+	for i, locale := range locales {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		if tag == wantTag {
+			return i
+		}
+		if base, _ := tag.Base(); match == -1 && base == wantBase {
+			match = i
+		}
+	}
+	return match
+}
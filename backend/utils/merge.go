@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	MergeConflictStart = "<<<<<<< yours"
+	MergeConflictSep   = "======="
+	MergeConflictEnd   = ">>>>>>> current"
+
+	// maxMergeLCSCells 超过此规模不再计算 LCS，整体视为冲突
+	maxMergeLCSCells = 4_000_000
+)
+
+var htmlBlockEndRegex = regexp.MustCompile(`(?i)(</(p|h[1-6]|li|ul|ol|pre|blockquote|table|thead|tbody|tr|div|section|figure)>|<br\s*/?>|<hr\s*/?>)`)
+
+// ThreeWayMerge merges two revisions (ours, theirs) derived from the same base.
+// Markdown is merged line by line, HTML by block elements.
+// The returned bool reports whether the result contains conflict markers.
+func ThreeWayMerge(base, ours, theirs string, isHTML bool) (string, bool) {
+	if ours == theirs {
+		return ours, false
+	}
+	if base == ours {
+		return theirs, false
+	}
+	if base == theirs {
+		return ours, false
+	}
+
+	o := splitMergeUnits(base, isHTML)
+	a := splitMergeUnits(ours, isHTML)
+	b := splitMergeUnits(theirs, isHTML)
+
+	ma := matchUnits(o, a)
+	mb := matchUnits(o, b)
+
+	var sb strings.Builder
+	conflict := false
+	oi, ai, bi := 0, 0, 0
+	for oi < len(o) || ai < len(a) || bi < len(b) {
+		// stable unit: present in all three at current positions
+		if oi < len(o) && ma[oi] == ai && mb[oi] == bi {
+			sb.WriteString(o[oi])
+			oi++
+			ai++
+			bi++
+			continue
+		}
+		// find next unit of base that is kept by both sides
+		j := oi
+		for j < len(o) && (ma[j] < 0 || mb[j] < 0) {
+			j++
+		}
+		aEnd, bEnd := len(a), len(b)
+		if j < len(o) {
+			aEnd, bEnd = ma[j], mb[j]
+		}
+		chunkO, chunkA, chunkB := o[oi:j], a[ai:aEnd], b[bi:bEnd]
+		switch {
+		case equalUnits(chunkA, chunkO):
+			writeUnits(&sb, chunkB)
+		case equalUnits(chunkB, chunkO), equalUnits(chunkA, chunkB):
+			writeUnits(&sb, chunkA)
+		default:
+			conflict = true
+			writeConflict(&sb, chunkA, chunkB, isHTML)
+		}
+		oi, ai, bi = j, aEnd, bEnd
+	}
+	return sb.String(), conflict
+}
+
+// splitMergeUnits splits content into units whose concatenation is the original content
+func splitMergeUnits(content string, isHTML bool) []string {
+	if content == "" {
+		return nil
+	}
+	if !isHTML {
+		return strings.SplitAfter(content, "\n")
+	}
+	units := make([]string, 0)
+	last := 0
+	for _, loc := range htmlBlockEndRegex.FindAllStringIndex(content, -1) {
+		units = append(units, content[last:loc[1]])
+		last = loc[1]
+	}
+	if last < len(content) {
+		units = append(units, content[last:])
+	}
+	return units
+}
+
+// matchUnits returns for each unit of base the index of the matched unit in other (by LCS), or -1
+func matchUnits(base, other []string) []int {
+	match := make([]int, len(base))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// common prefix and suffix are matched directly
+	prefix := 0
+	for prefix < len(base) && prefix < len(other) && base[prefix] == other[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(other)-prefix &&
+		base[len(base)-1-suffix] == other[len(other)-1-suffix] {
+		match[len(base)-1-suffix] = len(other) - 1 - suffix
+		suffix++
+	}
+
+	x := base[prefix : len(base)-suffix]
+	y := other[prefix : len(other)-suffix]
+	n, m := len(x), len(y)
+	if n == 0 || m == 0 || (n+1)*(m+1) > maxMergeLCSCells {
+		return match
+	}
+
+	// dp[i][j] = LCS length of x[i:] and y[j:]
+	dp := make([][]int32, n+1)
+	for i := range dp {
+		dp[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case x[i] == y[j]:
+			match[prefix+i] = prefix + j
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+func equalUnits(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeUnits(sb *strings.Builder, units []string) {
+	for _, u := range units {
+		sb.WriteString(u)
+	}
+}
+
+func writeConflict(sb *strings.Builder, ours, theirs []string, isHTML bool) {
+	if isHTML {
+		sb.WriteString("<p>" + MergeConflictStart + "</p>")
+		writeUnits(sb, ours)
+		sb.WriteString("<p>" + MergeConflictSep + "</p>")
+		writeUnits(sb, theirs)
+		sb.WriteString("<p>" + MergeConflictEnd + "</p>")
+		return
+	}
+	if s := sb.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(MergeConflictStart + "\n")
+	writeConflictSide(sb, ours)
+	sb.WriteString(MergeConflictSep + "\n")
+	writeConflictSide(sb, theirs)
+	sb.WriteString(MergeConflictEnd + "\n")
+}
+
+func writeConflictSide(sb *strings.Builder, units []string) {
+	writeUnits(sb, units)
+	if len(units) > 0 && !strings.HasSuffix(units[len(units)-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
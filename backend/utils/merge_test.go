@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreeWayMerge(t *testing.T) {
+	tests := []struct {
+		name         string
+		base         string
+		ours         string
+		theirs       string
+		isHTML       bool
+		want         string
+		wantConflict bool
+	}{
+		{
+			name:   "both sides unchanged",
+			base:   "a\nb\n",
+			ours:   "a\nb\n",
+			theirs: "a\nb\n",
+			want:   "a\nb\n",
+		},
+		{
+			name:   "only theirs changed",
+			base:   "a\nb\n",
+			ours:   "a\nb\n",
+			theirs: "a\nc\n",
+			want:   "a\nc\n",
+		},
+		{
+			name:   "only ours changed",
+			base:   "a\nb\n",
+			ours:   "x\nb\n",
+			theirs: "a\nb\n",
+			want:   "x\nb\n",
+		},
+		{
+			name:   "same change on both sides",
+			base:   "a\nb\nc\n",
+			ours:   "a\nB\nc\nd\n",
+			theirs: "a\nB\nc\n",
+			want:   "a\nB\nc\nd\n",
+		},
+		{
+			name:   "changes to different lines",
+			base:   "title\n\nfirst\n\nsecond\n",
+			ours:   "title\n\nfirst edited\n\nsecond\n",
+			theirs: "title\n\nfirst\n\nsecond edited\n",
+			want:   "title\n\nfirst edited\n\nsecond edited\n",
+		},
+		{
+			name:   "insertions at both ends",
+			base:   "b\n",
+			ours:   "a\nb\n",
+			theirs: "b\nc\n",
+			want:   "a\nb\nc\n",
+		},
+		{
+			name:   "deletion on one side",
+			base:   "a\nb\nc\n",
+			ours:   "a\nc\n",
+			theirs: "a\nb\nc\nd\n",
+			want:   "a\nc\nd\n",
+		},
+		{
+			name:         "conflicting change of the same line",
+			base:         "a\nb\nc\n",
+			ours:         "a\nmine\nc\n",
+			theirs:       "a\nyours\nc\n",
+			want:         "a\n" + MergeConflictStart + "\nmine\n" + MergeConflictSep + "\nyours\n" + MergeConflictEnd + "\nc\n",
+			wantConflict: true,
+		},
+		{
+			name:         "changes to adjacent lines conflict",
+			base:         "a\nb\nc\n",
+			ours:         "A\nb\nc\n",
+			theirs:       "a\nB\nc\n",
+			want:         MergeConflictStart + "\nA\nb\n" + MergeConflictSep + "\na\nB\n" + MergeConflictEnd + "\nc\n",
+			wantConflict: true,
+		},
+		{
+			name:         "conflict without trailing newline",
+			base:         "a\nb",
+			ours:         "a\nmine",
+			theirs:       "a\nyours",
+			want:         "a\n" + MergeConflictStart + "\nmine\n" + MergeConflictSep + "\nyours\n" + MergeConflictEnd + "\n",
+			wantConflict: true,
+		},
+		{
+			name:   "html blocks changed on different sides",
+			base:   "<h1>Title</h1><p>one</p><p>keep</p><p>two</p>",
+			ours:   "<h1>Title</h1><p>one edited</p><p>keep</p><p>two</p>",
+			theirs: "<h1>Title</h1><p>one</p><p>keep</p><p>two edited</p>",
+			isHTML: true,
+			want:   "<h1>Title</h1><p>one edited</p><p>keep</p><p>two edited</p>",
+		},
+		{
+			name:         "conflicting html block",
+			base:         "<p>one</p><p>two</p>",
+			ours:         "<p>mine</p><p>two</p>",
+			theirs:       "<p>yours</p><p>two</p>",
+			isHTML:       true,
+			want:         "<p>" + MergeConflictStart + "</p><p>mine</p><p>" + MergeConflictSep + "</p><p>yours</p><p>" + MergeConflictEnd + "</p><p>two</p>",
+			wantConflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := ThreeWayMerge(tt.base, tt.ours, tt.theirs, tt.isHTML)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantConflict, conflict)
+		})
+	}
+}
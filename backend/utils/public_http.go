@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a request to a user supplied url would reach a loopback, private,
+// link-local or other reserved address, e.g. the cloud metadata service or the containers of the deployment
+var ErrPrivateAddress = errors.New("url must not point to a private address")
+
+var errInvalidPublicURL = errors.New("url must be an http or https url")
+
+// ValidatePublicURL checks that the url is an http or https url and that its host only resolves to public addresses
+func ValidatePublicURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errInvalidPublicURL
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if IsPrivateOrReservedIP(addr.IP.String()) {
+			return ErrPrivateAddress
+		}
+	}
+	return nil
+}
+
+// NewPublicHTTPClient returns a client that refuses to connect to private addresses. The address is checked when
+// dialing, after dns resolution, so a host that resolves to a private address only at request time is refused too.
+// Proxies from the environment are not used, the proxy would connect to the target without the check.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicAddressControl,
+	}).DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+func publicAddressControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if IsPrivateOrReservedIP(host) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
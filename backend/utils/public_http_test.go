@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidatePublicURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr error
+	}{
+		{url: "https://1.1.1.1/hook"},
+		{url: "http://[2606:4700:4700::1111]:8080/hook"},
+		{url: "http://127.0.0.1:9000/", wantErr: ErrPrivateAddress},
+		{url: "http://10.0.0.8/", wantErr: ErrPrivateAddress},
+		{url: "http://172.17.0.2:4222/", wantErr: ErrPrivateAddress},
+		{url: "http://192.168.1.1/", wantErr: ErrPrivateAddress},
+		{url: "http://169.254.169.254/latest/meta-data/", wantErr: ErrPrivateAddress},
+		{url: "http://0.0.0.0:5432/", wantErr: ErrPrivateAddress},
+		{url: "http://[::1]/", wantErr: ErrPrivateAddress},
+		{url: "http://[::]/", wantErr: ErrPrivateAddress},
+		{url: "http://[fe80::1]/", wantErr: ErrPrivateAddress},
+		{url: "http://[::ffff:127.0.0.1]/", wantErr: ErrPrivateAddress},
+		{url: "ftp://1.1.1.1/", wantErr: errInvalidPublicURL},
+		{url: "http:///path", wantErr: errInvalidPublicURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidatePublicURL(context.Background(), tt.url)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidatePublicURL(%q) = %v, want %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPublicHTTPClientRefusesPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer server.Close()
+
+	resp, err := NewPublicHTTPClient(5 * time.Second).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("request to a loopback address succeeded")
+	}
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("err = %v, want %v", err, ErrPrivateAddress)
+	}
+}
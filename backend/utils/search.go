@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSearchTerms        = 10
+	maxNormalizedQueryLen = 500
+)
+
+var (
+	searchTermRegex     = regexp.MustCompile(`"([^"]+)"|(\S+)`)
+	mdTextImageRegex    = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdTextLinkRegex     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdTextMarkerRegex   = regexp.MustCompile(`(?m)^\s{0,3}(?:#{1,6}\s+|>\s?|[-*+]\s+|\d+\.\s+)`)
+	mdTextEmphasisRegex = regexp.MustCompile("[*_~`]+")
+	htmlBlockTagRegex   = regexp.MustCompile(`(?i)</?(?:p|div|br|li|h[1-6]|tr|td|th|pre|blockquote)\b[^>]*>`)
+	htmlScriptRegex     = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)\s*>`)
+	spaceRegex          = regexp.MustCompile(`\s+`)
+)
+
+// ParseSearchTerms splits a query into terms, "quoted text" is kept as one phrase.
+// Terms are de-duplicated case-insensitively and limited to 10.
+func ParseSearchTerms(query string) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, m := range searchTermRegex.FindAllStringSubmatch(query, -1) {
+		term := m[2]
+		if m[1] != "" {
+			term = m[1]
+		}
+		term = strings.TrimSpace(spaceRegex.ReplaceAllString(term, " "))
+		if term == "" {
+			continue
+		}
+		key := strings.ToLower(term)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		terms = append(terms, term)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// NormalizeSearchQuery lower-cases the query and collapses spaces so variants of a query are grouped together
+func NormalizeSearchQuery(query string) string {
+	runes := []rune(strings.ToLower(strings.Join(strings.Fields(query), " ")))
+	if len(runes) > maxNormalizedQueryLen {
+		runes = runes[:maxNormalizedQueryLen]
+	}
+	return string(runes)
+}
+
+// EscapeLikePattern escapes the wildcards of a LIKE pattern, the default escape character backslash is used
+func EscapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ContentToText converts markdown or html content into plain text for snippets
+func ContentToText(content string) string {
+	text := htmlScriptRegex.ReplaceAllString(content, " ")
+	text = htmlBlockTagRegex.ReplaceAllString(text, " ")
+	text = htmlTagRegex.ReplaceAllString(text, "")
+	text = mdTextImageRegex.ReplaceAllString(text, "$1")
+	text = mdTextLinkRegex.ReplaceAllString(text, "$1")
+	text = mdTextMarkerRegex.ReplaceAllString(text, "")
+	text = mdTextEmphasisRegex.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(spaceRegex.ReplaceAllString(text, " "))
+}
+
+// TruncateText cuts text to maxRunes runes, "..." is appended when it is cut
+func TruncateText(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:maxRunes])) + "..."
+}
+
+// HighlightText html-escapes text and wraps the matched terms in <mark>
+func HighlightText(text string, terms []string) string {
+	runes := []rune(text)
+	return highlightRunes(runes, findTermMatches(runes, terms), 0, len(runes))
+}
+
+// HighlightSnippets returns up to maxSnippets html-escaped snippets around the matched terms with radius runes of context,
+// the beginning of the text is returned when nothing matches
+func HighlightSnippets(text string, terms []string, maxSnippets, radius int) []string {
+	runes := []rune(text)
+	snippets := make([]string, 0, maxSnippets)
+	matches := findTermMatches(runes, terms)
+	if len(matches) == 0 {
+		if len(runes) > 0 {
+			end := min(len(runes), radius*2)
+			snippet := html.EscapeString(string(runes[:end]))
+			if end < len(runes) {
+				snippet += "..."
+			}
+			snippets = append(snippets, snippet)
+		}
+		return snippets
+	}
+
+	for i := 0; i < len(matches) && len(snippets) < maxSnippets; {
+		start := max(0, matches[i][0]-radius)
+		end := min(len(runes), matches[i][1]+radius)
+		j := i + 1
+		for ; j < len(matches) && matches[j][0] < end; j++ {
+			end = min(len(runes), max(end, matches[j][1]+radius/2))
+		}
+		snippet := highlightRunes(runes, matches[i:j], start, end)
+		if start > 0 {
+			snippet = "..." + snippet
+		}
+		if end < len(runes) {
+			snippet += "..."
+		}
+		snippets = append(snippets, snippet)
+		i = j
+	}
+	return snippets
+}
+
+// findTermMatches returns the sorted, non-overlapping [start, end) rune ranges of the terms, case-insensitive
+func findTermMatches(runes []rune, terms []string) [][2]int {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	matches := make([][2]int, 0)
+	for _, term := range terms {
+		termRunes := []rune(strings.ToLower(term))
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if equalRunes(lower[i:i+len(termRunes)], termRunes) {
+				matches = append(matches, [2]int{i, i + len(termRunes)})
+				i += len(termRunes) - 1
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i][0] != matches[j][0] {
+			return matches[i][0] < matches[j][0]
+		}
+		return matches[i][1] > matches[j][1]
+	})
+	merged := make([][2]int, 0, len(matches))
+	for _, m := range matches {
+		if len(merged) > 0 && m[0] < merged[len(merged)-1][1] {
+			merged[len(merged)-1][1] = max(merged[len(merged)-1][1], m[1])
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func highlightRunes(runes []rune, matches [][2]int, start, end int) string {
+	var sb strings.Builder
+	pos := start
+	for _, m := range matches {
+		if m[1] <= start || m[0] >= end {
+			continue
+		}
+		mStart, mEnd := max(m[0], start), min(m[1], end)
+		sb.WriteString(html.EscapeString(string(runes[pos:mStart])))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(string(runes[mStart:mEnd])))
+		sb.WriteString("</mark>")
+		pos = mEnd
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:end])))
+	return sb.String()
+}
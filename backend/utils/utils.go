@@ -19,11 +19,14 @@ import (
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/strikethrough"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/table"
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	tiktoken_loader "github.com/pkoukk/tiktoken-go-loader"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer/html"
 	"github.com/yuin/goldmark/text"
 
@@ -254,16 +257,46 @@ func GetTitleFromMarkdown(markdown string) string {
 	return title
 }
 
+type exchangeOptions struct {
+	gfm bool
+}
+
+// ExchangeOption configures ExchangeMarkDownImageUrl
+type ExchangeOption func(*exchangeOptions)
+
+// WithGFM keeps tables and strikethrough through the markdown -> html -> markdown round trip
+func WithGFM() ExchangeOption {
+	return func(o *exchangeOptions) {
+		o.gfm = true
+	}
+}
+
 func ExchangeMarkDownImageUrl(
 	ctx context.Context,
 	mdContent []byte,
 	getUrl func(ctx context.Context, originUrl *string) (string, error),
+	opts ...ExchangeOption,
 ) (string, error) {
-	md := goldmark.New(
+	var options exchangeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	mdOptions := []goldmark.Option{
 		goldmark.WithRendererOptions(
 			html.WithHardWraps(),
 		),
-	)
+	}
+	plugins := []converter.Plugin{
+		base.NewBasePlugin(),
+		commonmark.NewCommonmarkPlugin(
+			commonmark.WithStrongDelimiter("__"),
+		),
+	}
+	if options.gfm {
+		mdOptions = append(mdOptions, goldmark.WithExtensions(extension.Table, extension.Strikethrough))
+		plugins = append(plugins, strikethrough.NewStrikethroughPlugin(), table.NewTablePlugin())
+	}
+	md := goldmark.New(mdOptions...)
 	reader := text.NewReader(mdContent)
 	doc := md.Parser().Parse(reader)
 
@@ -328,12 +361,7 @@ func ExchangeMarkDownImageUrl(
 
 	// 5. 转换并返回字符串
 	conv := converter.NewConverter(
-		converter.WithPlugins(
-			base.NewBasePlugin(),
-			commonmark.NewCommonmarkPlugin(
-				commonmark.WithStrongDelimiter("__"),
-			),
-		),
+		converter.WithPlugins(plugins...),
 	)
 	converted, err := conv.ConvertReader(&buf)
 	if err != nil {
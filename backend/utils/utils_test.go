@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeMarkDownImageUrl(t *testing.T) {
+	getUrl := func(ctx context.Context, originUrl *string) (string, error) {
+		return "https://cdn.example.com/" + *originUrl, nil
+	}
+	content := []byte("![logo](a.png)\n\n| a | b |\n| --- | --- |\n| ~~old~~ | new |\n")
+
+	// the table is a paragraph with hard wraps by default
+	t.Run("default", func(t *testing.T) {
+		got, err := ExchangeMarkDownImageUrl(context.Background(), content, getUrl)
+		require.NoError(t, err)
+		assert.Contains(t, got, "![logo](https://cdn.example.com/a.png)")
+		assert.Contains(t, got, "| a | b |  \n| --- | --- |  \n")
+	})
+
+	t.Run("gfm", func(t *testing.T) {
+		got, err := ExchangeMarkDownImageUrl(context.Background(), content, getUrl, WithGFM())
+		require.NoError(t, err)
+		assert.Contains(t, got, "![logo](https://cdn.example.com/a.png)")
+		assert.Contains(t, got, "|---------|-----|\n| ~~old~~ | new |")
+	})
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var xmlNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]*$`)
+
+var xhtmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// elements which are dropped with their children, they are unsafe or invalid in an epub body
+var xhtmlDroppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "iframe": true, "object": true, "frame": true, "frameset": true,
+}
+
+var xhtmlNamespaces = map[string]string{
+	"svg":  "http://www.w3.org/2000/svg",
+	"math": "http://www.w3.org/1998/Math/MathML",
+}
+
+// RenderXHTML parses an html fragment and renders it as well-formed xhtml for epub and pdf output.
+// Scripts and event handlers are dropped, rewriteURL may replace the value of src and href attributes.
+func RenderXHTML(fragment string, rewriteURL func(tag, attr, value string) string) (string, error) {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(fragment), &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		renderXHTMLNode(&b, n, rewriteURL)
+	}
+	return b.String(), nil
+}
+
+func renderXHTMLNode(b *strings.Builder, n *nethtml.Node, rewriteURL func(tag, attr, value string) string) {
+	switch n.Type {
+	case nethtml.TextNode:
+		b.WriteString(html.EscapeString(stripInvalidXMLChars(n.Data)))
+		return
+	case nethtml.ElementNode:
+	default:
+		return
+	}
+
+	tag := strings.ToLower(n.Data)
+	if xhtmlDroppedElements[tag] || !xmlNameRegex.MatchString(tag) {
+		return
+	}
+	b.WriteString("<" + tag)
+	if ns, ok := xhtmlNamespaces[tag]; ok && n.Namespace == tag {
+		b.WriteString(` xmlns="` + ns + `"`)
+	}
+	seen := make(map[string]bool)
+	hasAlt := false
+	for _, attr := range n.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || seen[key] || strings.HasPrefix(key, "on") || key == "xmlns" || !xmlNameRegex.MatchString(key) {
+			continue
+		}
+		seen[key] = true
+		value := attr.Val
+		if key == "src" || key == "href" {
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(value)), "javascript:") {
+				continue
+			}
+			if rewriteURL != nil {
+				value = rewriteURL(tag, key, value)
+			}
+		}
+		if key == "alt" {
+			hasAlt = true
+		}
+		b.WriteString(" " + key + `="` + html.EscapeString(stripInvalidXMLChars(value)) + `"`)
+	}
+	if tag == "img" && !hasAlt {
+		b.WriteString(` alt=""`)
+	}
+	if xhtmlVoidElements[tag] || (n.FirstChild == nil && n.Namespace != "") {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderXHTMLNode(b, c, rewriteURL)
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+// stripInvalidXMLChars removes control characters which are not allowed in xml 1.0
+func stripInvalidXMLChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}